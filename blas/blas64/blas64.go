@@ -13,8 +13,12 @@ import (
 var blas64 blas.Float64 = gonum.Implementation{}
 
 // Use sets the BLAS float64 implementation to be used by subsequent BLAS calls.
-// The default implementation is native.Implementation.
+// The default implementation is gonum.Implementation. If b is nil, the
+// default implementation is restored.
 func Use(b blas.Float64) {
+	if b == nil {
+		b = gonum.Implementation{}
+	}
 	blas64 = b
 }
 
@@ -0,0 +1,340 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas64
+
+import (
+	"sync"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+var _ blas.Float64 = (*Instrumented)(nil)
+
+// Stats holds the number of calls made to a BLAS routine and the total number
+// of floating point operations performed by those calls.
+type Stats struct {
+	Calls int64
+	Flops int64
+}
+
+// Instrumented is a BLAS float64 implementation that records the number of
+// calls and floating point operations of each routine, and forwards the calls
+// to an underlying implementation. It is safe to call the methods of an
+// Instrumented concurrently.
+//
+// The floating point operation counts follow the usual convention of counting
+// one multiplication and one addition for each element of a product or update,
+// and ignore the scaling by alpha and beta. Routines that only move data, and
+// the scalar routines Drotg and Drotmg, are counted as performing no floating
+// point operations.
+type Instrumented struct {
+	impl blas.Float64
+
+	mu    sync.Mutex
+	stats map[string]Stats
+}
+
+// NewInstrumented returns an Instrumented that forwards calls to impl. If impl
+// is nil, the current implementation returned by Implementation is used.
+func NewInstrumented(impl blas.Float64) *Instrumented {
+	if impl == nil {
+		impl = Implementation()
+	}
+	return &Instrumented{
+		impl:  impl,
+		stats: make(map[string]Stats),
+	}
+}
+
+// Stats returns the statistics recorded for each called routine, keyed by the
+// routine name, for example "Dgemm". Routines that have not been called are
+// not included.
+func (b *Instrumented) Stats() map[string]Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	stats := make(map[string]Stats, len(b.stats))
+	for name, s := range b.stats {
+		stats[name] = s
+	}
+	return stats
+}
+
+// Reset clears the recorded statistics.
+func (b *Instrumented) Reset() {
+	b.mu.Lock()
+	b.stats = make(map[string]Stats)
+	b.mu.Unlock()
+}
+
+func (b *Instrumented) record(name string, flops int) {
+	b.mu.Lock()
+	s := b.stats[name]
+	s.Calls++
+	s.Flops += int64(flops)
+	b.stats[name] = s
+	b.mu.Unlock()
+}
+
+// triFlops returns the number of floating point operations needed to multiply
+// a vector by, or solve a system with, an n×n triangular matrix with the given
+// number of stored elements, including the diagonal.
+func triFlops(d blas.Diag, n, elems int) int {
+	if d == blas.Unit {
+		return 2 * (elems - n)
+	}
+	return 2*elems - n
+}
+
+// bandElems returns the number of elements in the band of an m×n matrix with
+// kL sub-diagonals and kU super-diagonals.
+func bandElems(m, n, kL, kU int) int {
+	var elems int
+	for i := 0; i < m; i++ {
+		lo := max(0, i-kL)
+		hi := min(n, i+kU+1)
+		if hi > lo {
+			elems += hi - lo
+		}
+	}
+	return elems
+}
+
+// triBandElems returns the number of elements in a triangular band matrix of
+// order n with k off-diagonals, including the diagonal.
+func triBandElems(n, k int) int {
+	k = max(0, min(k, n-1))
+	return n*(k+1) - k*(k+1)/2
+}
+
+// Level 1
+
+// Ddot calls the underlying Ddot and records the call.
+func (b *Instrumented) Ddot(n int, x []float64, incX int, y []float64, incY int) float64 {
+	b.record("Ddot", 2*n)
+	return b.impl.Ddot(n, x, incX, y, incY)
+}
+
+// Dnrm2 calls the underlying Dnrm2 and records the call.
+func (b *Instrumented) Dnrm2(n int, x []float64, incX int) float64 {
+	b.record("Dnrm2", 2*n)
+	return b.impl.Dnrm2(n, x, incX)
+}
+
+// Dasum calls the underlying Dasum and records the call.
+func (b *Instrumented) Dasum(n int, x []float64, incX int) float64 {
+	b.record("Dasum", n)
+	return b.impl.Dasum(n, x, incX)
+}
+
+// Idamax calls the underlying Idamax and records the call.
+func (b *Instrumented) Idamax(n int, x []float64, incX int) int {
+	b.record("Idamax", 0)
+	return b.impl.Idamax(n, x, incX)
+}
+
+// Dswap calls the underlying Dswap and records the call.
+func (b *Instrumented) Dswap(n int, x []float64, incX int, y []float64, incY int) {
+	b.record("Dswap", 0)
+	b.impl.Dswap(n, x, incX, y, incY)
+}
+
+// Dcopy calls the underlying Dcopy and records the call.
+func (b *Instrumented) Dcopy(n int, x []float64, incX int, y []float64, incY int) {
+	b.record("Dcopy", 0)
+	b.impl.Dcopy(n, x, incX, y, incY)
+}
+
+// Daxpy calls the underlying Daxpy and records the call.
+func (b *Instrumented) Daxpy(n int, alpha float64, x []float64, incX int, y []float64, incY int) {
+	b.record("Daxpy", 2*n)
+	b.impl.Daxpy(n, alpha, x, incX, y, incY)
+}
+
+// Drotg calls the underlying Drotg and records the call.
+func (b *Instrumented) Drotg(a, b2 float64) (c, s, r, z float64) {
+	b.record("Drotg", 0)
+	return b.impl.Drotg(a, b2)
+}
+
+// Drotmg calls the underlying Drotmg and records the call.
+func (b *Instrumented) Drotmg(d1, d2, b1, b2 float64) (p blas.DrotmParams, rd1, rd2, rb1 float64) {
+	b.record("Drotmg", 0)
+	return b.impl.Drotmg(d1, d2, b1, b2)
+}
+
+// Drot calls the underlying Drot and records the call.
+func (b *Instrumented) Drot(n int, x []float64, incX int, y []float64, incY int, c float64, s float64) {
+	b.record("Drot", 6*n)
+	b.impl.Drot(n, x, incX, y, incY, c, s)
+}
+
+// Drotm calls the underlying Drotm and records the call.
+func (b *Instrumented) Drotm(n int, x []float64, incX int, y []float64, incY int, p blas.DrotmParams) {
+	var flops int
+	switch p.Flag {
+	case blas.Rescaling:
+		flops = 6 * n
+	case blas.OffDiagonal, blas.Diagonal:
+		flops = 4 * n
+	}
+	b.record("Drotm", flops)
+	b.impl.Drotm(n, x, incX, y, incY, p)
+}
+
+// Dscal calls the underlying Dscal and records the call.
+func (b *Instrumented) Dscal(n int, alpha float64, x []float64, incX int) {
+	b.record("Dscal", n)
+	b.impl.Dscal(n, alpha, x, incX)
+}
+
+// Level 2
+
+// Dgemv calls the underlying Dgemv and records the call.
+func (b *Instrumented) Dgemv(tA blas.Transpose, m, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.record("Dgemv", 2*m*n)
+	b.impl.Dgemv(tA, m, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+// Dgbmv calls the underlying Dgbmv and records the call.
+func (b *Instrumented) Dgbmv(tA blas.Transpose, m, n, kL, kU int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.record("Dgbmv", 2*bandElems(m, n, kL, kU))
+	b.impl.Dgbmv(tA, m, n, kL, kU, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+// Dtrmv calls the underlying Dtrmv and records the call.
+func (b *Instrumented) Dtrmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	b.record("Dtrmv", triFlops(d, n, n*(n+1)/2))
+	b.impl.Dtrmv(ul, tA, d, n, a, lda, x, incX)
+}
+
+// Dtbmv calls the underlying Dtbmv and records the call.
+func (b *Instrumented) Dtbmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+	b.record("Dtbmv", triFlops(d, n, triBandElems(n, k)))
+	b.impl.Dtbmv(ul, tA, d, n, k, a, lda, x, incX)
+}
+
+// Dtpmv calls the underlying Dtpmv and records the call.
+func (b *Instrumented) Dtpmv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	b.record("Dtpmv", triFlops(d, n, n*(n+1)/2))
+	b.impl.Dtpmv(ul, tA, d, n, ap, x, incX)
+}
+
+// Dtrsv calls the underlying Dtrsv and records the call.
+func (b *Instrumented) Dtrsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, a []float64, lda int, x []float64, incX int) {
+	b.record("Dtrsv", triFlops(d, n, n*(n+1)/2))
+	b.impl.Dtrsv(ul, tA, d, n, a, lda, x, incX)
+}
+
+// Dtbsv calls the underlying Dtbsv and records the call.
+func (b *Instrumented) Dtbsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n, k int, a []float64, lda int, x []float64, incX int) {
+	b.record("Dtbsv", triFlops(d, n, triBandElems(n, k)))
+	b.impl.Dtbsv(ul, tA, d, n, k, a, lda, x, incX)
+}
+
+// Dtpsv calls the underlying Dtpsv and records the call.
+func (b *Instrumented) Dtpsv(ul blas.Uplo, tA blas.Transpose, d blas.Diag, n int, ap []float64, x []float64, incX int) {
+	b.record("Dtpsv", triFlops(d, n, n*(n+1)/2))
+	b.impl.Dtpsv(ul, tA, d, n, ap, x, incX)
+}
+
+// Dsymv calls the underlying Dsymv and records the call.
+func (b *Instrumented) Dsymv(ul blas.Uplo, n int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.record("Dsymv", 2*n*n)
+	b.impl.Dsymv(ul, n, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+// Dsbmv calls the underlying Dsbmv and records the call.
+func (b *Instrumented) Dsbmv(ul blas.Uplo, n, k int, alpha float64, a []float64, lda int, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.record("Dsbmv", 2*(2*triBandElems(n, k)-n))
+	b.impl.Dsbmv(ul, n, k, alpha, a, lda, x, incX, beta, y, incY)
+}
+
+// Dspmv calls the underlying Dspmv and records the call.
+func (b *Instrumented) Dspmv(ul blas.Uplo, n int, alpha float64, ap []float64, x []float64, incX int, beta float64, y []float64, incY int) {
+	b.record("Dspmv", 2*n*n)
+	b.impl.Dspmv(ul, n, alpha, ap, x, incX, beta, y, incY)
+}
+
+// Dger calls the underlying Dger and records the call.
+func (b *Instrumented) Dger(m, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	b.record("Dger", 2*m*n)
+	b.impl.Dger(m, n, alpha, x, incX, y, incY, a, lda)
+}
+
+// Dsyr calls the underlying Dsyr and records the call.
+func (b *Instrumented) Dsyr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, a []float64, lda int) {
+	b.record("Dsyr", n*(n+1))
+	b.impl.Dsyr(ul, n, alpha, x, incX, a, lda)
+}
+
+// Dspr calls the underlying Dspr and records the call.
+func (b *Instrumented) Dspr(ul blas.Uplo, n int, alpha float64, x []float64, incX int, ap []float64) {
+	b.record("Dspr", n*(n+1))
+	b.impl.Dspr(ul, n, alpha, x, incX, ap)
+}
+
+// Dsyr2 calls the underlying Dsyr2 and records the call.
+func (b *Instrumented) Dsyr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64, lda int) {
+	b.record("Dsyr2", 2*n*(n+1))
+	b.impl.Dsyr2(ul, n, alpha, x, incX, y, incY, a, lda)
+}
+
+// Dspr2 calls the underlying Dspr2 and records the call.
+func (b *Instrumented) Dspr2(ul blas.Uplo, n int, alpha float64, x []float64, incX int, y []float64, incY int, a []float64) {
+	b.record("Dspr2", 2*n*(n+1))
+	b.impl.Dspr2(ul, n, alpha, x, incX, y, incY, a)
+}
+
+// Level 3
+
+// Dgemm calls the underlying Dgemm and records the call.
+func (b *Instrumented) Dgemm(tA, tB blas.Transpose, m, n, k int, alpha float64, a []float64, lda int, b2 []float64, ldb int, beta float64, c []float64, ldc int) {
+	b.record("Dgemm", 2*m*n*k)
+	b.impl.Dgemm(tA, tB, m, n, k, alpha, a, lda, b2, ldb, beta, c, ldc)
+}
+
+// Dsymm calls the underlying Dsymm and records the call.
+func (b *Instrumented) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64, a []float64, lda int, b2 []float64, ldb int, beta float64, c []float64, ldc int) {
+	flops := 2 * m * m * n
+	if s == blas.Right {
+		flops = 2 * m * n * n
+	}
+	b.record("Dsymm", flops)
+	b.impl.Dsymm(s, ul, m, n, alpha, a, lda, b2, ldb, beta, c, ldc)
+}
+
+// Dsyrk calls the underlying Dsyrk and records the call.
+func (b *Instrumented) Dsyrk(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	b.record("Dsyrk", k*n*(n+1))
+	b.impl.Dsyrk(ul, t, n, k, alpha, a, lda, beta, c, ldc)
+}
+
+// Dsyr2k calls the underlying Dsyr2k and records the call.
+func (b *Instrumented) Dsyr2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha float64, a []float64, lda int, b2 []float64, ldb int, beta float64, c []float64, ldc int) {
+	b.record("Dsyr2k", 2*k*n*(n+1))
+	b.impl.Dsyr2k(ul, t, n, k, alpha, a, lda, b2, ldb, beta, c, ldc)
+}
+
+// Dtrmm calls the underlying Dtrmm and records the call.
+func (b *Instrumented) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b2 []float64, ldb int) {
+	b.record("Dtrmm", triSideFlops(s, d, m, n))
+	b.impl.Dtrmm(s, ul, tA, d, m, n, alpha, a, lda, b2, ldb)
+}
+
+// Dtrsm calls the underlying Dtrsm and records the call.
+func (b *Instrumented) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b2 []float64, ldb int) {
+	b.record("Dtrsm", triSideFlops(s, d, m, n))
+	b.impl.Dtrsm(s, ul, tA, d, m, n, alpha, a, lda, b2, ldb)
+}
+
+// triSideFlops returns the number of floating point operations needed to
+// multiply an m×n matrix by, or solve a system with, a triangular matrix from
+// the given side.
+func triSideFlops(s blas.Side, d blas.Diag, m, n int) int {
+	if s == blas.Left {
+		return n * triFlops(d, m, m*(m+1)/2)
+	}
+	return m * triFlops(d, n, n*(n+1)/2)
+}
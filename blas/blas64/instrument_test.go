@@ -0,0 +1,101 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas64
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+func TestInstrumented(t *testing.T) {
+	const m, n, k = 4, 3, 5
+	a := make([]float64, m*k)
+	for i := range a {
+		a[i] = float64(i + 1)
+	}
+	b := make([]float64, k*n)
+	for i := range b {
+		b[i] = float64(2*i - 3)
+	}
+	x := []float64{1, -2, 3, -4, 5}
+
+	want := make([]float64, m*n)
+	impl := gonum.Implementation{}
+	impl.Dgemm(blas.NoTrans, blas.NoTrans, m, n, k, 1, a, k, b, n, 0, want, n)
+	wantDot := impl.Ddot(k, x, 1, x, 1)
+
+	instr := NewInstrumented(impl)
+	var got []float64
+	var gotDot float64
+	With(instr, func() {
+		c := General{Rows: m, Cols: n, Stride: n, Data: make([]float64, m*n)}
+		Gemm(blas.NoTrans, blas.NoTrans, 1,
+			General{Rows: m, Cols: k, Stride: k, Data: a},
+			General{Rows: k, Cols: n, Stride: n, Data: b},
+			0, c)
+		Gemm(blas.NoTrans, blas.NoTrans, 1,
+			General{Rows: m, Cols: k, Stride: k, Data: a},
+			General{Rows: k, Cols: n, Stride: n, Data: b},
+			0, c)
+		got = c.Data
+		gotDot = Dot(k, Vector{Inc: 1, Data: x}, Vector{Inc: 1, Data: x})
+		Scal(k, 2, Vector{Inc: 1, Data: make([]float64, k)})
+	})
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("result mismatch at %d: got %v, want %v", i, got[i], want[i])
+		}
+	}
+	if gotDot != wantDot {
+		t.Errorf("dot mismatch: got %v, want %v", gotDot, wantDot)
+	}
+
+	stats := instr.Stats()
+	for name, want := range map[string]Stats{
+		"Dgemm": {Calls: 2, Flops: 2 * 2 * m * n * k},
+		"Ddot":  {Calls: 1, Flops: 2 * k},
+		"Dscal": {Calls: 1, Flops: k},
+	} {
+		if stats[name] != want {
+			t.Errorf("unexpected stats for %s: got %+v, want %+v", name, stats[name], want)
+		}
+	}
+	if len(stats) != 3 {
+		t.Errorf("unexpected number of routines recorded: got %d, want 3", len(stats))
+	}
+
+	instr.Reset()
+	if len(instr.Stats()) != 0 {
+		t.Errorf("stats not cleared by Reset")
+	}
+
+	for _, test := range []struct {
+		s    blas.Side
+		d    blas.Diag
+		m, n int
+		want int64
+	}{
+		{blas.Left, blas.NonUnit, 3, 2, 2 * 9},
+		{blas.Left, blas.Unit, 3, 2, 2 * 6},
+		{blas.Right, blas.NonUnit, 3, 2, 3 * 4},
+		{blas.Right, blas.Unit, 3, 2, 3 * 2},
+	} {
+		instr.Reset()
+		na := test.m
+		if test.s == blas.Right {
+			na = test.n
+		}
+		tri := make([]float64, na*na)
+		for i := 0; i < na; i++ {
+			tri[i*na+i] = 1
+		}
+		instr.Dtrsm(test.s, blas.Upper, blas.NoTrans, test.d, test.m, test.n, 1, tri, na, make([]float64, test.m*test.n), test.n)
+		if got := instr.Stats()["Dtrsm"].Flops; got != test.want {
+			t.Errorf("unexpected Dtrsm flops for side %c, diag %c: got %d, want %d", test.s, test.d, got, test.want)
+		}
+	}
+}
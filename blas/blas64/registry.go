@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas64
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+// Reference is the name under which the pure Go reference implementation,
+// gonum.Implementation, is registered.
+const Reference = "gonum"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]blas.Float64{Reference: gonum.Implementation{}}
+)
+
+// Register makes the BLAS float64 implementation b available under the given
+// name. Register panics if name is empty or already registered, or if b is nil.
+func Register(name string, b blas.Float64) {
+	if name == "" {
+		panic("blas64: empty implementation name")
+	}
+	if b == nil {
+		panic("blas64: nil implementation")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("blas64: implementation " + name + " already registered")
+	}
+	registry[name] = b
+}
+
+// Registered returns the names of the registered BLAS float64 implementations
+// in sorted order. The reference implementation is always registered.
+func Registered() []string {
+	registryMu.RLock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the BLAS float64 implementation registered under the given
+// name, and whether the name is registered.
+func Lookup(name string) (b blas.Float64, ok bool) {
+	registryMu.RLock()
+	b, ok = registry[name]
+	registryMu.RUnlock()
+	return b, ok
+}
+
+// Name returns the name under which the current BLAS float64 implementation is
+// registered. If the current implementation is not registered, Name returns
+// the empty string.
+func Name() string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if !reflect.TypeOf(blas64).Comparable() {
+		return ""
+	}
+	for name, b := range registry {
+		if reflect.TypeOf(b).Comparable() && b == blas64 {
+			return name
+		}
+	}
+	return ""
+}
+
+// With sets the BLAS float64 implementation to b for the duration of the call
+// to fn, and restores the previous implementation when fn returns or panics.
+// If b is nil, the reference implementation is used.
+//
+// Like Use, With changes the implementation used by all BLAS calls, so it must
+// not be called concurrently with other BLAS calls.
+func With(b blas.Float64, fn func()) {
+	prev := blas64
+	defer func() { blas64 = prev }()
+	Use(b)
+	fn()
+}
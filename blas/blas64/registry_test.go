@@ -0,0 +1,88 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package blas64
+
+import (
+	"testing"
+
+	"gonum.org/v1/gonum/blas/gonum"
+)
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return false
+}
+
+func TestRegistry(t *testing.T) {
+	if Name() != Reference {
+		t.Errorf("unexpected default implementation name: got %q, want %q", Name(), Reference)
+	}
+	b, ok := Lookup(Reference)
+	if !ok {
+		t.Fatalf("reference implementation not registered")
+	}
+	if _, ok := b.(gonum.Implementation); !ok {
+		t.Errorf("unexpected reference implementation type %T", b)
+	}
+	if _, ok := Lookup("missing"); ok {
+		t.Errorf("unexpected implementation found for unregistered name")
+	}
+
+	type other struct{ gonum.Implementation }
+	Register("test-other", other{})
+	defer func() {
+		registryMu.Lock()
+		delete(registry, "test-other")
+		registryMu.Unlock()
+	}()
+	names := Registered()
+	if len(names) != 2 || names[0] != Reference || names[1] != "test-other" {
+		t.Errorf("unexpected registered names: %v", names)
+	}
+
+	if !panics(func() { Register(Reference, other{}) }) {
+		t.Errorf("expected panic registering an existing name")
+	}
+	if !panics(func() { Register("", other{}) }) {
+		t.Errorf("expected panic registering an empty name")
+	}
+	if !panics(func() { Register("test-nil", nil) }) {
+		t.Errorf("expected panic registering a nil implementation")
+	}
+
+	With(other{}, func() {
+		if Name() != "test-other" {
+			t.Errorf("unexpected implementation name inside With: got %q, want %q", Name(), "test-other")
+		}
+	})
+	if Name() != Reference {
+		t.Errorf("implementation not restored after With: got %q", Name())
+	}
+
+	instr := NewInstrumented(nil)
+	panicked := panics(func() {
+		With(instr, func() {
+			if Name() != "" {
+				t.Errorf("unexpected name for unregistered implementation: %q", Name())
+			}
+			panic("test")
+		})
+	})
+	if !panicked {
+		t.Errorf("expected panic to propagate from With")
+	}
+	if Name() != Reference {
+		t.Errorf("implementation not restored after panic in With: got %q", Name())
+	}
+
+	Use(other{})
+	Use(nil)
+	if Name() != Reference {
+		t.Errorf("reference implementation not restored by Use(nil): got %q", Name())
+	}
+}
@@ -133,38 +133,8 @@ func (Implementation) Cher2k(ul blas.Uplo, t blas.Transpose, n, k int, alpha com
 	panic(noComplex)
 }
 
-// Level 1 complex128 routines.
-
-func (Implementation) Zdotu(n int, x []complex128, incX int, y []complex128, incY int) (dotu complex128) {
-	panic(noComplex)
-}
-func (Implementation) Zdotc(n int, x []complex128, incX int, y []complex128, incY int) (dotc complex128) {
-	panic(noComplex)
-}
-func (Implementation) Dznrm2(n int, x []complex128, incX int) float64 {
-	panic(noComplex)
-}
-func (Implementation) Dzasum(n int, x []complex128, incX int) float64 {
-	panic(noComplex)
-}
-func (Implementation) Izamax(n int, x []complex128, incX int) int {
-	panic(noComplex)
-}
-func (Implementation) Zswap(n int, x []complex128, incX int, y []complex128, incY int) {
-	panic(noComplex)
-}
-func (Implementation) Zscal(n int, alpha complex128, x []complex128, incX int) {
-	panic(noComplex)
-}
-func (Implementation) Zdscal(n int, alpha float64, x []complex128, incX int) {
-	panic(noComplex)
-}
-
 // Level 2 complex128 routines.
 
-func (Implementation) Zgemv(tA blas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
-	panic(noComplex)
-}
 func (Implementation) Zgbmv(tA blas.Transpose, m, n int, kL int, kU int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	panic(noComplex)
 }
@@ -195,12 +165,6 @@ func (Implementation) Zhbmv(ul blas.Uplo, n, k int, alpha complex128, a []comple
 func (Implementation) Zhpmv(ul blas.Uplo, n int, alpha complex128, ap []complex128, x []complex128, incX int, beta complex128, y []complex128, incY int) {
 	panic(noComplex)
 }
-func (Implementation) Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
-	panic(noComplex)
-}
-func (Implementation) Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int) {
-	panic(noComplex)
-}
 func (Implementation) Zher(ul blas.Uplo, n int, alpha float64, x []complex128, incX int, a []complex128, lda int) {
 	panic(noComplex)
 }
@@ -216,9 +180,6 @@ func (Implementation) Zhpr2(ul blas.Uplo, n int, alpha complex128, x []complex12
 
 // Level 3 complex128 routines.
 
-func (Implementation) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
-	panic(noComplex)
-}
 func (Implementation) Zsymm(s blas.Side, ul blas.Uplo, m, n int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
 	panic(noComplex)
 }
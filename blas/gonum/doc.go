@@ -9,6 +9,12 @@ Package native is a Go implementation of the BLAS API. This implementation
 panics when the input arguments are invalid as per the standard, for example
 if a vector increment is zero. Please note that the treatment of NaN values
 is not specified, and differs among the BLAS implementations.
+
+The level 3 routines Dgemm, Dsymm, Dsyrk, Dsyr2k, Dtrmm and Dtrsm, and their
+single precision counterparts, partition large matrices into blocks that are
+computed concurrently. The maximum number of goroutines used is set by
+SetWorkers and defaults to runtime.GOMAXPROCS.
+
 gonum.org/v1/gonum/blas/blas64 provides helpful wrapper functions to the BLAS
 interface. The rest of this text describes the layout of the data for the input types.
 
@@ -6,7 +6,14 @@
 
 package gonum
 
-import "math"
+import (
+	"math"
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"gonum.org/v1/gonum/blas"
+)
 
 type Implementation struct{}
 
@@ -45,11 +52,116 @@ const (
 	buffMul     = 4  // how big is the buffer relative to the number of workers
 )
 
-// subMul is a common type shared by [SD]gemm.
+// subMul is a common type shared by [SD]gemm and the parallel level 3
+// routines.
 type subMul struct {
 	i, j int // index of block
 }
 
+// workers is the maximum number of goroutines used by the parallel level 3
+// routines. Values less than one indicate that runtime.GOMAXPROCS is used.
+var workers int32
+
+// SetWorkers sets the maximum number of goroutines used to compute the level 3
+// BLAS routines. If n is less than one, the number of goroutines is given by
+// runtime.GOMAXPROCS at the time of each call, which is the default behavior.
+// SetWorkers(1) causes the level 3 routines to be computed serially.
+//
+// SetWorkers may be called concurrently with the BLAS routines.
+func SetWorkers(n int) {
+	if n > math.MaxInt32 {
+		n = math.MaxInt32
+	}
+	atomic.StoreInt32(&workers, int32(n))
+}
+
+// Workers returns the maximum number of goroutines used to compute the level 3
+// BLAS routines.
+func Workers() int {
+	n := int(atomic.LoadInt32(&workers))
+	if n < 1 {
+		return runtime.GOMAXPROCS(0)
+	}
+	return n
+}
+
+// useParallel returns whether a level 3 operation that is split into nblocks
+// independent blocks should be computed concurrently.
+func useParallel(nblocks int) bool {
+	return nblocks >= minParBlock && Workers() > 1
+}
+
+// rectBlocks returns the starting indices of the blockSize×blockSize blocks
+// that partition an m×n matrix.
+func rectBlocks(m, n int) []subMul {
+	subs := make([]subMul, 0, blocks(m, blockSize)*blocks(n, blockSize))
+	for i := 0; i < m; i += blockSize {
+		for j := 0; j < n; j += blockSize {
+			subs = append(subs, subMul{i: i, j: j})
+		}
+	}
+	return subs
+}
+
+// triBlocks returns the starting indices of the blockSize×blockSize blocks
+// that partition the upper or lower triangle of an n×n matrix, as specified
+// by ul.
+func triBlocks(ul blas.Uplo, n int) []subMul {
+	nb := blocks(n, blockSize)
+	subs := make([]subMul, 0, nb*(nb+1)/2)
+	for i := 0; i < n; i += blockSize {
+		if ul == blas.Upper {
+			for j := i; j < n; j += blockSize {
+				subs = append(subs, subMul{i: i, j: j})
+			}
+		} else {
+			for j := 0; j <= i; j += blockSize {
+				subs = append(subs, subMul{i: i, j: j})
+			}
+		}
+	}
+	return subs
+}
+
+// runParallel calls fn for each of the blocks in subs using a pool of worker
+// goroutines, and returns once all of the blocks have been processed. The
+// computations for different blocks must be independent.
+func runParallel(subs []subMul, fn func(i, j int)) {
+	nWorkers := Workers()
+	if len(subs) < nWorkers {
+		nWorkers = len(subs)
+	}
+	if nWorkers <= 1 {
+		for _, sub := range subs {
+			fn(sub.i, sub.j)
+		}
+		return
+	}
+	// There is a tradeoff between the workers having to wait for work
+	// and a large buffer making operations slow.
+	buf := buffMul * nWorkers
+	if buf > len(subs) {
+		buf = len(subs)
+	}
+	sendChan := make(chan subMul, buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < nWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for sub := range sendChan {
+				fn(sub.i, sub.j)
+			}
+		}()
+	}
+	for _, sub := range subs {
+		sendChan <- sub
+	}
+	close(sendChan)
+	wg.Wait()
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -7,7 +7,7 @@
 package gonum
 
 import (
-	math "gonum.org/v1/gonum/blas/gonum/internal/math32"
+	math "gonum.org/v1/gonum/internal/math32"
 
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/internal/asm/f32"
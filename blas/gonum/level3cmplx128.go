@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/internal/asm/c128"
+)
+
+// Zgemm performs one of the matrix-matrix operations
+//  C = alpha * op(A) * op(B) + beta * C
+// where op(X) is one of
+//  op(X) = X  or  op(X) = X^T  or  op(X) = X^H,
+// alpha and beta are scalars, and A, B and C are matrices, with op(A) an m×k matrix,
+// op(B) a k×n matrix and C an m×n matrix.
+func (Implementation) Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int) {
+	if tA != blas.NoTrans && tA != blas.Trans && tA != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if tB != blas.NoTrans && tB != blas.Trans && tB != blas.ConjTrans {
+		panic(badTranspose)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	if lda < max(1, colA) {
+		panic(badLdA)
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	if ldb < max(1, colB) {
+		panic(badLdB)
+	}
+	if ldc < max(1, n) {
+		panic(badLdC)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if len(a) < (rowA-1)*lda+colA && k > 0 {
+		panic(badLdA)
+	}
+	if len(b) < (rowB-1)*ldb+colB && k > 0 {
+		panic(badLdB)
+	}
+	if len(c) < (m-1)*ldc+n {
+		panic(badLdC)
+	}
+
+	// Form C = beta * C.
+	if beta != 1 {
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			if beta == 0 {
+				for j := range ci {
+					ci[j] = 0
+				}
+			} else {
+				c128.ScalUnitary(beta, ci)
+			}
+		}
+	}
+
+	if alpha == 0 || k == 0 {
+		return
+	}
+
+	// opA returns the (i,l) element of op(A).
+	opA := func(i, l int) complex128 {
+		switch tA {
+		case blas.NoTrans:
+			return a[i*lda+l]
+		case blas.Trans:
+			return a[l*lda+i]
+		default:
+			return cmplx.Conj(a[l*lda+i])
+		}
+	}
+
+	if tB == blas.NoTrans {
+		// Form C += alpha * op(A) * B.
+		for i := 0; i < m; i++ {
+			ci := c[i*ldc : i*ldc+n]
+			for l := 0; l < k; l++ {
+				tmp := alpha * opA(i, l)
+				if tmp != 0 {
+					c128.AxpyUnitary(tmp, b[l*ldb:l*ldb+n], ci)
+				}
+			}
+		}
+		return
+	}
+
+	// Form C += alpha * op(A) * B^T or C += alpha * op(A) * B^H.
+	conj := tB == blas.ConjTrans
+	for i := 0; i < m; i++ {
+		ci := c[i*ldc : i*ldc+n]
+		for j := range ci {
+			bj := b[j*ldb : j*ldb+k]
+			var sum complex128
+			for l, v := range bj {
+				if conj {
+					v = cmplx.Conj(v)
+				}
+				sum += opA(i, l) * v
+			}
+			ci[j] += alpha * sum
+		}
+	}
+}
@@ -71,6 +71,10 @@ func (Implementation) Dtrsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	if s == blas.Left && useParallel(blocks(n, blockSize)) || s == blas.Right && useParallel(blocks(m, blockSize)) {
+		dtrsmParallel(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+		return
+	}
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -290,6 +294,10 @@ func (Implementation) Dsymm(s blas.Side, ul blas.Uplo, m, n int, alpha float64,
 		}
 		return
 	}
+	if useParallel(blocks(m, blockSize) * blocks(n, blockSize)) {
+		dsymmParallel(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 
 	isUpper := ul == blas.Upper
 	if s == blas.Left {
@@ -430,6 +438,10 @@ func (Implementation) Dsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	if nb := blocks(n, blockSize); k > 0 && useParallel(nb*(nb+1)/2) {
+		dsyrkParallel(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -470,7 +482,7 @@ func (Implementation) Dsyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 	}
 	for i := 0; i < n; i++ {
 		ctmp := c[i*ldc : i*ldc+i+1]
-		if beta != 0 {
+		if beta != 1 {
 			for j := range ctmp {
 				ctmp[j] *= beta
 			}
@@ -555,6 +567,10 @@ func (Implementation) Dsyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 		}
 		return
 	}
+	if nb := blocks(n, blockSize); k > 0 && useParallel(nb*(nb+1)/2) {
+		dsyr2kParallel(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -679,6 +695,10 @@ func (Implementation) Dtrmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	if s == blas.Left && useParallel(blocks(n, blockSize)) || s == blas.Right && useParallel(blocks(m, blockSize)) {
+		dtrmmParallel(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+		return
+	}
 
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
@@ -0,0 +1,167 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// The routines in this file compute the level 3 operations concurrently by
+// partitioning the output matrix into blocks that can be updated
+// independently. The blocks are then computed by the serial routines. These
+// never recurse into the parallel code because each block is smaller than the
+// parallel threshold.
+
+// dsyrkParallel computes the Dsyrk operation concurrently. The referenced
+// triangle of C is partitioned into blockSize×blockSize blocks. The diagonal
+// blocks are updated by Dsyrk and the off-diagonal blocks by dgemmSerial.
+func dsyrkParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64, ldc int) {
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	runParallel(triBlocks(ul, n), func(i, j int) {
+		leni := min(blockSize, n-i)
+		lenj := min(blockSize, n-j)
+		var ai, aj []float64
+		if aTrans {
+			ai = a[i:]
+			aj = a[j:]
+		} else {
+			ai = a[i*lda:]
+			aj = a[j*lda:]
+		}
+		if i == j {
+			Implementation{}.Dsyrk(ul, tA, leni, k, alpha, ai, lda, beta, c[i*ldc+i:], ldc)
+			return
+		}
+		cSub := sliceView64(c, ldc, i, j, leni, lenj)
+		scaleBlock64(leni, lenj, beta, cSub, ldc)
+		dgemmSerial(aTrans, !aTrans, leni, lenj, k, ai, lda, aj, lda, cSub, ldc, alpha)
+	})
+}
+
+// dsyr2kParallel computes the Dsyr2k operation concurrently. The referenced
+// triangle of C is partitioned into blockSize×blockSize blocks. The diagonal
+// blocks are updated by Dsyr2k and the off-diagonal blocks by dgemmSerial.
+func dsyr2kParallel(ul blas.Uplo, tA blas.Transpose, n, k int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	aTrans := tA == blas.Trans || tA == blas.ConjTrans
+	runParallel(triBlocks(ul, n), func(i, j int) {
+		leni := min(blockSize, n-i)
+		lenj := min(blockSize, n-j)
+		var ai, aj, bi, bj []float64
+		if aTrans {
+			ai = a[i:]
+			aj = a[j:]
+			bi = b[i:]
+			bj = b[j:]
+		} else {
+			ai = a[i*lda:]
+			aj = a[j*lda:]
+			bi = b[i*ldb:]
+			bj = b[j*ldb:]
+		}
+		if i == j {
+			Implementation{}.Dsyr2k(ul, tA, leni, k, alpha, ai, lda, bi, ldb, beta, c[i*ldc+i:], ldc)
+			return
+		}
+		cSub := sliceView64(c, ldc, i, j, leni, lenj)
+		scaleBlock64(leni, lenj, beta, cSub, ldc)
+		dgemmSerial(aTrans, !aTrans, leni, lenj, k, ai, lda, bj, ldb, cSub, ldc, alpha)
+		dgemmSerial(aTrans, !aTrans, leni, lenj, k, bi, ldb, aj, lda, cSub, ldc, alpha)
+	})
+}
+
+// dsymmParallel computes the Dsymm operation concurrently. C is partitioned
+// into blockSize×blockSize blocks, each of which is updated by the sum over
+// the corresponding block row or column of A. Products with the diagonal
+// blocks of A are computed by Dsymm and the remaining products by
+// dgemmSerial, using the transpose of the stored block of A when the block is
+// in the unreferenced triangle.
+func dsymmParallel(s blas.Side, ul blas.Uplo, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int, beta float64, c []float64, ldc int) {
+	isUpper := ul == blas.Upper
+	runParallel(rectBlocks(m, n), func(i, j int) {
+		leni := min(blockSize, m-i)
+		lenj := min(blockSize, n-j)
+		cSub := sliceView64(c, ldc, i, j, leni, lenj)
+		scaleBlock64(leni, lenj, beta, cSub, ldc)
+		if s == blas.Left {
+			// C_ij += alpha * \sum_l A_il * B_lj.
+			for l := 0; l < m; l += blockSize {
+				lenl := min(blockSize, m-l)
+				bSub := b[l*ldb+j:]
+				switch {
+				case l == i:
+					Implementation{}.Dsymm(s, ul, leni, lenj, alpha, a[i*lda+i:], lda, bSub, ldb, 1, cSub, ldc)
+				case isUpper == (i < l):
+					dgemmSerial(false, false, leni, lenj, lenl, a[i*lda+l:], lda, bSub, ldb, cSub, ldc, alpha)
+				default:
+					dgemmSerial(true, false, leni, lenj, lenl, a[l*lda+i:], lda, bSub, ldb, cSub, ldc, alpha)
+				}
+			}
+			return
+		}
+		// C_ij += alpha * \sum_l B_il * A_lj.
+		for l := 0; l < n; l += blockSize {
+			lenl := min(blockSize, n-l)
+			bSub := b[i*ldb+l:]
+			switch {
+			case l == j:
+				Implementation{}.Dsymm(s, ul, leni, lenj, alpha, a[j*lda+j:], lda, bSub, ldb, 1, cSub, ldc)
+			case isUpper == (l < j):
+				dgemmSerial(false, false, leni, lenj, lenl, bSub, ldb, a[l*lda+j:], lda, cSub, ldc, alpha)
+			default:
+				dgemmSerial(false, true, leni, lenj, lenl, bSub, ldb, a[j*lda+l:], lda, cSub, ldc, alpha)
+			}
+		}
+	})
+}
+
+// dtrmmParallel computes the Dtrmm operation concurrently. When A multiplies
+// from the left the columns of B are transformed independently, otherwise the
+// rows of B are. B is partitioned into panels of blockSize columns or rows
+// respectively, and each panel is updated by Dtrmm.
+func dtrmmParallel(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	if s == blas.Left {
+		runParallel(rectBlocks(1, n), func(_, j int) {
+			Implementation{}.Dtrmm(s, ul, tA, d, m, min(blockSize, n-j), alpha, a, lda, b[j:], ldb)
+		})
+		return
+	}
+	runParallel(rectBlocks(m, 1), func(i, _ int) {
+		Implementation{}.Dtrmm(s, ul, tA, d, min(blockSize, m-i), n, alpha, a, lda, b[i*ldb:], ldb)
+	})
+}
+
+// dtrsmParallel computes the Dtrsm operation concurrently. When A multiplies
+// from the left the columns of X are solved for independently, otherwise the
+// rows of X are. B is partitioned into panels of blockSize columns or rows
+// respectively, and each panel is solved by Dtrsm.
+func dtrsmParallel(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas.Diag, m, n int, alpha float64, a []float64, lda int, b []float64, ldb int) {
+	if s == blas.Left {
+		runParallel(rectBlocks(1, n), func(_, j int) {
+			Implementation{}.Dtrsm(s, ul, tA, d, m, min(blockSize, n-j), alpha, a, lda, b[j:], ldb)
+		})
+		return
+	}
+	runParallel(rectBlocks(m, 1), func(i, _ int) {
+		Implementation{}.Dtrsm(s, ul, tA, d, min(blockSize, m-i), n, alpha, a, lda, b[i*ldb:], ldb)
+	})
+}
+
+// scaleBlock64 scales the m×n matrix C by beta. If beta is zero, C is set to
+// zero without reading its elements.
+func scaleBlock64(m, n int, beta float64, c []float64, ldc int) {
+	if beta == 1 {
+		return
+	}
+	for i := 0; i < m; i++ {
+		ctmp := c[i*ldc : i*ldc+n]
+		if beta == 0 {
+			for j := range ctmp {
+				ctmp[j] = 0
+			}
+			continue
+		}
+		for j := range ctmp {
+			ctmp[j] *= beta
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"fmt"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// testParallelSerial calls fn with the given data serially and in parallel,
+// and checks that the results match.
+func testParallelSerial(t *testing.T, name string, c general64, fn func(c []float64)) {
+	defer SetWorkers(0)
+
+	want := c.clone()
+	SetWorkers(1)
+	fn(want.data)
+
+	got := c.clone()
+	SetWorkers(4)
+	fn(got.data)
+
+	if !got.equalWithinAbs(want, 1e-12) {
+		t.Errorf("%s: result mismatch between parallel and serial", name)
+	}
+}
+
+func TestLevel3Parallel(t *testing.T) {
+	const extra = 3
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{5, 4, 3},
+		{blockSize*2 + 7, blockSize*3 - 5, blockSize + 11},
+		{blockSize * 4, blockSize*minParBlock + 1, 17},
+		{blockSize*3 + 5, 9, blockSize * 2},
+		{9, blockSize*3 + 5, 1},
+	} {
+		m, n, k := test.m, test.n, test.k
+		for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, beta := range []float64{0, 1, 0.7} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					row, col := n, k
+					if tA == blas.Trans {
+						row, col = k, n
+					}
+					a := randmat(row, col, col+extra)
+					b := randmat(row, col, col+extra)
+					c := randmat(n, n, n+extra)
+					name := fmt.Sprintf("Dsyrk ul=%c,tA=%c,n=%d,k=%d,beta=%v", ul, tA, n, k, beta)
+					testParallelSerial(t, name, c, func(c []float64) {
+						impl.Dsyrk(ul, tA, n, k, 1.3, a.data, a.stride, beta, c, n+extra)
+					})
+					name = fmt.Sprintf("Dsyr2k ul=%c,tA=%c,n=%d,k=%d,beta=%v", ul, tA, n, k, beta)
+					testParallelSerial(t, name, c, func(c []float64) {
+						impl.Dsyr2k(ul, tA, n, k, 1.3, a.data, a.stride, b.data, b.stride, beta, c, n+extra)
+					})
+				}
+				for _, s := range []blas.Side{blas.Left, blas.Right} {
+					na := m
+					if s == blas.Right {
+						na = n
+					}
+					a := randmat(na, na, na+extra)
+					b := randmat(m, n, n+extra)
+					c := randmat(m, n, n+extra)
+					name := fmt.Sprintf("Dsymm s=%c,ul=%c,m=%d,n=%d,beta=%v", s, ul, m, n, beta)
+					testParallelSerial(t, name, c, func(c []float64) {
+						impl.Dsymm(s, ul, m, n, 1.3, a.data, a.stride, b.data, b.stride, beta, c, n+extra)
+					})
+				}
+			}
+			for _, s := range []blas.Side{blas.Left, blas.Right} {
+				for _, tA := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+					for _, d := range []blas.Diag{blas.NonUnit, blas.Unit} {
+						na := m
+						if s == blas.Right {
+							na = n
+						}
+						a := randmat(na, na, na+extra)
+						// Make A diagonally dominant so that the solution
+						// of the triangular system is well conditioned.
+						for i := 0; i < na; i++ {
+							a.data[i*a.stride+i] += float64(na)
+						}
+						b := randmat(m, n, n+extra)
+						name := fmt.Sprintf("Dtrmm s=%c,ul=%c,tA=%c,d=%c,m=%d,n=%d", s, ul, tA, d, m, n)
+						testParallelSerial(t, name, b, func(b []float64) {
+							impl.Dtrmm(s, ul, tA, d, m, n, 1.3, a.data, a.stride, b, n+extra)
+						})
+						name = fmt.Sprintf("Dtrsm s=%c,ul=%c,tA=%c,d=%c,m=%d,n=%d", s, ul, tA, d, m, n)
+						testParallelSerial(t, name, b, func(b []float64) {
+							impl.Dtrsm(s, ul, tA, d, m, n, 1.3, a.data, a.stride, b, n+extra)
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestSetWorkers(t *testing.T) {
+	defer SetWorkers(0)
+
+	SetWorkers(3)
+	if got := Workers(); got != 3 {
+		t.Errorf("unexpected number of workers: got %d, want 3", got)
+	}
+	SetWorkers(0)
+	if got := Workers(); got < 1 {
+		t.Errorf("unexpected default number of workers: got %d", got)
+	}
+}
@@ -75,6 +75,10 @@ func (Implementation) Strsm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	if s == blas.Left && useParallel(blocks(n, blockSize)) || s == blas.Right && useParallel(blocks(m, blockSize)) {
+		strsmParallel(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+		return
+	}
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
 		if tA == blas.NoTrans {
@@ -296,6 +300,10 @@ func (Implementation) Ssymm(s blas.Side, ul blas.Uplo, m, n int, alpha float32,
 		}
 		return
 	}
+	if useParallel(blocks(m, blockSize) * blocks(n, blockSize)) {
+		ssymmParallel(s, ul, m, n, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 
 	isUpper := ul == blas.Upper
 	if s == blas.Left {
@@ -438,6 +446,10 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 		}
 		return
 	}
+	if nb := blocks(n, blockSize); k > 0 && useParallel(nb*(nb+1)/2) {
+		ssyrkParallel(ul, tA, n, k, alpha, a, lda, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -478,7 +490,7 @@ func (Implementation) Ssyrk(ul blas.Uplo, tA blas.Transpose, n, k int, alpha flo
 	}
 	for i := 0; i < n; i++ {
 		ctmp := c[i*ldc : i*ldc+i+1]
-		if beta != 0 {
+		if beta != 1 {
 			for j := range ctmp {
 				ctmp[j] *= beta
 			}
@@ -565,6 +577,10 @@ func (Implementation) Ssyr2k(ul blas.Uplo, tA blas.Transpose, n, k int, alpha fl
 		}
 		return
 	}
+	if nb := blocks(n, blockSize); k > 0 && useParallel(nb*(nb+1)/2) {
+		ssyr2kParallel(ul, tA, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+		return
+	}
 	if tA == blas.NoTrans {
 		if ul == blas.Upper {
 			for i := 0; i < n; i++ {
@@ -691,6 +707,10 @@ func (Implementation) Strmm(s blas.Side, ul blas.Uplo, tA blas.Transpose, d blas
 		}
 		return
 	}
+	if s == blas.Left && useParallel(blocks(n, blockSize)) || s == blas.Right && useParallel(blocks(m, blockSize)) {
+		strmmParallel(s, ul, tA, d, m, n, alpha, a, lda, b, ldb)
+		return
+	}
 
 	nonUnit := d == blas.NonUnit
 	if s == blas.Left {
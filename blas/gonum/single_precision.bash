@@ -30,7 +30,7 @@ cat level1double.go \
       -e "s_^\(func (Implementation) \)Id\(.*\)\$_$WARNING\1Is\2_" \
       -e 's_^// Id_// Is_' \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
-      -e 's_"math"_math "gonum.org/v1/gonum/blas/gonum/internal/math32"_' \
+      -e 's_"math"_math "gonum.org/v1/gonum/internal/math32"_' \
 >> level1single.go
 
 echo Generating level1single_sdot.go
@@ -108,6 +108,12 @@ cat level3double.go \
 \
 | gofmt -r 'float64 -> float32' \
 \
+| gofmt -r 'dsymmParallel -> ssymmParallel' \
+| gofmt -r 'dsyrkParallel -> ssyrkParallel' \
+| gofmt -r 'dsyr2kParallel -> ssyr2kParallel' \
+| gofmt -r 'dtrmmParallel -> strmmParallel' \
+| gofmt -r 'dtrsmParallel -> strsmParallel' \
+\
 | gofmt -r 'f64.AxpyUnitaryTo -> f32.AxpyUnitaryTo' \
 | gofmt -r 'f64.DotUnitary -> f32.DotUnitary' \
 \
@@ -116,6 +122,32 @@ cat level3double.go \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> level3single.go
 
+echo Generating level3single_parallel.go
+echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > level3single_parallel.go
+cat level3double_parallel.go \
+| gofmt -r 'float64 -> float32' \
+| gofmt -r 'sliceView64 -> sliceView32' \
+| gofmt -r 'scaleBlock64 -> scaleBlock32' \
+\
+| gofmt -r 'dgemmSerial -> sgemmSerial' \
+| gofmt -r 'dsymmParallel -> ssymmParallel' \
+| gofmt -r 'dsyrkParallel -> ssyrkParallel' \
+| gofmt -r 'dsyr2kParallel -> ssyr2kParallel' \
+| gofmt -r 'dtrmmParallel -> strmmParallel' \
+| gofmt -r 'dtrsmParallel -> strsmParallel' \
+\
+| gofmt -r 'Implementation{}.Dsymm -> Implementation{}.Ssymm' \
+| gofmt -r 'Implementation{}.Dsyrk -> Implementation{}.Ssyrk' \
+| gofmt -r 'Implementation{}.Dsyr2k -> Implementation{}.Ssyr2k' \
+| gofmt -r 'Implementation{}.Dtrmm -> Implementation{}.Strmm' \
+| gofmt -r 'Implementation{}.Dtrsm -> Implementation{}.Strsm' \
+\
+| sed -e 's_^// d_// s_' \
+      -e 's_dgemmSerial_sgemmSerial_g' \
+      -e 's_scaleBlock64_scaleBlock32_g' \
+      -e 's_ D\(sy[mr]\|tr[ms]\)_ S\1_g' \
+>> level3single_parallel.go
+
 echo Generating general_single.go
 echo -e '// Code generated by "go generate gonum.org/v1/gonum/blas/gonum”; DO NOT EDIT.\n' > general_single.go
 cat general_double.go \
@@ -125,7 +157,7 @@ cat general_double.go \
 | gofmt -r 'newGeneral64 -> newGeneral32' \
 \
 | sed -e 's/(g general64) print()/(g general32) print()/' \
-      -e 's_"math"_math "gonum.org/v1/gonum/blas/gonum/internal/math32"_' \
+      -e 's_"math"_math "gonum.org/v1/gonum/internal/math32"_' \
       -e 's_"gonum.org/v1/gonum/internal/asm/f64"_"gonum.org/v1/gonum/internal/asm/f32"_' \
 >> general_single.go
 
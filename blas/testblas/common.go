@@ -2,6 +2,8 @@ package testblas
 
 import (
 	"math"
+	"math/cmplx"
+	"math/rand"
 	"testing"
 
 	"gonum.org/v1/gonum/blas"
@@ -261,3 +263,72 @@ func zsame(x, y []complex128) bool {
 	}
 	return true
 }
+
+// makeZVector returns a random complex vector of length n stored with the
+// increment inc. The elements that are not part of the vector are set to NaN.
+func makeZVector(rnd *rand.Rand, n, inc int) []complex128 {
+	if n == 0 {
+		return nil
+	}
+	inc = abs(inc)
+	x := make([]complex128, (n-1)*inc+1)
+	for i := range x {
+		x[i] = complex(math.NaN(), math.NaN())
+	}
+	for i := 0; i < n; i++ {
+		x[i*inc] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+	}
+	return x
+}
+
+// makeZGeneral returns a random m×n complex matrix stored with the leading
+// dimension ld. The elements that are not part of the matrix are set to NaN.
+func makeZGeneral(rnd *rand.Rand, m, n, ld int) []complex128 {
+	var a []complex128
+	if m > 0 && n > 0 {
+		a = make([]complex128, (m-1)*ld+n)
+	}
+	for i := range a {
+		a[i] = complex(math.NaN(), math.NaN())
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			a[i*ld+j] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+		}
+	}
+	return a
+}
+
+// zEqualApprox returns whether the slices x and y are element-wise equal
+// within the absolute tolerance tol. NaN elements are considered equal.
+func zEqualApprox(x, y []complex128, tol float64) bool {
+	if len(x) != len(y) {
+		return false
+	}
+	for i, v := range x {
+		w := y[i]
+		if math.IsNaN(real(v)) && math.IsNaN(real(w)) {
+			continue
+		}
+		if cmplx.Abs(v-w) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+// zStridedIndex returns the index in a slice of the i-th element of a vector
+// of length n stored with the increment inc.
+func zStridedIndex(i, n, inc int) int {
+	if inc < 0 {
+		return (n - 1 - i) * -inc
+	}
+	return i * inc
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
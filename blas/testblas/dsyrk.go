@@ -159,6 +159,120 @@ func DsyrkTest(t *testing.T, blasser Dsyker) {
 				{57, 127, 195},
 			},
 		},
+		// With beta == 0 the input values of C must be ignored.
+		{
+			ul:    blas.Upper,
+			tA:    blas.NoTrans,
+			n:     3,
+			k:     2,
+			alpha: 3,
+			a: [][]float64{
+				{1, 2},
+				{3, 4},
+				{5, 6},
+			},
+			c: [][]float64{
+				{1, 2, 3},
+				{0, 4, 5},
+				{0, 0, 6},
+			},
+			beta: 0,
+			ans: [][]float64{
+				{15, 33, 51},
+				{0, 75, 117},
+				{0, 0, 183},
+			},
+		},
+		{
+			ul:    blas.Lower,
+			tA:    blas.NoTrans,
+			n:     3,
+			k:     2,
+			alpha: 3,
+			a: [][]float64{
+				{1, 2},
+				{3, 4},
+				{5, 6},
+			},
+			c: [][]float64{
+				{1, 0, 0},
+				{2, 4, 0},
+				{3, 5, 6},
+			},
+			beta: 0,
+			ans: [][]float64{
+				{15, 0, 0},
+				{33, 75, 0},
+				{51, 117, 183},
+			},
+		},
+		{
+			ul:    blas.Upper,
+			tA:    blas.Trans,
+			n:     3,
+			k:     2,
+			alpha: 3,
+			a: [][]float64{
+				{1, 3, 5},
+				{2, 4, 6},
+			},
+			c: [][]float64{
+				{1, 2, 3},
+				{0, 4, 5},
+				{0, 0, 6},
+			},
+			beta: 0,
+			ans: [][]float64{
+				{15, 33, 51},
+				{0, 75, 117},
+				{0, 0, 183},
+			},
+		},
+		{
+			ul:    blas.Lower,
+			tA:    blas.Trans,
+			n:     3,
+			k:     2,
+			alpha: 3,
+			a: [][]float64{
+				{1, 3, 5},
+				{2, 4, 6},
+			},
+			c: [][]float64{
+				{1, 0, 0},
+				{2, 4, 0},
+				{3, 5, 6},
+			},
+			beta: 0,
+			ans: [][]float64{
+				{15, 0, 0},
+				{33, 75, 0},
+				{51, 117, 183},
+			},
+		},
+		// With beta == 1 C must be updated without scaling.
+		{
+			ul:    blas.Lower,
+			tA:    blas.Trans,
+			n:     3,
+			k:     2,
+			alpha: 3,
+			a: [][]float64{
+				{1, 3, 5},
+				{2, 4, 6},
+			},
+			c: [][]float64{
+				{1, 0, 0},
+				{2, 4, 0},
+				{3, 5, 6},
+			},
+			beta: 1,
+			ans: [][]float64{
+				{16, 0, 0},
+				{35, 79, 0},
+				{54, 122, 189},
+			},
+		},
 	} {
 		aFlat := flatten(test.a)
 		cFlat := flatten(test.c)
@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dznrm2er interface {
+	Dznrm2(n int, x []complex128, incX int) float64
+}
+
+func Dznrm2Test(t *testing.T, impl Dznrm2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, incX := range []int{1, 2, 5} {
+			for _, scale := range []float64{1e-200, 1, 1e200} {
+				x := makeZVector(rnd, n, incX)
+				var want float64
+				for i := 0; i < n; i++ {
+					v := x[i*incX]
+					want += real(v)*real(v) + imag(v)*imag(v)
+					x[i*incX] = complex(scale*real(v), scale*imag(v))
+				}
+				want = scale * math.Sqrt(want)
+
+				got := impl.Dznrm2(n, x, incX)
+
+				prefix := fmt.Sprintf("n=%v,incX=%v,scale=%v", n, incX, scale)
+				if math.Abs(got-want) > 1e-14*want {
+					t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+				}
+			}
+		}
+	}
+	if got := impl.Dznrm2(2, []complex128{1, 2}, -1); got != 0 {
+		t.Errorf("unexpected result for negative incX: got %v", got)
+	}
+	if got := impl.Dznrm2(2, []complex128{1, complex(math.Inf(1), 0)}, 1); !math.IsInf(got, 1) {
+		t.Errorf("unexpected result for infinite element: got %v", got)
+	}
+}
+
+type Dzasumer interface {
+	Dzasum(n int, x []complex128, incX int) float64
+}
+
+func DzasumTest(t *testing.T, impl Dzasumer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, incX := range []int{-1, 1, 2, 5} {
+			x := makeZVector(rnd, n, incX)
+			var want float64
+			if incX > 0 {
+				for i := 0; i < n; i++ {
+					want += math.Abs(real(x[i*incX])) + math.Abs(imag(x[i*incX]))
+				}
+			}
+
+			got := impl.Dzasum(n, x, incX)
+
+			if math.Abs(got-want) > 1e-14*math.Max(1, want) {
+				t.Errorf("n=%v,incX=%v: unexpected result: want %v, got %v", n, incX, want, got)
+			}
+		}
+	}
+}
+
+type Izamaxer interface {
+	Izamax(n int, x []complex128, incX int) int
+}
+
+func IzamaxTest(t *testing.T, impl Izamaxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, incX := range []int{-1, 1, 2, 5} {
+			x := makeZVector(rnd, n, incX)
+			want := -1
+			if incX > 0 {
+				var max float64
+				for i := 0; i < n; i++ {
+					v := math.Abs(real(x[i*incX])) + math.Abs(imag(x[i*incX]))
+					if want == -1 || v > max {
+						max = v
+						want = i
+					}
+				}
+			}
+
+			got := impl.Izamax(n, x, incX)
+
+			if got != want {
+				t.Errorf("n=%v,incX=%v: unexpected index: want %v, got %v", n, incX, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Zdotcer interface {
+	Zdotc(n int, x []complex128, incX int, y []complex128, incY int) complex128
+}
+
+func ZdotcTest(t *testing.T, impl Zdotcer) {
+	testZdot(t, "Zdotc", impl.Zdotc, true)
+}
+
+type Zdotuer interface {
+	Zdotu(n int, x []complex128, incX int, y []complex128, incY int) complex128
+}
+
+func ZdotuTest(t *testing.T, impl Zdotuer) {
+	testZdot(t, "Zdotu", impl.Zdotu, false)
+}
+
+func testZdot(t *testing.T, name string, dot func(int, []complex128, int, []complex128, int) complex128, conj bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, inc := range allPairs([]int{-7, -3, 1, 4}, []int{-5, -2, 1, 3}) {
+			incX := inc[0]
+			incY := inc[1]
+			if n == 0 {
+				if got := dot(0, nil, incX, nil, incY); got != 0 {
+					t.Errorf("%v: unexpected result for n=0: got %v", name, got)
+				}
+				continue
+			}
+			x := makeZVector(rnd, n, incX)
+			y := makeZVector(rnd, n, incY)
+			xCopy := make([]complex128, len(x))
+			copy(xCopy, x)
+			yCopy := make([]complex128, len(y))
+			copy(yCopy, y)
+
+			var want complex128
+			for i := 0; i < n; i++ {
+				xi := x[zStridedIndex(i, n, incX)]
+				if conj {
+					xi = cmplx.Conj(xi)
+				}
+				want += xi * y[zStridedIndex(i, n, incY)]
+			}
+
+			got := dot(n, x, incX, y, incY)
+
+			prefix := fmt.Sprintf("%v: n=%v,incX=%v,incY=%v", name, n, incX, incY)
+			if !zsame(x, xCopy) {
+				t.Errorf("%v: unexpected modification of x", prefix)
+			}
+			if !zsame(y, yCopy) {
+				t.Errorf("%v: unexpected modification of y", prefix)
+			}
+			if cmplx.Abs(got-want) > 1e-13 {
+				t.Errorf("%v: unexpected result: want %v, got %v", prefix, want, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgemmer interface {
+	Zgemm(tA, tB blas.Transpose, m, n, k int, alpha complex128, a []complex128, lda int, b []complex128, ldb int, beta complex128, c []complex128, ldc int)
+}
+
+func ZgemmTest(t *testing.T, impl Zgemmer) {
+	rnd := rand.New(rand.NewSource(1))
+	transes := []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans}
+	for _, tA := range transes {
+		for _, tB := range transes {
+			for _, mnk := range [][3]int{{1, 1, 1}, {1, 2, 3}, {3, 2, 1}, {2, 3, 4}, {5, 4, 3}, {7, 7, 7}, {4, 6, 0}} {
+				for _, ab := range [][2]complex128{{0, 0}, {0, 1}, {1, 0}, {2 - 1i, 0.5i}} {
+					for _, extra := range []int{0, 3} {
+						testZgemm(t, impl, rnd, tA, tB, mnk[0], mnk[1], mnk[2], ab[0], ab[1], extra)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testZgemm(t *testing.T, impl Zgemmer, rnd *rand.Rand, tA, tB blas.Transpose, m, n, k int, alpha, beta complex128, extra int) {
+	rowA, colA := m, k
+	if tA != blas.NoTrans {
+		rowA, colA = k, m
+	}
+	rowB, colB := k, n
+	if tB != blas.NoTrans {
+		rowB, colB = n, k
+	}
+	lda := max(1, colA) + extra
+	ldb := max(1, colB) + extra
+	ldc := n + extra
+	a := makeZGeneral(rnd, rowA, colA, lda)
+	aCopy := make([]complex128, len(a))
+	copy(aCopy, a)
+	b := makeZGeneral(rnd, rowB, colB, ldb)
+	bCopy := make([]complex128, len(b))
+	copy(bCopy, b)
+	c := makeZGeneral(rnd, m, n, ldc)
+
+	op := func(x []complex128, ld int, trans blas.Transpose, i, j int) complex128 {
+		switch trans {
+		case blas.NoTrans:
+			return x[i*ld+j]
+		case blas.Trans:
+			return x[j*ld+i]
+		default:
+			return cmplx.Conj(x[j*ld+i])
+		}
+	}
+	want := make([]complex128, len(c))
+	copy(want, c)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			var sum complex128
+			for l := 0; l < k; l++ {
+				sum += op(a, lda, tA, i, l) * op(b, ldb, tB, l, j)
+			}
+			if beta == 0 {
+				want[i*ldc+j] = alpha * sum
+			} else {
+				want[i*ldc+j] = alpha*sum + beta*c[i*ldc+j]
+			}
+		}
+	}
+
+	impl.Zgemm(tA, tB, m, n, k, alpha, a, lda, b, ldb, beta, c, ldc)
+
+	prefix := fmt.Sprintf("tA=%v,tB=%v,m=%v,n=%v,k=%v,extra=%v,alpha=%v,beta=%v", tA, tB, m, n, k, extra, alpha, beta)
+	if !zsame(a, aCopy) {
+		t.Errorf("%v: unexpected modification of A", prefix)
+	}
+	if !zsame(b, bCopy) {
+		t.Errorf("%v: unexpected modification of B", prefix)
+	}
+	if !zEqualApprox(c, want, 1e-13) {
+		t.Errorf("%v: unexpected C:\nwant %v\ngot %v", prefix, want, c)
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgemver interface {
+	Zgemv(trans blas.Transpose, m, n int, alpha complex128, a []complex128, lda int, x []complex128, incX int, beta complex128, y []complex128, incY int)
+}
+
+func ZgemvTest(t *testing.T, impl Zgemver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, mn := range [][2]int{{1, 1}, {1, 3}, {3, 1}, {2, 5}, {5, 2}, {7, 7}, {11, 4}} {
+			m := mn[0]
+			n := mn[1]
+			for _, inc := range allPairs([]int{-3, 1, 2}, []int{-2, 1, 4}) {
+				for _, ab := range [][2]complex128{{0, 0}, {0, 1}, {1, 0}, {2 - 1i, 0.5i}} {
+					for _, extra := range []int{0, 3} {
+						testZgemv(t, impl, rnd, trans, m, n, ab[0], ab[1], n+extra, inc[0], inc[1])
+					}
+				}
+			}
+		}
+	}
+}
+
+func testZgemv(t *testing.T, impl Zgemver, rnd *rand.Rand, trans blas.Transpose, m, n int, alpha, beta complex128, lda, incX, incY int) {
+	lenX, lenY := n, m
+	if trans != blas.NoTrans {
+		lenX, lenY = m, n
+	}
+	a := makeZGeneral(rnd, m, n, lda)
+	aCopy := make([]complex128, len(a))
+	copy(aCopy, a)
+	x := makeZVector(rnd, lenX, incX)
+	xCopy := make([]complex128, len(x))
+	copy(xCopy, x)
+	y := makeZVector(rnd, lenY, incY)
+
+	want := make([]complex128, len(y))
+	copy(want, y)
+	for i := 0; i < lenY; i++ {
+		var sum complex128
+		for j := 0; j < lenX; j++ {
+			var aij complex128
+			switch trans {
+			case blas.NoTrans:
+				aij = a[i*lda+j]
+			case blas.Trans:
+				aij = a[j*lda+i]
+			case blas.ConjTrans:
+				aij = cmplx.Conj(a[j*lda+i])
+			}
+			sum += aij * x[zStridedIndex(j, lenX, incX)]
+		}
+		iy := zStridedIndex(i, lenY, incY)
+		if beta == 0 {
+			want[iy] = alpha * sum
+		} else {
+			want[iy] = alpha*sum + beta*y[iy]
+		}
+	}
+
+	impl.Zgemv(trans, m, n, alpha, a, lda, x, incX, beta, y, incY)
+
+	prefix := fmt.Sprintf("trans=%v,m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v,beta=%v", trans, m, n, lda, incX, incY, alpha, beta)
+	if !zsame(a, aCopy) {
+		t.Errorf("%v: unexpected modification of A", prefix)
+	}
+	if !zsame(x, xCopy) {
+		t.Errorf("%v: unexpected modification of x", prefix)
+	}
+	if !zEqualApprox(y, want, 1e-13) {
+		t.Errorf("%v: unexpected y:\nwant %v\ngot %v", prefix, want, y)
+	}
+}
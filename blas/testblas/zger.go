@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Zgercer interface {
+	Zgerc(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+func ZgercTest(t *testing.T, impl Zgercer) {
+	testZger(t, "Zgerc", impl.Zgerc, true)
+}
+
+type Zgeruer interface {
+	Zgeru(m, n int, alpha complex128, x []complex128, incX int, y []complex128, incY int, a []complex128, lda int)
+}
+
+func ZgeruTest(t *testing.T, impl Zgeruer) {
+	testZger(t, "Zgeru", impl.Zgeru, false)
+}
+
+func testZger(t *testing.T, name string, ger func(int, int, complex128, []complex128, int, []complex128, int, []complex128, int), conj bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, mn := range [][2]int{{1, 1}, {1, 3}, {3, 1}, {2, 5}, {5, 2}, {7, 7}, {11, 4}} {
+		m := mn[0]
+		n := mn[1]
+		for _, inc := range allPairs([]int{-3, 1, 2}, []int{-2, 1, 4}) {
+			incX := inc[0]
+			incY := inc[1]
+			for _, alpha := range []complex128{0, 1, 2 - 1i} {
+				for _, extra := range []int{0, 3} {
+					lda := n + extra
+					a := makeZGeneral(rnd, m, n, lda)
+					x := makeZVector(rnd, m, incX)
+					xCopy := make([]complex128, len(x))
+					copy(xCopy, x)
+					y := makeZVector(rnd, n, incY)
+					yCopy := make([]complex128, len(y))
+					copy(yCopy, y)
+
+					want := make([]complex128, len(a))
+					copy(want, a)
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							yj := y[zStridedIndex(j, n, incY)]
+							if conj {
+								yj = cmplx.Conj(yj)
+							}
+							want[i*lda+j] += alpha * x[zStridedIndex(i, m, incX)] * yj
+						}
+					}
+
+					ger(m, n, alpha, x, incX, y, incY, a, lda)
+
+					prefix := fmt.Sprintf("%v: m=%v,n=%v,lda=%v,incX=%v,incY=%v,alpha=%v", name, m, n, lda, incX, incY, alpha)
+					if !zsame(x, xCopy) {
+						t.Errorf("%v: unexpected modification of x", prefix)
+					}
+					if !zsame(y, yCopy) {
+						t.Errorf("%v: unexpected modification of y", prefix)
+					}
+					if !zEqualApprox(a, want, 1e-13) {
+						t.Errorf("%v: unexpected A:\nwant %v\ngot %v", prefix, want, a)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+type Zscaler interface {
+	Zscal(n int, alpha complex128, x []complex128, incX int)
+}
+
+func ZscalTest(t *testing.T, impl Zscaler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, incX := range []int{-3, 1, 2, 5} {
+			for _, alpha := range []complex128{0, 1, -2 + 0.5i, 3i} {
+				x := makeZVector(rnd, n, incX)
+				want := make([]complex128, len(x))
+				copy(want, x)
+				if incX > 0 {
+					for i := 0; i < n; i++ {
+						want[i*incX] *= alpha
+					}
+				}
+
+				impl.Zscal(n, alpha, x, incX)
+
+				prefix := fmt.Sprintf("n=%v,incX=%v,alpha=%v", n, incX, alpha)
+				if !zEqualApprox(x, want, 1e-14) {
+					t.Errorf("%v: unexpected x:\nwant %v\ngot %v", prefix, want, x)
+				}
+			}
+		}
+	}
+}
+
+type Zdscaler interface {
+	Zdscal(n int, alpha float64, x []complex128, incX int)
+}
+
+func ZdscalTest(t *testing.T, impl Zdscaler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, incX := range []int{-3, 1, 2, 5} {
+			for _, alpha := range []float64{0, 1, -2.5} {
+				x := makeZVector(rnd, n, incX)
+				want := make([]complex128, len(x))
+				copy(want, x)
+				if incX > 0 {
+					for i := 0; i < n; i++ {
+						want[i*incX] *= complex(alpha, 0)
+					}
+				}
+
+				impl.Zdscal(n, alpha, x, incX)
+
+				prefix := fmt.Sprintf("n=%v,incX=%v,alpha=%v", n, incX, alpha)
+				if !zEqualApprox(x, want, 1e-14) {
+					t.Errorf("%v: unexpected x:\nwant %v\ngot %v", prefix, want, x)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testblas
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+type Zswaper interface {
+	Zswap(n int, x []complex128, incX int, y []complex128, incY int)
+}
+
+func ZswapTest(t *testing.T, impl Zswaper) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 31} {
+		for _, inc := range allPairs([]int{-5, -1, 1, 2}, []int{-3, -1, 1, 4}) {
+			incX := inc[0]
+			incY := inc[1]
+			if n == 0 {
+				impl.Zswap(0, nil, incX, nil, incY)
+				continue
+			}
+			x := makeZVector(rnd, n, incX)
+			y := makeZVector(rnd, n, incY)
+			wantX := make([]complex128, len(x))
+			copy(wantX, x)
+			wantY := make([]complex128, len(y))
+			copy(wantY, y)
+			for i := 0; i < n; i++ {
+				ix := zStridedIndex(i, n, incX)
+				iy := zStridedIndex(i, n, incY)
+				wantX[ix], wantY[iy] = y[iy], x[ix]
+			}
+
+			impl.Zswap(n, x, incX, y, incY)
+
+			prefix := fmt.Sprintf("n=%v,incX=%v,incY=%v", n, incX, incY)
+			if !zsame(x, wantX) {
+				t.Errorf("%v: unexpected x:\nwant %v\ngot %v", prefix, wantX, x)
+			}
+			if !zsame(y, wantY) {
+				t.Errorf("%v: unexpected y:\nwant %v\ngot %v", prefix, wantY, y)
+			}
+		}
+	}
+}
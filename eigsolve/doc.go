@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package eigsolve implements iterative methods for computing a few
+// eigenvalues and eigenvectors of a large symmetric matrix
+//  A * x = λ * x,
+// where A is accessed only through matrix-vector products. The methods are
+// suited to large, sparse problems for which a full eigendecomposition as
+// computed by mat.EigenSym is not feasible.
+package eigsolve // import "gonum.org/v1/gonum/eigsolve"
@@ -0,0 +1,133 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"errors"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const defaultTolerance = 1e-8
+
+// ErrIterationLimit is returned when the maximum number of restarts is
+// reached before all the requested eigenpairs have converged.
+var ErrIterationLimit = errors.New("eigsolve: iteration limit reached")
+
+// MulVecToer represents a symmetric linear operator A that can compute the
+// product of itself with a vector. Its method set matches
+// mat.MulVecToer and linsolve.MulVecToer, so the same operator can be used
+// with all three packages.
+type MulVecToer interface {
+	// MulVecTo computes A*x, or A^T*x if trans is true, and stores the
+	// result into dst. Since A is symmetric the two products are equal
+	// and Lanczos always calls MulVecTo with trans set to false. dst will
+	// have the correct length and will not alias x.
+	MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense)
+}
+
+// MulVecFunc is a function type that implements the MulVecToer interface.
+type MulVecFunc func(dst *mat.VecDense, trans bool, x *mat.VecDense)
+
+// MulVecTo calls f(dst, trans, x).
+func (f MulVecFunc) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	f(dst, trans, x)
+}
+
+// MatrixOperator returns a MulVecToer that multiplies vectors by the matrix
+// a. a must be square and it is assumed to be symmetric.
+func MatrixOperator(a mat.Matrix) MulVecToer {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrShape)
+	}
+	return matrixOperator{a}
+}
+
+type matrixOperator struct {
+	a mat.Matrix
+}
+
+func (m matrixOperator) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	if trans {
+		dst.MulVec(m.a.T(), x)
+		return
+	}
+	dst.MulVec(m.a, x)
+}
+
+// Which specifies the part of the spectrum whose eigenpairs are computed.
+type Which int
+
+const (
+	// LargestAlgebraic specifies the algebraically largest eigenvalues.
+	LargestAlgebraic Which = iota
+	// SmallestAlgebraic specifies the algebraically smallest eigenvalues.
+	SmallestAlgebraic
+	// LargestMagnitude specifies the eigenvalues largest in magnitude.
+	LargestMagnitude
+)
+
+// Settings holds the settings for computing the eigenpairs.
+// In general, users should use DefaultSettings rather than constructing
+// a Settings literal.
+type Settings struct {
+	// InitVector holds the starting vector of the iteration. It must
+	// not be the zero vector. If it is nil, a random vector is used.
+	InitVector *mat.VecDense
+
+	// Tolerance specifies the convergence threshold. An approximate
+	// eigenpair (θ, x) with ‖x‖ = 1 has converged when
+	//  ‖A*x - θ*x‖ <= Tolerance * ‖A‖,
+	// where ‖A‖ is estimated by the largest magnitude of the approximate
+	// eigenvalues. Tolerance must be positive and smaller than one. If it
+	// is zero, a default value of 1e-8 is used.
+	Tolerance float64
+
+	// BasisSize is the maximum dimension of the search subspace. It must
+	// be larger than the number of requested eigenpairs k and not larger
+	// than the dimension n of the operator, unless k == n in which case it
+	// must be equal to n. Larger values generally reduce the number of
+	// restarts at the cost of more memory and orthogonalization work. If
+	// it is zero, a default value of min(n, max(2*k+1, 20)) is used.
+	BasisSize int
+
+	// MaxRestarts is the maximum number of restarts allowed.
+	// ErrIterationLimit is returned if the requested eigenpairs have not
+	// converged after this number of restarts. If it is zero, a default
+	// value of max(100, n) is used.
+	MaxRestarts int
+}
+
+// DefaultSettings returns a new Settings struct containing the default settings.
+func DefaultSettings() *Settings {
+	return &Settings{
+		Tolerance: defaultTolerance,
+	}
+}
+
+// Result holds the result of an iterative eigenvalue computation.
+type Result struct {
+	// Values holds the approximate eigenvalues.
+	Values []float64
+
+	// Vectors holds the corresponding orthonormal approximate
+	// eigenvectors in its columns.
+	Vectors *mat.Dense
+
+	// ResidualNorms holds the estimates of ‖A*x - θ*x‖ for each of the
+	// approximate eigenpairs.
+	ResidualNorms []float64
+
+	Stats
+}
+
+// Stats contains the statistics of the run.
+type Stats struct {
+	Restarts int           // Number of restarts
+	MulVec   int           // Number of matrix-vector products
+	Runtime  time.Duration // Total runtime of the computation
+}
@@ -0,0 +1,178 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/linsolve"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Operators for the mat and linsolve packages can be used with Lanczos.
+var (
+	_ MulVecToer = mat.MulVecToer(nil)
+	_ MulVecToer = linsolve.MulVecToer(nil)
+)
+
+// laplacian1D returns the n×n matrix of the three-point finite difference
+// discretization of the negative one-dimensional Laplacian and its
+// eigenvalues in ascending order.
+func laplacian1D(n int) (*mat.CSR, []float64) {
+	t := mat.NewTriplet(n, n)
+	values := make([]float64, n)
+	for i := 0; i < n; i++ {
+		t.Append(i, i, 2)
+		if i > 0 {
+			t.Append(i, i-1, -1)
+		}
+		if i < n-1 {
+			t.Append(i, i+1, -1)
+		}
+		values[i] = 2 - 2*math.Cos(float64(i+1)*math.Pi/float64(n+1))
+	}
+	return t.ToCSR(), values
+}
+
+// randomSymmetric returns a random n×n symmetric matrix with eigenvalues
+// spread uniformly in [-1, 1].
+func randomSymmetric(n int, rnd *rand.Rand) *mat.SymDense {
+	a := mat.NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			a.SetSym(i, j, rnd.NormFloat64()/math.Sqrt(float64(2*n)))
+		}
+	}
+	return a
+}
+
+func TestLanczos(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	lap, lapValues := laplacian1D(200)
+
+	for _, test := range []struct {
+		name     string
+		a        mat.Matrix
+		k        int
+		which    Which
+		settings *Settings
+	}{
+		{name: "laplacian largest", a: lap, k: 5, which: LargestAlgebraic},
+		{name: "laplacian smallest", a: lap, k: 5, which: SmallestAlgebraic},
+		{name: "laplacian magnitude", a: lap, k: 3, which: LargestMagnitude},
+		{name: "laplacian small basis", a: lap, k: 4, which: LargestAlgebraic, settings: &Settings{BasisSize: 10, MaxRestarts: 1000}},
+		{name: "random largest", a: randomSymmetric(100, rnd), k: 10, which: LargestAlgebraic},
+		{name: "random smallest", a: randomSymmetric(100, rnd), k: 10, which: SmallestAlgebraic},
+		{name: "random magnitude", a: randomSymmetric(100, rnd), k: 10, which: LargestMagnitude},
+		{name: "random tight", a: randomSymmetric(60, rnd), k: 6, which: LargestAlgebraic, settings: &Settings{Tolerance: 1e-12}},
+		{name: "full spectrum", a: randomSymmetric(8, rnd), k: 8, which: SmallestAlgebraic},
+		{name: "full basis", a: randomSymmetric(8, rnd), k: 3, which: LargestAlgebraic},
+		{name: "diagonal", a: mat.NewDiagonal(30, func() []float64 {
+			d := make([]float64, 30)
+			for i := range d {
+				d[i] = float64(i % 5)
+			}
+			return d
+		}()), k: 2, which: LargestAlgebraic},
+		{name: "zero", a: mat.NewDense(30, 30, nil), k: 3, which: LargestMagnitude},
+	} {
+		n, _ := test.a.Dims()
+		tol := defaultTolerance
+		if test.settings != nil && test.settings.Tolerance != 0 {
+			tol = test.settings.Tolerance
+		}
+
+		res, err := Lanczos(MatrixOperator(test.a), n, test.k, test.which, test.settings)
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(res.Values) != test.k {
+			t.Errorf("%v: unexpected number of eigenvalues", test.name)
+			continue
+		}
+
+		// Compute the reference eigenvalues.
+		var want []float64
+		if test.a == lap {
+			want = lapValues
+		} else {
+			var es mat.EigenSym
+			es.Factorize(mat.NewSymDense(n, mat.DenseCopyOf(test.a).RawMatrix().Data), false)
+			want = es.Values(nil)
+		}
+		idx := order(want, test.which)
+		anorm := math.Max(math.Abs(want[0]), math.Abs(want[n-1]))
+		for l, i := range idx[:test.k] {
+			if math.Abs(res.Values[l]-want[i]) > 10*tol*math.Max(anorm, 1) {
+				t.Errorf("%v: unexpected eigenvalue %d: got %v, want %v", test.name, l, res.Values[l], want[i])
+			}
+		}
+
+		// Check the eigenvectors.
+		x := res.Vectors
+		var xtx mat.Dense
+		xtx.Mul(x.T(), x)
+		if !mat.EqualApprox(&xtx, eye(test.k), 1e-12) {
+			t.Errorf("%v: eigenvectors are not orthonormal", test.name)
+		}
+		for l := 0; l < test.k; l++ {
+			var r mat.VecDense
+			xl := x.ColView(l)
+			r.MulVec(test.a, xl)
+			r.AddScaledVec(&r, -res.Values[l], xl)
+			if resid := mat.Norm(&r, 2); resid > 10*tol*math.Max(anorm, 1) {
+				t.Errorf("%v: eigenpair %d has residual %v", test.name, l, resid)
+			}
+		}
+		if res.MulVec == 0 {
+			t.Errorf("%v: no matrix-vector products counted", test.name)
+		}
+	}
+}
+
+func TestLanczosMulVecFunc(t *testing.T) {
+	// The operator A = diag(1, 2, ..., n).
+	const n = 500
+	a := MulVecFunc(func(dst *mat.VecDense, _ bool, x *mat.VecDense) {
+		for i := 0; i < n; i++ {
+			dst.SetVec(i, float64(i+1)*x.At(i, 0))
+		}
+	})
+	init := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		init.SetVec(i, 1)
+	}
+	res, err := Lanczos(a, n, 4, LargestAlgebraic, &Settings{InitVector: init, Tolerance: 1e-10})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !floats.EqualApprox(res.Values, []float64{500, 499, 498, 497}, 1e-6) {
+		t.Errorf("unexpected eigenvalues: got %v", res.Values)
+	}
+}
+
+func TestLanczosIterationLimit(t *testing.T) {
+	lap, _ := laplacian1D(400)
+	res, err := Lanczos(MatrixOperator(lap), 400, 6, SmallestAlgebraic, &Settings{BasisSize: 8, MaxRestarts: 2})
+	if err != ErrIterationLimit {
+		t.Errorf("unexpected error: got %v, want %v", err, ErrIterationLimit)
+	}
+	if res == nil || len(res.Values) != 6 || res.Restarts != 2 {
+		t.Errorf("unexpected result when iteration limit is reached")
+	}
+}
+
+func eye(n int) *mat.Dense {
+	d := mat.NewDense(n, n, nil)
+	for i := 0; i < n; i++ {
+		d.Set(i, i, 1)
+	}
+	return d
+}
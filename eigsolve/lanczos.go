@@ -0,0 +1,291 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package eigsolve
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// Lanczos computes k eigenvalues of the n×n symmetric operator a from the part
+// of the spectrum specified by which, and the corresponding eigenvectors,
+// using the thick-restart Lanczos method. If settings is nil, the default
+// settings returned by DefaultSettings are used.
+//
+// The method builds an orthonormal basis of a Krylov subspace of dimension
+// settings.BasisSize and computes approximate eigenpairs (Ritz pairs) from the
+// projection of A onto the subspace. If the requested Ritz pairs have not
+// converged, the subspace is restarted from the best Ritz vectors found so
+// far. The basis is kept orthogonal by full reorthogonalization, so the
+// method needs O(n*BasisSize) memory and does not produce spurious copies of
+// eigenvalues.
+//
+// On return, the eigenvalues are ordered by the criterion given by which,
+// that is, in descending order for LargestAlgebraic, in ascending order for
+// SmallestAlgebraic and in descending order of magnitude for
+// LargestMagnitude.
+//
+// Lanczos will panic if n is not positive, if k is not in [1, n], or if the
+// settings are invalid. Lanczos returns a non-nil result with the most recent
+// approximate eigenpairs even if it also returns an error.
+func Lanczos(a MulVecToer, n, k int, which Which, settings *Settings) (*Result, error) {
+	if n <= 0 {
+		panic("eigsolve: dimension is not positive")
+	}
+	if k <= 0 || n < k {
+		panic("eigsolve: invalid number of eigenpairs")
+	}
+	switch which {
+	default:
+		panic("eigsolve: invalid Which")
+	case LargestAlgebraic, SmallestAlgebraic, LargestMagnitude:
+	}
+
+	var s Settings
+	if settings != nil {
+		s = *settings
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.Tolerance < 0 || 1 <= s.Tolerance {
+		panic("eigsolve: invalid tolerance")
+	}
+	if s.BasisSize == 0 {
+		s.BasisSize = min(n, max(2*k+1, 20))
+	}
+	m := s.BasisSize
+	if n < m || (m <= k && m != n) {
+		panic("eigsolve: invalid basis size")
+	}
+	if s.MaxRestarts == 0 {
+		s.MaxRestarts = max(100, n)
+	}
+	if s.MaxRestarts < 0 {
+		panic("eigsolve: negative restart limit")
+	}
+	if s.InitVector != nil && s.InitVector.Len() != n {
+		panic("eigsolve: mismatched length of initial vector")
+	}
+
+	start := time.Now()
+	var stats Stats
+	rnd := rand.New(rand.NewSource(1))
+
+	// The columns of v hold the orthonormal basis of the search subspace.
+	// Column m holds the direction of the residual of the Ritz pairs.
+	v := mat.NewDense(n, m+1, nil)
+	// t holds the projection V^T * A * V of A onto the subspace.
+	t := mat.NewSymDense(m, nil)
+	w := mat.NewVecDense(n, nil)
+	h := mat.NewVecDense(m, nil)
+	dh := mat.NewVecDense(m, nil)
+	tmp := mat.NewVecDense(n, nil)
+
+	v0 := v.ColView(0)
+	if s.InitVector != nil {
+		v0.CopyVec(s.InitVector)
+	} else {
+		randomize(v0, rnd)
+	}
+	norm := mat.Norm(v0, 2)
+	if norm == 0 {
+		panic("eigsolve: zero initial vector")
+	}
+	v0.ScaleVec(1/norm, v0)
+
+	var (
+		es    mat.EigenSym
+		y     mat.Dense
+		ritz  = make([]float64, m)
+		anorm float64
+
+		// kept is the number of Ritz vectors at the beginning of the
+		// basis that have been retained from the previous restart.
+		kept int
+	)
+	for {
+		// Extend the basis to m vectors. On exit, the Lanczos relation
+		//  A * V = V * T + beta * v_m * e_m^T
+		// holds.
+		var beta float64
+		for j := kept; j < m; j++ {
+			vj := v.ColView(j)
+			stats.MulVec++
+			a.MulVecTo(w, false, vj)
+			normAv := mat.Norm(w, 2)
+
+			hj := h.SliceVec(0, j+1)
+			orthogonalize(w, v.Slice(0, n, 0, j+1), hj, dh.SliceVec(0, j+1), tmp)
+			for i := 0; i <= j; i++ {
+				t.SetSym(i, j, hj.At(i, 0))
+			}
+
+			beta = mat.Norm(w, 2)
+			if beta <= float64(n)*dlamchE*normAv {
+				// The basis spans an invariant subspace of A.
+				beta = 0
+				if j == m-1 {
+					break
+				}
+				// Continue with a random vector orthogonal to the
+				// basis. The coupling between the two subspaces
+				// in T is zero.
+				for {
+					randomize(w, rnd)
+					orthogonalize(w, v.Slice(0, n, 0, j+1), hj, dh.SliceVec(0, j+1), tmp)
+					norm = mat.Norm(w, 2)
+					if norm > 0 {
+						break
+					}
+				}
+				v.ColView(j + 1).ScaleVec(1/norm, w)
+				continue
+			}
+			v.ColView(j + 1).ScaleVec(1/beta, w)
+		}
+
+		// Compute the Ritz pairs.
+		if !es.Factorize(t, true) {
+			panic("eigsolve: projected eigenproblem failed to converge")
+		}
+		ritz = es.Values(ritz)
+		y.EigenvectorsSym(&es)
+		for _, r := range ritz {
+			anorm = math.Max(anorm, math.Abs(r))
+		}
+
+		// The residual norm of the Ritz pair (θ_i, V*y_i) is
+		// |beta * y_i[m-1]|.
+		idx := order(ritz, which)
+		var converged int
+		for _, i := range idx[:k] {
+			if math.Abs(beta*y.At(m-1, i)) <= s.Tolerance*anorm {
+				converged++
+			}
+		}
+		if converged == k || stats.Restarts >= s.MaxRestarts {
+			result := &Result{
+				Values:        make([]float64, k),
+				Vectors:       ritzVectors(v.Slice(0, n, 0, m), &y, idx[:k]),
+				ResidualNorms: make([]float64, k),
+			}
+			for l, i := range idx[:k] {
+				result.Values[l] = ritz[i]
+				result.ResidualNorms[l] = math.Abs(beta * y.At(m-1, i))
+			}
+			stats.Runtime = time.Since(start)
+			result.Stats = stats
+			if converged < k {
+				return result, ErrIterationLimit
+			}
+			return result, nil
+		}
+
+		// Restart with the best Ritz vectors followed by the residual
+		// direction.
+		stats.Restarts++
+		kept = min(k+(m-k)/2, m-1)
+		x := ritzVectors(v.Slice(0, n, 0, m), &y, idx[:kept])
+		v.Slice(0, n, 0, kept).(*mat.Dense).Copy(x)
+		v.ColView(kept).CopyVec(v.ColView(m))
+		for i := 0; i < m; i++ {
+			for j := i; j < m; j++ {
+				t.SetSym(i, j, 0)
+			}
+		}
+		for l, i := range idx[:kept] {
+			t.SetSym(l, l, ritz[i])
+		}
+	}
+}
+
+// orthogonalize orthogonalizes w against the orthonormal columns of v using
+// classical Gram-Schmidt with one step of reorthogonalization, and stores the
+// projection coefficients v^T*w into h. dh and tmp are used as workspace.
+func orthogonalize(w *mat.VecDense, v mat.Matrix, h, dh, tmp *mat.VecDense) {
+	h.MulVec(v.T(), w)
+	tmp.MulVec(v, h)
+	w.SubVec(w, tmp)
+	dh.MulVec(v.T(), w)
+	tmp.MulVec(v, dh)
+	w.SubVec(w, tmp)
+	h.AddVec(h, dh)
+}
+
+// ritzVectors returns the Ritz vectors V*y_i for the columns i of y given by
+// idx.
+func ritzVectors(v mat.Matrix, y *mat.Dense, idx []int) *mat.Dense {
+	r, _ := y.Dims()
+	ysel := mat.NewDense(r, len(idx), nil)
+	for l, i := range idx {
+		for j := 0; j < r; j++ {
+			ysel.Set(j, l, y.At(j, i))
+		}
+	}
+	var x mat.Dense
+	x.Mul(v, ysel)
+	return &x
+}
+
+// order returns the indices of the ascending values sorted by the criterion
+// given by which.
+func order(values []float64, which Which) []int {
+	n := len(values)
+	idx := make([]int, n)
+	for i := range idx {
+		switch which {
+		case LargestAlgebraic:
+			idx[i] = n - 1 - i
+		default:
+			idx[i] = i
+		}
+	}
+	if which == LargestMagnitude {
+		sort.Stable(byMagnitude{idx: idx, values: values})
+	}
+	return idx
+}
+
+// byMagnitude sorts indices into values in descending order of the magnitude
+// of the values.
+type byMagnitude struct {
+	idx    []int
+	values []float64
+}
+
+func (b byMagnitude) Len() int { return len(b.idx) }
+func (b byMagnitude) Less(i, j int) bool {
+	return math.Abs(b.values[b.idx[i]]) > math.Abs(b.values[b.idx[j]])
+}
+func (b byMagnitude) Swap(i, j int) { b.idx[i], b.idx[j] = b.idx[j], b.idx[i] }
+
+// randomize fills v with normally distributed random numbers.
+func randomize(v *mat.VecDense, rnd *rand.Rand) {
+	for i := 0; i < v.Len(); i++ {
+		v.SetVec(i, rnd.NormFloat64())
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// dlamchE is the machine epsilon.
+const dlamchE = 1.0 / (1 << 53)
@@ -104,19 +104,20 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 		indexOf[n.ID()] = i
 	}
 
-	m := make(rowCompressedMatrix, len(nodes))
+	m := mat.NewTriplet(len(nodes), len(nodes))
 	var dangling compressedRow
 	df := damp / float64(len(nodes))
 	for j, u := range nodes {
 		to := g.From(u)
 		f := damp / float64(len(to))
 		for _, v := range to {
-			m.addTo(indexOf[v.ID()], j, f)
+			m.Append(indexOf[v.ID()], j, f)
 		}
 		if len(to) == 0 {
 			dangling.addTo(j, df)
 		}
 	}
+	h := m.ToCSR()
 
 	last := make([]float64, len(nodes))
 	for i := range last {
@@ -141,7 +142,7 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	for {
 		lastV, v = v, lastV
 
-		m.mulVecUnitary(v, lastV)          // First term of the G matrix equation;
+		v.MulVec(h, lastV)                 // First term of the G matrix equation;
 		with := dangling.dotUnitary(lastV) // Second term;
 		away := onesDotUnitary(dt, lastV)  // Last term.
 
@@ -159,25 +160,6 @@ func PageRankSparse(g graph.Directed, damp, tol float64) map[int64]float64 {
 	return ranks
 }
 
-// rowCompressedMatrix implements row-compressed
-// matrix/vector multiplication.
-type rowCompressedMatrix []compressedRow
-
-// addTo adds the value v to the matrix element at (i,j). Repeated
-// calls to addTo with the same column index will result in
-// non-unique element representation.
-func (m rowCompressedMatrix) addTo(i, j int, v float64) { m[i].addTo(j, v) }
-
-// mulVecUnitary multiplies the receiver by the src vector, storing
-// the result in dst. It assumes src and dst are the same length as m
-// and that both have unitary vector increments.
-func (m rowCompressedMatrix) mulVecUnitary(dst, src *mat.VecDense) {
-	dMat := dst.RawVector().Data
-	for i, r := range m {
-		dMat[i] = r.dotUnitary(src)
-	}
-}
-
 // compressedRow implements a simplified scatter-based Ddot.
 type compressedRow []sparseElement
 
@@ -8,7 +8,7 @@
 
 // Package math32 provides float32 versions of standard library math package
 // routines used by gonum/blas/native.
-package math32 // import "gonum.org/v1/gonum/blas/gonum/internal/math32"
+package math32 // import "gonum.org/v1/gonum/internal/math32"
 
 import (
 	"math"
@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dbdsdc computes the singular value decomposition of a real n×n bidiagonal
+// matrix B using a divide and conquer method.
+//
+// The SVD of B is
+//  B = U * S * VT
+// where S is a diagonal matrix of singular values, U is an orthogonal matrix
+// of left singular vectors, and VT is the transpose of an orthogonal matrix of
+// right singular vectors.
+//
+// d and e contain the elements of the bidiagonal matrix B. d must have length
+// at least n, and e must have length at least n-1. On exit, d contains the
+// singular values of B in decreasing order and e is overwritten.
+//
+// compq specifies whether the singular vectors are computed.
+//  compq == lapack.None      Only the singular values are computed.
+//  compq == lapack.BidiagSV  The singular values and vectors of B are computed.
+// If compq == lapack.BidiagSV, on exit u and vt contain the n×n matrices U
+// and VT. Otherwise u and vt are not referenced.
+//
+// work must have length at least 3*n*n + 4*n if compq == lapack.BidiagSV and
+// at least 4*n otherwise. iwork must have length at least 8*n.
+//
+// Dbdsdc returns whether the decomposition was successful.
+//
+// Dbdsdc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dbdsdc(uplo blas.Uplo, compq lapack.EVComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	wantvec := compq == lapack.BidiagSV
+	if !wantvec && compq != lapack.None {
+		panic(badEVComp)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if wantvec {
+		checkMatrix(n, n, u, ldu)
+		checkMatrix(n, n, vt, ldvt)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if wantvec {
+		if len(work) < 3*n*n+4*n {
+			panic(badWork)
+		}
+	} else if len(work) < 4*n {
+		panic(badWork)
+	}
+	if len(iwork) < 8*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		if wantvec {
+			u[0] = math.Copysign(1, d[0])
+			vt[0] = 1
+		}
+		d[0] = math.Abs(d[0])
+		return true
+	}
+	nm1 := n - 1
+
+	// If the matrix is lower bidiagonal, rotate to be upper bidiagonal by
+	// applying Givens rotations on the left. The rotations are stored in
+	// work[:2*nm1] so that they can be applied to U at the end.
+	var wstart int
+	if uplo == blas.Lower {
+		if wantvec {
+			wstart = 2*n - 2
+		}
+		for i := 0; i < nm1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if wantvec {
+				work[i] = cs
+				work[nm1+i] = -sn
+			}
+		}
+	}
+
+	const smlsiz = 25
+	ok = true
+	switch {
+	case !wantvec:
+		// Use Dlasdq to compute the singular values.
+		ok = impl.Dlasdq(blas.Upper, 0, n, 0, 0, 0, d, e, nil, 1, nil, 1, nil, 1, work)
+	case n <= smlsiz:
+		// The matrix is small enough to use Dlasdq directly.
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+		ok = impl.Dlasdq(blas.Upper, 0, n, n, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work[wstart:])
+	default:
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+
+		// Scale.
+		orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+		if orgnrm == 0 {
+			return true
+		}
+		impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+		impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, nm1, 1, e, 1)
+
+		eps := 0.9 * dlamchE
+		for i, v := range d[:n] {
+			if math.Abs(v) < eps {
+				d[i] = math.Copysign(eps, v)
+			}
+		}
+
+		// Split the matrix at negligible off-diagonal elements and apply
+		// divide and conquer to each subproblem.
+		start := 0
+		for i := 0; i < nm1; i++ {
+			if math.Abs(e[i]) >= eps && i < nm1-1 {
+				continue
+			}
+			// Determine the size of the subproblem.
+			var nsize int
+			switch {
+			case i < nm1-1:
+				// A subproblem with e[i] small for i < n-2.
+				nsize = i - start + 1
+			case math.Abs(e[i]) >= eps:
+				// A subproblem with e[n-2] not too small.
+				nsize = n - start
+			default:
+				// A subproblem with e[n-2] small. This implies a 1×1
+				// subproblem at d[n-1], which is solved first.
+				nsize = i - start + 1
+				u[(n-1)*ldu+n-1] = math.Copysign(1, d[n-1])
+				vt[(n-1)*ldvt+n-1] = 1
+				d[n-1] = math.Abs(d[n-1])
+			}
+			ok = impl.Dlasd0(nsize, 0, d[start:], e[start:], u[start*ldu+start:], ldu, vt[start*ldvt+start:], ldvt, smlsiz, iwork, work[wstart:])
+			if !ok {
+				return false
+			}
+			start = i + 1
+		}
+
+		// Unscale.
+		impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	}
+
+	// Sort the singular values into decreasing order using selection sort
+	// to minimize swaps of singular vectors.
+	bi := blas64.Implementation()
+	for i := 0; i < nm1; i++ {
+		kk := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] > p {
+				kk = j
+				p = d[j]
+			}
+		}
+		if kk == i {
+			continue
+		}
+		d[kk] = d[i]
+		d[i] = p
+		if wantvec {
+			bi.Dswap(n, u[i:], ldu, u[kk:], ldu)
+			bi.Dswap(n, vt[i*ldvt:], 1, vt[kk*ldvt:], 1)
+		}
+	}
+
+	// If B is lower bidiagonal, update U by the Givens rotations which
+	// rotated B to be upper bidiagonal.
+	if uplo == blas.Lower && wantvec {
+		impl.Dlasr(blas.Left, lapack.Variable, lapack.Backward, n, n, work[:nm1], work[nm1:2*nm1], u, ldu)
+	}
+	return ok
+}
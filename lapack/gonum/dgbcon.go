@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgbcon estimates the reciprocal of the condition number of an n×n band
+// matrix A with kl sub-diagonals and ku super-diagonals, given the LU
+// factorization of A computed by Dgbtrf. The condition number computed may be
+// based on the 1-norm or the ∞-norm.
+//
+// ab and ipiv must contain the factorization and the pivot indices as returned
+// by Dgbtrf.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dgbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgbcon will panic otherwise.
+func (impl Implementation) Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	if norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum {
+		panic(badNorm)
+	}
+	checkBanded(ab, n, n, kl, kl+ku, ldab)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 1
+	}
+	if anorm == 0 {
+		return 0
+	}
+
+	// Estimate the norm of the inverse of A.
+	var ainvnm float64
+	var kase int
+	isave := new([3]int)
+	kase1 := 2
+	if norm == lapack.MaxColumnSum {
+		kase1 = 1
+	}
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, isave)
+		if kase == 0 {
+			break
+		}
+		if kase == kase1 {
+			// Multiply by inv(A).
+			impl.Dgbtrs(blas.NoTrans, n, kl, ku, 1, ab, ldab, ipiv, work, 1)
+		} else {
+			// Multiply by inv(A^T).
+			impl.Dgbtrs(blas.Trans, n, kl, ku, 1, ab, ldab, ipiv, work, 1)
+		}
+	}
+
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
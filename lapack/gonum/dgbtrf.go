@@ -0,0 +1,106 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas/blas64"
+
+// Dgbtrf computes the LU factorization of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular band matrix
+// with kl sub-diagonals and U is an upper triangular band matrix with kl+ku
+// super-diagonals.
+//
+// On entry, ab contains the band of A stored in rows of length ldab with the
+// diagonal of A in column kl of ab, that is, the element A[i,j] for
+// max(0,i-kl) <= j <= min(n-1,i+ku) is stored in ab[i*ldab+j-i+kl]. ldab must
+// be at least 2*kl+ku+1 to provide space for the kl additional super-diagonals
+// of U that are generated by the row interchanges. The elements in columns
+// kl+ku+1 to 2*kl+ku of ab need not be set on entry. The storage scheme is
+// illustrated below when m = n = 6, kl = 2 and ku = 1.
+//
+//  On entry:               On return:
+//   *   *  a11 a12  +   +    *   *  u11 u12 u13 u14
+//   *  a21 a22 a23  +   +    *  l21 u22 u23 u24 u25
+//  a31 a32 a33 a34  +   +   l31 l32 u33 u34 u35 u36
+//  a42 a43 a44 a45  +   +   l42 l43 u44 u45 u46  *
+//  a53 a54 a55 a56  +   +   l53 l54 u55 u56  *   *
+//  a64 a65 a66  *   +   +   l64 l65 u66  *   *   *
+//
+// Elements marked * are not used and elements marked + need not be set on
+// entry but are used to store elements of U. On return, ab contains the band
+// of U in columns kl to 2*kl+ku and the multipliers used during the
+// factorization in columns 0 to kl-1.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// interchanged with row ipiv[i]. ipiv must have length at least min(m,n) and
+// Dgbtrf will panic otherwise. ipiv is zero-indexed.
+//
+// Dgbtrf returns whether the matrix U is nonsingular. The factorization is
+// completed regardless of the singularity of A, but division by zero will
+// occur if false is returned and the result is used to solve a system of
+// equations.
+func (Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	checkBanded(ab, m, n, kl, kl+ku, ldab)
+	mn := min(m, n)
+	if len(ipiv) < mn {
+		panic(badIpiv)
+	}
+	if mn == 0 {
+		return true
+	}
+
+	// kv is the number of super-diagonals of U.
+	kv := kl + ku
+
+	// Zero the elements of ab that will hold the fill-in of U.
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := kv + 1; j <= kv+kl; j++ {
+			ab[i*ldab+j] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+	ok = true
+	// ju is the index of the last column affected by the current stage
+	// of the factorization.
+	var ju int
+	for j := 0; j < mn; j++ {
+		// km is the number of sub-diagonal elements in column j.
+		km := min(kl, m-j-1)
+
+		// Find the pivot and test for singularity. The elements of
+		// column j from row j downwards are stored with stride ldab-1.
+		var jp int
+		if km > 0 {
+			jp = bi.Idamax(km+1, ab[j*ldab+kl:], ldab-1)
+		}
+		ipiv[j] = j + jp
+		if ab[(j+jp)*ldab+kl-jp] == 0 {
+			// The pivot is exactly zero. The factorization is
+			// completed, but U is exactly singular.
+			ok = false
+			continue
+		}
+
+		ju = max(ju, min(j+ku+jp, n-1))
+
+		// Apply the interchange to columns j to ju.
+		if jp != 0 {
+			bi.Dswap(ju-j+1, ab[(j+jp)*ldab+kl-jp:], 1, ab[j*ldab+kl:], 1)
+		}
+		if km > 0 {
+			// Compute the multipliers.
+			bi.Dscal(km, 1/ab[j*ldab+kl], ab[(j+1)*ldab+kl-1:], ldab-1)
+
+			// Update the trailing submatrix within the band.
+			if ju > j {
+				bi.Dger(km, ju-j, -1, ab[(j+1)*ldab+kl-1:], ldab-1, ab[j*ldab+kl+1:], 1, ab[(j+1)*ldab+kl:], ldab-1)
+			}
+		}
+	}
+	return ok
+}
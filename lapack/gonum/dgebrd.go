@@ -103,6 +103,11 @@ func (impl Implementation) Dgebrd(m, n int, a []float64, lda int, d, e, tauQ, ta
 	} else {
 		nx = minmn
 	}
+	if lwork < (m+n)*nb {
+		// There is not enough workspace for the blocked code, so use the
+		// unblocked code for the whole matrix.
+		nb = minmn
+	}
 	bi := blas64.Implementation()
 	ldworkx := nb
 	ldworky := nb
@@ -0,0 +1,540 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgesdd computes the singular value decomposition of the input matrix A
+// using a divide and conquer method.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz is the option for computing the singular vectors. The behavior is as
+// follows
+//  jobz == lapack.SVDAll       All m columns of U and all n rows of V^T are
+//                              returned in u and vt
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U and rows of V^T
+//                              are returned in u and vt
+//  jobz == lapack.SVDNone      The singular vectors are not computed.
+// Dgesdd will panic if jobz == lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Dgesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll, u is of size m×m. If jobz == lapack.SVDInPlace u is
+// of size m×min(m,n). If jobz == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll, vt is of size n×n. If jobz == lapack.SVDInPlace vt is
+// of size min(m,n)×n. If jobz == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If jobz == lapack.SVDNone, lwork must be at least
+// 7*min(m,n) + max(m,n). Otherwise lwork must be at least
+// 4*min(m,n)*min(m,n) + 7*min(m,n) + max(m,n).
+// If lwork == -1, instead of performing Dgesdd, the optimal work length will be
+// stored into work[0]. Dgesdd will panic if the working memory has insufficient
+// storage.
+//
+// iwork must have length at least 8*min(m,n).
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	minmn := min(m, n)
+	checkMatrix(m, n, a, lda)
+	wantqa := jobz == lapack.SVDAll
+	wantqs := jobz == lapack.SVDInPlace
+	wantqn := jobz == lapack.SVDNone
+	if jobz == lapack.SVDOverwrite {
+		panic("dgesdd: not coded for overwrite")
+	}
+	if !wantqa && !wantqs && !wantqn {
+		panic(badJob)
+	}
+	if wantqa {
+		checkMatrix(m, m, u, ldu)
+		checkMatrix(n, n, vt, ldvt)
+	} else if wantqs {
+		checkMatrix(m, minmn, u, ldu)
+		checkMatrix(minmn, n, vt, ldvt)
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+
+	// Compute the minimal and optimal workspace. The singular vectors are
+	// computed from a preliminary QR (LQ) factorization if A has many more
+	// rows (columns) than columns (rows).
+	mnthr := minmn * 11 / 6
+	bdspac := 4 * minmn
+	if !wantqn {
+		bdspac = 3*minmn*minmn + 4*minmn
+	}
+	var minwrk, maxwrk int
+	if m >= n {
+		nbqr := impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+		if m >= mnthr {
+			wrkbl := n + n*nbqr
+			wrkbl = max(wrkbl, 3*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+			switch {
+			case wantqn:
+				maxwrk = max(wrkbl, 3*n+bdspac)
+				minwrk = 3*n + max(n, bdspac)
+			case wantqs:
+				wrkbl = max(wrkbl, n+n*impl.Ilaenv(1, "DORGQR", " ", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = n*n + wrkbl
+				minwrk = n*n + 3*n + bdspac
+			default:
+				wrkbl = max(wrkbl, n+m*impl.Ilaenv(1, "DORGQR", " ", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = n*n + wrkbl
+				minwrk = n*n + max(3*n+bdspac, n+m)
+			}
+		} else {
+			wrkbl := 3*n + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			switch {
+			case wantqs:
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+			case wantqa:
+				wrkbl = max(wrkbl, 3*n+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, m, -1))
+			}
+			maxwrk = max(wrkbl, 3*n+bdspac)
+			minwrk = 3*n + max(m, bdspac)
+		}
+	} else {
+		nblq := impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+		if n >= mnthr {
+			wrkbl := m + m*nblq
+			wrkbl = max(wrkbl, 3*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+			switch {
+			case wantqn:
+				maxwrk = max(wrkbl, 3*m+bdspac)
+				minwrk = 3*m + max(m, bdspac)
+			case wantqs:
+				wrkbl = max(wrkbl, m+m*impl.Ilaenv(1, "DORGLQ", " ", m, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = m*m + wrkbl
+				minwrk = m*m + 3*m + bdspac
+			default:
+				wrkbl = max(wrkbl, m+n*impl.Ilaenv(1, "DORGLQ", " ", n, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = m*m + wrkbl
+				minwrk = m*m + max(3*m+bdspac, m+n)
+			}
+		} else {
+			wrkbl := 3*m + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			switch {
+			case wantqs:
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, n, m, -1))
+			case wantqa:
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*m+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, m, -1))
+			}
+			maxwrk = max(wrkbl, 3*m+bdspac)
+			minwrk = 3*m + max(n, bdspac)
+		}
+	}
+	minwrk = max(1, minwrk)
+	maxwrk = max(maxwrk, minwrk)
+
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwrk {
+		panic(badWork)
+	}
+	if len(iwork) < 8*minmn {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// Scale A if max element outside range [smlnum, bignum].
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+	}
+
+	bi := blas64.Implementation()
+	compq := lapack.EVComp(lapack.None)
+	if !wantqn {
+		compq = lapack.BidiagSV
+	}
+	if m >= n {
+		if m >= mnthr {
+			switch {
+			case wantqn:
+				// Path 1 (m >> n, jobz == SVDNone).
+				// No singular vectors to be computed.
+				itau := 0
+				nwork := itau + n
+
+				// Compute A = Q * R.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Zero out below R.
+				if n > 1 {
+					impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+				}
+				ie := 0
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in A.
+				impl.Dgebrd(n, n, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing singular values only.
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], nil, 1, nil, 1, work[nwork:], iwork)
+
+			case wantqs:
+				// Path 3 (m >> n, jobz == SVDInPlace).
+				// n left singular vectors to be computed in U and n
+				// right singular vectors to be computed in VT.
+				ir := 0
+
+				// work[ir:] is n×n.
+				ldwrkr := n
+				itau := ir + ldwrkr*n
+				nwork := itau + n
+
+				// Compute A = Q * R.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy R to work[ir:], zeroing out below it.
+				impl.Dlacpy(blas.Upper, n, n, a, lda, work[ir:], ldwrkr)
+				if n > 1 {
+					impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, work[ir+ldwrkr:], ldwrkr)
+				}
+
+				// Generate Q in A.
+				impl.Dorgqr(m, n, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in work[ir:].
+				impl.Dgebrd(n, n, work[ir:], ldwrkr, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite U by the left singular vectors of R and VT by
+				// the right singular vectors of R.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, work[ir:], ldwrkr, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, work[ir:], ldwrkr, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply Q in A by the left singular vectors of R in
+				// work[ir:], storing the result in U.
+				impl.Dlacpy(blas.All, n, n, u, ldu, work[ir:], ldwrkr)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, n, 1, a, lda, work[ir:], ldwrkr, 0, u, ldu)
+
+			default:
+				// Path 4 (m >> n, jobz == SVDAll).
+				// m left singular vectors to be computed in U and n
+				// right singular vectors to be computed in VT.
+				iu := 0
+
+				// work[iu:] is n×n.
+				ldwrku := n
+				itau := iu + ldwrku*n
+				nwork := itau + n
+
+				// Compute A = Q * R, copying the result to U.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				impl.Dlacpy(blas.Lower, m, n, a, lda, u, ldu)
+
+				// Generate Q in U.
+				impl.Dorgqr(m, m, n, u, ldu, work[itau:], work[nwork:], lwork-nwork)
+
+				// Produce R in A, zeroing out other entries.
+				if n > 1 {
+					impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+				}
+				ie := itau
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in A.
+				impl.Dgebrd(n, n, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in work[iu:] and right singular
+				// vectors of the bidiagonal matrix in VT.
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], work[iu:], ldwrku, vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite work[iu:] by the left singular vectors of R
+				// and VT by the right singular vectors of R.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, a, lda, work[itauq:itaup], work[iu:], ldwrku, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply Q in U by the left singular vectors of R in
+				// work[iu:], storing the result in A.
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, n, 1, u, ldu, work[iu:], ldwrku, 0, a, lda)
+
+				// Copy the left singular vectors of A from A to U.
+				impl.Dlacpy(blas.All, m, n, a, lda, u, ldu)
+			}
+		} else {
+			// Path 5 (m >= n, but not much larger).
+			// Reduce to bidiagonal form without QR decomposition.
+			ie := 0
+			itauq := ie + n
+			itaup := itauq + n
+			nwork := itaup + n
+
+			// Bidiagonalize A.
+			impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+			switch {
+			case wantqn:
+				// Perform bidiagonal SVD, only computing singular values.
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], nil, 1, nil, 1, work[nwork:], iwork)
+			case wantqs:
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				impl.Dlaset(blas.All, m, n, 0, 0, u, ldu)
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite U by the left singular vectors of A and VT by
+				// the right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, n, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			default:
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				impl.Dlaset(blas.All, m, m, 0, 0, u, ldu)
+				ok = impl.Dbdsdc(blas.Upper, compq, n, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Set the right corner of U to the identity matrix.
+				if m > n {
+					impl.Dlaset(blas.All, m-n, m-n, 0, 1, u[n*ldu+n:], ldu)
+				}
+
+				// Overwrite U by the left singular vectors of A and VT by
+				// the right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, m, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	} else {
+		if n >= mnthr {
+			switch {
+			case wantqn:
+				// Path 1t (n >> m, jobz == SVDNone).
+				// No singular vectors to be computed.
+				itau := 0
+				nwork := itau + m
+
+				// Compute A = L * Q.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Zero out above L.
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, a[1:], lda)
+				ie := 0
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in A.
+				impl.Dgebrd(m, m, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing singular values only.
+				ok = impl.Dbdsdc(blas.Upper, compq, m, s, work[ie:], nil, 1, nil, 1, work[nwork:], iwork)
+
+			case wantqs:
+				// Path 3t (n >> m, jobz == SVDInPlace).
+				// m right singular vectors to be computed in VT and m
+				// left singular vectors to be computed in U.
+				il := 0
+
+				// work[il:] is m×m.
+				ldwrkl := m
+				itau := il + ldwrkl*m
+				nwork := itau + m
+
+				// Compute A = L * Q.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy L to work[il:], zeroing out above it.
+				impl.Dlacpy(blas.Lower, m, m, a, lda, work[il:], ldwrkl)
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[il+1:], ldwrkl)
+
+				// Generate Q in A.
+				impl.Dorglq(m, n, m, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in work[il:].
+				impl.Dgebrd(m, m, work[il:], ldwrkl, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				ok = impl.Dbdsdc(blas.Upper, compq, m, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite U by the left singular vectors of L and VT by
+				// the right singular vectors of L.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, work[il:], ldwrkl, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, work[il:], ldwrkl, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply the right singular vectors of L in work[il:] by
+				// Q in A, storing the result in VT.
+				impl.Dlacpy(blas.All, m, m, vt, ldvt, work[il:], ldwrkl)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, m, 1, work[il:], ldwrkl, a, lda, 0, vt, ldvt)
+
+			default:
+				// Path 4t (n >> m, jobz == SVDAll).
+				// n right singular vectors to be computed in VT and m
+				// left singular vectors to be computed in U.
+				ivt := 0
+
+				// work[ivt:] is m×m.
+				ldwkvt := m
+				itau := ivt + ldwkvt*m
+				nwork := itau + m
+
+				// Compute A = L * Q, copying the result to VT.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				impl.Dlacpy(blas.Upper, m, n, a, lda, vt, ldvt)
+
+				// Generate Q in VT.
+				impl.Dorglq(n, n, m, vt, ldvt, work[itau:], work[nwork:], lwork-nwork)
+
+				// Produce L in A, zeroing out other entries.
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, a[1:], lda)
+				ie := itau
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in A.
+				impl.Dgebrd(m, m, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in work[ivt:].
+				ok = impl.Dbdsdc(blas.Upper, compq, m, s, work[ie:], u, ldu, work[ivt:], ldwkvt, work[nwork:], iwork)
+
+				// Overwrite U by the left singular vectors of L and
+				// work[ivt:] by the right singular vectors of L.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, a, lda, work[itaup:nwork], work[ivt:], ldwkvt, work[nwork:], lwork-nwork)
+
+				// Multiply the right singular vectors of L in work[ivt:]
+				// by Q in VT, storing the result in A.
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, m, 1, work[ivt:], ldwkvt, vt, ldvt, 0, a, lda)
+
+				// Copy the right singular vectors of A from A to VT.
+				impl.Dlacpy(blas.All, m, n, a, lda, vt, ldvt)
+			}
+		} else {
+			// Path 5t (n > m, but not much larger).
+			// Reduce to bidiagonal form without LQ decomposition.
+			ie := 0
+			itauq := ie + m
+			itaup := itauq + m
+			nwork := itaup + m
+
+			// Bidiagonalize A.
+			impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:], work[itaup:], work[nwork:], lwork-nwork)
+
+			switch {
+			case wantqn:
+				// Perform bidiagonal SVD, only computing singular values.
+				ok = impl.Dbdsdc(blas.Lower, compq, m, s, work[ie:], nil, 1, nil, 1, work[nwork:], iwork)
+			case wantqs:
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				impl.Dlaset(blas.All, m, n, 0, 0, vt, ldvt)
+				ok = impl.Dbdsdc(blas.Lower, compq, m, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite U by the left singular vectors of A and VT by
+				// the right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, n, m, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			default:
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of the bidiagonal matrix in U and right singular vectors
+				// of the bidiagonal matrix in VT.
+				impl.Dlaset(blas.All, n, n, 0, 0, vt, ldvt)
+				ok = impl.Dbdsdc(blas.Lower, compq, m, s, work[ie:], u, ldu, vt, ldvt, work[nwork:], iwork)
+
+				// Set the right corner of VT to the identity matrix.
+				if n > m {
+					impl.Dlaset(blas.All, n-m, n-m, 0, 1, vt[m*ldvt+m:], ldvt)
+				}
+
+				// Overwrite U by the left singular vectors of A and VT by
+				// the right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda, work[itauq:itaup], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, m, a, lda, work[itaup:nwork], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, minmn)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, minmn)
+		}
+	}
+	work[0] = float64(maxwrk)
+	return ok
+}
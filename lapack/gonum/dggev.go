@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// A generalized eigenvalue of (A,B) is a scalar λ or a ratio α/β = λ such that
+// A - λ*B is singular. It is usually represented as the pair (α,β), as there
+// is a reasonable interpretation for β == 0, and even for both being zero.
+//
+// The right eigenvector v_j corresponding to the eigenvalue λ_j of (A,B)
+// satisfies
+//  A v_j = λ_j B v_j,
+// and the left eigenvector u_j corresponding to the eigenvalue λ_j satisfies
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// The eigenvalues are computed by the QZ algorithm. B is first reduced to
+// upper triangular form by a QR factorization, the pair is then reduced to
+// generalized upper Hessenberg form by Dgghrd and finally to generalized
+// Schur form by Dhgeqz. The eigenvectors are computed from the generalized
+// Schur form by Dtgevc. No balancing of the pair is performed.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR in
+// the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that its
+// largest component has |real part| + |imaginary part| equal to 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Dggev will panic.
+//
+// On return, the eigenvalues of (A,B) are
+//  λ_j = (alphar[j] + i*alphai[j]) / beta[j].
+// beta[j] is non-negative and it may be zero, in which case λ_j is an infinite
+// eigenvalue. If alphai[j] is zero, λ_j is real. Complex conjugate pairs of
+// eigenvalues appear consecutively with the eigenvalue having the positive
+// imaginary part first. The quotients alphar[j]/beta[j] and alphai[j]/beta[j]
+// may easily over- or underflow, and beta[j] may even be zero. Thus, the user
+// should avoid naively computing the ratio. alphar, alphai and beta must have
+// length n, otherwise Dggev will panic.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n),
+// otherwise Dggev will panic. For good performance, lwork must generally be
+// larger. On return, the optimal value of lwork will be stored in work[0].
+//
+// If lwork == -1, instead of performing Dggev, the function only calculates
+// the optimal value of lwork and stores it into work[0].
+//
+// On return, first is the index of the first valid eigenvalue. If first == 0,
+// all eigenvalues and eigenvectors have been computed. If first is positive,
+// the QZ iteration failed to compute all the eigenvalues, no eigenvectors have
+// been computed and alphar[first:], alphai[first:] and beta[first:] contain
+// those eigenvalues which have converged.
+func (impl Implementation) Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (first int) {
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	minwrk := max(1, 8*n)
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		checkMatrix(n, n, b, ldb)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(alphar) != n:
+			panic("lapack: bad length of alphar")
+		case len(alphai) != n:
+			panic("lapack: bad length of alphai")
+		case len(beta) != n:
+			panic(badBeta)
+		case lwork < minwrk:
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0
+	}
+
+	impl.Dgeqrf(n, n, nil, n, nil, work, -1)
+	maxwrk := n + int(work[0])
+	impl.Dormqr(blas.Left, blas.Trans, n, n, n, nil, n, nil, nil, n, work, -1)
+	maxwrk = max(maxwrk, n+int(work[0]))
+	if wantvl {
+		impl.Dorgqr(n, n, n, nil, n, nil, work, -1)
+		maxwrk = max(maxwrk, n+int(work[0]))
+	}
+	maxwrk = max(maxwrk, minwrk)
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return 0
+	}
+
+	// Reduce B to triangular form (QR decomposition of B) and apply the
+	// orthogonal transformation to A.
+	tau := work[:n]
+	iwrk := n
+	impl.Dgeqrf(n, n, b, ldb, tau, work[iwrk:], lwork-iwrk)
+	impl.Dormqr(blas.Left, blas.Trans, n, n, n, b, ldb, tau, a, lda, work[iwrk:], lwork-iwrk)
+
+	compq := lapack.EVComp(lapack.None)
+	if wantvl {
+		// Initialize VL with the orthogonal matrix of the QR
+		// decomposition of B.
+		impl.Dlaset(blas.All, n, n, 0, 1, vl, ldvl)
+		if n > 1 {
+			impl.Dlacpy(blas.Lower, n-1, n-1, b[ldb:], ldb, vl[ldvl:], ldvl)
+		}
+		impl.Dorgqr(n, n, n, vl, ldvl, tau, work[iwrk:], lwork-iwrk)
+		compq = lapack.OriginalEV
+	}
+	compz := lapack.EVComp(lapack.None)
+	if wantvr {
+		compz = lapack.HessEV
+	}
+
+	// Reduce the pair to generalized upper Hessenberg form.
+	impl.Dgghrd(compq, compz, n, 0, n-1, a, lda, b, ldb, vl, ldvl, vr, ldvr)
+
+	// Perform the QZ algorithm, computing the Schur vectors if eigenvectors
+	// are desired.
+	job := lapack.EigenvaluesOnly
+	if wantvl || wantvr {
+		job = lapack.EigenvaluesAndSchur
+	}
+	if wantvr {
+		compz = lapack.OriginalEV
+	}
+	first = impl.Dhgeqz(job, compq, compz, n, 0, n-1, a, lda, b, ldb, alphar, alphai, beta, vl, ldvl, vr, ldvr)
+	if first != 0 {
+		work[0] = float64(maxwrk)
+		return first
+	}
+
+	// Compute the eigenvectors and back-transform them.
+	if wantvl || wantvr {
+		var side lapack.EVSide
+		switch {
+		case wantvl && wantvr:
+			side = lapack.RightLeftEV
+		case wantvl:
+			side = lapack.LeftEV
+		default:
+			side = lapack.RightEV
+		}
+		impl.Dtgevc(side, lapack.AllEVMulQ, n, a, lda, b, ldb, vl, ldvl, vr, ldvr, work[iwrk:])
+	}
+
+	work[0] = float64(maxwrk)
+	return 0
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dgghrd reduces a pair of real n×n matrices (A, B) to generalized upper
+// Hessenberg form using orthogonal transformations, where A is a general
+// matrix and B is upper triangular. The form is
+//  Q1^T * A * Z1 = H,
+//  Q1^T * B * Z1 = T,
+// where H is upper Hessenberg, T is upper triangular, and Q1 and Z1 are
+// orthogonal.
+//
+// The orthogonal matrices Q1 and Z1 may be either formed explicitly, or they
+// may be postmultiplied into input matrices Q and Z, so that
+//  Q * A * Z^T = (Q*Q1) * H * (Z*Z1)^T,
+//  Q * B * Z^T = (Q*Q1) * T * (Z*Z1)^T.
+// If Q is the orthogonal matrix from the QR factorization of B in the
+// original equation A*x = λ*B*x, then Dgghrd reduces the original problem to
+// generalized Hessenberg form.
+//
+// If compq == lapack.None, Q is not computed and q is not referenced.
+// If compq == lapack.HessEV, Q is initialized to the identity matrix and on
+// return it will contain the orthogonal matrix Q1.
+// If compq == lapack.OriginalEV, on entry q must contain an orthogonal matrix
+// Q and on return it will contain the product Q*Q1.
+// For other values of compq Dgghrd will panic. compz and z are interpreted in
+// the same way for the matrix Z.
+//
+// ilo and ihi determine the block of the pencil that will be reduced. It is
+// assumed that A is already upper triangular in rows and columns [0:ilo] and
+// [ihi+1:n]. ilo and ihi are typically set by a previous call to a balancing
+// routine, otherwise they should be set to 0 and n-1, respectively. It must
+// hold that
+//  0 <= ilo <= ihi < n,     if n > 0,
+//  ilo == 0 and ihi == -1,  if n == 0,
+// otherwise Dgghrd will panic.
+//
+// On return, A will be overwritten by the upper Hessenberg matrix H, and B
+// will be overwritten by the upper triangular matrix T. The elements of B
+// below the diagonal are not referenced on entry and are set to zero on return.
+//
+// Dgghrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int) {
+	var wantq, initq bool
+	switch compq {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		wantq = true
+		initq = true
+	case lapack.OriginalEV:
+		wantq = true
+	}
+	var wantz, initz bool
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		wantz = true
+		initz = true
+	case lapack.OriginalEV:
+		wantz = true
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	if initq {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+	if initz {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	// Quick return if possible.
+	if n <= 1 {
+		return
+	}
+
+	// Zero out the lower triangle of B.
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b[i*ldb+j] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+	for jcol := ilo; jcol < ihi-1; jcol++ {
+		for jrow := ihi; jrow > jcol+1; jrow-- {
+			// Rotate rows jrow-1 and jrow to annihilate A[jrow,jcol].
+			var c, s float64
+			c, s, a[(jrow-1)*lda+jcol] = impl.Dlartg(a[(jrow-1)*lda+jcol], a[jrow*lda+jcol])
+			a[jrow*lda+jcol] = 0
+			bi.Drot(n-jcol-1, a[(jrow-1)*lda+jcol+1:], 1, a[jrow*lda+jcol+1:], 1, c, s)
+			bi.Drot(n-jrow+1, b[(jrow-1)*ldb+jrow-1:], 1, b[jrow*ldb+jrow-1:], 1, c, s)
+			if wantq {
+				bi.Drot(n, q[jrow-1:], ldq, q[jrow:], ldq, c, s)
+			}
+
+			// Rotate columns jrow and jrow-1 to annihilate the fill-in
+			// B[jrow,jrow-1].
+			c, s, b[jrow*ldb+jrow] = impl.Dlartg(b[jrow*ldb+jrow], b[jrow*ldb+jrow-1])
+			b[jrow*ldb+jrow-1] = 0
+			bi.Drot(ihi+1, a[jrow:], lda, a[jrow-1:], lda, c, s)
+			bi.Drot(jrow, b[jrow:], ldb, b[jrow-1:], ldb, c, s)
+			if wantz {
+				bi.Drot(n, z[jrow:], ldz, z[jrow-1:], ldz, c, s)
+			}
+		}
+	}
+}
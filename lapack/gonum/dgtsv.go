@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dgtsv solves the equation
+//  A * X = B
+// where A is an n×n tridiagonal matrix, by Gaussian elimination with partial
+// pivoting. Note that the equation
+//  A^T * X = B
+// may be solved by interchanging the order of the arguments dl and du.
+//
+// On entry, dl, d and du contain the sub-diagonal, the diagonal and the
+// super-diagonal, respectively, of A. On return, the first n-2 elements of dl
+// contain the second super-diagonal of the upper triangular matrix U from the
+// LU factorization of A, d contains the diagonal of U and du contains the first
+// super-diagonal of U. dl and du must have length at least n-1 and d must have
+// length at least n, and Dgtsv will panic otherwise.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, if ok is
+// true, b contains the n×nrhs solution matrix X.
+//
+// Dgtsv returns whether the solution X has been successfully computed. If the
+// matrix U is exactly singular, ok will be false and the solution will not have
+// been computed.
+func (impl Implementation) Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 0 {
+		panic(negDimension)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if n == 0 {
+		return true
+	}
+
+	for i := 0; i < n-1; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required.
+			if d[i] == 0 {
+				return false
+			}
+			fact := dl[i] / d[i]
+			d[i+1] -= fact * du[i]
+			for j := 0; j < nrhs; j++ {
+				b[(i+1)*ldb+j] -= fact * b[i*ldb+j]
+			}
+			dl[i] = 0
+		} else {
+			// Interchange rows i and i+1.
+			fact := d[i] / dl[i]
+			d[i] = dl[i]
+			tmp := d[i+1]
+			d[i+1] = du[i] - fact*tmp
+			du[i] = tmp
+			if i < n-2 {
+				dl[i] = du[i+1]
+				du[i+1] = -fact * dl[i]
+			}
+			for j := 0; j < nrhs; j++ {
+				tmp = b[i*ldb+j]
+				b[i*ldb+j] = b[(i+1)*ldb+j]
+				b[(i+1)*ldb+j] = tmp - fact*b[(i+1)*ldb+j]
+			}
+		}
+	}
+	if d[n-1] == 0 {
+		return false
+	}
+
+	// Back solve with the matrix U from the factorization.
+	for j := 0; j < nrhs; j++ {
+		b[(n-1)*ldb+j] /= d[n-1]
+		if n > 1 {
+			b[(n-2)*ldb+j] = (b[(n-2)*ldb+j] - du[n-2]*b[(n-1)*ldb+j]) / d[n-2]
+		}
+		for i := n - 3; i >= 0; i-- {
+			b[i*ldb+j] = (b[i*ldb+j] - du[i]*b[(i+1)*ldb+j] - dl[i]*b[(i+2)*ldb+j]) / d[i]
+		}
+	}
+	return true
+}
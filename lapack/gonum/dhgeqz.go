@@ -0,0 +1,846 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dhgeqz computes the eigenvalues of a real matrix pair (H,T), where H is an
+// n×n upper Hessenberg matrix and T is an n×n upper triangular matrix, using
+// the single- and double-shift QZ method. Matrix pairs of this type are
+// produced by the reduction to generalized upper Hessenberg form of a real
+// matrix pair (A,B) by Dgghrd:
+//  A = Q1 * H * Z1^T,
+//  B = Q1 * T * Z1^T.
+//
+// If job == lapack.EigenvaluesAndSchur, the pair (H,T) is also reduced to the
+// generalized Schur form
+//  H = Q * S * Z^T,
+//  T = Q * P * Z^T,
+// where Q and Z are orthogonal matrices, P is an upper triangular matrix with
+// non-negative diagonal elements and S is a block upper triangular matrix with
+// 1×1 and 2×2 diagonal blocks. The 1×1 blocks correspond to real eigenvalues
+// of the pair and the 2×2 blocks to complex conjugate pairs of eigenvalues.
+// The 2×2 blocks of P corresponding to 2×2 blocks of S are reduced to positive
+// diagonal form, that is, if S[j+1,j] is non-zero, then P[j+1,j] == P[j,j+1]
+// == 0, P[j,j] > 0 and P[j+1,j+1] > 0. On return, S will be stored in h and
+// P in t.
+// If job == lapack.EigenvaluesOnly, only the eigenvalues are computed and the
+// contents of h and t on return is unspecified.
+// For other values of job Dhgeqz will panic.
+//
+// If compq == lapack.None, Q is not computed and q is not referenced.
+// If compq == lapack.HessEV, Q is initialized to the identity matrix and on
+// return it will contain the orthogonal matrix Q of the Schur form of (H,T).
+// If compq == lapack.OriginalEV, on entry q must contain an orthogonal matrix
+// Q1, typically from Dgghrd, and on return it will contain the product Q1*Q,
+// so that the Schur form of (A,B) is
+//  A = (Q1*Q) * S * (Z1*Z)^T,
+//  B = (Q1*Q) * P * (Z1*Z)^T.
+// For other values of compq Dhgeqz will panic. compz and z are interpreted in
+// the same way for the matrix Z.
+//
+// ilo and ihi determine the block of the pair on which Dhgeqz operates. It is
+// assumed that H is already upper triangular in rows and columns [0:ilo] and
+// [ihi+1:n]. ilo and ihi are typically set by a previous call to a balancing
+// routine, otherwise they should be set to 0 and n-1, respectively. It must
+// hold that
+//  0 <= ilo <= ihi < n,     if n > 0,
+//  ilo == 0 and ihi == -1,  if n == 0,
+// otherwise Dhgeqz will panic.
+//
+// On return, the eigenvalues of the pair are
+//  λ_j = (alphar[j] + i*alphai[j]) / beta[j],
+// where beta[j] is non-negative. beta[j] may be zero, in which case λ_j is an
+// infinite eigenvalue. If alphai[j] is zero, λ_j is real. Complex conjugate
+// pairs of eigenvalues appear consecutively with the eigenvalue having the
+// positive imaginary part first. If job == lapack.EigenvaluesAndSchur, alphar,
+// alphai and beta are the values that would be obtained from the 1×1 and 2×2
+// diagonal blocks of (S,P) if the 2×2 blocks were further reduced to
+// triangular form using unitary transformations. alphar, alphai and beta must
+// have length n, otherwise Dhgeqz will panic.
+//
+// unconverged indicates whether Dhgeqz computed all the eigenvalues. If
+// unconverged == 0, all the eigenvalues have been computed. If unconverged is
+// positive, the QZ iteration failed to converge and the blocks [0:ilo] and
+// [unconverged:n] of alphar, alphai and beta contain those eigenvalues which
+// have been successfully computed. Failures are rare.
+//
+// Dhgeqz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dhgeqz(job lapack.EVJob, compq, compz lapack.EVComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int, alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int) (unconverged int) {
+	var ilschr bool
+	switch job {
+	default:
+		panic(badEVJob)
+	case lapack.EigenvaluesOnly:
+	case lapack.EigenvaluesAndSchur:
+		ilschr = true
+	}
+	var ilq, initq bool
+	switch compq {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		ilq = true
+		initq = true
+	case lapack.OriginalEV:
+		ilq = true
+	}
+	var ilz, initz bool
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.HessEV:
+		ilz = true
+		initz = true
+	case lapack.OriginalEV:
+		ilz = true
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	case len(alphar) != n:
+		panic("lapack: bad length of alphar")
+	case len(alphai) != n:
+		panic("lapack: bad length of alphai")
+	case len(beta) != n:
+		panic(badBeta)
+	}
+	checkMatrix(n, n, h, ldh)
+	checkMatrix(n, n, t, ldt)
+	if ilq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if ilz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	if initq {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+	if initz {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	const safety = 100
+	safmin := dlamchS
+	safmax := 1 / safmin
+	ulp := dlamchP
+	anorm := impl.dlanhsFrob(ihi-ilo+1, h[ilo*ldh+ilo:], ldh)
+	bnorm := impl.dlanhsFrob(ihi-ilo+1, t[ilo*ldt+ilo:], ldt)
+	atol := math.Max(safmin, ulp*anorm)
+	btol := math.Max(safmin, ulp*bnorm)
+	ascale := 1 / math.Max(safmin, anorm)
+	bscale := 1 / math.Max(safmin, bnorm)
+
+	bi := blas64.Implementation()
+
+	// setEigenvalue stores the real eigenvalue held in the 1×1 diagonal block
+	// at j, negating the j-th column of the pair if necessary so that beta[j]
+	// is non-negative. Only rows [ifrstm:j+1] are modified.
+	setEigenvalue := func(j, ifrstm int) {
+		if t[j*ldt+j] < 0 {
+			if ilschr {
+				for jr := ifrstm; jr <= j; jr++ {
+					h[jr*ldh+j] = -h[jr*ldh+j]
+					t[jr*ldt+j] = -t[jr*ldt+j]
+				}
+			} else {
+				h[j*ldh+j] = -h[j*ldh+j]
+				t[j*ldt+j] = -t[j*ldt+j]
+			}
+			if ilz {
+				bi.Dscal(n, -1, z[j:], ldz)
+			}
+		}
+		alphar[j] = h[j*ldh+j]
+		alphai[j] = 0
+		beta[j] = t[j*ldt+j]
+	}
+
+	// Set eigenvalues ihi+1:n.
+	for j := ihi + 1; j < n; j++ {
+		setEigenvalue(j, 0)
+	}
+
+	// Main QZ iteration loop.
+	//
+	// Eigenvalues ilast+1:n have been found. Column operations modify rows
+	// ifrstm:whatever and row operations modify columns whatever:ilastm+1.
+	// If only eigenvalues are being computed, then ifrstm is the row of the
+	// last splitting row above row ilast; this is always at least ilo.
+	// iiter counts the iterations since the last eigenvalue was found, to
+	// tell when to use an exceptional shift. maxit is the maximum number of
+	// QZ sweeps allowed.
+	const (
+		deflate  = iota // H[ilast,ilast-1] is zero, split off a 1×1 block.
+		infinite        // T[ilast,ilast] is zero, clear H[ilast,ilast-1] and deflate.
+		qzStep          // Perform a QZ sweep on rows and columns ifirst:ilast+1.
+	)
+	ilast := ihi
+	ifrstm := ilo
+	ilastm := ihi
+	if ilschr {
+		ifrstm = 0
+		ilastm = n - 1
+	}
+	var iiter int
+	var eshift float64
+	maxit := 30 * (ihi - ilo + 1)
+	for jiter := 0; ilast >= ilo; jiter++ {
+		if jiter == maxit {
+			// Drop-through is non-convergence.
+			return ilast + 1
+		}
+
+		// Split the matrix if possible. Two tests:
+		//  1: H[j,j-1] == 0 or j == ilo,
+		//  2: T[j,j] == 0.
+		var ifirst int
+		action := -1
+		switch {
+		case ilast == ilo:
+			action = deflate
+		case math.Abs(h[ilast*ldh+ilast-1]) <= math.Max(safmin, ulp*(math.Abs(h[ilast*ldh+ilast])+math.Abs(h[(ilast-1)*ldh+ilast-1]))):
+			h[ilast*ldh+ilast-1] = 0
+			action = deflate
+		case math.Abs(t[ilast*ldt+ilast]) < btol:
+			t[ilast*ldt+ilast] = 0
+			action = infinite
+		default:
+			// General case: j < ilast.
+			for j := ilast - 1; j >= ilo; j-- {
+				// Test 1: for H[j,j-1] == 0 or j == ilo.
+				var ilazro bool
+				if j == ilo {
+					ilazro = true
+				} else if math.Abs(h[j*ldh+j-1]) <= math.Max(safmin, ulp*(math.Abs(h[j*ldh+j])+math.Abs(h[(j-1)*ldh+j-1]))) {
+					h[j*ldh+j-1] = 0
+					ilazro = true
+				}
+
+				// Test 2: for T[j,j] == 0.
+				if math.Abs(t[j*ldt+j]) >= btol {
+					if ilazro {
+						// Only test 1 passed, work on j:ilast+1.
+						ifirst = j
+						action = qzStep
+						break
+					}
+					// Neither test passed, try the next j.
+					continue
+				}
+				t[j*ldt+j] = 0
+
+				// Test 1a: check for two consecutive small
+				// subdiagonals in H.
+				var ilazr2 bool
+				if !ilazro {
+					temp := math.Abs(h[j*ldh+j-1])
+					temp2 := math.Abs(h[j*ldh+j])
+					tempr := math.Max(temp, temp2)
+					if tempr < 1 && tempr != 0 {
+						temp /= tempr
+						temp2 /= tempr
+					}
+					if temp*(ascale*math.Abs(h[(j+1)*ldh+j])) <= temp2*(ascale*atol) {
+						ilazr2 = true
+					}
+				}
+
+				if ilazro || ilazr2 {
+					// If both tests pass, that is, the leading
+					// diagonal element of T in the block is zero,
+					// split a 1×1 block off at the top (at the j-th
+					// row and column). The leading diagonal element
+					// of the remainder can also be zero, so this may
+					// have to be done repeatedly.
+					action = infinite
+					for jch := j; jch < ilast; jch++ {
+						var c, s float64
+						c, s, h[jch*ldh+jch] = impl.Dlartg(h[jch*ldh+jch], h[(jch+1)*ldh+jch])
+						h[(jch+1)*ldh+jch] = 0
+						bi.Drot(ilastm-jch, h[jch*ldh+jch+1:], 1, h[(jch+1)*ldh+jch+1:], 1, c, s)
+						bi.Drot(ilastm-jch, t[jch*ldt+jch+1:], 1, t[(jch+1)*ldt+jch+1:], 1, c, s)
+						if ilq {
+							bi.Drot(n, q[jch:], ldq, q[jch+1:], ldq, c, s)
+						}
+						if ilazr2 {
+							h[jch*ldh+jch-1] *= c
+						}
+						ilazr2 = false
+						if math.Abs(t[(jch+1)*ldt+jch+1]) >= btol {
+							if jch+1 >= ilast {
+								action = deflate
+							} else {
+								ifirst = jch + 1
+								action = qzStep
+							}
+							break
+						}
+						t[(jch+1)*ldt+jch+1] = 0
+					}
+					break
+				}
+
+				// Only test 2 passed, chase the zero to T[ilast,ilast]
+				// and then process as in the case T[ilast,ilast] == 0.
+				for jch := j; jch < ilast; jch++ {
+					var c, s float64
+					c, s, t[jch*ldt+jch+1] = impl.Dlartg(t[jch*ldt+jch+1], t[(jch+1)*ldt+jch+1])
+					t[(jch+1)*ldt+jch+1] = 0
+					if jch < ilastm-1 {
+						bi.Drot(ilastm-jch-1, t[jch*ldt+jch+2:], 1, t[(jch+1)*ldt+jch+2:], 1, c, s)
+					}
+					bi.Drot(ilastm-jch+2, h[jch*ldh+jch-1:], 1, h[(jch+1)*ldh+jch-1:], 1, c, s)
+					if ilq {
+						bi.Drot(n, q[jch:], ldq, q[jch+1:], ldq, c, s)
+					}
+					c, s, h[(jch+1)*ldh+jch] = impl.Dlartg(h[(jch+1)*ldh+jch], h[(jch+1)*ldh+jch-1])
+					h[(jch+1)*ldh+jch-1] = 0
+					bi.Drot(jch+1-ifrstm, h[ifrstm*ldh+jch:], ldh, h[ifrstm*ldh+jch-1:], ldh, c, s)
+					bi.Drot(jch-ifrstm, t[ifrstm*ldt+jch:], ldt, t[ifrstm*ldt+jch-1:], ldt, c, s)
+					if ilz {
+						bi.Drot(n, z[jch:], ldz, z[jch-1:], ldz, c, s)
+					}
+				}
+				action = infinite
+				break
+			}
+			if action == -1 {
+				// Drop-through is impossible.
+				return ilast + 1
+			}
+		}
+
+		if action == infinite {
+			// T[ilast,ilast] == 0, clear H[ilast,ilast-1] to split off
+			// a 1×1 block.
+			var c, s float64
+			c, s, h[ilast*ldh+ilast] = impl.Dlartg(h[ilast*ldh+ilast], h[ilast*ldh+ilast-1])
+			h[ilast*ldh+ilast-1] = 0
+			bi.Drot(ilast-ifrstm, h[ifrstm*ldh+ilast:], ldh, h[ifrstm*ldh+ilast-1:], ldh, c, s)
+			bi.Drot(ilast-ifrstm, t[ifrstm*ldt+ilast:], ldt, t[ifrstm*ldt+ilast-1:], ldt, c, s)
+			if ilz {
+				bi.Drot(n, z[ilast:], ldz, z[ilast-1:], ldz, c, s)
+			}
+			action = deflate
+		}
+
+		if action == deflate {
+			// H[ilast,ilast-1] == 0, standardize T and set alphar,
+			// alphai and beta.
+			setEigenvalue(ilast, ifrstm)
+
+			// Go to the next block.
+			ilast--
+			iiter = 0
+			eshift = 0
+			if !ilschr {
+				ilastm = ilast
+				if ifrstm > ilast {
+					ifrstm = ilo
+				}
+			}
+			continue
+		}
+
+		// QZ step.
+		//
+		// This iteration only involves rows and columns ifirst:ilast+1.
+		// We assume ifirst < ilast and that the diagonal of T is non-zero.
+		iiter++
+		if !ilschr {
+			ifrstm = ifirst
+		}
+
+		// Compute single shifts.
+		//
+		// At this point ifirst < ilast and the diagonal elements of
+		// T[ifirst:ilast+1,ifirst:ilast+1] are larger than btol in
+		// magnitude.
+		var s1, wr, wi float64
+		if iiter%10 == 0 {
+			// Exceptional shift. Chosen for no particularly good reason
+			// (single shift only).
+			if float64(maxit)*safmin*math.Abs(h[ilast*ldh+ilast-1]) < math.Abs(t[(ilast-1)*ldt+ilast-1]) {
+				eshift = h[ilast*ldh+ilast-1] / t[(ilast-1)*ldt+ilast-1]
+			} else {
+				eshift += 1 / (safmin * float64(maxit))
+			}
+			s1 = 1
+			wr = eshift
+		} else {
+			// Shifts based on the generalized eigenvalues of the
+			// bottom-right 2×2 block of H and T. The first eigenvalue
+			// returned by Dlag2 is the Wilkinson shift.
+			var s2, wr2 float64
+			s1, s2, wr, wr2, wi = impl.Dlag2(h[(ilast-1)*ldh+ilast-1:], ldh, t[(ilast-1)*ldt+ilast-1:], ldt, safmin*safety)
+			tll := t[ilast*ldt+ilast]
+			hll := h[ilast*ldh+ilast]
+			if math.Abs((wr/s1)*tll-hll) > math.Abs((wr2/s2)*tll-hll) {
+				wr, wr2 = wr2, wr
+				s1, s2 = s2, s1
+			}
+		}
+
+		if wi == 0 {
+			// Fiddle with the shift to avoid overflow.
+			temp := math.Min(ascale, 1) * (0.5 * safmax)
+			scale := 1.0
+			if s1 > temp {
+				scale = temp / s1
+			}
+			temp = math.Min(bscale, 1) * (0.5 * safmax)
+			if math.Abs(wr) > temp {
+				scale = math.Min(scale, temp/math.Abs(wr))
+			}
+			s1 *= scale
+			wr *= scale
+
+			// Now check for two consecutive small subdiagonals.
+			istart := ifirst
+			for j := ilast - 1; j > ifirst; j-- {
+				temp := math.Abs(s1 * h[j*ldh+j-1])
+				temp2 := math.Abs(s1*h[j*ldh+j] - wr*t[j*ldt+j])
+				tempr := math.Max(temp, temp2)
+				if tempr < 1 && tempr != 0 {
+					temp /= tempr
+					temp2 /= tempr
+				}
+				if math.Abs((ascale*h[(j+1)*ldh+j])*temp) <= (ascale*atol)*temp2 {
+					istart = j
+					break
+				}
+			}
+
+			// Do an implicit single-shift QZ sweep.
+			c, s, _ := impl.Dlartg(s1*h[istart*ldh+istart]-wr*t[istart*ldt+istart], s1*h[(istart+1)*ldh+istart])
+			for j := istart; j < ilast; j++ {
+				if j > istart {
+					c, s, h[j*ldh+j-1] = impl.Dlartg(h[j*ldh+j-1], h[(j+1)*ldh+j-1])
+					h[(j+1)*ldh+j-1] = 0
+				}
+				bi.Drot(ilastm-j+1, h[j*ldh+j:], 1, h[(j+1)*ldh+j:], 1, c, s)
+				bi.Drot(ilastm-j+1, t[j*ldt+j:], 1, t[(j+1)*ldt+j:], 1, c, s)
+				if ilq {
+					bi.Drot(n, q[j:], ldq, q[j+1:], ldq, c, s)
+				}
+
+				c, s, t[(j+1)*ldt+j+1] = impl.Dlartg(t[(j+1)*ldt+j+1], t[(j+1)*ldt+j])
+				t[(j+1)*ldt+j] = 0
+				bi.Drot(min(j+2, ilast)-ifrstm+1, h[ifrstm*ldh+j+1:], ldh, h[ifrstm*ldh+j:], ldh, c, s)
+				bi.Drot(j-ifrstm+1, t[ifrstm*ldt+j+1:], ldt, t[ifrstm*ldt+j:], ldt, c, s)
+				if ilz {
+					bi.Drot(n, z[j+1:], ldz, z[j:], ldz, c, s)
+				}
+			}
+			continue
+		}
+
+		// Use the Francis double-shift.
+		//
+		// The Francis double-shift should work with real shifts, but only
+		// if the block is at least 3×3. This code may break if this point
+		// is reached with a 2×2 block with real eigenvalues.
+		if ifirst+1 == ilast {
+			// Special case: 2×2 block with complex eigenvalues.
+			//
+			// Step 1: Standardize, that is, rotate so that
+			//  T = [ b11  0  ]
+			//      [  0  b22 ]
+			// with b11 non-negative.
+			b22, b11, sr, cr, sl, cl := impl.Dlasv2(t[(ilast-1)*ldt+ilast-1], t[(ilast-1)*ldt+ilast], t[ilast*ldt+ilast])
+			if b11 < 0 {
+				cr = -cr
+				sr = -sr
+				b11 = -b11
+				b22 = -b22
+			}
+			bi.Drot(ilastm+1-ifirst, h[(ilast-1)*ldh+ilast-1:], 1, h[ilast*ldh+ilast-1:], 1, cl, sl)
+			bi.Drot(ilast+1-ifrstm, h[ifrstm*ldh+ilast-1:], ldh, h[ifrstm*ldh+ilast:], ldh, cr, sr)
+			if ilast < ilastm {
+				bi.Drot(ilastm-ilast, t[(ilast-1)*ldt+ilast+1:], 1, t[ilast*ldt+ilast+1:], 1, cl, sl)
+			}
+			if ifrstm < ilast-1 {
+				bi.Drot(ifirst-ifrstm, t[ifrstm*ldt+ilast-1:], ldt, t[ifrstm*ldt+ilast:], ldt, cr, sr)
+			}
+			if ilq {
+				bi.Drot(n, q[ilast-1:], ldq, q[ilast:], ldq, cl, sl)
+			}
+			if ilz {
+				bi.Drot(n, z[ilast-1:], ldz, z[ilast:], ldz, cr, sr)
+			}
+			t[(ilast-1)*ldt+ilast-1] = b11
+			t[(ilast-1)*ldt+ilast] = 0
+			t[ilast*ldt+ilast-1] = 0
+			t[ilast*ldt+ilast] = b22
+
+			// If b22 is negative, negate column ilast.
+			if b22 < 0 {
+				for j := ifrstm; j <= ilast; j++ {
+					h[j*ldh+ilast] = -h[j*ldh+ilast]
+					t[j*ldt+ilast] = -t[j*ldt+ilast]
+				}
+				if ilz {
+					bi.Dscal(n, -1, z[ilast:], ldz)
+				}
+				b22 = -b22
+			}
+
+			// Step 2: Compute alphar, alphai and beta.
+
+			// Recompute the shift.
+			s1, _, wr, _, wi = impl.Dlag2(h[(ilast-1)*ldh+ilast-1:], ldh, t[(ilast-1)*ldt+ilast-1:], ldt, safmin*safety)
+
+			// If standardization has perturbed the shift onto the real
+			// line, do another (real single-shift) QR step.
+			if wi == 0 {
+				continue
+			}
+			s1inv := 1 / s1
+
+			// Do the EISPACK (QZVAL) computation of alpha and beta.
+			a11 := h[(ilast-1)*ldh+ilast-1]
+			a21 := h[ilast*ldh+ilast-1]
+			a12 := h[(ilast-1)*ldh+ilast]
+			a22 := h[ilast*ldh+ilast]
+
+			// Compute the complex Givens rotation on the right, assuming
+			// some element of C = (s*A - w*B) is larger than underflow.
+			//  (s*A - w*B) * [ cz        -conj(sz) ]
+			//                [ sz         cz       ]
+			c11r := s1*a11 - wr*b11
+			c11i := -wi * b11
+			c12 := s1 * a12
+			c21 := s1 * a21
+			c22r := s1*a22 - wr*b22
+			c22i := -wi * b22
+			var cz, szr, szi float64
+			if math.Abs(c11r)+math.Abs(c11i)+math.Abs(c12) > math.Abs(c21)+math.Abs(c22r)+math.Abs(c22i) {
+				t1 := dlapy3(c12, c11r, c11i)
+				cz = c12 / t1
+				szr = -c11r / t1
+				szi = -c11i / t1
+			} else {
+				cz = impl.Dlapy2(c22r, c22i)
+				if cz <= safmin {
+					cz = 0
+					szr = 1
+					szi = 0
+				} else {
+					tempr := c22r / cz
+					tempi := c22i / cz
+					t1 := impl.Dlapy2(cz, c21)
+					cz /= t1
+					szr = -c21 * tempr / t1
+					szi = c21 * tempi / t1
+				}
+			}
+
+			// Compute the Givens rotation on the left.
+			//  [  cq        sq ] * A or B
+			//  [ -conj(sq)  cq ]
+			an := math.Abs(a11) + math.Abs(a12) + math.Abs(a21) + math.Abs(a22)
+			bn := math.Abs(b11) + math.Abs(b22)
+			wabs := math.Abs(wr) + math.Abs(wi)
+			var cq, sqr, sqi float64
+			if s1*an > wabs*bn {
+				cq = cz * b11
+				sqr = szr * b22
+				sqi = -szi * b22
+			} else {
+				a1r := cz*a11 + szr*a12
+				a1i := szi * a12
+				a2r := cz*a21 + szr*a22
+				a2i := szi * a22
+				cq = impl.Dlapy2(a1r, a1i)
+				if cq <= safmin {
+					cq = 0
+					sqr = 1
+					sqi = 0
+				} else {
+					tempr := a1r / cq
+					tempi := a1i / cq
+					sqr = tempr*a2r + tempi*a2i
+					sqi = tempi*a2r - tempr*a2i
+				}
+			}
+			t1 := dlapy3(cq, sqr, sqi)
+			cq /= t1
+			sqr /= t1
+			sqi /= t1
+
+			// Compute the diagonal elements of Q*B*Z.
+			tempr := sqr*szr - sqi*szi
+			tempi := sqr*szi + sqi*szr
+			b1r := cq*cz*b11 + tempr*b22
+			b1i := tempi * b22
+			b1a := impl.Dlapy2(b1r, b1i)
+			b2r := cq*cz*b22 + tempr*b11
+			b2i := -tempi * b11
+			b2a := impl.Dlapy2(b2r, b2i)
+
+			// Normalize so that beta > 0 and Im(alpha1) > 0.
+			beta[ilast-1] = b1a
+			beta[ilast] = b2a
+			alphar[ilast-1] = (wr * b1a) * s1inv
+			alphai[ilast-1] = (wi * b1a) * s1inv
+			alphar[ilast] = (wr * b2a) * s1inv
+			alphai[ilast] = -(wi * b2a) * s1inv
+
+			// Step 3: Go to the next block.
+			ilast = ifirst - 1
+			iiter = 0
+			eshift = 0
+			if !ilschr {
+				ilastm = ilast
+				if ifrstm > ilast {
+					ifrstm = ilo
+				}
+			}
+			continue
+		}
+
+		// Usual case: 3×3 or larger block, using the Francis implicit
+		// double-shift.
+		//
+		// The eigenvalue equation is
+		//  w^2 - c*w + d = 0,
+		// so compute the first column of
+		//  (A*B^{-1})^2 - c*A*B^{-1} + d
+		// using the formula in QZIT (from EISPACK).
+		ad11 := (ascale * h[(ilast-1)*ldh+ilast-1]) / (bscale * t[(ilast-1)*ldt+ilast-1])
+		ad21 := (ascale * h[ilast*ldh+ilast-1]) / (bscale * t[(ilast-1)*ldt+ilast-1])
+		ad12 := (ascale * h[(ilast-1)*ldh+ilast]) / (bscale * t[ilast*ldt+ilast])
+		ad22 := (ascale * h[ilast*ldh+ilast]) / (bscale * t[ilast*ldt+ilast])
+		u12 := t[(ilast-1)*ldt+ilast] / t[ilast*ldt+ilast]
+		ad11l := (ascale * h[ifirst*ldh+ifirst]) / (bscale * t[ifirst*ldt+ifirst])
+		ad21l := (ascale * h[(ifirst+1)*ldh+ifirst]) / (bscale * t[ifirst*ldt+ifirst])
+		ad12l := (ascale * h[ifirst*ldh+ifirst+1]) / (bscale * t[(ifirst+1)*ldt+ifirst+1])
+		ad22l := (ascale * h[(ifirst+1)*ldh+ifirst+1]) / (bscale * t[(ifirst+1)*ldt+ifirst+1])
+		ad32l := (ascale * h[(ifirst+2)*ldh+ifirst+1]) / (bscale * t[(ifirst+1)*ldt+ifirst+1])
+		u12l := t[ifirst*ldt+ifirst+1] / t[(ifirst+1)*ldt+ifirst+1]
+
+		var v [3]float64
+		v[0] = (ad11-ad11l)*(ad22-ad11l) - ad12*ad21 + ad21*u12*ad11l + (ad12l-ad11l*u12l)*ad21l
+		v[1] = ((ad22l - ad11l) - ad21l*u12l - (ad11 - ad11l) - (ad22 - ad11l) + ad21*u12) * ad21l
+		v[2] = ad32l * ad21l
+
+		istart := ifirst
+		_, tau := impl.Dlarfg(3, v[0], v[1:], 1)
+		v[0] = 1
+
+		// Sweep.
+		for j := istart; j < ilast-1; j++ {
+			// All but the last elements: use 3×3 Householder
+			// transforms.
+
+			// Zero the (j-1)-th column of H.
+			if j > istart {
+				v[0] = h[j*ldh+j-1]
+				v[1] = h[(j+1)*ldh+j-1]
+				v[2] = h[(j+2)*ldh+j-1]
+				h[j*ldh+j-1], tau = impl.Dlarfg(3, h[j*ldh+j-1], v[1:], 1)
+				v[0] = 1
+				h[(j+1)*ldh+j-1] = 0
+				h[(j+2)*ldh+j-1] = 0
+			}
+
+			t2 := tau * v[1]
+			t3 := tau * v[2]
+			for jc := j; jc <= ilastm; jc++ {
+				temp := h[j*ldh+jc] + v[1]*h[(j+1)*ldh+jc] + v[2]*h[(j+2)*ldh+jc]
+				h[j*ldh+jc] -= temp * tau
+				h[(j+1)*ldh+jc] -= temp * t2
+				h[(j+2)*ldh+jc] -= temp * t3
+				temp2 := t[j*ldt+jc] + v[1]*t[(j+1)*ldt+jc] + v[2]*t[(j+2)*ldt+jc]
+				t[j*ldt+jc] -= temp2 * tau
+				t[(j+1)*ldt+jc] -= temp2 * t2
+				t[(j+2)*ldt+jc] -= temp2 * t3
+			}
+			if ilq {
+				for jr := 0; jr < n; jr++ {
+					temp := q[jr*ldq+j] + v[1]*q[jr*ldq+j+1] + v[2]*q[jr*ldq+j+2]
+					q[jr*ldq+j] -= temp * tau
+					q[jr*ldq+j+1] -= temp * t2
+					q[jr*ldq+j+2] -= temp * t3
+				}
+			}
+
+			// Zero the j-th column of T.
+
+			// Swap rows to pivot.
+			var ilpivt bool
+			var scale, u1, u2 float64
+			temp := math.Max(math.Abs(t[(j+1)*ldt+j+1]), math.Abs(t[(j+1)*ldt+j+2]))
+			temp2 := math.Max(math.Abs(t[(j+2)*ldt+j+1]), math.Abs(t[(j+2)*ldt+j+2]))
+			if math.Max(temp, temp2) < safmin {
+				scale = 0
+				u1 = 1
+				u2 = 0
+			} else {
+				var w11, w12, w21, w22 float64
+				if temp >= temp2 {
+					w11 = t[(j+1)*ldt+j+1]
+					w21 = t[(j+2)*ldt+j+1]
+					w12 = t[(j+1)*ldt+j+2]
+					w22 = t[(j+2)*ldt+j+2]
+					u1 = t[(j+1)*ldt+j]
+					u2 = t[(j+2)*ldt+j]
+				} else {
+					w21 = t[(j+1)*ldt+j+1]
+					w11 = t[(j+2)*ldt+j+1]
+					w22 = t[(j+1)*ldt+j+2]
+					w12 = t[(j+2)*ldt+j+2]
+					u2 = t[(j+1)*ldt+j]
+					u1 = t[(j+2)*ldt+j]
+				}
+
+				// Swap columns if necessary.
+				if math.Abs(w12) > math.Abs(w11) {
+					ilpivt = true
+					w11, w12 = w12, w11
+					w21, w22 = w22, w21
+				}
+
+				// LU-factor.
+				temp = w21 / w11
+				u2 -= temp * u1
+				w22 -= temp * w12
+
+				// Compute scale.
+				scale = 1
+				if math.Abs(w22) < safmin {
+					scale = 0
+					u2 = 1
+					u1 = -w12 / w11
+				} else {
+					if math.Abs(w22) < math.Abs(u2) {
+						scale = math.Abs(w22 / u2)
+					}
+					if math.Abs(w11) < math.Abs(u1) {
+						scale = math.Min(scale, math.Abs(w11/u1))
+					}
+
+					// Solve.
+					u2 = (scale * u2) / w22
+					u1 = (scale*u1 - w12*u2) / w11
+				}
+			}
+			if ilpivt {
+				u1, u2 = u2, u1
+			}
+
+			// Compute the Householder vector.
+			t1 := math.Sqrt(scale*scale + u1*u1 + u2*u2)
+			tau = 1 + scale/t1
+			vs := -1 / (scale + t1)
+			v[0] = 1
+			v[1] = vs * u1
+			v[2] = vs * u2
+
+			// Apply transformations from the right.
+			t2 = tau * v[1]
+			t3 = tau * v[2]
+			for jr := ifrstm; jr <= min(j+3, ilast); jr++ {
+				temp := h[jr*ldh+j] + v[1]*h[jr*ldh+j+1] + v[2]*h[jr*ldh+j+2]
+				h[jr*ldh+j] -= temp * tau
+				h[jr*ldh+j+1] -= temp * t2
+				h[jr*ldh+j+2] -= temp * t3
+			}
+			for jr := ifrstm; jr <= j+2; jr++ {
+				temp := t[jr*ldt+j] + v[1]*t[jr*ldt+j+1] + v[2]*t[jr*ldt+j+2]
+				t[jr*ldt+j] -= temp * tau
+				t[jr*ldt+j+1] -= temp * t2
+				t[jr*ldt+j+2] -= temp * t3
+			}
+			if ilz {
+				for jr := 0; jr < n; jr++ {
+					temp := z[jr*ldz+j] + v[1]*z[jr*ldz+j+1] + v[2]*z[jr*ldz+j+2]
+					z[jr*ldz+j] -= temp * tau
+					z[jr*ldz+j+1] -= temp * t2
+					z[jr*ldz+j+2] -= temp * t3
+				}
+			}
+			t[(j+1)*ldt+j] = 0
+			t[(j+2)*ldt+j] = 0
+		}
+
+		// Last elements: use Givens rotations.
+
+		// Rotations from the left.
+		j := ilast - 1
+		var c, s float64
+		c, s, h[j*ldh+j-1] = impl.Dlartg(h[j*ldh+j-1], h[(j+1)*ldh+j-1])
+		h[(j+1)*ldh+j-1] = 0
+		bi.Drot(ilastm-j+1, h[j*ldh+j:], 1, h[(j+1)*ldh+j:], 1, c, s)
+		bi.Drot(ilastm-j+1, t[j*ldt+j:], 1, t[(j+1)*ldt+j:], 1, c, s)
+		if ilq {
+			bi.Drot(n, q[j:], ldq, q[j+1:], ldq, c, s)
+		}
+
+		// Rotations from the right.
+		c, s, t[(j+1)*ldt+j+1] = impl.Dlartg(t[(j+1)*ldt+j+1], t[(j+1)*ldt+j])
+		t[(j+1)*ldt+j] = 0
+		bi.Drot(ilast-ifrstm+1, h[ifrstm*ldh+j+1:], ldh, h[ifrstm*ldh+j:], ldh, c, s)
+		bi.Drot(ilast-ifrstm, t[ifrstm*ldt+j+1:], ldt, t[ifrstm*ldt+j:], ldt, c, s)
+		if ilz {
+			bi.Drot(n, z[j+1:], ldz, z[j:], ldz, c, s)
+		}
+	}
+
+	// Successful completion of all QZ steps. Set eigenvalues 0:ilo.
+	for j := 0; j < ilo; j++ {
+		setEigenvalue(j, 0)
+	}
+	return 0
+}
+
+// dlanhsFrob returns the Frobenius norm of the n×n upper Hessenberg matrix
+// held in a. The elements below the first subdiagonal are not referenced.
+func (impl Implementation) dlanhsFrob(n int, a []float64, lda int) float64 {
+	scale := 0.0
+	sum := 1.0
+	for i := 0; i < n; i++ {
+		j := max(0, i-1)
+		scale, sum = impl.Dlassq(n-j, a[i*lda+j:], 1, scale, sum)
+	}
+	return scale * math.Sqrt(sum)
+}
+
+// dlapy3 returns sqrt(x^2+y^2+z^2), taking care not to cause unnecessary
+// overflow.
+func dlapy3(x, y, z float64) float64 {
+	xabs := math.Abs(x)
+	yabs := math.Abs(y)
+	zabs := math.Abs(z)
+	w := math.Max(xabs, math.Max(yabs, zabs))
+	if w == 0 {
+		// w can be zero for max(0,nan,0); adding all three entries
+		// together will make sure NaN will not disappear.
+		return xabs + yabs + zabs
+	}
+	xabs /= w
+	yabs /= w
+	zabs /= w
+	return w * math.Sqrt(xabs*xabs+yabs*yabs+zabs*zabs)
+}
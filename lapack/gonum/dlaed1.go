@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas/blas64"
+
+// Dlaed1 computes the updated eigensystem of a diagonal matrix after
+// modification by a rank-one symmetric matrix. It is used when the original
+// matrix is tridiagonal and is called by Dlaed0 as part of the divide and
+// conquer method.
+//
+// Dlaed1 computes the eigenvalues and eigenvectors of
+//  T = Q * (D + rho * z * z^T) * Q^T
+// where D = diag(d) holds the eigenvalues of the two subproblems, Q is the
+// block diagonal matrix of their eigenvectors and z is formed from the last
+// row of the first block of Q and the first row of the second block.
+//
+// On entry, d contains the eigenvalues of the two submatrices to be combined
+// and on exit the eigenvalues of the repaired n×n matrix. On entry, Q
+// contains the eigenvectors of the two submatrices in its leading cutpnt×cutpnt
+// and trailing (n-cutpnt)×(n-cutpnt) blocks, the remaining elements being
+// zero, and on exit the eigenvectors of the repaired tridiagonal matrix.
+//
+// indxq contains on entry the permutations which separately sort the two
+// subproblems in d into ascending order, and on exit the permutation which
+// will reintegrate the subproblems just solved back into sorted order, that
+// is d[indxq[0:n]] will be in ascending order.
+//
+// rho is the off-diagonal element associated with the rank-one cut which
+// originally split the two submatrices and cutpnt is the location of the
+// last eigenvalue in the leading submatrix. It must satisfy
+// min(1,n) <= cutpnt <= n/2.
+//
+// work must have length at least 4*n+n*n and iwork must have length at least
+// 4*n.
+//
+// Dlaed1 returns whether the secular equation was solved successfully.
+//
+// Dlaed1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed1(n int, d, q []float64, ldq int, indxq []int, rho float64, cutpnt int, work []float64, iwork []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if cutpnt < min(1, n) || n/2 < cutpnt {
+		panic("lapack: cutpnt out of range")
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(d) < n || len(indxq) < n {
+		panic(badSlice)
+	}
+	if len(work) < 4*n+n*n || len(iwork) < 4*n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+
+	// The following values are indices into the workspace used by Dlaed2
+	// and Dlaed3.
+	iz := 0
+	idlmda := iz + n
+	iw := idlmda + n
+	iq2 := iw + n
+
+	indx := 0
+	indxc := indx + n
+	coltyp := indxc + n
+	indxp := coltyp + n
+
+	// Form the z vector which consists of the last row of Q_1 and the
+	// first row of Q_2.
+	bi := blas64.Implementation()
+	bi.Dcopy(cutpnt, q[(cutpnt-1)*ldq:], 1, work[iz:], 1)
+	bi.Dcopy(n-cutpnt, q[cutpnt*ldq+cutpnt:], 1, work[iz+cutpnt:], 1)
+
+	// Deflate eigenvalues.
+	k, rho := impl.Dlaed2(n, cutpnt, d, q, ldq, indxq, rho, work[iz:idlmda], work[idlmda:iw], work[iw:iq2], work[iq2:],
+		iwork[indx:indxc], iwork[indxc:coltyp], iwork[indxp:], iwork[coltyp:indxp])
+	if k == 0 {
+		for i := 0; i < n; i++ {
+			indxq[i] = i
+		}
+		return true
+	}
+
+	// Solve the secular equation.
+	ctot := iwork[coltyp : coltyp+4]
+	is := (ctot[0]+ctot[1])*cutpnt + (ctot[1]+ctot[2])*(n-cutpnt) + iq2
+	ok = impl.Dlaed3(k, n, cutpnt, d, q, ldq, rho, work[idlmda:iw], work[iq2:], iwork[indxc:coltyp], ctot, work[iw:iq2], work[is:])
+	if !ok {
+		return false
+	}
+
+	// Prepare the indxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, indxq)
+	return true
+}
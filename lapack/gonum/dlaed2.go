@@ -0,0 +1,276 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dlaed2 merges the two sets of eigenvalues of adjacent subproblems into a
+// single sorted set and deflates the size of the problem. It is called by
+// Dlaed1 as part of the divide and conquer method for symmetric tridiagonal
+// matrices.
+//
+// There are two ways in which deflation can occur: when two or more
+// eigenvalues are close together or if there is a tiny entry in the z
+// vector. For each such occurrence the order of the related secular equation
+// problem is reduced by one.
+//
+// On entry, d contains the eigenvalues of the two submatrices to be combined
+// and on exit the trailing n-k elements of d contain the deflated eigenvalues
+// in descending order. On entry, the leading n1×n1 and trailing
+// (n-n1)×(n-n1) blocks of the n×n matrix Q contain the eigenvectors of the two
+// submatrices in their columns and on exit the trailing n-k columns of Q
+// contain the eigenvectors of the deflated eigenvalues.
+//
+// indxq contains the permutations which separately sort the two subproblems
+// in d into ascending order. The elements of indxq corresponding to the
+// second subproblem are offset by n1 on exit.
+//
+// rho is the off-diagonal element associated with the rank-one cut which
+// originally split the two submatrices. On entry, z contains the updating
+// vector, the last row of the first subeigenvector matrix and the first row
+// of the second subeigenvector matrix. z is destroyed on exit.
+//
+// On exit, the leading k elements of dlamda contain the non-deflated
+// eigenvalues in ascending order and the leading k elements of w contain the
+// corresponding components of the updating vector. q2 is overwritten with
+// the eigenvectors in the order required by Dlaed3: first the leading
+// n1×(ctot[0]+ctot[1]) block, then the trailing (n-n1)×(ctot[1]+ctot[2])
+// block and finally the n×ctot[3] deflated block, each stored contiguously.
+// q2 must have length at least n*n.
+//
+// indx, indxc, indxp and coltyp are integer workspace of length at least n.
+// On exit, indxc contains the permutation used by Dlaed3 to arrange the
+// columns of the deflated Q matrix into three groups and the first four
+// elements of coltyp contain the number of columns of each type: columns
+// only in the first block, columns in both blocks, columns only in the
+// second block and deflated columns.
+//
+// Dlaed2 returns the number of non-deflated eigenvalues k and the modified
+// value of rho.
+//
+// Dlaed2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed2(n, n1 int, d, q []float64, ldq int, indxq []int, rho float64, z, dlamda, w, q2 []float64, indx, indxc, indxp, coltyp []int) (k int, rhoOut float64) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n1 < min(1, n) || n/2 < n1 {
+		panic("lapack: n1 out of range")
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(d) < n || len(z) < n || len(dlamda) < n || len(w) < n || len(q2) < n*n {
+		panic(badSlice)
+	}
+	if len(indxq) < n || len(indx) < n || len(indxc) < n || len(indxp) < n || len(coltyp) < max(4, n) {
+		panic(badSlice)
+	}
+	if n == 0 {
+		return 0, rho
+	}
+
+	bi := blas64.Implementation()
+	n2 := n - n1
+	if rho < 0 {
+		bi.Dscal(n2, -1, z[n1:], 1)
+	}
+
+	// Normalize z so that norm(z) = 1. Since z is the concatenation of two
+	// normalized vectors, norm2(z) = sqrt(2).
+	bi.Dscal(n, 1/math.Sqrt2, z, 1)
+	// rho = abs(norm(z)^2 * rho).
+	rho = math.Abs(2 * rho)
+
+	// Sort the eigenvalues into increasing order.
+	for i := n1; i < n; i++ {
+		indxq[i] += n1
+	}
+	// Re-integrate the deflated parts from the last pass.
+	for i := 0; i < n; i++ {
+		dlamda[i] = d[indxq[i]]
+	}
+	impl.Dlamrg(n1, n2, dlamda, 1, 1, indxc)
+	for i := 0; i < n; i++ {
+		indx[i] = indxq[indxc[i]]
+	}
+
+	// Calculate the allowable deflation tolerance.
+	imax := bi.Idamax(n, z, 1)
+	jmax := bi.Idamax(n, d, 1)
+	tol := 8 * dlamchE * math.Max(math.Abs(d[jmax]), math.Abs(z[imax]))
+
+	// If the rank-one modifier is small enough, no more needs to be done
+	// except to reorganize Q so that its columns correspond with the
+	// elements in d.
+	if rho*math.Abs(z[imax]) <= tol {
+		for j := 0; j < n; j++ {
+			i := indx[j]
+			bi.Dcopy(n, q[i:], ldq, q2[j:], n)
+			dlamda[j] = d[i]
+		}
+		impl.Dlacpy(blas.All, n, n, q2, n, q, ldq)
+		bi.Dcopy(n, dlamda, 1, d, 1)
+		return 0, rho
+	}
+
+	// If there are multiple eigenvalues then the problem deflates. Here the
+	// number of equal eigenvalues is found. As each equal eigenvalue is
+	// found, an elementary reflector is computed to rotate the
+	// corresponding eigensubspace so that the corresponding components of
+	// z are zero in this new basis.
+	const (
+		upper   = 1 // Column has non-zero elements only in the first block.
+		both    = 2 // Column has non-zero elements in both blocks.
+		lower   = 3 // Column has non-zero elements only in the second block.
+		deflate = 4 // Column has been deflated.
+	)
+	for i := 0; i < n1; i++ {
+		coltyp[i] = upper
+	}
+	for i := n1; i < n; i++ {
+		coltyp[i] = lower
+	}
+
+	k2 := n
+	var pj, j int
+	for ; j < n; j++ {
+		nj := indx[j]
+		if rho*math.Abs(z[nj]) > tol {
+			pj = nj
+			break
+		}
+		// Deflate due to small z component.
+		k2--
+		coltyp[nj] = deflate
+		indxp[k2] = nj
+	}
+	for j++; j < n; j++ {
+		nj := indx[j]
+		if rho*math.Abs(z[nj]) <= tol {
+			// Deflate due to small z component.
+			k2--
+			coltyp[nj] = deflate
+			indxp[k2] = nj
+			continue
+		}
+
+		// Check if eigenvalues are close enough to allow deflation.
+		s := z[pj]
+		c := z[nj]
+		// Find sqrt(a^2+b^2) without overflow or destructive underflow.
+		tau := impl.Dlapy2(c, s)
+		t := d[nj] - d[pj]
+		c /= tau
+		s = -s / tau
+		if math.Abs(t*c*s) > tol {
+			dlamda[k] = d[pj]
+			w[k] = z[pj]
+			indxp[k] = pj
+			k++
+			pj = nj
+			continue
+		}
+
+		// Deflation is possible.
+		z[nj] = tau
+		z[pj] = 0
+		if coltyp[nj] != coltyp[pj] {
+			coltyp[nj] = both
+		}
+		coltyp[pj] = deflate
+		bi.Drot(n, q[pj:], ldq, q[nj:], ldq, c, s)
+		t = d[pj]*c*c + d[nj]*s*s
+		d[nj] = d[pj]*s*s + d[nj]*c*c
+		d[pj] = t
+		k2--
+		i := 1
+		for k2+i < n && d[pj] < d[indxp[k2+i]] {
+			indxp[k2+i-1] = indxp[k2+i]
+			indxp[k2+i] = pj
+			i++
+		}
+		indxp[k2+i-1] = pj
+		pj = nj
+	}
+	// Record the last eigenvalue.
+	dlamda[k] = d[pj]
+	w[k] = z[pj]
+	indxp[k] = pj
+
+	// Count up the total number of the various types of columns, then form
+	// a permutation which positions the four column types into four
+	// uniform groups (although one or more of these groups may be empty).
+	var ctot [4]int
+	for j := 0; j < n; j++ {
+		ctot[coltyp[j]-1]++
+	}
+	// psm is the position in the submatrix of types 1 through 4.
+	psm := [4]int{0, ctot[0], ctot[0] + ctot[1], ctot[0] + ctot[1] + ctot[2]}
+	k = n - ctot[3]
+
+	// Fill out the indxc array so that the permutation which it induces
+	// will place all type-1 columns first, all type-2 columns next, then
+	// all type-3's, and finally all type-4's.
+	for j := 0; j < n; j++ {
+		js := indxp[j]
+		ct := coltyp[js] - 1
+		indx[psm[ct]] = js
+		indxc[psm[ct]] = j
+		psm[ct]++
+	}
+
+	// Sort the eigenvalues and corresponding eigenvectors into dlamda and
+	// q2 respectively. The eigenvalues and vectors which were not deflated
+	// go into the first k slots of dlamda and q2 respectively, while those
+	// which were deflated go into the last n-k slots.
+	n12 := ctot[0] + ctot[1]
+	n23 := ctot[1] + ctot[2]
+	iq2 := n1 * n12
+	iq3 := iq2 + n2*n23
+	var i, col1, col2 int
+	for j := 0; j < ctot[0]; j++ {
+		js := indx[i]
+		bi.Dcopy(n1, q[js:], ldq, q2[col1:], n12)
+		z[i] = d[js]
+		i++
+		col1++
+	}
+	for j := 0; j < ctot[1]; j++ {
+		js := indx[i]
+		bi.Dcopy(n1, q[js:], ldq, q2[col1:], n12)
+		bi.Dcopy(n2, q[n1*ldq+js:], ldq, q2[iq2+col2:], n23)
+		z[i] = d[js]
+		i++
+		col1++
+		col2++
+	}
+	for j := 0; j < ctot[2]; j++ {
+		js := indx[i]
+		bi.Dcopy(n2, q[n1*ldq+js:], ldq, q2[iq2+col2:], n23)
+		z[i] = d[js]
+		i++
+		col2++
+	}
+	for j := 0; j < ctot[3]; j++ {
+		js := indx[i]
+		bi.Dcopy(n, q[js:], ldq, q2[iq3+j:], ctot[3])
+		z[i] = d[js]
+		i++
+	}
+
+	// The deflated eigenvalues and their corresponding vectors go back
+	// into the last n-k slots of d and Q respectively.
+	if k < n {
+		impl.Dlacpy(blas.All, n, ctot[3], q2[iq3:], ctot[3], q[k:], ldq)
+		bi.Dcopy(n-k, z[k:], 1, d[k:], 1)
+	}
+
+	// Copy ctot into coltyp for referencing in Dlaed3.
+	copy(coltyp[:4], ctot[:])
+	return k, rho
+}
@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dlaed3 finds the roots of the secular equation, as defined by the values in
+// dlamda, w and rho, between 1 and k. It makes the appropriate calls to
+// Dlaed4 and then updates the eigenvectors by multiplying the matrix of
+// eigenvectors of the pair of eigensystems being combined by the matrix of
+// eigenvectors of the k×k system which is solved here. It is called by
+// Dlaed1 as part of the divide and conquer method for symmetric tridiagonal
+// matrices.
+//
+// k is the number of terms in the rational function to be solved by Dlaed4
+// and n1 is the location of the last eigenvalue in the leading submatrix.
+// On exit, the leading k elements of d contain the updated eigenvalues and
+// the leading k columns of the n×n matrix Q contain the corresponding
+// eigenvectors.
+//
+// dlamda, q2, indx and ctot are as returned by Dlaed2. On entry, w contains
+// the components of the deflation-adjusted updating vector and is destroyed
+// on exit. s is workspace of length at least max(k, (ctot[0]+ctot[1])*k,
+// (ctot[1]+ctot[2])*k).
+//
+// Dlaed3 returns whether all the roots of the secular equation were found.
+//
+// Dlaed3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed3(k, n, n1 int, d, q []float64, ldq int, rho float64, dlamda, q2 []float64, indx, ctot []int, w, s []float64) (ok bool) {
+	if k < 0 {
+		panic(kLT0)
+	}
+	if n < k {
+		panic(kGTN)
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(d) < n || len(dlamda) < k || len(w) < k || len(indx) < k || len(ctot) < 4 {
+		panic(badSlice)
+	}
+	n12 := ctot[0] + ctot[1]
+	n23 := ctot[1] + ctot[2]
+	if len(s) < max(k, max(n12, n23)*k) {
+		panic(badWork)
+	}
+	if k == 0 {
+		return true
+	}
+
+	bi := blas64.Implementation()
+	for j := 0; j < k; j++ {
+		// s is used to hold the differences dlamda - λ_j.
+		var dlam float64
+		dlam, ok = impl.Dlaed4(k, j, dlamda, w, s, rho)
+		d[j] = dlam
+		if !ok {
+			return false
+		}
+		bi.Dcopy(k, s, 1, q[j:], ldq)
+	}
+
+	switch k {
+	case 1:
+	case 2:
+		// Dlaed4 returned the eigenvectors directly.
+		for j := 0; j < k; j++ {
+			w[0] = q[j]
+			w[1] = q[ldq+j]
+			q[j] = w[indx[0]]
+			q[ldq+j] = w[indx[1]]
+		}
+	default:
+		// Compute updated w.
+		bi.Dcopy(k, w, 1, s, 1)
+		// Initialize w[i] = Q[i,i].
+		bi.Dcopy(k, q, ldq+1, w, 1)
+		for j := 0; j < k; j++ {
+			for i := 0; i < j; i++ {
+				w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+			}
+			for i := j + 1; i < k; i++ {
+				w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+			}
+		}
+		for i := 0; i < k; i++ {
+			w[i] = math.Copysign(math.Sqrt(-w[i]), s[i])
+		}
+
+		// Compute eigenvectors of the modified rank-one modification.
+		for j := 0; j < k; j++ {
+			for i := 0; i < k; i++ {
+				s[i] = w[i] / q[i*ldq+j]
+			}
+			temp := bi.Dnrm2(k, s, 1)
+			for i := 0; i < k; i++ {
+				q[i*ldq+j] = s[indx[i]] / temp
+			}
+		}
+	}
+
+	// Compute the updated eigenvectors.
+	n2 := n - n1
+	iq2 := n1 * n12
+	if n23 != 0 {
+		impl.Dlacpy(blas.All, n23, k, q[ctot[0]*ldq:], ldq, s, k)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n2, k, n23, 1, q2[iq2:], n23, s, k, 0, q[n1*ldq:], ldq)
+	} else {
+		impl.Dlaset(blas.All, n2, k, 0, 0, q[n1*ldq:], ldq)
+	}
+	if n12 != 0 {
+		impl.Dlacpy(blas.All, n12, k, q, ldq, s, k)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n1, k, n12, 1, q2, n12, s, k, 0, q, ldq)
+	} else {
+		impl.Dlaset(blas.All, n1, k, 0, 0, q, ldq)
+	}
+	return true
+}
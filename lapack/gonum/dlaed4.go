@@ -0,0 +1,476 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlaed4 computes the i-th updated eigenvalue of a symmetric rank-one
+// modification of a diagonal matrix
+//  diag(d) + rho * z * z^T.
+// It is assumed that the elements of d are distinct and in ascending order,
+// that rho > 0 and that the Euclidean norm of z is one. The eigenvalues of
+// the modified matrix are the roots of the secular equation
+//  f(λ) = 1/rho + sum_j z[j]^2/(d[j]-λ) = 0.
+//
+// On return, for n > 2 delta contains the differences d[j] - λ_i for
+// j = 0, ..., n-1, where λ_i is the returned eigenvalue dlam. The vector delta
+// can be used to compute the corresponding eigenvector accurately. For n == 1
+// and n == 2 delta contains the normalized eigenvector.
+//
+// d, z and delta must have length at least n. i must satisfy 0 <= i < n.
+//
+// Dlaed4 returns whether the iteration converged.
+//
+// Dlaed4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool) {
+	if n < 1 {
+		panic(nLT0)
+	}
+	if i < 0 || n <= i {
+		panic("lapack: index out of range")
+	}
+	if len(d) < n || len(z) < n || len(delta) < n {
+		panic(badSlice)
+	}
+
+	// Quick return for small problems.
+	if n == 1 {
+		delta[0] = 1
+		return d[0] + rho*z[0]*z[0], true
+	}
+	if n == 2 {
+		return impl.Dlaed5(i, d, z, delta, rho), true
+	}
+
+	const maxit = 30
+	eps := dlamchE
+	rhoinv := 1 / rho
+
+	if i == n-1 {
+		// The last eigenvalue lies in (d[n-1], d[n-1]+rho).
+		ii := n - 2
+		niter := 1
+
+		// Calculate the initial guess.
+		midpt := rho / 2
+		for j := 0; j < n; j++ {
+			delta[j] = (d[j] - d[i]) - midpt
+		}
+		var psi float64
+		for j := 0; j < n-2; j++ {
+			psi += z[j] * z[j] / delta[j]
+		}
+		c := rhoinv + psi
+		w := c + z[ii]*z[ii]/delta[ii] + z[n-1]*z[n-1]/delta[n-1]
+
+		var tau, dltlb, dltub float64
+		if w <= 0 {
+			temp := z[n-2]*z[n-2]/(d[n-1]-d[n-2]+rho) + z[n-1]*z[n-1]/rho
+			if c <= temp {
+				tau = rho
+			} else {
+				del := d[n-1] - d[n-2]
+				a := -c*del + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+				b := z[n-1] * z[n-1] * del
+				if a < 0 {
+					tau = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+				} else {
+					tau = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+				}
+			}
+			// It can be proved that
+			//  d[n-1]+rho/2 <= λ_{n-1} < d[n-1]+tau <= d[n-1]+rho.
+			dltlb = midpt
+			dltub = rho
+		} else {
+			del := d[n-1] - d[n-2]
+			a := -c*del + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+			b := z[n-1] * z[n-1] * del
+			if a < 0 {
+				tau = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+			} else {
+				tau = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+			}
+			// It can be proved that
+			//  d[n-1] < d[n-1]+tau < λ_{n-1} < d[n-1]+rho/2.
+			dltlb = 0
+			dltub = midpt
+		}
+		for j := 0; j < n; j++ {
+			delta[j] = (d[j] - d[i]) - tau
+		}
+
+		// evaluate computes the value of the secular function, its
+		// derivative and an error bound.
+		evaluate := func() (w, dw, erretm float64) {
+			var psi, dpsi float64
+			for j := 0; j <= ii; j++ {
+				temp := z[j] / delta[j]
+				psi += z[j] * temp
+				dpsi += temp * temp
+				erretm += psi
+			}
+			erretm = math.Abs(erretm)
+			temp := z[n-1] / delta[n-1]
+			phi := z[n-1] * temp
+			dphi := temp * temp
+			erretm = 8*(-phi-psi) + erretm - phi + rhoinv + math.Abs(tau)*(dpsi+dphi)
+			return rhoinv + phi + psi, dpsi + dphi, erretm
+		}
+		w, _, erretm := evaluate()
+
+		for ; niter <= maxit; niter++ {
+			// Test for convergence.
+			if math.Abs(w) <= eps*erretm {
+				return d[i] + tau, true
+			}
+			if w <= 0 {
+				dltlb = math.Max(dltlb, tau)
+			} else {
+				dltub = math.Min(dltub, tau)
+			}
+
+			// Calculate the new step.
+			var dpsi, dphi float64
+			for j := 0; j <= ii; j++ {
+				temp := z[j] / delta[j]
+				dpsi += temp * temp
+			}
+			temp := z[n-1] / delta[n-1]
+			dphi = temp * temp
+			c := w - delta[n-2]*dpsi - delta[n-1]*dphi
+			a := (delta[n-2]+delta[n-1])*w - delta[n-2]*delta[n-1]*(dpsi+dphi)
+			b := delta[n-2] * delta[n-1] * w
+			if c < 0 {
+				c = math.Abs(c)
+			}
+			var eta float64
+			switch {
+			case c == 0:
+				eta = dltub - tau
+			case a >= 0:
+				eta = (a + math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+			default:
+				eta = 2 * b / (a - math.Sqrt(math.Abs(a*a-4*b*c)))
+			}
+
+			// Note, eta should be positive if w is negative, and eta
+			// should be negative otherwise. However, if for some reason
+			// caused by roundoff, eta*w > 0, we simply use one Newton
+			// step instead. This way will guarantee eta*w < 0.
+			if w*eta > 0 {
+				eta = -w / (dpsi + dphi)
+			}
+			temp = tau + eta
+			if temp > dltub || temp < dltlb {
+				if w < 0 {
+					eta = (dltub - tau) / 2
+				} else {
+					eta = (dltlb - tau) / 2
+				}
+			}
+			for j := 0; j < n; j++ {
+				delta[j] -= eta
+			}
+			tau += eta
+			w, _, erretm = evaluate()
+		}
+		return d[i] + tau, false
+	}
+
+	// The i-th eigenvalue lies in (d[i], d[i+1]).
+	ip1 := i + 1
+	niter := 1
+
+	// Calculate the initial guess.
+	del := d[ip1] - d[i]
+	midpt := del / 2
+	for j := 0; j < n; j++ {
+		delta[j] = (d[j] - d[i]) - midpt
+	}
+	var psi float64
+	for j := 0; j < i; j++ {
+		psi += z[j] * z[j] / delta[j]
+	}
+	var phi float64
+	for j := n - 1; j > i+1; j-- {
+		phi += z[j] * z[j] / delta[j]
+	}
+	c := rhoinv + psi + phi
+	w := c + z[i]*z[i]/delta[i] + z[ip1]*z[ip1]/delta[ip1]
+
+	var (
+		orgati       bool
+		tau          float64
+		dltlb, dltub float64
+	)
+	if w > 0 {
+		// d[i] < λ_i < (d[i]+d[i+1])/2. Choose d[i] as the origin.
+		orgati = true
+		a := c*del + z[i]*z[i] + z[ip1]*z[ip1]
+		b := z[i] * z[i] * del
+		if a > 0 {
+			tau = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		} else {
+			tau = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		}
+		dltlb = 0
+		dltub = midpt
+	} else {
+		// (d[i]+d[i+1])/2 <= λ_i < d[i+1]. Choose d[i+1] as the origin.
+		orgati = false
+		a := c*del - z[i]*z[i] - z[ip1]*z[ip1]
+		b := z[ip1] * z[ip1] * del
+		if a < 0 {
+			tau = 2 * b / (a - math.Sqrt(math.Abs(a*a+4*b*c)))
+		} else {
+			tau = -(a + math.Sqrt(math.Abs(a*a+4*b*c))) / (2 * c)
+		}
+		dltlb = -midpt
+		dltub = 0
+	}
+	origin := d[ip1]
+	ii := i + 1
+	if orgati {
+		origin = d[i]
+		ii = i
+	}
+	for j := 0; j < n; j++ {
+		delta[j] = (d[j] - origin) - tau
+	}
+	iim1 := ii - 1
+	iip1 := ii + 1
+
+	// evaluate computes the value of the secular function w, the
+	// derivatives of its parts, its derivative dw and an error bound.
+	evaluate := func(tau float64) (w, psi, dpsi, phi, dphi, dw, erretm float64) {
+		for j := 0; j < ii; j++ {
+			temp := z[j] / delta[j]
+			psi += z[j] * temp
+			dpsi += temp * temp
+			erretm += psi
+		}
+		erretm = math.Abs(erretm)
+		for j := n - 1; j > ii; j-- {
+			temp := z[j] / delta[j]
+			phi += z[j] * temp
+			dphi += temp * temp
+			erretm += phi
+		}
+		temp := z[ii] / delta[ii]
+		dw = dpsi + dphi + temp*temp
+		temp *= z[ii]
+		w = rhoinv + phi + psi + temp
+		erretm = 8*(phi-psi) + erretm + 2*rhoinv + 3*math.Abs(temp) + math.Abs(tau)*dw
+		return w, psi, dpsi, phi, dphi, dw, erretm
+	}
+	w, psi, dpsi, phi, dphi, dw, erretm := evaluate(tau)
+
+	// w - z[ii]^2/delta[ii] is the value of the secular function with its
+	// ii-th element removed.
+	swtch3 := false
+	if orgati {
+		swtch3 = w-z[ii]*z[ii]/delta[ii] < 0
+	} else {
+		swtch3 = w-z[ii]*z[ii]/delta[ii] > 0
+	}
+	if ii == 0 || ii == n-1 {
+		swtch3 = false
+	}
+
+	// Test for convergence.
+	if math.Abs(w) <= eps*erretm {
+		return origin + tau, true
+	}
+	if w <= 0 {
+		dltlb = math.Max(dltlb, tau)
+	} else {
+		dltub = math.Min(dltub, tau)
+	}
+
+	// Calculate the new step.
+	niter++
+	var eta float64
+	if !swtch3 {
+		if orgati {
+			temp := z[i] / delta[i]
+			c = w - delta[ip1]*dw - (d[i]-d[ip1])*temp*temp
+		} else {
+			temp := z[ip1] / delta[ip1]
+			c = w - delta[i]*dw - (d[ip1]-d[i])*temp*temp
+		}
+		a := (delta[i]+delta[ip1])*w - delta[i]*delta[ip1]*dw
+		b := delta[i] * delta[ip1] * w
+		switch {
+		case c == 0:
+			if a == 0 {
+				if orgati {
+					a = z[i]*z[i] + delta[ip1]*delta[ip1]*(dpsi+dphi)
+				} else {
+					a = z[ip1]*z[ip1] + delta[i]*delta[i]*(dpsi+dphi)
+				}
+			}
+			eta = b / a
+		case a <= 0:
+			eta = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		default:
+			eta = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		}
+	} else {
+		// Interpolation using three most relevant poles.
+		var zz [3]float64
+		temp := rhoinv + psi + phi
+		if orgati {
+			temp1 := z[iim1] / delta[iim1]
+			temp1 *= temp1
+			c = temp - delta[iip1]*(dpsi+dphi) - (d[iim1]-d[iip1])*temp1
+			zz[0] = z[iim1] * z[iim1]
+			zz[2] = delta[iip1] * delta[iip1] * ((dpsi - temp1) + dphi)
+		} else {
+			temp1 := z[iip1] / delta[iip1]
+			temp1 *= temp1
+			c = temp - delta[iim1]*(dpsi+dphi) - (d[iip1]-d[iim1])*temp1
+			zz[0] = delta[iim1] * delta[iim1] * (dpsi + (dphi - temp1))
+			zz[2] = z[iip1] * z[iip1]
+		}
+		zz[1] = z[ii] * z[ii]
+		var ok bool
+		eta, ok = impl.Dlaed6(niter, orgati, c, delta[iim1:], zz[:], w)
+		if !ok {
+			return origin + tau, false
+		}
+	}
+
+	// Note, eta should be positive if w is negative, and eta should be
+	// negative otherwise. However, if for some reason caused by roundoff,
+	// eta*w > 0, we simply use one Newton step instead. This way will
+	// guarantee eta*w < 0.
+	if w*eta >= 0 {
+		eta = -w / dw
+	}
+	if temp := tau + eta; temp > dltub || temp < dltlb {
+		if w < 0 {
+			eta = (dltub - tau) / 2
+		} else {
+			eta = (dltlb - tau) / 2
+		}
+	}
+	prew := w
+	for j := 0; j < n; j++ {
+		delta[j] -= eta
+	}
+	w, psi, dpsi, phi, dphi, dw, erretm = evaluate(tau + eta)
+	swtch := false
+	if orgati {
+		swtch = -w > math.Abs(prew)/10
+	} else {
+		swtch = w > math.Abs(prew)/10
+	}
+	tau += eta
+
+	// Main loop to update the values of the array delta.
+	for niter++; niter <= maxit; niter++ {
+		// Test for convergence.
+		if math.Abs(w) <= eps*erretm {
+			return origin + tau, true
+		}
+		if w <= 0 {
+			dltlb = math.Max(dltlb, tau)
+		} else {
+			dltub = math.Min(dltub, tau)
+		}
+
+		// Calculate the new step.
+		if !swtch3 {
+			if !swtch {
+				if orgati {
+					temp := z[i] / delta[i]
+					c = w - delta[ip1]*dw - (d[i]-d[ip1])*temp*temp
+				} else {
+					temp := z[ip1] / delta[ip1]
+					c = w - delta[i]*dw - (d[ip1]-d[i])*temp*temp
+				}
+			} else {
+				temp := z[ii] / delta[ii]
+				if orgati {
+					dpsi += temp * temp
+				} else {
+					dphi += temp * temp
+				}
+				c = w - delta[i]*dpsi - delta[ip1]*dphi
+			}
+			a := (delta[i]+delta[ip1])*w - delta[i]*delta[ip1]*dw
+			b := delta[i] * delta[ip1] * w
+			switch {
+			case c == 0:
+				if a == 0 {
+					if !swtch {
+						if orgati {
+							a = z[i]*z[i] + delta[ip1]*delta[ip1]*(dpsi+dphi)
+						} else {
+							a = z[ip1]*z[ip1] + delta[i]*delta[i]*(dpsi+dphi)
+						}
+					} else {
+						a = delta[i]*delta[i]*dpsi + delta[ip1]*delta[ip1]*dphi
+					}
+				}
+				eta = b / a
+			case a <= 0:
+				eta = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+			default:
+				eta = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+			}
+		} else {
+			// Interpolation using three most relevant poles.
+			var zz [3]float64
+			temp := rhoinv + psi + phi
+			if swtch {
+				c = temp - delta[iim1]*dpsi - delta[iip1]*dphi
+				zz[0] = delta[iim1] * delta[iim1] * dpsi
+				zz[2] = delta[iip1] * delta[iip1] * dphi
+			} else {
+				if orgati {
+					temp1 := z[iim1] / delta[iim1]
+					temp1 *= temp1
+					c = temp - delta[iip1]*(dpsi+dphi) - (d[iim1]-d[iip1])*temp1
+					zz[0] = z[iim1] * z[iim1]
+					zz[2] = delta[iip1] * delta[iip1] * ((dpsi - temp1) + dphi)
+				} else {
+					temp1 := z[iip1] / delta[iip1]
+					temp1 *= temp1
+					c = temp - delta[iim1]*(dpsi+dphi) - (d[iip1]-d[iim1])*temp1
+					zz[0] = delta[iim1] * delta[iim1] * (dpsi + (dphi - temp1))
+					zz[2] = z[iip1] * z[iip1]
+				}
+			}
+			zz[1] = z[ii] * z[ii]
+			var ok bool
+			eta, ok = impl.Dlaed6(niter, orgati, c, delta[iim1:], zz[:], w)
+			if !ok {
+				return origin + tau, false
+			}
+		}
+		if w*eta >= 0 {
+			eta = -w / dw
+		}
+		if temp := tau + eta; temp > dltub || temp < dltlb {
+			if w < 0 {
+				eta = (dltub - tau) / 2
+			} else {
+				eta = (dltlb - tau) / 2
+			}
+		}
+		for j := 0; j < n; j++ {
+			delta[j] -= eta
+		}
+		tau += eta
+		prew = w
+		w, psi, dpsi, phi, dphi, dw, erretm = evaluate(tau)
+		if w*prew > 0 && math.Abs(w) > math.Abs(prew)/10 {
+			swtch = !swtch
+		}
+	}
+	return origin + tau, false
+}
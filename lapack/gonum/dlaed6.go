@@ -0,0 +1,229 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlaed6 computes the positive or negative root closest to the origin of the
+// rational function
+//  f(x) = rho + z[0]/(d[0]-x) + z[1]/(d[1]-x) + z[2]/(d[2]-x).
+// It is assumed that
+//  - if orgati is true, the root lies between d[1] and d[2],
+//  - if orgati is false, the root lies between d[0] and d[1],
+// and that d[0] < 0 < d[2] and all elements of z are positive. finit is the
+// value of f at the origin.
+//
+// kniter is the iteration number of the calling routine. On the second
+// iteration a more refined initial guess is used.
+//
+// Dlaed6 returns the computed root tau and whether the iteration converged.
+//
+// Dlaed6 is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlaed6(kniter int, orgati bool, rho float64, d, z []float64, finit float64) (tau float64, ok bool) {
+	if len(d) < 3 || len(z) < 3 {
+		panic(badSlice)
+	}
+	const maxit = 40
+
+	var lbd, ubd float64
+	if orgati {
+		lbd = d[1]
+		ubd = d[2]
+	} else {
+		lbd = d[0]
+		ubd = d[1]
+	}
+	if finit < 0 {
+		lbd = 0
+	} else {
+		ubd = 0
+	}
+
+	niter := 1
+	if kniter == 2 {
+		var a, b, c float64
+		if orgati {
+			temp := (d[2] - d[1]) / 2
+			c = rho + z[0]/((d[0]-d[1])-temp)
+			a = c*(d[1]+d[2]) + z[1] + z[2]
+			b = c*d[1]*d[2] + z[1]*d[2] + z[2]*d[1]
+		} else {
+			temp := (d[0] - d[1]) / 2
+			c = rho + z[2]/((d[2]-d[1])-temp)
+			a = c*(d[0]+d[1]) + z[0] + z[1]
+			b = c*d[0]*d[1] + z[0]*d[1] + z[1]*d[0]
+		}
+		temp := math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c)))
+		a /= temp
+		b /= temp
+		c /= temp
+		switch {
+		case c == 0:
+			tau = b / a
+		case a <= 0:
+			tau = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		default:
+			tau = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		}
+		if tau < lbd || tau > ubd {
+			tau = (lbd + ubd) / 2
+		}
+		if d[0] == tau || d[1] == tau || d[2] == tau {
+			tau = 0
+		} else {
+			temp := finit + tau*z[0]/(d[0]*(d[0]-tau)) +
+				tau*z[1]/(d[1]*(d[1]-tau)) +
+				tau*z[2]/(d[2]*(d[2]-tau))
+			if temp <= 0 {
+				lbd = tau
+			} else {
+				ubd = tau
+			}
+			if math.Abs(finit) <= math.Abs(temp) {
+				tau = 0
+			}
+		}
+	}
+
+	// Determine if scaling of the inputs is necessary to avoid overflow
+	// when computing 1/temp^3.
+	eps := dlamchE
+	small1 := math.Pow(dlamchB, math.Trunc(math.Log(dlamchS)/math.Log(dlamchB)/3))
+	sminv1 := 1 / small1
+	small2 := small1 * small1
+	sminv2 := sminv1 * sminv1
+
+	var temp float64
+	if orgati {
+		temp = math.Min(math.Abs(d[1]-tau), math.Abs(d[2]-tau))
+	} else {
+		temp = math.Min(math.Abs(d[0]-tau), math.Abs(d[1]-tau))
+	}
+	var dscale, zscale [3]float64
+	scale := temp <= small1
+	var sclinv float64
+	if scale {
+		// Scale up by a power of the radix nearest 1/safmin^(1/3) or
+		// 1/safmin^(2/3). This is safe since d, z and tau have been
+		// scaled elsewhere to be of order one.
+		sclfac := sminv1
+		sclinv = small1
+		if temp <= small2 {
+			sclfac = sminv2
+			sclinv = small2
+		}
+		for i := range dscale {
+			dscale[i] = d[i] * sclfac
+			zscale[i] = z[i] * sclfac
+		}
+		tau *= sclfac
+		lbd *= sclfac
+		ubd *= sclfac
+	} else {
+		copy(dscale[:], d[:3])
+		copy(zscale[:], z[:3])
+	}
+
+	var fc, df, ddf float64
+	for i := range dscale {
+		temp := 1 / (dscale[i] - tau)
+		temp1 := zscale[i] * temp
+		temp2 := temp1 * temp
+		temp3 := temp2 * temp
+		fc += temp1 / dscale[i]
+		df += temp2
+		ddf += temp3
+	}
+	f := finit + tau*fc
+	if math.Abs(f) <= 0 {
+		if scale {
+			tau *= sclinv
+		}
+		return tau, true
+	}
+	if f <= 0 {
+		lbd = tau
+	} else {
+		ubd = tau
+	}
+
+	// Iteration begins. Use the Gragg-Thornton-Warner cubic convergent
+	// scheme. The iterations go up monotonically if finit < 0 and down
+	// monotonically if finit > 0.
+	ok = false
+	for niter++; niter <= maxit; niter++ {
+		var temp1, temp2 float64
+		if orgati {
+			temp1 = dscale[1] - tau
+			temp2 = dscale[2] - tau
+		} else {
+			temp1 = dscale[0] - tau
+			temp2 = dscale[1] - tau
+		}
+		a := (temp1+temp2)*f - temp1*temp2*df
+		b := temp1 * temp2 * f
+		c := f - (temp1+temp2)*df + temp1*temp2*ddf
+		temp := math.Max(math.Abs(a), math.Max(math.Abs(b), math.Abs(c)))
+		a /= temp
+		b /= temp
+		c /= temp
+		var eta float64
+		switch {
+		case c == 0:
+			eta = b / a
+		case a <= 0:
+			eta = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		default:
+			eta = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		}
+		if f*eta >= 0 {
+			eta = -f / df
+		}
+		tau += eta
+		if tau < lbd || tau > ubd {
+			tau = (lbd + ubd) / 2
+		}
+
+		fc = 0
+		erretm := 0.0
+		df = 0
+		ddf = 0
+		converged := false
+		for i := range dscale {
+			if dscale[i]-tau == 0 {
+				converged = true
+				break
+			}
+			temp := 1 / (dscale[i] - tau)
+			temp1 := zscale[i] * temp
+			temp2 := temp1 * temp
+			temp3 := temp2 * temp
+			temp4 := temp1 / dscale[i]
+			fc += temp4
+			erretm += math.Abs(temp4)
+			df += temp2
+			ddf += temp3
+		}
+		if converged {
+			ok = true
+			break
+		}
+		f = finit + tau*fc
+		erretm = 8*(math.Abs(finit)+math.Abs(tau)*erretm) + math.Abs(tau)*df
+		if math.Abs(f) <= 4*eps*erretm || ubd-lbd <= 4*eps*math.Abs(tau) {
+			ok = true
+			break
+		}
+		if f <= 0 {
+			lbd = tau
+		} else {
+			ubd = tau
+		}
+	}
+	if scale {
+		tau *= sclinv
+	}
+	return tau, ok
+}
@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dlamrg creates a permutation list to merge the entries of two independently
+// sorted sets into a single set sorted in ascending order.
+//
+// The first n1 elements of a form the first set and the following n2 elements
+// the second. The elements of the first set are in ascending order if
+// strd1 == 1 and in descending order if strd1 == -1, and similarly for the
+// second set and strd2.
+//
+// On return, index contains the permutation such that a[index[i]] for
+// i = 0, ..., n1+n2-1 is in ascending order. index must have length at least
+// n1+n2.
+//
+// Dlamrg is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlamrg(n1, n2 int, a []float64, strd1, strd2 int, index []int) {
+	if n1 < 0 || n2 < 0 {
+		panic(nLT0)
+	}
+	if (strd1 != 1 && strd1 != -1) || (strd2 != 1 && strd2 != -1) {
+		panic(absIncNotOne)
+	}
+	if len(a) < n1+n2 || len(index) < n1+n2 {
+		panic(badSlice)
+	}
+
+	ind1 := 0
+	if strd1 < 0 {
+		ind1 = n1 - 1
+	}
+	ind2 := n1
+	if strd2 < 0 {
+		ind2 = n1 + n2 - 1
+	}
+	var i int
+	for n1 > 0 && n2 > 0 {
+		if a[ind1] <= a[ind2] {
+			index[i] = ind1
+			ind1 += strd1
+			n1--
+		} else {
+			index[i] = ind2
+			ind2 += strd2
+			n2--
+		}
+		i++
+	}
+	for ; n1 > 0; n1-- {
+		index[i] = ind1
+		ind1 += strd1
+		i++
+	}
+	for ; n2 > 0; n2-- {
+		index[i] = ind2
+		ind2 += strd2
+		i++
+	}
+}
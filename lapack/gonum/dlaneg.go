@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlaneg computes the Sturm count, the number of negative pivots encountered
+// while factoring the tridiagonal matrix
+//  T - sigma*I = L * D * L^T.
+// This implementation works directly on the factors without forming the
+// tridiagonal matrix T. The Sturm count is also the number of eigenvalues of
+// T less than sigma.
+//
+// d contains the n diagonal elements of D and lld contains the n-1 elements
+// L[i]*L[i]*D[i]. The factorization is twisted at index r, 0 <= r < n. pivmin
+// is the minimum pivot in the Sturm sequence.
+//
+// Dlaneg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaneg(n int, d, lld []float64, sigma, pivmin float64, r int) int {
+	// Some architectures propagate Infinities and NaNs very slowly, so the
+	// code computes counts in blklen chunks. Then a NaN can propagate at most
+	// blklen columns before being detected. This is not a general tuning
+	// parameter; it needs only to be just large enough that the overhead is
+	// tiny in common cases.
+	const blklen = 128
+
+	if n <= 0 {
+		return 0
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(lld) < n-1 {
+		panic(badSlice)
+	}
+	if r < 0 || n <= r {
+		panic("lapack: r out of range")
+	}
+
+	var negcnt int
+
+	// I) Upper part: L D L^T - sigma*I = L+ D+ L+^T.
+	t := -sigma
+	for bj := 0; bj < r; bj += blklen {
+		var neg1 int
+		bsav := t
+		jend := min(bj+blklen, r)
+		for j := bj; j < jend; j++ {
+			dplus := d[j] + t
+			if dplus < 0 {
+				neg1++
+			}
+			tmp := t / dplus
+			t = tmp*lld[j] - sigma
+		}
+		if math.IsNaN(t) {
+			// Run a slower version of the above loop if a NaN is detected.
+			// A NaN should occur only with a zero pivot after an infinite
+			// pivot. In that case, substituting 1 for t/dplus is the correct
+			// limit.
+			neg1 = 0
+			t = bsav
+			for j := bj; j < jend; j++ {
+				dplus := d[j] + t
+				if dplus < 0 {
+					neg1++
+				}
+				tmp := t / dplus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				t = tmp*lld[j] - sigma
+			}
+		}
+		negcnt += neg1
+	}
+
+	// II) Lower part: L D L^T - sigma*I = U- D- U-^T.
+	p := d[n-1] - sigma
+	for bj := n - 2; bj >= r; bj -= blklen {
+		var neg2 int
+		bsav := p
+		jend := max(bj-blklen+1, r)
+		for j := bj; j >= jend; j-- {
+			dminus := lld[j] + p
+			if dminus < 0 {
+				neg2++
+			}
+			tmp := p / dminus
+			p = tmp*d[j] - sigma
+		}
+		if math.IsNaN(p) {
+			// As above, run a slower version that substitutes 1 for
+			// p/dminus.
+			neg2 = 0
+			p = bsav
+			for j := bj; j >= jend; j-- {
+				dminus := lld[j] + p
+				if dminus < 0 {
+					neg2++
+				}
+				tmp := p / dminus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				p = tmp*d[j] - sigma
+			}
+		}
+		negcnt += neg2
+	}
+
+	// III) Twist index.
+	gamma := (t + sigma) + p
+	if gamma < 0 {
+		negcnt++
+	}
+	return negcnt
+}
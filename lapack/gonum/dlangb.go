@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlangb computes the specified norm of an m×n band matrix A with kl
+// sub-diagonals and ku super-diagonals. The element A[i,j] for
+// max(0,i-kl) <= j <= min(n-1,i+ku) is stored in ab[i*ldab+j-i+kl]. If
+// norm == lapack.MaxColumnSum, work must have length at least n, otherwise
+// work is unused.
+func (impl Implementation) Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	checkBanded(ab, m, n, kl, ku, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if norm == lapack.MaxColumnSum && len(work) < n {
+		panic(badWork)
+	}
+
+	if m == 0 || n == 0 {
+		return 0
+	}
+
+	// Rows below min(m,n+kl) contain no elements of the band.
+	rows := min(m, n+kl)
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		for i := 0; i < rows; i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				v := math.Abs(ab[i*ldab+j-i+kl])
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				if v > value {
+					value = v
+				}
+			}
+		}
+		return value
+	case lapack.MaxRowSum:
+		var value float64
+		for i := 0; i < rows; i++ {
+			var sum float64
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				sum += math.Abs(ab[i*ldab+j-i+kl])
+			}
+			if math.IsNaN(sum) {
+				return math.NaN()
+			}
+			if sum > value {
+				value = sum
+			}
+		}
+		return value
+	case lapack.MaxColumnSum:
+		for j := 0; j < n; j++ {
+			work[j] = 0
+		}
+		for i := 0; i < rows; i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				work[j] += math.Abs(ab[i*ldab+j-i+kl])
+			}
+		}
+		var value float64
+		for j := 0; j < n; j++ {
+			v := work[j]
+			if math.IsNaN(v) {
+				return math.NaN()
+			}
+			if v > value {
+				value = v
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		var sum float64
+		for i := 0; i < rows; i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				v := ab[i*ldab+j-i+kl]
+				sum += v * v
+			}
+		}
+		return math.Sqrt(sum)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlansb computes the specified norm of an n×n symmetric band matrix with kd
+// super- or sub-diagonals. The band is stored in ab in the triangle specified
+// by uplo, see the documentation for Dpbtf2 for a description of the band
+// storage scheme. If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum,
+// work must have length at least n, otherwise work is unused.
+func (impl Implementation) Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64 {
+	checkSymBanded(ab, n, kd, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n {
+		panic(badWork)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+
+	if n == 0 {
+		return 0
+	}
+
+	// jlo and jhi return the range of storage columns of row i of ab that
+	// hold elements of the band.
+	jlo := func(i int) int {
+		if uplo == blas.Upper {
+			return 0
+		}
+		return max(0, kd-i)
+	}
+	jhi := func(i int) int {
+		if uplo == blas.Upper {
+			return min(kd, n-i-1)
+		}
+		return kd
+	}
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var max float64
+		for i := 0; i < n; i++ {
+			for j := jlo(i); j <= jhi(i); j++ {
+				v := math.Abs(ab[i*ldab+j])
+				if math.IsNaN(v) {
+					return math.NaN()
+				}
+				if v > max {
+					max = v
+				}
+			}
+		}
+		return max
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm.
+		for i := 0; i < n; i++ {
+			work[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			for j := jlo(i); j <= jhi(i); j++ {
+				// col is the column of A of the element in
+				// column j of row i of ab.
+				col := i + j
+				if uplo == blas.Lower {
+					col = i + j - kd
+				}
+				v := math.Abs(ab[i*ldab+j])
+				work[i] += v
+				if col != i {
+					work[col] += v
+				}
+			}
+		}
+		var max float64
+		for i := 0; i < n; i++ {
+			v := work[i]
+			if math.IsNaN(v) {
+				return math.NaN()
+			}
+			if v > max {
+				max = v
+			}
+		}
+		return max
+	case lapack.NormFrob:
+		var sum float64
+		for i := 0; i < n; i++ {
+			for j := jlo(i); j <= jhi(i); j++ {
+				v := ab[i*ldab+j]
+				if (uplo == blas.Upper && j == 0) || (uplo == blas.Lower && j == kd) {
+					sum += v * v
+				} else {
+					sum += 2 * v * v
+				}
+			}
+		}
+		return math.Sqrt(sum)
+	}
+}
@@ -0,0 +1,286 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlar1v computes the (scaled) r-th column of the inverse of the submatrix in
+// rows b1 through bn of the tridiagonal matrix
+//  L * D * L^T - lambda * I.
+// When lambda is close to an eigenvalue, the computed vector is an accurate
+// eigenvector. Usually, r corresponds to the index where the eigenvector is
+// largest in magnitude.
+//
+// The following steps accomplish this computation:
+//  (a) Stationary qd transform, L D L^T - lambda I = L(+) D(+) L(+)^T,
+//  (b) Progressive qd transform, L D L^T - lambda I = U(-) D(-) U(-)^T,
+//  (c) Computation of the diagonal elements of the inverse of
+//      L D L^T - lambda I by combining the above transforms, and choosing
+//      r as the index where the diagonal of the inverse is (one of the)
+//      largest in magnitude.
+//  (d) Computation of the (scaled) r-th column of the inverse using the
+//      twisted factorization obtained by combining the top part of the
+//      stationary and the bottom part of the progressive transform.
+//
+// d contains the n diagonal elements of D, l contains the n-1 subdiagonal
+// elements of the unit bidiagonal matrix L, ld contains the n-1 elements
+// L[i]*D[i] and lld contains the n-1 elements L[i]*L[i]*D[i]. pivmin is the
+// minimum pivot in the Sturm sequence.
+//
+// gaptol is the tolerance that indicates when eigenvector entries are
+// negligible with respect to their contribution to the residual.
+//
+// On exit, z[b1:bn+1] contains the (scaled) r-th column of the inverse,
+// normalized so that z[r] = 1. isuppz[0] and isuppz[1] contain the first and
+// the last indices of the support of z, so that z[i] is zero for i outside
+// isuppz[0] through isuppz[1]. isuppz must have length 2.
+//
+// If r is in the range [b1,bn], it is used as the twist index, otherwise the
+// twist index is chosen as the index of the largest magnitude diagonal element
+// of the inverse. The used twist index is returned in twist.
+//
+// If wantnc is true, negcnt is the number of negative pivots of the
+// factorization, otherwise it is -1. ztz is the square of the 2-norm of z,
+// mingma is the reciprocal of the largest (in magnitude) diagonal element of
+// the inverse, nrminv is 1/sqrt(ztz), resid is the residual of the FP vector
+// |mingma|/sqrt(ztz) and rqcorr is the Rayleigh quotient correction to lambda,
+// mingma/ztz.
+//
+// work must have length at least 4*n.
+//
+// Dlar1v is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlar1v(n, b1, bn int, lambda float64, d, l, ld, lld []float64, pivmin, gaptol float64, z []float64, wantnc bool, r int, isuppz []int, work []float64) (negcnt int, ztz, mingma float64, twist int, nrminv, resid, rqcorr float64) {
+	if n <= 0 {
+		return -1, 0, 0, r, 0, 0, 0
+	}
+	if b1 < 0 || bn < b1 || n <= bn {
+		panic(badIlo)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(l) < n-1 || len(ld) < n-1 || len(lld) < n-1 {
+		panic(badSlice)
+	}
+	if len(z) < n {
+		panic(badZ)
+	}
+	if len(isuppz) < 2 {
+		panic(badSlice)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+
+	eps := dlamchP
+
+	var r1, r2 int
+	if r < b1 || bn < r {
+		r1 = b1
+		r2 = bn
+	} else {
+		r1 = r
+		r2 = r
+	}
+
+	// lplus holds the elements of L(+), uminus the elements of U(-), s[i]
+	// the auxiliary quantity entering row i of the stationary transform and
+	// p[i] the auxiliary quantity of row i of the progressive transform.
+	lplus := work[:n]
+	uminus := work[n : 2*n]
+	s := work[2*n : 3*n]
+	p := work[3*n : 4*n]
+
+	if b1 == 0 {
+		s[0] = 0
+	} else {
+		s[b1] = lld[b1-1]
+	}
+
+	// Compute the stationary transform (using the differential form) until
+	// the index r2.
+	var neg1 int
+	sigma := s[b1] - lambda
+	for i := b1; i < r1; i++ {
+		dplus := d[i] + sigma
+		lplus[i] = ld[i] / dplus
+		if dplus < 0 {
+			neg1++
+		}
+		s[i+1] = sigma * lplus[i] * l[i]
+		sigma = s[i+1] - lambda
+	}
+	sawnan1 := math.IsNaN(sigma)
+	if !sawnan1 {
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + sigma
+			lplus[i] = ld[i] / dplus
+			s[i+1] = sigma * lplus[i] * l[i]
+			sigma = s[i+1] - lambda
+		}
+		sawnan1 = math.IsNaN(sigma)
+	}
+	if sawnan1 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg1 = 0
+		sigma = s[b1] - lambda
+		for i := b1; i < r1; i++ {
+			dplus := d[i] + sigma
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			lplus[i] = ld[i] / dplus
+			if dplus < 0 {
+				neg1++
+			}
+			s[i+1] = sigma * lplus[i] * l[i]
+			if lplus[i] == 0 {
+				s[i+1] = lld[i]
+			}
+			sigma = s[i+1] - lambda
+		}
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + sigma
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			lplus[i] = ld[i] / dplus
+			s[i+1] = sigma * lplus[i] * l[i]
+			if lplus[i] == 0 {
+				s[i+1] = lld[i]
+			}
+			sigma = s[i+1] - lambda
+		}
+	}
+
+	// Compute the progressive transform (using the differential form) until
+	// the index r1.
+	var neg2 int
+	p[bn] = d[bn] - lambda
+	for i := bn - 1; i >= r1; i-- {
+		dminus := lld[i] + p[i+1]
+		tmp := d[i] / dminus
+		if dminus < 0 {
+			neg2++
+		}
+		uminus[i] = l[i] * tmp
+		p[i] = p[i+1]*tmp - lambda
+	}
+	sawnan2 := math.IsNaN(p[r1])
+	if sawnan2 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg2 = 0
+		for i := bn - 1; i >= r1; i-- {
+			dminus := lld[i] + p[i+1]
+			if math.Abs(dminus) < pivmin {
+				dminus = -pivmin
+			}
+			tmp := d[i] / dminus
+			if dminus < 0 {
+				neg2++
+			}
+			uminus[i] = l[i] * tmp
+			p[i] = p[i+1]*tmp - lambda
+			if tmp == 0 {
+				p[i] = d[i] - lambda
+			}
+		}
+	}
+
+	// Find the index (from r1 to r2) of the largest (in magnitude) diagonal
+	// element of the inverse.
+	mingma = s[r1] + p[r1]
+	if mingma < 0 {
+		neg1++
+	}
+	if wantnc {
+		negcnt = neg1 + neg2
+	} else {
+		negcnt = -1
+	}
+	if mingma == 0 {
+		mingma = eps * s[r1]
+	}
+	twist = r1
+	for i := r1; i < r2; i++ {
+		tmp := s[i+1] + p[i+1]
+		if tmp == 0 {
+			tmp = eps * s[i+1]
+		}
+		if math.Abs(tmp) <= math.Abs(mingma) {
+			mingma = tmp
+			twist = i + 1
+		}
+	}
+
+	// Compute the FP vector: solve N^T v = e_r.
+	isuppz[0] = b1
+	isuppz[1] = bn
+	z[twist] = 1
+	ztz = 1
+
+	// Compute the FP vector upwards from the twist index.
+	if !sawnan1 && !sawnan2 {
+		for i := twist - 1; i >= b1; i-- {
+			z[i] = -(lplus[i] * z[i+1])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+	} else {
+		// Run slower loop if NaN occurred.
+		for i := twist - 1; i >= b1; i-- {
+			if z[i+1] == 0 {
+				z[i] = -(ld[i+1] / ld[i]) * z[i+2]
+			} else {
+				z[i] = -(lplus[i] * z[i+1])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+	}
+
+	// Compute the FP vector downwards from the twist index.
+	if !sawnan1 && !sawnan2 {
+		for i := twist; i < bn; i++ {
+			z[i+1] = -(uminus[i] * z[i])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	} else {
+		// Run slower loop if NaN occurred.
+		for i := twist; i < bn; i++ {
+			if z[i] == 0 {
+				z[i+1] = -(ld[i-1] / ld[i]) * z[i-1]
+			} else {
+				z[i+1] = -(uminus[i] * z[i])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	}
+
+	// Compute quantities for convergence test.
+	tmp := 1 / ztz
+	nrminv = math.Sqrt(tmp)
+	resid = math.Abs(mingma) * nrminv
+	rqcorr = mingma * tmp
+	return negcnt, ztz, mingma, twist, nrminv, resid, rqcorr
+}
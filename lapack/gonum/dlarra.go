@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlarra computes the splitting points of the n×n symmetric tridiagonal matrix
+// T with diagonal d and off-diagonal e, using the specified splitting
+// tolerance spltol.
+//
+// If spltol < 0, an absolute criterion |e[i]| <= |spltol|*tnrm is used to
+// decide whether the off-diagonal element e[i] is negligible, where tnrm is
+// the norm of T. Otherwise, the relative criterion
+// |e[i]| <= spltol*sqrt(|d[i]|)*sqrt(|d[i+1]|) is used. Negligible elements
+// of e and e2, the squares of the elements of e, are set to zero.
+//
+// On return, the first nsplit elements of isplit contain the indices of the
+// last rows of the nsplit unreduced blocks of T, so that the first block is
+// formed by rows 0 through isplit[0], the second block by rows isplit[0]+1
+// through isplit[1] and so on. isplit[nsplit-1] is always n-1. isplit must
+// have length at least n.
+//
+// Dlarra is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarra(n int, d, e, e2 []float64, spltol, tnrm float64, isplit []int) (nsplit int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 || len(e2) < n-1 {
+		panic(badE)
+	}
+	if len(isplit) < n {
+		panic(badSlice)
+	}
+
+	if spltol < 0 {
+		// Criterion based on absolute off-diagonal value.
+		tmp1 := math.Abs(spltol) * tnrm
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= tmp1 {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	} else {
+		// Criterion that guarantees relative accuracy.
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= spltol*math.Sqrt(math.Abs(d[i]))*math.Sqrt(math.Abs(d[i+1])) {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	}
+	isplit[nsplit] = n - 1
+	return nsplit + 1
+}
@@ -0,0 +1,115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlarrb refines, by bisection, the initial eigenvalue approximations of the
+// tridiagonal matrix
+//  L * D * L^T
+// given in factored form. d contains the n diagonal elements of D and lld
+// contains the n-1 elements L[i]*L[i]*D[i].
+//
+// The eigenvalues with (0-based) indices ifirst through ilast are refined.
+// On entry, w[i-offset] and werr[i-offset] contain an approximation of the
+// i-th eigenvalue and the semi-width of an interval around it, and
+// wgap[i-offset] contains the gap to the right of the i-th eigenvalue. On
+// exit they contain the refined values. An interval is considered converged
+// if its semi-width is at most max(rtol1*gap, rtol2*max(|left|,|right|)),
+// where gap is the minimum of the left and right gaps.
+//
+// pivmin is the minimum pivot in the Sturm sequence, spdiam is the spectral
+// diameter of the matrix and twist is the twist index used by Dlaneg for the
+// Sturm count. If twist is outside the range [0,n), n-1 is used instead.
+//
+// Dlarrb is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrb(n int, d, lld []float64, ifirst, ilast int, rtol1, rtol2 float64, offset int, w, wgap, werr []float64, pivmin, spdiam float64, twist int) {
+	if n <= 0 {
+		return
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(lld) < n-1 {
+		panic(badSlice)
+	}
+	if ifirst < 0 || ilast < ifirst-1 || n <= ilast {
+		panic(badIlo)
+	}
+	if len(w) < ilast-offset+1 || len(wgap) < ilast-offset+1 || len(werr) < ilast-offset+1 {
+		panic(badSlice)
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+	mnwdth := 2 * pivmin
+
+	r := twist
+	if r < 0 || n <= r {
+		r = n - 1
+	}
+
+	// The intervals are independent of each other, so each one is refined
+	// separately until it converges or the maximum number of iterations is
+	// reached. The gaps are updated at the end.
+	rgap := wgap[ifirst-offset]
+	for i := ifirst; i <= ilast; i++ {
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		lgap := rgap
+		rgap = wgap[ii]
+		gap := math.Min(lgap, rgap)
+
+		// Make sure that [left,right] contains the desired eigenvalue.
+		back := werr[ii]
+		for impl.Dlaneg(n, d, lld, left, pivmin, r) > i {
+			left -= back
+			back *= 2
+		}
+		back = werr[ii]
+		for impl.Dlaneg(n, d, lld, right, pivmin, r) <= i {
+			right += back
+			back *= 2
+		}
+		width := math.Abs(left-right) / 2
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+		if width <= cvrgd || width <= mnwdth {
+			// This interval has already converged and does not need
+			// refinement. Note that the gaps might change through
+			// refining the eigenvalues, however, they can only get
+			// bigger.
+			continue
+		}
+
+		// Bisection uses the gaps of the current approximations.
+		gap = wgap[ii]
+		if ii > 0 {
+			gap = math.Min(wgap[ii-1], gap)
+		}
+		for iter := 0; ; iter++ {
+			mid := (left + right) / 2
+			width = right - mid
+			tmp = math.Max(math.Abs(left), math.Abs(right))
+			cvrgd = math.Max(rtol1*gap, rtol2*tmp)
+			if width <= cvrgd || width <= mnwdth || iter == maxitr {
+				break
+			}
+			// Perform one bisection step.
+			if impl.Dlaneg(n, d, lld, mid, pivmin, r) <= i {
+				left = mid
+			} else {
+				right = mid
+			}
+		}
+		w[ii] = (left + right) / 2
+		werr[ii] = right - w[ii]
+	}
+
+	for i := ifirst + 1; i <= ilast; i++ {
+		ii := i - offset
+		wgap[ii-1] = math.Max(0, w[ii]-werr[ii]-w[ii-1]-werr[ii-1])
+	}
+}
@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// Dlarrc computes the number of eigenvalues of the n×n symmetric tridiagonal
+// matrix T with diagonal d and off-diagonal e in the half-open interval
+// (vl, vu]. It uses Sturm sequences of T - vl*I and T - vu*I.
+//
+// lcnt and rcnt are the Sturm counts, the number of eigenvalues less than or
+// equal to vl and vu respectively, and eigcnt = rcnt - lcnt.
+//
+// Dlarrc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrc(n int, vl, vu float64, d, e []float64) (eigcnt, lcnt, rcnt int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0, 0, 0
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	lpivot := d[0] - vl
+	rpivot := d[0] - vu
+	if lpivot <= 0 {
+		lcnt++
+	}
+	if rpivot <= 0 {
+		rcnt++
+	}
+	for i := 0; i < n-1; i++ {
+		tmp := e[i] * e[i]
+		lpivot = (d[i+1] - vl) - tmp/lpivot
+		rpivot = (d[i+1] - vu) - tmp/rpivot
+		if lpivot <= 0 {
+			lcnt++
+		}
+		if rpivot <= 0 {
+			rcnt++
+		}
+	}
+	return rcnt - lcnt, lcnt, rcnt
+}
@@ -0,0 +1,295 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlarrd computes the eigenvalues of the n×n symmetric tridiagonal matrix T
+// with diagonal d and off-diagonal e to suitable accuracy using bisection.
+// This is an auxiliary code to be called from Dstemr.
+//
+// If rng == lapack.AllEigenvalues, all eigenvalues are computed. If
+// rng == lapack.IntervalEigenvalues, the eigenvalues in the half-open interval
+// (vl, vu] are computed. If rng == lapack.IndexEigenvalues, the eigenvalues
+// with (0-based) indices il through iu are computed.
+//
+// gers contains the Gerschgorin intervals of T, the i-th interval being
+// [gers[2*i], gers[2*i+1]]. e2 contains the squares of the off-diagonal
+// elements of T, with the elements at the splitting points set to zero.
+// reltol is the minimum relative width of an interval and pivmin is the
+// minimum pivot allowed in the Sturm sequence.
+//
+// nsplit and isplit describe the splitting of T into unreduced blocks as
+// computed by Dlarra.
+//
+// On return, the first m elements of w contain the eigenvalue approximations
+// and the first m elements of werr their error bounds. The eigenvalues are
+// grouped by block and sorted in ascending order within each block. iblock[i]
+// contains the (0-based) block number of the i-th eigenvalue and indexw[i] its
+// (0-based) index within the block. w, werr, iblock and indexw must have
+// length at least n.
+//
+// wl and wu are bounds such that all the computed eigenvalues lie in the
+// half-open interval (wl, wu].
+//
+// Dlarrd returns whether the bisection converged for all eigenvalues.
+//
+// Dlarrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrd(rng lapack.EVRange, n int, vl, vu float64, il, iu int, gers []float64, reltol float64, d, e, e2 []float64, pivmin float64, nsplit int, isplit []int, w, werr []float64, iblock, indexw []int) (m int, wl, wu float64, ok bool) {
+	const fudge = 2
+
+	switch rng {
+	default:
+		panic("lapack: bad eigenvalue range")
+	case lapack.AllEigenvalues:
+	case lapack.IntervalEigenvalues:
+		if vl >= vu {
+			panic("lapack: vl >= vu")
+		}
+	case lapack.IndexEigenvalues:
+		if il < 0 || iu < il || n <= iu {
+			panic("lapack: il or iu out of range")
+		}
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0, vl, vu, true
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 || len(e2) < n-1 {
+		panic(badE)
+	}
+	if nsplit < 1 || len(isplit) < nsplit {
+		panic(badSlice)
+	}
+	if len(w) < n || len(werr) < n || len(iblock) < n || len(indexw) < n {
+		panic(badSlice)
+	}
+
+	eps := dlamchP
+
+	// Simplification: if the index range covers the whole spectrum, compute
+	// all eigenvalues.
+	if rng == lapack.IndexEigenvalues && il == 0 && iu == n-1 {
+		rng = lapack.AllEigenvalues
+	}
+
+	// Compute the global Gerschgorin bounds and the spectral norm estimate.
+	gl := d[0]
+	gu := d[0]
+	for i := 0; i < n; i++ {
+		gl = math.Min(gl, gers[2*i])
+		gu = math.Max(gu, gers[2*i+1])
+	}
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl -= fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+	gu += fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	rtoli := reltol
+	atoli := fudge*2*dlamchS + fudge*2*pivmin
+
+	// count returns the number of eigenvalues of the submatrix of T in rows
+	// ib through ie that are less than or equal to x.
+	count := func(ib, ie int, x float64) int {
+		var cnt int
+		tmp := d[ib] - x
+		if math.Abs(tmp) < pivmin {
+			tmp = -pivmin
+		}
+		if tmp <= 0 {
+			cnt++
+		}
+		for j := ib + 1; j <= ie; j++ {
+			tmp = d[j] - e2[j-1]/tmp - x
+			if math.Abs(tmp) < pivmin {
+				tmp = -pivmin
+			}
+			if tmp <= 0 {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	// bisect finds by bisection in [left,right] the eigenvalue with index k
+	// of the submatrix of T in rows ib through ie. On entry, the number of
+	// eigenvalues less than or equal to left must be at most k and the
+	// number less than or equal to right must be greater than k.
+	bisect := func(ib, ie, k int, left, right, tnrm float64) (lo, hi float64, ok bool) {
+		itmax := int((math.Log(tnrm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		for it := 0; ; it++ {
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			if right-left < math.Max(atoli, math.Max(pivmin, rtoli*tmp)) {
+				return left, right, true
+			}
+			if it > itmax {
+				return left, right, false
+			}
+			mid := (left + right) / 2
+			if count(ib, ie, mid) > k {
+				right = mid
+			} else {
+				left = mid
+			}
+		}
+	}
+
+	ok = true
+	switch rng {
+	case lapack.AllEigenvalues:
+		wl = gl
+		wu = gu
+	case lapack.IntervalEigenvalues:
+		wl = vl
+		wu = vu
+	case lapack.IndexEigenvalues:
+		// Find the interval (wl,wu] that contains the eigenvalues il
+		// through iu. wl is a lower bound of the il-th eigenvalue and wu
+		// an upper bound of the iu-th eigenvalue.
+		var okl, oku bool
+		wl, _, okl = bisect(0, n-1, il, gl, gu, tnorm)
+		_, wu, oku = bisect(0, n-1, iu, gl, gu, tnorm)
+		if !okl || !oku {
+			return 0, wl, wu, false
+		}
+	}
+
+	// Find the eigenvalues in (wl,wu] block by block. nwl and nwu count the
+	// eigenvalues that are less than or equal to wl and wu, respectively.
+	var nwl, nwu int
+	ibegin := 0
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		if in == 1 {
+			// 1×1 block.
+			if wl >= d[ibegin]-pivmin {
+				nwl++
+			}
+			if wu >= d[ibegin]-pivmin {
+				nwu++
+			}
+			if rng == lapack.AllEigenvalues || (wl < d[ibegin]-pivmin && wu >= d[ibegin]-pivmin) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+			}
+			ibegin = iend + 1
+			continue
+		}
+
+		// General case: block of size larger than one. Compute the
+		// Gerschgorin interval for the block.
+		bl := gers[2*ibegin]
+		bu := gers[2*ibegin+1]
+		for j := ibegin + 1; j <= iend; j++ {
+			bl = math.Min(bl, gers[2*j])
+			bu = math.Max(bu, gers[2*j+1])
+		}
+		btnorm := math.Max(math.Abs(bl), math.Abs(bu))
+		bl -= fudge*btnorm*eps*float64(in) + fudge*pivmin
+		bu += fudge*btnorm*eps*float64(in) + fudge*pivmin
+
+		if rng != lapack.AllEigenvalues {
+			if bu < wl {
+				// The whole block lies to the left of the interval.
+				nwl += in
+				nwu += in
+				ibegin = iend + 1
+				continue
+			}
+			// Refine the search interval if possible, only the
+			// eigenvalues in (wl,wu] are wanted.
+			bl = math.Max(bl, wl)
+			bu = math.Min(bu, wu)
+			if bl >= bu {
+				ibegin = iend + 1
+				continue
+			}
+		}
+
+		// Find the eigenvalues of the block in (bl,bu].
+		lcnt := count(ibegin, iend, bl)
+		rcnt := count(ibegin, iend, bu)
+		nwl += lcnt
+		nwu += rcnt
+		for k := lcnt; k < rcnt; k++ {
+			lo, hi, conv := bisect(ibegin, iend, k, bl, bu, btnorm)
+			if !conv {
+				ok = false
+			}
+			w[m] = (lo + hi) / 2
+			werr[m] = (hi - lo) / 2
+			iblock[m] = jblk
+			indexw[m] = k
+			m++
+		}
+		ibegin = iend + 1
+	}
+
+	if rng == lapack.IndexEigenvalues {
+		// If too many eigenvalues were found, discard the smallest and
+		// the largest ones. This can happen because of ties and bad
+		// arithmetic. The eigenvalues to be discarded are marked by
+		// setting their block number to -1.
+		idiscl := il - nwl
+		idiscu := nwu - iu - 1
+		if idiscl < 0 || idiscu < 0 {
+			// Too few eigenvalues were found.
+			ok = false
+		}
+		if idiscl > 0 || idiscu > 0 {
+			for ; idiscl > 0; idiscl-- {
+				wkill := wu
+				iw := -1
+				for je := 0; je < m; je++ {
+					if iblock[je] >= 0 && (w[je] < wkill || iw < 0) {
+						iw = je
+						wkill = w[je]
+					}
+				}
+				iblock[iw] = -1
+			}
+			for ; idiscu > 0; idiscu-- {
+				wkill := wl
+				iw := -1
+				for je := 0; je < m; je++ {
+					if iblock[je] >= 0 && (w[je] >= wkill || iw < 0) {
+						iw = je
+						wkill = w[je]
+					}
+				}
+				iblock[iw] = -1
+			}
+			// Erase all eigenvalues with negative block number.
+			var im int
+			for je := 0; je < m; je++ {
+				if iblock[je] >= 0 {
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+	}
+	return m, wl, wu, ok
+}
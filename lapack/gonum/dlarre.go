@@ -0,0 +1,549 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlarre finds, for each unreduced block Ti of the n×n symmetric tridiagonal
+// matrix T, a suitable shift and a base representation
+//  Ti - sigma_i * I = Li * Di * Li^T,
+// and computes the eigenvalues of each Li*Di*Li^T to high relative accuracy
+// using dqds or bisection. This is an auxiliary code to be called from
+// Dstemr.
+//
+// rng, vl, vu, il and iu specify the wanted eigenvalues as in Dstemr, with il
+// and iu 0-based.
+//
+// On entry, d, e and e2 contain the diagonal, the off-diagonal and the squares
+// of the off-diagonal elements of T, respectively. On exit, d contains the n
+// diagonal elements of the Di, e[:n-1] the subdiagonal elements of the unit
+// bidiagonal matrices Li and the elements at the splitting points are set to
+// zero in e2. e[isplit[j]] contains the shift sigma_j of the j-th block. d, e
+// and e2 must have length at least n.
+//
+// rtol1 and rtol2 are the tolerances for the bisection, an interval is
+// converged if its semi-width is at most max(rtol1*gap, rtol2*max(|left|,|right|)).
+// spltol is the splitting threshold as in Dlarra.
+//
+// On return, isplit contains the splitting points as described in Dlarra,
+// the first m elements of w contain the eigenvalue approximations relative to
+// the shift of their block, werr their error bounds and wgap the separation
+// from the right neighbor. iblock[i] contains the (0-based) block number of
+// the i-th eigenvalue and indexw[i] its (0-based) index within the block.
+// gers contains the Gerschgorin intervals of T, the i-th interval being
+// [gers[2*i], gers[2*i+1]]. isplit, w, werr, wgap, iblock and indexw must have
+// length at least n and gers at least 2*n.
+//
+// work must have length at least 4*n.
+//
+// Dlarre returns the bounds (wl, wu] of the part of the spectrum that contains
+// the computed eigenvalues, the number of blocks nsplit, the number of
+// computed eigenvalues m, the minimum pivot pivmin used in the Sturm sequences
+// and whether the computation was successful.
+//
+// Dlarre is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarre(rng lapack.EVRange, n int, vl, vu float64, il, iu int, d, e, e2 []float64, rtol1, rtol2, spltol float64, isplit []int, w, werr, wgap []float64, iblock, indexw []int, gers, work []float64) (wl, wu float64, nsplit, m int, pivmin float64, ok bool) {
+	const (
+		fac       = 0.5
+		maxgrowth = 64
+		fudge     = 2
+		maxtry    = 6
+		pert      = 8
+	)
+
+	switch rng {
+	default:
+		panic("lapack: bad eigenvalue range")
+	case lapack.AllEigenvalues, lapack.IntervalEigenvalues, lapack.IndexEigenvalues:
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return vl, vu, 0, 0, 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n || len(e2) < n {
+		panic(badE)
+	}
+	if len(isplit) < n || len(w) < n || len(werr) < n || len(wgap) < n || len(iblock) < n || len(indexw) < n {
+		panic(badSlice)
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+
+	// Set parameters.
+	rtl := math.Sqrt(eps)
+	bsrtol := math.Sqrt(eps)
+
+	wl = vl
+	wu = vu
+
+	// Treat case of 1×1 matrix for quick return.
+	if n == 1 {
+		if rng == lapack.AllEigenvalues ||
+			(rng == lapack.IntervalEigenvalues && d[0] > vl && d[0] <= vu) ||
+			(rng == lapack.IndexEigenvalues && il == 0 && iu == 0) {
+			m = 1
+			w[0] = d[0]
+			// The computation error of the eigenvalue is zero.
+			werr[0] = 0
+			wgap[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+			gers[0] = d[0]
+			gers[1] = d[0]
+		}
+		isplit[0] = 0
+		// Store the shift for the initial RRR, which is zero in this
+		// case.
+		e[0] = 0
+		return wl, wu, 1, m, safmin, true
+	}
+
+	// General case: tridiagonal matrix of order > 1.
+
+	// Init werr, wgap. Compute Gerschgorin intervals and spectral
+	// diameter. Compute maximum off-diagonal entry and pivmin.
+	gl := d[0]
+	gu := d[0]
+	var eold, emax float64
+	e[n-1] = 0
+	for i := 0; i < n; i++ {
+		werr[i] = 0
+		wgap[i] = 0
+		eabs := math.Abs(e[i])
+		if eabs >= emax {
+			emax = eabs
+		}
+		tmp := eabs + eold
+		gers[2*i] = d[i] - tmp
+		gl = math.Min(gl, gers[2*i])
+		gers[2*i+1] = d[i] + tmp
+		gu = math.Max(gu, gers[2*i+1])
+		eold = eabs
+	}
+	// The minimum pivot allowed in the Sturm sequence for T.
+	pivmin = safmin * math.Max(1, emax*emax)
+	// Compute spectral diameter. The Gerschgorin bounds give an estimate
+	// that is wrong by at most a factor of sqrt(2).
+	spdiam := gu - gl
+
+	// Compute splitting points.
+	nsplit = impl.Dlarra(n, d, e, e2, spltol, spdiam, isplit)
+
+	// dqds is used for all eigenvalues, otherwise Dlarrd finds crude
+	// approximations to the eigenvalues in the desired range.
+	usedqd := rng == lapack.AllEigenvalues
+	var mm int
+	if usedqd {
+		// Set interval [vl,vu] that contains all eigenvalues.
+		wl = gl
+		wu = gu
+	} else {
+		// In case rng == lapack.IndexEigenvalues, we also obtain the
+		// interval (wl,wu] that contains all the wanted eigenvalues.
+		var conv bool
+		mm, wl, wu, conv = impl.Dlarrd(rng, n, vl, vu, il, iu, gers, bsrtol, d, e, e2, pivmin, nsplit, isplit, w, werr, iblock, indexw)
+		if !conv {
+			return wl, wu, nsplit, 0, pivmin, false
+		}
+		// Make sure that the entries mm to n-1 in w, werr, iblock,
+		// indexw are zero.
+		for i := mm; i < n; i++ {
+			w[i] = 0
+			werr[i] = 0
+			iblock[i] = -1
+			indexw[i] = 0
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+
+	// Loop over unreduced blocks.
+	ibegin := 0
+	wbegin := 0
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		// 1×1 block.
+		if in == 1 {
+			if rng == lapack.AllEigenvalues || (wbegin < mm && iblock[wbegin] == jblk) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				// The gap for a single block doesn't matter for
+				// the later algorithm and is assigned an
+				// arbitrary large value.
+				wgap[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+				wbegin++
+			}
+			// e[iend] holds the shift for the initial RRR.
+			e[iend] = 0
+			ibegin = iend + 1
+			continue
+		}
+
+		// Blocks of size larger than 1×1.
+
+		// e[iend] will hold the shift for the initial RRR, for now set it
+		// to zero.
+		e[iend] = 0
+
+		// Find local outer bounds gl, gu for the block.
+		gl = d[ibegin]
+		gu = d[ibegin]
+		for i := ibegin; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam = gu - gl
+
+		var mb, wend, indl, indu int
+		if rng != lapack.AllEigenvalues {
+			// Count the number of eigenvalues in the current block.
+			for i := wbegin; i < mm && iblock[i] == jblk; i++ {
+				mb++
+			}
+			if mb == 0 {
+				// No eigenvalue in the current block lies in the
+				// desired range.
+				ibegin = iend + 1
+				continue
+			}
+			// Decide whether dqds or bisection is more efficient.
+			usedqd = float64(mb) > fac*float64(in)
+			wend = wbegin + mb - 1
+			// Calculate gaps for the current block. In later stages,
+			// when representations for individual eigenvalues are
+			// different, we use sigma = e[iend].
+			for i := wbegin; i < wend; i++ {
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[wend] = math.Max(0, wu-(w[wend]+werr[wend]))
+			// Find local index of the first and last desired
+			// eigenvalue.
+			indl = indexw[wbegin]
+			indu = indexw[wend]
+		}
+
+		var isleft, isrght float64
+		if rng == lapack.AllEigenvalues || usedqd {
+			// Case of dqds. Find approximations to the extremal
+			// eigenvalues of the block.
+			tmp, tmp1, conv := impl.Dlarrk(in, 0, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !conv {
+				return wl, wu, nsplit, m, pivmin, false
+			}
+			isleft = math.Max(gl, tmp-tmp1-100*eps*math.Abs(tmp-tmp1))
+			tmp, tmp1, conv = impl.Dlarrk(in, in-1, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !conv {
+				return wl, wu, nsplit, m, pivmin, false
+			}
+			isrght = math.Min(gu, tmp+tmp1+100*eps*math.Abs(tmp+tmp1))
+			// Improve the estimate of the spectral diameter.
+			spdiam = isrght - isleft
+		} else {
+			// Case of bisection. Find approximations to the wanted
+			// extremal eigenvalues.
+			isleft = math.Max(gl, w[wbegin]-werr[wbegin]-100*eps*math.Abs(w[wbegin]-werr[wbegin]))
+			isrght = math.Min(gu, w[wend]+werr[wend]+100*eps*math.Abs(w[wend]+werr[wend]))
+		}
+
+		// Decide whether the base representation for the current block
+		//  L_jblk D_jblk L_jblk^T = T_jblk - sigma_jblk I
+		// should be on the left or the right end of the current block.
+		// The strategy is to shift to the end which is "more populated".
+		// Furthermore, decide whether to use dqds for the computation of
+		// the eigenvalue approximations at the end of Dlarre or
+		// bisection. dqds is chosen if all eigenvalues are desired or the
+		// number of eigenvalues to be computed is large compared to the
+		// block size.
+		var s1, s2 float64
+		if rng == lapack.AllEigenvalues {
+			// If all the eigenvalues have to be computed, we use dqd.
+			usedqd = true
+			// indl is the local index of the first eigenvalue to
+			// compute.
+			indl = 0
+			indu = in - 1
+			// mb is the number of eigenvalues to compute.
+			mb = in
+			wend = wbegin + mb - 1
+			// Define 1/4 and 3/4 points of the spectrum.
+			s1 = isleft + spdiam/4
+			s2 = isrght - spdiam/4
+		} else {
+			// Dlarrd has computed iblock and indexw for each
+			// eigenvalue approximation. Choose sigma.
+			if usedqd {
+				s1 = isleft + spdiam/4
+				s2 = isrght - spdiam/4
+			} else {
+				tmp := math.Min(isrght, wu) - math.Max(isleft, wl)
+				s1 = math.Max(isleft, wl) + tmp/4
+				s2 = math.Min(isrght, wu) - tmp/4
+			}
+		}
+
+		// Compute the negcount at the 1/4 and 3/4 points.
+		var cnt1, cnt2 int
+		if mb > 1 {
+			_, cnt1, cnt2 = impl.Dlarrc(in, s1, s2, d[ibegin:], e[ibegin:])
+		}
+
+		var sigma, sgndef float64
+		switch {
+		case mb == 1:
+			sigma = gl
+			sgndef = 1
+		case cnt1-indl-1 >= indu-cnt2+1:
+			// The local indices are 0-based while the counts are
+			// not, so the comparison is the same as in the 1-based
+			// reference implementation.
+			switch {
+			case rng == lapack.AllEigenvalues:
+				sigma = math.Max(isleft, gl)
+			case usedqd:
+				// Use Gerschgorin bound as shift to get pos def
+				// matrix for dqds.
+				sigma = isleft
+			default:
+				// Use approximation of the first desired
+				// eigenvalue of the block as shift.
+				sigma = math.Max(isleft, wl)
+			}
+			sgndef = 1
+		default:
+			switch {
+			case rng == lapack.AllEigenvalues:
+				sigma = math.Min(isrght, gu)
+			case usedqd:
+				// Use Gerschgorin bound as shift to get neg def
+				// matrix for dqds.
+				sigma = isrght
+			default:
+				// Use approximation of the first desired
+				// eigenvalue of the block as shift.
+				sigma = math.Min(isrght, wu)
+			}
+			sgndef = -1
+		}
+
+		// An initial sigma has been chosen that will be used for
+		// computing T - sigma I = L D L^T. Define the increment tau of
+		// the shift in case the initial shift needs to be refined to
+		// obtain a factorization with not too much element growth.
+		var tau float64
+		if usedqd {
+			// The initial sigma was to the outer end of the spectrum
+			// the matrix is definite and we need not retreat.
+			tau = spdiam*eps*float64(n) + 2*pivmin
+			tau = math.Max(tau, 2*eps*math.Abs(sigma))
+		} else {
+			if mb > 1 {
+				clwdth := w[wend] + werr[wend] - w[wbegin] - werr[wbegin]
+				avgap := math.Abs(clwdth / float64(wend-wbegin))
+				if sgndef == 1 {
+					tau = math.Max(wgap[wbegin], avgap) / 2
+					tau = math.Max(tau, werr[wbegin])
+				} else {
+					tau = math.Max(wgap[wend-1], avgap) / 2
+					tau = math.Max(tau, werr[wend])
+				}
+			} else {
+				tau = werr[wbegin]
+			}
+		}
+
+		// Compute L D L^T factorization of tridiagonal matrix
+		// T - sigma I. Store D in work[:in], L in work[in:2*in].
+		found := false
+		for idum := 0; idum < maxtry; idum++ {
+			dpivot := d[ibegin] - sigma
+			work[0] = dpivot
+			dmax := math.Abs(work[0])
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				tmp := e[j] / work[i]
+				work[in+i] = tmp
+				dpivot = (d[j+1] - sigma) - tmp*e[j]
+				work[i+1] = dpivot
+				dmax = math.Max(dmax, math.Abs(dpivot))
+				j++
+			}
+			// Check for element growth.
+			norep := dmax > maxgrowth*spdiam
+			if usedqd && !norep {
+				// Ensure the definiteness of the representation.
+				// All entries of D (of L D L^T) must have the
+				// same sign.
+				for i := 0; i < in; i++ {
+					if sgndef*work[i] < 0 {
+						norep = true
+					}
+				}
+			}
+			if !norep {
+				// An initial RRR is found.
+				found = true
+				break
+			}
+			// Note that in the case of rng == lapack.AllEigenvalues,
+			// we use the Gerschgorin shift which makes the matrix
+			// definite. So we should end up here really only in the
+			// case of the other ranges.
+			if idum == maxtry-2 {
+				if sgndef == 1 {
+					// The fudged Gerschgorin shift should
+					// succeed.
+					sigma = gl - fudge*spdiam*eps*float64(n) - fudge*2*pivmin
+				} else {
+					sigma = gu + fudge*spdiam*eps*float64(n) + fudge*2*pivmin
+				}
+			} else {
+				sigma -= sgndef * tau
+				tau *= 2
+			}
+		}
+		if !found {
+			// No base representation could be found in maxtry
+			// iterations.
+			return wl, wu, nsplit, m, pivmin, false
+		}
+
+		// At this point, we have found an initial base representation
+		// T - sigma I = L D L^T with not too much element growth. Store
+		// the shift, D and L.
+		e[iend] = sigma
+		copy(d[ibegin:ibegin+in], work[:in])
+		copy(e[ibegin:ibegin+in-1], work[in:2*in-1])
+
+		if mb > 1 {
+			// Perturb each entry of the base representation by a
+			// small multiple of its size.
+			for i := 0; i < 2*in-1; i++ {
+				work[i] = 2*rnd.Float64() - 1
+			}
+			for i := 0; i < in-1; i++ {
+				d[ibegin+i] *= 1 + eps*pert*work[i]
+				e[ibegin+i] *= 1 + eps*pert*work[in+i]
+			}
+			d[iend] *= 1 + eps*4*work[in-1]
+		}
+
+		// Don't update the Gerschgorin intervals because keeping track
+		// of the updates would be too much work in Dlarrv. We update w
+		// instead and use it to locate the proper Gerschgorin intervals.
+
+		// Compute the required eigenvalues of L D L^T by bisection or
+		// dqds.
+		if !usedqd {
+			// If Dlarrd has been used, shift the eigenvalue
+			// approximations according to their representation. This
+			// is necessary for a uniform Dlarrv since dqds computes
+			// eigenvalues of the shifted representation. In Dlarrv, w
+			// will always hold the unshifted eigenvalue approximation.
+			for j := wbegin; j <= wend; j++ {
+				w[j] -= sigma
+				werr[j] += math.Abs(w[j]) * eps
+			}
+			// Call Dlarrb to reduce eigenvalue error of the
+			// approximations from Dlarrd.
+			for i := ibegin; i < iend; i++ {
+				work[i] = d[i] * e[i] * e[i]
+			}
+			// Use bisection to find the eigenvalues from indl to
+			// indu.
+			impl.Dlarrb(in, d[ibegin:], work[ibegin:], indl, indu, rtol1, rtol2, indl,
+				w[wbegin:], wgap[wbegin:], werr[wbegin:], pivmin, spdiam, in-1)
+			// Dlarrb computes all gaps correctly except for the last
+			// one. Record distance to wu.
+			wgap[wend] = math.Max(0, (wu-sigma)-(w[wend]+werr[wend]))
+			for i := indl; i <= indu; i++ {
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+		} else {
+			// Call dqds to get all eigenvalues (and then possibly
+			// delete unwanted eigenvalues). Note that dqds finds the
+			// eigenvalues of the L D L^T representation of T to high
+			// relative accuracy. High relative accuracy might be lost
+			// when the shift of the RRR is subtracted to obtain the
+			// eigenvalues of T. However, T is not guaranteed to define
+			// its eigenvalues to high relative accuracy anyway.
+			//
+			// Set rtol to the order of the tolerance used in Dlasq2.
+			// This is an estimated error, the worst case bound is
+			// 4*n*eps which is usually too large and requires
+			// unnecessary work to be done by bisection when computing
+			// the eigenvectors.
+			rtol := math.Log(float64(in)) * 4 * eps
+			j := ibegin
+			for i := 0; i < in-1; i++ {
+				work[2*i] = math.Abs(d[j])
+				work[2*i+1] = e[j] * e[j] * work[2*i]
+				j++
+			}
+			work[2*in-2] = math.Abs(d[iend])
+			work[2*in-1] = 0
+			if impl.Dlasq2(in, work) != 0 {
+				return wl, wu, nsplit, m, pivmin, false
+			}
+			// Test that all eigenvalues are positive as expected.
+			for i := 0; i < in; i++ {
+				if work[i] < 0 {
+					return wl, wu, nsplit, m, pivmin, false
+				}
+			}
+			if sgndef > 0 {
+				for i := indl; i <= indu; i++ {
+					w[m] = work[in-i-1]
+					iblock[m] = jblk
+					indexw[m] = i
+					m++
+				}
+			} else {
+				for i := indl; i <= indu; i++ {
+					w[m] = -work[i]
+					iblock[m] = jblk
+					indexw[m] = i
+					m++
+				}
+			}
+			for i := m - mb; i < m; i++ {
+				// The value of rtol below should be the tolerance
+				// in Dlasq2.
+				werr[i] = rtol * math.Abs(w[i])
+			}
+			for i := m - mb; i < m-1; i++ {
+				// Compute the right gap between the intervals.
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[m-1] = math.Max(0, (wu-sigma)-(w[m-1]+werr[m-1]))
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return wl, wu, nsplit, m, pivmin, true
+}
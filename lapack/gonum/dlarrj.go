@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlarrj refines, by bisection, the initial eigenvalue approximations of the
+// n×n symmetric tridiagonal matrix T with diagonal d and squared off-diagonal
+// elements e2, until they are converged to a relative width of rtol.
+//
+// The eigenvalues with (0-based) indices ifirst through ilast are refined.
+// On entry, w[i-offset] and werr[i-offset] contain an approximation of the
+// i-th eigenvalue and an estimate of its error. On exit they contain the
+// refined approximation and error. pivmin is the minimum pivot in the Sturm
+// sequence and spdiam is the spectral diameter of T.
+//
+// Dlarrj is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrj(n int, d, e2 []float64, ifirst, ilast int, rtol float64, offset int, w, werr []float64, pivmin, spdiam float64) {
+	if n <= 0 {
+		return
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e2) < n-1 {
+		panic(badE)
+	}
+	if ifirst < 0 || ilast < ifirst-1 || n <= ilast {
+		panic(badIlo)
+	}
+	if len(w) < ilast-offset+1 || len(werr) < ilast-offset+1 {
+		panic(badSlice)
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	// count returns the number of negative pivots of T - s*I.
+	count := func(s float64) int {
+		var cnt int
+		dplus := d[0] - s
+		if dplus < 0 {
+			cnt++
+		}
+		for j := 1; j < n; j++ {
+			dplus = d[j] - s - e2[j-1]/dplus
+			if dplus < 0 {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	// The intervals are independent of each other, so each one is refined
+	// separately until it converges or the maximum number of iterations is
+	// reached.
+	for i := ifirst; i <= ilast; i++ {
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		width := right - w[ii]
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		if width < rtol*tmp {
+			// This interval has already converged and does not need
+			// refinement.
+			continue
+		}
+
+		// Make sure that [left,right] contains the desired eigenvalue.
+		fac := 1.0
+		for count(left) > i {
+			left -= werr[ii] * fac
+			fac *= 2
+		}
+		fac = 1
+		for count(right) <= i {
+			right += werr[ii] * fac
+			fac *= 2
+		}
+
+		for iter := 0; ; iter++ {
+			mid := (left + right) / 2
+			width = right - mid
+			tmp = math.Max(math.Abs(left), math.Abs(right))
+			if width < rtol*tmp || iter == maxitr {
+				break
+			}
+			// Perform one bisection step.
+			if count(mid) <= i {
+				left = mid
+			} else {
+				right = mid
+			}
+		}
+		w[ii] = (left + right) / 2
+		werr[ii] = right - w[ii]
+	}
+}
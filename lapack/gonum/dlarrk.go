@@ -0,0 +1,88 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlarrk computes one eigenvalue of the n×n symmetric tridiagonal matrix T to
+// suitable accuracy using bisection. The index iw of the desired eigenvalue
+// is 0-based, with the eigenvalues ordered from smallest to largest.
+//
+// gl and gu are lower and upper bounds on the spectrum of T, d contains the
+// n diagonal elements of T and e2 contains the n-1 squared off-diagonal
+// elements. pivmin is the minimum pivot allowed in the Sturm sequence and
+// reltol is the minimum relative width of an interval.
+//
+// Dlarrk returns the eigenvalue approximation w, the error bound werr on w
+// and whether the bisection converged.
+//
+// Dlarrk is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrk(n, iw int, gl, gu float64, d, e2 []float64, pivmin, reltol float64) (w, werr float64, ok bool) {
+	const fudge = 2
+
+	if n <= 0 {
+		return 0, 0, true
+	}
+	if iw < 0 || n <= iw {
+		panic("lapack: iw out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e2) < n-1 {
+		panic(badE)
+	}
+
+	eps := dlamchP
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	rtoli := reltol
+	atoli := fudge * 2 * pivmin
+	itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	left := gl - fudge*tnorm*eps*float64(n) - fudge*2*pivmin
+	right := gu + fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	for it := 0; ; it++ {
+		// Check if interval converged or maximum number of iterations
+		// reached.
+		tmp1 := math.Abs(right - left)
+		tmp2 := math.Max(math.Abs(right), math.Abs(left))
+		if tmp1 < math.Max(atoli, math.Max(pivmin, rtoli*tmp2)) {
+			ok = true
+			break
+		}
+		if it > itmax {
+			break
+		}
+
+		// Count number of negative pivots for mid-point.
+		mid := (left + right) / 2
+		var negcnt int
+		tmp1 = d[0] - mid
+		if math.Abs(tmp1) < pivmin {
+			tmp1 = -pivmin
+		}
+		if tmp1 <= 0 {
+			negcnt++
+		}
+		for i := 1; i < n; i++ {
+			tmp1 = d[i] - e2[i-1]/tmp1 - mid
+			if math.Abs(tmp1) < pivmin {
+				tmp1 = -pivmin
+			}
+			if tmp1 <= 0 {
+				negcnt++
+			}
+		}
+		if negcnt > iw {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+
+	// Converged or maximum number of iterations reached.
+	return (left + right) / 2, math.Abs(right-left) / 2, ok
+}
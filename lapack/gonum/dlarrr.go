@@ -0,0 +1,51 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlarrr performs tests to decide whether the n×n symmetric tridiagonal
+// matrix T with diagonal d and off-diagonal e warrants expensive computations
+// which guarantee high relative accuracy in the eigenvalues. It returns true
+// if T is scaled diagonally dominant.
+//
+// Dlarrr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrr(n int, d, e []float64) bool {
+	const relcond = 0.999
+
+	if n <= 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	rmin := math.Sqrt(dlamchS / dlamchP)
+
+	// Test for scaled diagonal dominance. Let T = D H D be the scaling of T
+	// by D = diag(sqrt(|d|)) and test whether the off-diagonal elements of
+	// H are small enough so that H is diagonally dominant.
+	var offdig float64
+	tmp := math.Sqrt(math.Abs(d[0]))
+	if tmp < rmin {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		tmp2 := math.Sqrt(math.Abs(d[i]))
+		if tmp2 < rmin {
+			return false
+		}
+		offdig2 := math.Abs(e[i-1]) / (tmp * tmp2)
+		if offdig+offdig2 >= relcond {
+			return false
+		}
+		tmp = tmp2
+		offdig = offdig2
+	}
+	return true
+}
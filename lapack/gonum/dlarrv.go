@@ -0,0 +1,636 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// Dlarrv computes the eigenvectors of the n×n symmetric tridiagonal matrix
+//  T = L * D * L^T
+// given L, D and approximations to the eigenvalues of L*D*L^T. This is an
+// auxiliary code to be called from Dstemr.
+//
+// The input eigenvalues should have been computed by Dlarre. vl and vu are
+// lower and upper bounds of the interval that contains the desired
+// eigenvalues.
+//
+// On entry, d contains the n diagonal elements of D and l the n-1 subdiagonal
+// elements of the unit bidiagonal matrix L, with l[isplit[j]] containing the
+// shift of the j-th block's base representation. d and l are overwritten on
+// return. pivmin is the minimum pivot allowed in the Sturm sequence.
+//
+// isplit contains the splitting points as computed by Dlarra and m is the
+// total number of input eigenvalues.
+//
+// minrgp is the minimum relative gap between eigenvalues for which the
+// eigenvectors are computed separately. rtol1 and rtol2 are the tolerances
+// for the bisection.
+//
+// On entry, the first m elements of w contain the eigenvalue approximations
+// of the base representations, werr their error bounds and wgap the
+// separation from the right neighbor. On exit, w contains the eigenvalues of
+// the unshifted matrix T and werr and wgap are refined. iblock, indexw and
+// gers are as computed by Dlarre.
+//
+// On return, the first m columns of the n×m matrix Z contain the orthonormal
+// eigenvectors, the i-th column corresponding to w[i]. isuppz contains the
+// (0-based) support of the eigenvectors, the nonzero elements of the i-th
+// eigenvector are in rows isuppz[2*i] through isuppz[2*i+1]. isuppz must have
+// length at least 2*m.
+//
+// work must have length at least 12*n and iwork at least 7*n.
+//
+// Dlarrv returns whether all eigenvectors were computed successfully.
+//
+// Dlarrv is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrv(n int, vl, vu float64, d, l []float64, pivmin float64, isplit []int, m int, minrgp, rtol1, rtol2 float64, w, werr, wgap []float64, iblock, indexw []int, gers, z []float64, ldz int, isuppz []int, work []float64, iwork []int) (ok bool) {
+	const maxitr = 10
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if m < 0 || n < m {
+		panic("lapack: m out of range")
+	}
+	if n == 0 || m == 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(l) < n {
+		panic(badSlice)
+	}
+	if len(isplit) < n || len(w) < m || len(werr) < m || len(wgap) < m || len(iblock) < m || len(indexw) < m {
+		panic(badSlice)
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	checkMatrix(n, m, z, ldz)
+	if len(isuppz) < 2*m {
+		panic(badSlice)
+	}
+	if len(work) < 12*n {
+		panic(badWork)
+	}
+	if len(iwork) < 7*n {
+		panic(badWork)
+	}
+
+	// The first n entries of work are reserved for the eigenvalues.
+	indld := n
+	indlld := 2 * n
+	indwrk := 3 * n
+	indz := 7 * n
+	inddpl := 8 * n
+	indlpl := 9 * n
+	for i := 0; i < 12*n; i++ {
+		work[i] = 0
+	}
+
+	// iwork[iindr:iindr+n] hold the twist indices r for the factorization
+	// used to compute the FP vector. iwork[iindc1:iindc1+2*n] and
+	// iwork[iindc2:iindc2+2*n] are used to store the clusters of the
+	// current layer and the one above.
+	iindr := 0
+	iindc1 := n
+	iindc2 := 3 * n
+	for i := 0; i < n; i++ {
+		iwork[iindr+i] = -1
+	}
+
+	impl.Dlaset(blas.All, n, m, 0, 0, z, ldz)
+
+	eps := dlamchP
+	rqtol := 2 * eps
+
+	// The entries wbegin:wend+1 in w, werr, wgap correspond to the desired
+	// eigenvalues. The support of the nonzero eigenvector entries is
+	// contained in the interval ibegin:iend+1. Remark that if k eigenpairs
+	// are desired, then the eigenvectors are stored in k contiguous columns
+	// of Z.
+	ibegin := 0
+	wbegin := 0
+	for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+		iend := isplit[jblk]
+		sigma := l[iend]
+		// Find the eigenvectors of the submatrix indexed ibegin through
+		// iend.
+		wend := wbegin - 1
+		for wend < m-1 && iblock[wend+1] == jblk {
+			wend++
+		}
+		if wend < wbegin {
+			ibegin = iend + 1
+			continue
+		}
+
+		// Find local spectral diameter of the block.
+		gl := gers[2*ibegin]
+		gu := gers[2*ibegin+1]
+		for i := ibegin + 1; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam := gu - gl
+
+		// oldien is the last index of the previous block.
+		oldien := ibegin - 1
+		// Calculate the size of the current block.
+		in := iend - ibegin + 1
+		// The number of eigenvalues in the current block.
+		im := wend - wbegin + 1
+
+		// This is for a 1×1 block.
+		if ibegin == iend {
+			z[ibegin*ldz+wbegin] = 1
+			isuppz[2*wbegin] = ibegin
+			isuppz[2*wbegin+1] = ibegin
+			w[wbegin] += sigma
+			work[wbegin] = w[wbegin]
+			ibegin = iend + 1
+			wbegin++
+			continue
+		}
+
+		// The desired (shifted) eigenvalues are stored in
+		// w[wbegin:wend+1]. Note that these can be approximations, in
+		// this case, the corresponding entries of werr give the size of
+		// the uncertainty interval. The eigenvalue approximations will be
+		// refined when necessary as high relative accuracy is required
+		// for the computation of the corresponding eigenvectors.
+		copy(work[wbegin:wbegin+im], w[wbegin:wbegin+im])
+
+		// We store in w the eigenvalue approximations with respect to
+		// the original matrix T.
+		for i := 0; i < im; i++ {
+			w[wbegin+i] += sigma
+		}
+
+		// ndepth is the current depth of the representation tree.
+		ndepth := 0
+		// parity is either 1 or 0.
+		parity := 1
+		// nclus is the number of clusters for the next level of the
+		// representation tree, we start with nclus = 1 for the root.
+		nclus := 1
+		iwork[iindc1] = 0
+		iwork[iindc1+1] = im - 1
+
+		// idone is the number of eigenvectors already computed in the
+		// current block.
+		idone := 0
+		// Generate the representation tree for the current block and
+		// compute the eigenvectors.
+		for idone < im {
+			// This is a crude protection against infinitely deep
+			// trees.
+			if ndepth > m {
+				return false
+			}
+			// Breadth first processing of the current level of the
+			// representation tree: oldncl is the number of clusters
+			// on the current level.
+			oldncl := nclus
+			// Reset nclus to count the number of child clusters.
+			nclus = 0
+
+			parity = 1 - parity
+			var oldcls, newcls int
+			if parity == 0 {
+				oldcls = iindc1
+				newcls = iindc2
+			} else {
+				oldcls = iindc2
+				newcls = iindc1
+			}
+			// Process the clusters on the current level.
+			for i := 0; i < oldncl; i++ {
+				// oldfst, oldlst are the first and the last index
+				// of the current cluster. Cluster indices start
+				// with 0 and are relative to wbegin when accessing
+				// w, wgap, werr and Z.
+				oldfst := iwork[oldcls+2*i]
+				oldlst := iwork[oldcls+2*i+1]
+				if ndepth > 0 {
+					// Retrieve relatively robust representation
+					// (RRR) of cluster that has been computed at
+					// the previous level. The RRR is stored in Z
+					// at the location of the leftmost eigenvalue
+					// of the cluster and overwritten once the
+					// eigenvectors have been computed or when
+					// the cluster is refined.
+					j := wbegin + oldfst
+					for k := 0; k < in; k++ {
+						d[ibegin+k] = z[(ibegin+k)*ldz+j]
+					}
+					for k := 0; k < in-1; k++ {
+						l[ibegin+k] = z[(ibegin+k)*ldz+j+1]
+					}
+					sigma = z[iend*ldz+j+1]
+					// Set the corresponding entries in Z to zero.
+					impl.Dlaset(blas.All, in, 2, 0, 0, z[ibegin*ldz+j:], ldz)
+				}
+
+				// Compute dl and dll of current RRR.
+				for j := ibegin; j < iend; j++ {
+					tmp := d[j] * l[j]
+					work[indld+j] = tmp
+					work[indlld+j] = tmp * l[j]
+				}
+
+				if ndepth > 0 {
+					// p and q are the indices of the first and
+					// last eigenvalue to compute within the
+					// current block.
+					p := indexw[wbegin+oldfst]
+					q := indexw[wbegin+oldlst]
+					// Offset for the arrays work, wgap and werr,
+					// i.e., the p-offset through the q-offset
+					// elements of these arrays are to be used.
+					offset := indexw[wbegin]
+					// Perform limited bisection (if necessary) to
+					// get approximate eigenvalues to the
+					// precision needed.
+					impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], p, q, rtol1, rtol2, offset,
+						work[wbegin:], wgap[wbegin:], werr[wbegin:], pivmin, spdiam, in-1)
+					// We also recompute the extremal gaps. w holds
+					// all eigenvalues of the unshifted matrix and
+					// must be used for computation of wgap, the
+					// entries of work might stem from RRRs with
+					// different shifts. The gaps from
+					// wbegin+oldfst to wbegin+oldlst are correctly
+					// computed in Dlarrb. However, we only allow
+					// the gaps to become greater since this is
+					// what should happen when we decrease werr.
+					if oldfst > 0 {
+						wgap[wbegin+oldfst-1] = math.Max(wgap[wbegin+oldfst-1],
+							w[wbegin+oldfst]-werr[wbegin+oldfst]-w[wbegin+oldfst-1]-werr[wbegin+oldfst-1])
+					}
+					if wbegin+oldlst < wend {
+						wgap[wbegin+oldlst] = math.Max(wgap[wbegin+oldlst],
+							w[wbegin+oldlst+1]-werr[wbegin+oldlst+1]-w[wbegin+oldlst]-werr[wbegin+oldlst])
+					}
+					// Each time the eigenvalues in work get
+					// refined, we store the newly found
+					// approximation with all shifts applied in w.
+					for j := oldfst; j <= oldlst; j++ {
+						w[wbegin+j] = work[wbegin+j] + sigma
+					}
+				}
+
+				// Process the current node.
+				newfst := oldfst
+				for j := oldfst; j <= oldlst; j++ {
+					var newlst int
+					if j == oldlst {
+						// We are at the right end of the
+						// cluster, this is also the boundary of
+						// the child cluster.
+						newlst = j
+					} else if wgap[wbegin+j] >= minrgp*math.Abs(work[wbegin+j]) {
+						// The right relative gap is big enough,
+						// the child cluster (newfst,..,newlst)
+						// is well separated from the following.
+						newlst = j
+					} else {
+						// Inside a child cluster, the relative
+						// gap is not big enough.
+						continue
+					}
+
+					// Compute size of child cluster found.
+					newsiz := newlst - newfst + 1
+
+					// newftt is the place in Z where the new RRR
+					// or the computed eigenvector is to be stored.
+					newftt := wbegin + newfst
+
+					if newsiz > 1 {
+						// Current child is not a singleton but a
+						// cluster. Compute and store new
+						// representation of child.
+
+						// Compute left and right cluster gap.
+						//
+						// lgap and rgap are not computed from
+						// work because the eigenvalue
+						// approximations may stem from RRRs
+						// different shifts. However, w hold all
+						// eigenvalues of the unshifted matrix.
+						// Still, the entries in wgap have to be
+						// computed from work since the entries in
+						// w might be of the same order so that
+						// gaps are not exhibited correctly for
+						// very close eigenvalues.
+						var lgap float64
+						if newfst == 0 {
+							lgap = math.Max(0, w[wbegin]-werr[wbegin]-vl)
+						} else {
+							lgap = wgap[wbegin+newfst-1]
+						}
+						rgap := wgap[wbegin+newlst]
+
+						// Compute left- and rightmost eigenvalue
+						// of child to high precision in order to
+						// shift as close as possible and obtain as
+						// large relative gaps as possible.
+						offset := indexw[wbegin]
+						for _, p := range []int{indexw[wbegin+newfst], indexw[wbegin+newlst]} {
+							impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], p, p, rqtol, rqtol, offset,
+								work[wbegin:], wgap[wbegin:], werr[wbegin:], pivmin, spdiam, in-1)
+						}
+
+						// Compute RRR of child cluster. The new
+						// RRR is stored in Z.
+						tau, found := impl.Dlarrf(in, d[ibegin:], l[ibegin:], work[indld+ibegin:],
+							newfst, newlst, work[wbegin:], wgap[wbegin:], werr[wbegin:],
+							spdiam, lgap, rgap, pivmin, work[inddpl:], work[indlpl:], work[indwrk:])
+						if !found {
+							return false
+						}
+						// A new RRR for the cluster was found by
+						// Dlarrf. Store it and update the shift.
+						for k := 0; k < in; k++ {
+							z[(ibegin+k)*ldz+newftt] = work[inddpl+k]
+						}
+						for k := 0; k < in-1; k++ {
+							z[(ibegin+k)*ldz+newftt+1] = work[indlpl+k]
+						}
+						z[iend*ldz+newftt+1] = sigma + tau
+						// work contains the midpoints and werr the
+						// semi-widths. Note that the entries in w
+						// are unchanged.
+						for k := newfst; k <= newlst; k++ {
+							fudge := 3 * eps * math.Abs(work[wbegin+k])
+							work[wbegin+k] -= tau
+							fudge += 4 * eps * math.Abs(work[wbegin+k])
+							// Fudge errors.
+							werr[wbegin+k] += fudge
+							// Gaps are not fudged. Provided that
+							// werr is small when eigenvalues are
+							// close, a zero gap indicates that a
+							// new representation is needed for
+							// resolving the cluster. A fudge could
+							// lead to a wrong decision of judging
+							// eigenvalues 'separated' which in
+							// reality are not. This could have a
+							// negative impact on the orthogonality
+							// of the computed eigenvectors.
+						}
+
+						iwork[newcls+2*nclus] = newfst
+						iwork[newcls+2*nclus+1] = newlst
+						nclus++
+					} else {
+						// Compute eigenvector of singleton.
+						iter := 0
+						tol := 4 * math.Log(float64(in)) * eps
+
+						k := newfst
+						windex := wbegin + k
+						windmn := max(windex-1, 0)
+						windpl := min(windex+1, m-1)
+						lambda := work[windex]
+						left := work[windex] - werr[windex]
+						right := work[windex] + werr[windex]
+						indeig := indexw[windex]
+						// Note that since we compute the eigenpairs
+						// for a child, all eigenvalue
+						// approximations are with respect to the
+						// same shift. In this case, the entries in
+						// work should be used for computing the
+						// gaps since they exhibit even very small
+						// differences in the eigenvalues, as
+						// opposed to the entries in w which might
+						// "look" the same.
+						var lgap, rgap float64
+						if k == 0 {
+							// In the case of an index range and
+							// with not much initial accuracy in
+							// lambda and vl, the formula
+							//  lgap = max(0, (sigma - vl) + lambda)
+							// can lead to an overestimation of
+							// the left gap and thus to
+							// inadequately early RQI
+							// 'convergence'. Prevent this by
+							// forcing a small left gap.
+							lgap = eps * math.Max(math.Abs(left), math.Abs(right))
+						} else {
+							lgap = wgap[windmn]
+						}
+						if k == im-1 {
+							// In the case of an index range and
+							// with not much initial accuracy in
+							// lambda and vu, the formula can lead
+							// to an overestimation of the right
+							// gap and thus to inadequately early
+							// RQI 'convergence'. Prevent this by
+							// forcing a small right gap.
+							rgap = eps * math.Max(math.Abs(left), math.Abs(right))
+						} else {
+							rgap = wgap[windex]
+						}
+						gap := math.Min(lgap, rgap)
+						var gaptol float64
+						if k != 0 && k != im-1 {
+							// The eigenvector support can become
+							// wrong because significant entries
+							// could be cut off due to a large
+							// gaptol parameter in Dlar1v. This is
+							// prevented for the extremal
+							// eigenvalues by a zero gaptol.
+							gaptol = gap * eps
+						}
+						// Update wgap so that it holds the minimum
+						// gap to the left or the right. This is
+						// crucial in the case where bisection is
+						// used to ensure that the eigenvalue is
+						// refined up to the required precision. The
+						// correct value is restored afterwards.
+						savgap := wgap[windex]
+						wgap[windex] = gap
+						// We want to use the Rayleigh Quotient
+						// Correction as often as possible since it
+						// converges quadratically when we are close
+						// enough to the desired eigenvalue. However,
+						// the Rayleigh Quotient can have the wrong
+						// sign and lead us away from the desired
+						// eigenvalue. In this case, the best we can
+						// do is to use bisection.
+						usedbs := false
+						usedrq := false
+						// Bisection is initially turned off.
+						needbs := false
+						zv := work[indz : indz+in]
+						var (
+							bstres, bstw        float64
+							negcnt              int
+							nrminv, resid, rqcr float64
+						)
+						for {
+							// Check if bisection should be used
+							// to refine the eigenvalue.
+							if needbs {
+								// Take the bisection as new
+								// iterate.
+								usedbs = true
+								offset := indexw[wbegin]
+								impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], indeig, indeig, 0, 2*eps, offset,
+									work[wbegin:], wgap[wbegin:], werr[wbegin:], pivmin, spdiam, iwork[iindr+windex])
+								lambda = work[windex]
+								// Reset twist index from
+								// inaccurate lambda to force
+								// computation of true mingma.
+								iwork[iindr+windex] = -1
+							}
+							// Given lambda, compute the
+							// eigenvector.
+							negcnt, _, _, iwork[iindr+windex], nrminv, resid, rqcr = impl.Dlar1v(in, 0, in-1, lambda,
+								d[ibegin:], l[ibegin:], work[indld+ibegin:], work[indlld+ibegin:],
+								pivmin, gaptol, zv, !usedbs, iwork[iindr+windex], isuppz[2*windex:], work[indwrk:])
+							if iter == 0 || resid < bstres {
+								bstres = resid
+								bstw = lambda
+							}
+							iter++
+
+							// sin alpha <= |resid|/gap
+							// Note that both the residual and the
+							// gap are proportional to the matrix,
+							// so ||T|| doesn't play a role in the
+							// quotient.
+
+							// Convergence test for Rayleigh
+							// Quotient iteration (omitted if
+							// bisection has been used).
+							if resid > tol*gap && math.Abs(rqcr) > rqtol*math.Abs(lambda) && !usedbs {
+								// We need to check that the
+								// rqcorr update doesn't move the
+								// eigenvalue away from the
+								// desired one and towards a
+								// neighbor. -> protection with
+								// bisection.
+								var sgndef float64
+								if indeig < negcnt {
+									// The wanted eigenvalue
+									// lies to the left.
+									sgndef = -1
+								} else {
+									// The wanted eigenvalue
+									// lies to the right.
+									sgndef = 1
+								}
+								// We only use the rqcorr if it
+								// improves the iterate
+								// reasonably.
+								if rqcr*sgndef >= 0 && lambda+rqcr <= right && lambda+rqcr >= left {
+									usedrq = true
+									// Store new midpoint of
+									// bisection interval in
+									// work.
+									if sgndef == 1 {
+										// The current lambda
+										// is on the left of
+										// the true eigenvalue.
+										left = lambda
+									} else {
+										// The current lambda
+										// is on the right of
+										// the true eigenvalue.
+										right = lambda
+									}
+									work[windex] = (right + left) / 2
+									// Take rqcorr since it has
+									// the correct sign and
+									// improves the iterate
+									// reasonably.
+									lambda += rqcr
+									// Update width of error
+									// interval.
+									werr[windex] = (right - left) / 2
+								} else {
+									needbs = true
+								}
+								if right-left < rqtol*math.Abs(lambda) {
+									// The eigenvalue is
+									// computed to bisection
+									// accuracy compute
+									// eigenvector and stop.
+									usedbs = true
+									continue
+								}
+								if iter < maxitr {
+									continue
+								}
+								if iter == maxitr {
+									needbs = true
+									continue
+								}
+								return false
+							}
+							if usedrq && usedbs && bstres <= resid {
+								// Improve error angle by
+								// second step.
+								lambda = bstw
+								_, _, _, iwork[iindr+windex], nrminv, _, _ = impl.Dlar1v(in, 0, in-1, lambda,
+									d[ibegin:], l[ibegin:], work[indld+ibegin:], work[indlld+ibegin:],
+									pivmin, gaptol, zv, !usedbs, iwork[iindr+windex], isuppz[2*windex:], work[indwrk:])
+							}
+							work[windex] = lambda
+							break
+						}
+
+						// Compute FP-vector support with respect
+						// to the whole matrix and store the
+						// normalized vector in Z, making sure the
+						// entries outside the final support are
+						// zero in case the support has changed in
+						// the RQI.
+						zfrom := isuppz[2*windex]
+						zto := isuppz[2*windex+1]
+						for ii := 0; ii < in; ii++ {
+							v := 0.0
+							if zfrom <= ii && ii <= zto {
+								v = nrminv * zv[ii]
+							}
+							z[(ibegin+ii)*ldz+windex] = v
+						}
+						isuppz[2*windex] = zfrom + oldien + 1
+						isuppz[2*windex+1] = zto + oldien + 1
+						// Update w.
+						w[windex] = lambda + sigma
+						// Recompute the gaps on the left and
+						// right. But only allow them to become
+						// larger and not smaller (which can only
+						// happen through "bad" cancellation and
+						// doesn't reflect the theory where the
+						// initial gaps are underestimated due to
+						// werr being too crude.)
+						if k > 0 {
+							wgap[windmn] = math.Max(wgap[windmn], w[windex]-werr[windex]-w[windmn]-werr[windmn])
+						}
+						if windex < wend {
+							wgap[windex] = math.Max(savgap, w[windpl]-werr[windpl]-w[windex]-werr[windex])
+						}
+						idone++
+					}
+					// Here ends the code for the current child.
+
+					// Proceed to any remaining child nodes.
+					newfst = j + 1
+				}
+			}
+			ndepth++
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return true
+}
@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dlasd0 computes, using a divide and conquer approach, the singular value
+// decomposition of a real upper bidiagonal n×m matrix B with diagonal d and
+// off-diagonal e, where m = n+sqre. The SVD of B has the form
+//  B = U * S * VT
+// where S is an n×m diagonal matrix with non-negative diagonal elements.
+//
+// On exit, d contains the singular values of B in ascending order and e is
+// overwritten.
+//
+// U must be n×n and VT must be m×m. On entry they must contain the identity
+// matrix, and on exit they contain the left singular vectors and the
+// transposed right singular vectors respectively.
+//
+// smlsiz is the maximum size of the subproblems at the bottom of the
+// computation tree.
+//
+// iwork must have length at least 8*n and work must have length at least
+// 3*m*m + 2*m.
+//
+// Dlasd0 returns whether all the singular values converged.
+//
+// Dlasd0 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd0(n, sqre int, d, e, u []float64, ldu int, vt []float64, ldvt, smlsiz int, iwork []int, work []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: bad sqre")
+	}
+	if smlsiz < 3 {
+		panic("lapack: smlsiz < 3")
+	}
+	m := n + sqre
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(m, m, vt, ldvt)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < m-1 {
+		panic(badE)
+	}
+	if len(iwork) < 8*n {
+		panic(badWork)
+	}
+	if len(work) < 3*m*m+2*m {
+		panic(badWork)
+	}
+
+	// If the input matrix is too small, call Dlasdq to find the SVD.
+	if n <= smlsiz {
+		return impl.Dlasdq(blas.Upper, sqre, n, m, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+
+	// Set up the computation tree.
+	inode := iwork[:n]
+	ndiml := iwork[n : 2*n]
+	ndimr := iwork[2*n : 3*n]
+	idxq := iwork[3*n : 4*n]
+	iwk := iwork[4*n:]
+	nlvl, nd := impl.Dlasdt(n, inode, ndiml, ndimr, smlsiz)
+
+	// For the nodes on the bottom level of the tree, solve their
+	// subproblems by Dlasdq.
+	for i := (nd - 1) / 2; i < nd; i++ {
+		// ic is the centre row of the node, nl and nr are the numbers of
+		// rows of the left and right subproblems and nlf and nrf are their
+		// starting rows.
+		ic := inode[i]
+		nl := ndiml[i]
+		nr := ndimr[i]
+		nlf := ic - nl
+		nrf := ic + 1
+		ok = impl.Dlasdq(blas.Upper, 1, nl, nl+1, nl, 0, d[nlf:], e[nlf:], vt[nlf*ldvt+nlf:], ldvt, u[nlf*ldu+nlf:], ldu, nil, 1, work)
+		if !ok {
+			return false
+		}
+		for j := 0; j < nl; j++ {
+			idxq[nlf+j] = j
+		}
+		sqrei := 1
+		if i == nd-1 {
+			sqrei = sqre
+		}
+		ok = impl.Dlasdq(blas.Upper, sqrei, nr, nr+sqrei, nr, 0, d[nrf:], e[nrf:], vt[nrf*ldvt+nrf:], ldvt, u[nrf*ldu+nrf:], ldu, nil, 1, work)
+		if !ok {
+			return false
+		}
+		for j := 0; j < nr; j++ {
+			idxq[nrf+j] = j
+		}
+	}
+
+	// Now conquer each subproblem bottom-up.
+	for lvl := nlvl; lvl >= 1; lvl-- {
+		// Find the first node lf and last node ll on the current level.
+		lf := 1<<uint(lvl-1) - 1
+		ll := 2 * lf
+		for i := lf; i <= ll; i++ {
+			ic := inode[i]
+			nl := ndiml[i]
+			nr := ndimr[i]
+			nlf := ic - nl
+			sqrei := 1
+			if sqre == 0 && i == ll {
+				sqrei = 0
+			}
+			ok = impl.Dlasd1(nl, nr, sqrei, d[nlf:], d[ic], e[ic], u[nlf*ldu+nlf:], ldu, vt[nlf*ldvt+nlf:], ldvt, idxq[nlf:], iwk, work)
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,109 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlasd1 computes the singular value decomposition of an upper bidiagonal
+// n×m matrix B, where n = nl+nr+1 and m = n+sqre, by merging the SVDs of its
+// upper nl×(nl+1) and lower nr×(nr+1+sqre) blocks. It is used by Dlasd0.
+//
+// B is represented as
+//  B = [ D1 Z1  0 ]
+//      [ 0  Z2 D2 ]
+// where D1 and D2 are the singular values of the two blocks and the middle
+// row of Z1 and Z2 holds the row [alpha*l^T beta*f^T] formed from the last
+// row l^T of the first block's right singular vectors and the first row f^T
+// of the second block's.
+//
+// On entry, d contains the singular values of the two blocks in d[:nl] and
+// d[nl+1:n]; d[nl] is ignored. On exit d contains the singular values of B.
+//
+// U is an n×n matrix containing the left singular vectors of the two blocks
+// on entry and those of B on exit. VT is an m×m matrix containing the
+// transposed right singular vectors of the two blocks on entry and those of
+// B on exit.
+//
+// idxq must contain, on entry, the permutations which separately sort the
+// two sets of singular values into ascending order, and on exit contains the
+// permutation which sorts d into ascending order.
+//
+// iwork must have length at least 4*n and work must have length at least
+// 3*m*m + 2*m.
+//
+// Dlasd1 returns whether the secular equation solver converged.
+//
+// Dlasd1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd1(nl, nr, sqre int, d []float64, alpha, beta float64, u []float64, ldu int, vt []float64, ldvt int, idxq, iwork []int, work []float64) (ok bool) {
+	if nl < 1 || nr < 1 {
+		panic(nLT0)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: bad sqre")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(m, m, vt, ldvt)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(idxq) < n {
+		panic(badSlice)
+	}
+	if len(iwork) < 4*n {
+		panic(badWork)
+	}
+	if len(work) < 3*m*m+2*m {
+		panic(badWork)
+	}
+
+	// Set up the workspace used by Dlasd2 and Dlasd3.
+	ldu2 := n
+	ldvt2 := m
+	iz := 0
+	isigma := iz + m
+	iu2 := isigma + n
+	ivt2 := iu2 + ldu2*n
+	iq := ivt2 + ldvt2*m
+	idx := 0
+	idxc := idx + n
+	coltyp := idxc + n
+	idxp := coltyp + n
+
+	// Scale.
+	orgnrm := math.Max(math.Abs(alpha), math.Abs(beta))
+	d[nl] = 0
+	for _, v := range d[:n] {
+		orgnrm = math.Max(orgnrm, math.Abs(v))
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	alpha /= orgnrm
+	beta /= orgnrm
+
+	// Deflate singular values.
+	k := impl.Dlasd2(nl, nr, sqre, d, work[iz:isigma], alpha, beta, u, ldu, vt, ldvt,
+		work[isigma:iu2], work[iu2:ivt2], ldu2, work[ivt2:iq], ldvt2,
+		iwork[idxp:idxp+n], iwork[idx:idxc], iwork[idxc:coltyp], idxq, iwork[coltyp:])
+
+	// Solve the secular equation and update the singular vectors.
+	ldq := k
+	ok = impl.Dlasd3(nl, nr, sqre, k, d, work[iq:], ldq, work[isigma:iu2], u, ldu,
+		work[iu2:ivt2], ldu2, vt, ldvt, work[ivt2:iq], ldvt2, iwork[idxc:coltyp], iwork[coltyp:coltyp+4], work[iz:isigma])
+	if !ok {
+		return false
+	}
+
+	// Unscale.
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+
+	// Prepare the idxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, idxq)
+	return true
+}
@@ -0,0 +1,189 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlasd3 finds all the square roots of the roots of the secular equation, as
+// defined by the values in dsigma and z, and updates the singular vectors. It
+// is used by Dlasd1 after the problem has been deflated by Dlasd2.
+//
+// The upper block is nl×(nl+1) and the lower block is nr×(nr+1+sqre), so the
+// merged problem is n×m with n = nl+nr+1 and m = n+sqre. k is the size of the
+// secular equation as returned by Dlasd2.
+//
+// On exit d contains the k updated singular values in ascending order.
+//
+// Q is a k×k workspace matrix.
+//
+// dsigma contains the first k values of the secular equation, which are the
+// poles of the equation. U2 (n×n) and VT2 (m×m) contain the first k left and
+// right singular vectors of the deflated problem as computed by Dlasd2. On
+// exit the first k columns of U and the first k rows of VT contain the updated
+// singular vectors. The contents of VT2 are modified.
+//
+// idxc and ctot are the column grouping permutation and the number of
+// columns of each of the four types computed by Dlasd2. z contains the
+// updating row vector of the secular equation and is overwritten.
+//
+// Dlasd3 returns whether the secular equation solver converged.
+//
+// Dlasd3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd3(nl, nr, sqre, k int, d, q []float64, ldq int, dsigma, u []float64, ldu int, u2 []float64, ldu2 int, vt []float64, ldvt int, vt2 []float64, ldvt2 int, idxc, ctot []int, z []float64) (ok bool) {
+	if nl < 1 || nr < 1 {
+		panic(nLT0)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: bad sqre")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	if k < 1 || n < k {
+		panic("lapack: k out of range")
+	}
+	checkMatrix(k, k, q, ldq)
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(n, n, u2, ldu2)
+	checkMatrix(m, m, vt, ldvt)
+	checkMatrix(m, m, vt2, ldvt2)
+	if len(d) < k || len(dsigma) < k || len(z) < k || len(idxc) < k {
+		panic(badSlice)
+	}
+	if len(ctot) < 4 {
+		panic(badSlice)
+	}
+
+	bi := blas64.Implementation()
+
+	if k == 1 {
+		d[0] = math.Abs(z[0])
+		bi.Dcopy(m, vt2, 1, vt, 1)
+		if z[0] > 0 {
+			bi.Dcopy(n, u2, ldu2, u, ldu)
+		} else {
+			for i := 0; i < n; i++ {
+				u[i*ldu] = -u2[i*ldu2]
+			}
+		}
+		return true
+	}
+
+	// Keep a copy of z.
+	copy(q[:k], z[:k])
+
+	// Normalize z.
+	rho := bi.Dnrm2(k, z, 1)
+	impl.Dlascl(lapack.General, 0, 0, rho, 1, k, 1, z, 1)
+	rho *= rho
+
+	// Find the new singular values. The differences and sums of the poles
+	// and the j-th singular value returned by Dlasd4 are stored in the j-th
+	// row of U and VT respectively.
+	for j := 0; j < k; j++ {
+		var ok bool
+		d[j], ok = impl.Dlasd4(k, j, dsigma, z, u[j*ldu:j*ldu+k], rho, vt[j*ldvt:j*ldvt+k])
+		if !ok {
+			// The zero finder failed.
+			return false
+		}
+	}
+
+	// Compute the updated z.
+	for i := 0; i < k; i++ {
+		zi := u[(k-1)*ldu+i] * vt[(k-1)*ldvt+i]
+		for j := 0; j < i; j++ {
+			zi *= u[j*ldu+i] * vt[j*ldvt+i] / (dsigma[i] - dsigma[j]) / (dsigma[i] + dsigma[j])
+		}
+		for j := i; j < k-1; j++ {
+			zi *= u[j*ldu+i] * vt[j*ldvt+i] / (dsigma[i] - dsigma[j+1]) / (dsigma[i] + dsigma[j+1])
+		}
+		z[i] = math.Copysign(math.Sqrt(math.Abs(zi)), q[i])
+	}
+
+	// Compute the left singular vectors of the modified diagonal matrix and
+	// store related information for the right singular vectors.
+	for i := 0; i < k; i++ {
+		ui := u[i*ldu : i*ldu+k]
+		vti := vt[i*ldvt : i*ldvt+k]
+		vti[0] = z[0] / ui[0] / vti[0]
+		ui[0] = -1
+		for j := 1; j < k; j++ {
+			vti[j] = z[j] / ui[j] / vti[j]
+			ui[j] = dsigma[j] * vti[j]
+		}
+		temp := bi.Dnrm2(k, ui, 1)
+		q[i] = ui[0] / temp
+		for j := 1; j < k; j++ {
+			q[j*ldq+i] = ui[idxc[j]] / temp
+		}
+	}
+
+	// Update the left singular vector matrix.
+	if k == 2 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, k, k, 1, u2, ldu2, q, ldq, 0, u, ldu)
+	} else {
+		ktemp := 1 + ctot[0] + ctot[1]
+		switch {
+		case ctot[0] > 0:
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[0], 1, u2[1:], ldu2, q[ldq:], ldq, 0, u, ldu)
+			if ctot[2] > 0 {
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[2], 1, u2[ktemp:], ldu2, q[ktemp*ldq:], ldq, 1, u, ldu)
+			}
+		case ctot[2] > 0:
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[2], 1, u2[ktemp:], ldu2, q[ktemp*ldq:], ldq, 0, u, ldu)
+		default:
+			impl.Dlacpy(blas.All, nl, k, u2, ldu2, u, ldu)
+		}
+		copy(u[nl*ldu:nl*ldu+k], q[:k])
+		ktemp = 1 + ctot[0]
+		ctemp := ctot[1] + ctot[2]
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, nr, k, ctemp, 1, u2[(nl+1)*ldu2+ktemp:], ldu2, q[ktemp*ldq:], ldq, 0, u[(nl+1)*ldu:], ldu)
+	}
+
+	// Generate the right singular vectors.
+	for i := 0; i < k; i++ {
+		vti := vt[i*ldvt : i*ldvt+k]
+		temp := bi.Dnrm2(k, vti, 1)
+		q[i*ldq] = vti[0] / temp
+		for j := 1; j < k; j++ {
+			q[i*ldq+j] = vti[idxc[j]] / temp
+		}
+	}
+
+	// Update the right singular vector matrix.
+	if k == 2 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, k, m, k, 1, q, ldq, vt2, ldvt2, 0, vt, ldvt)
+		return true
+	}
+	ktemp := 1 + ctot[0]
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nl+1, ktemp, 1, q, ldq, vt2, ldvt2, 0, vt, ldvt)
+	ktemp = 1 + ctot[0] + ctot[1]
+	if ctot[2] > 0 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nl+1, ctot[2], 1, q[ktemp:], ldq, vt2[ktemp*ldvt2:], ldvt2, 1, vt, ldvt)
+	}
+
+	// The last column of Q of type 1 and the corresponding row of VT2 are
+	// not used by the lower block, so they hold the first column of Q and
+	// the first row of VT2 for the final product.
+	ktemp = ctot[0]
+	nrp1 := nr + sqre
+	if ktemp > 0 {
+		for i := 0; i < k; i++ {
+			q[i*ldq+ktemp] = q[i*ldq]
+		}
+		for i := nl + 1; i < m; i++ {
+			vt2[ktemp*ldvt2+i] = vt2[i]
+		}
+	}
+	ctemp := 1 + ctot[1] + ctot[2]
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nrp1, ctemp, 1, q[ktemp:], ldq, vt2[ktemp*ldvt2+nl+1:], ldvt2, 0, vt[nl+1:], ldvt)
+	return true
+}
@@ -0,0 +1,519 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlasd4 computes the square root of the i-th updated eigenvalue of a positive
+// symmetric rank-one modification of a positive diagonal matrix
+//  diag(d)*diag(d) + rho * z * z^T.
+// It is assumed that the elements of d satisfy 0 <= d[0] < d[1] < ... < d[n-1],
+// that rho > 0 and that the Euclidean norm of z is one. The squares of the
+// updated singular values are the roots of the secular equation
+//  f(σ^2) = 1/rho + sum_j z[j]^2/((d[j]-σ)*(d[j]+σ)) = 0.
+//
+// On return, delta contains d[j] - σ_i and work contains d[j] + σ_i for
+// j = 0, ..., n-1, where σ_i is the returned value sigma. For n == 1 both
+// delta and work contain one. delta and work can be used to compute the
+// corresponding singular vectors accurately.
+//
+// d, z, delta and work must have length at least n. i must satisfy 0 <= i < n.
+//
+// Dlasd4 returns whether the iteration converged.
+//
+// Dlasd4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd4(n, i int, d, z, delta []float64, rho float64, work []float64) (sigma float64, ok bool) {
+	if n < 1 {
+		panic(nLT0)
+	}
+	if i < 0 || n <= i {
+		panic("lapack: index out of range")
+	}
+	if len(d) < n || len(z) < n || len(delta) < n || len(work) < n {
+		panic(badSlice)
+	}
+
+	// Quick return for small problems.
+	if n == 1 {
+		delta[0] = 1
+		work[0] = 1
+		return math.Sqrt(d[0]*d[0] + rho*z[0]*z[0]), true
+	}
+	if n == 2 {
+		return impl.Dlasd5(i, d, z, delta, rho, work), true
+	}
+
+	const maxit = 400
+	eps := dlamchE
+	rhoinv := 1 / rho
+
+	if i == n-1 {
+		// The last singular value satisfies
+		//  d[n-1]^2 < σ_{n-1}^2 < d[n-1]^2 + rho.
+		ii := n - 2
+
+		// Calculate the initial guess.
+		temp := rho / 2
+		// If the norm of z is not one, temp should be set to
+		// rho * ||z||^2 / 2.
+		temp1 := temp / (d[n-1] + math.Sqrt(d[n-1]*d[n-1]+temp))
+		for j := 0; j < n; j++ {
+			work[j] = d[j] + d[n-1] + temp1
+			delta[j] = (d[j] - d[n-1]) - temp1
+		}
+		var psi float64
+		for j := 0; j < n-2; j++ {
+			psi += z[j] * z[j] / (delta[j] * work[j])
+		}
+		c := rhoinv + psi
+		w := c + z[ii]*z[ii]/(delta[ii]*work[ii]) + z[n-1]*z[n-1]/(delta[n-1]*work[n-1])
+
+		// tau2 approximates σ_{n-1}^2 - d[n-1]^2.
+		var tau2 float64
+		if w <= 0 {
+			temp1 = math.Sqrt(d[n-1]*d[n-1] + rho)
+			temp = z[n-2]*z[n-2]/((d[n-2]+temp1)*(d[n-1]-d[n-2]+rho/(d[n-1]+temp1))) + z[n-1]*z[n-1]/rho
+			// It can be proved that
+			//  d[n-1]^2 + rho/2 <= σ_{n-1}^2 < d[n-1]^2 + tau2 <= d[n-1]^2 + rho.
+			if c <= temp {
+				tau2 = rho
+			} else {
+				delsq := (d[n-1] - d[n-2]) * (d[n-1] + d[n-2])
+				a := -c*delsq + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+				b := z[n-1] * z[n-1] * delsq
+				if a < 0 {
+					tau2 = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+				} else {
+					tau2 = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+				}
+			}
+		} else {
+			// It can be proved that
+			//  d[n-1]^2 < d[n-1]^2 + tau2 < σ_{n-1}^2 < d[n-1]^2 + rho/2.
+			delsq := (d[n-1] - d[n-2]) * (d[n-1] + d[n-2])
+			a := -c*delsq + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+			b := z[n-1] * z[n-1] * delsq
+			if a < 0 {
+				tau2 = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+			} else {
+				tau2 = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+			}
+		}
+		// tau approximates σ_{n-1} - d[n-1].
+		tau := tau2 / (d[n-1] + math.Sqrt(d[n-1]*d[n-1]+tau2))
+		sigma = d[n-1] + tau
+		for j := 0; j < n; j++ {
+			delta[j] = (d[j] - d[n-1]) - tau
+			work[j] = d[j] + d[n-1] + tau
+		}
+
+		// evaluate computes the value of the secular function, the
+		// derivatives of its parts and an error bound.
+		evaluate := func() (w, dpsi, dphi, erretm float64) {
+			var psi float64
+			for j := 0; j <= ii; j++ {
+				temp := z[j] / (work[j] * delta[j])
+				psi += z[j] * temp
+				dpsi += temp * temp
+				erretm += psi
+			}
+			erretm = math.Abs(erretm)
+			temp := z[n-1] / (work[n-1] * delta[n-1])
+			phi := z[n-1] * temp
+			dphi = temp * temp
+			erretm = 8*(-phi-psi) + erretm - phi + rhoinv
+			return rhoinv + phi + psi, dpsi, dphi, erretm
+		}
+		w, dpsi, dphi, erretm := evaluate()
+
+		// Test for convergence.
+		if math.Abs(w) <= eps*erretm {
+			return sigma, true
+		}
+
+		// Calculate the new step.
+		dtnsq1 := work[n-2] * delta[n-2]
+		dtnsq := work[n-1] * delta[n-1]
+		c = w - dtnsq1*dpsi - dtnsq*dphi
+		a := (dtnsq+dtnsq1)*w - dtnsq*dtnsq1*(dpsi+dphi)
+		b := dtnsq * dtnsq1 * w
+		if c < 0 {
+			c = math.Abs(c)
+		}
+		var eta float64
+		switch {
+		case c == 0:
+			eta = rho - sigma*sigma
+		case a >= 0:
+			eta = (a + math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		default:
+			eta = 2 * b / (a - math.Sqrt(math.Abs(a*a-4*b*c)))
+		}
+
+		// Note, eta should be positive if w is negative, and eta should
+		// be negative otherwise. However, if for some reason caused by
+		// roundoff, eta*w > 0, we simply use one Newton step instead.
+		// This way will guarantee eta*w < 0.
+		if w*eta > 0 {
+			eta = -w / (dpsi + dphi)
+		}
+		if eta-dtnsq > rho {
+			eta = rho + dtnsq
+		}
+		eta /= sigma + math.Sqrt(eta+sigma*sigma)
+		tau += eta
+		sigma += eta
+		for j := 0; j < n; j++ {
+			delta[j] -= eta
+			work[j] += eta
+		}
+		w, dpsi, dphi, erretm = evaluate()
+
+		// Main loop to update the values of the arrays delta and work.
+		for niter := 3; niter <= maxit; niter++ {
+			// Test for convergence.
+			if math.Abs(w) <= eps*erretm {
+				return sigma, true
+			}
+
+			// Calculate the new step.
+			dtnsq1 = work[n-2] * delta[n-2]
+			dtnsq = work[n-1] * delta[n-1]
+			c = w - dtnsq1*dpsi - dtnsq*dphi
+			a = (dtnsq+dtnsq1)*w - dtnsq1*dtnsq*(dpsi+dphi)
+			b = dtnsq1 * dtnsq * w
+			if a >= 0 {
+				eta = (a + math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+			} else {
+				eta = 2 * b / (a - math.Sqrt(math.Abs(a*a-4*b*c)))
+			}
+			if w*eta > 0 {
+				eta = -w / (dpsi + dphi)
+			}
+			if eta-dtnsq <= 0 {
+				eta /= 2
+			}
+			eta /= sigma + math.Sqrt(eta+sigma*sigma)
+			tau += eta
+			sigma += eta
+			for j := 0; j < n; j++ {
+				delta[j] -= eta
+				work[j] += eta
+			}
+			w, dpsi, dphi, erretm = evaluate()
+		}
+		return sigma, false
+	}
+
+	// The i-th singular value satisfies d[i] < σ_i < d[i+1].
+	ip1 := i + 1
+	niter := 1
+
+	// Calculate the initial guess.
+	delsq := (d[ip1] - d[i]) * (d[ip1] + d[i])
+	delsq2 := delsq / 2
+	sq2 := math.Sqrt((d[i]*d[i] + d[ip1]*d[ip1]) / 2)
+	temp := delsq2 / (d[i] + sq2)
+	for j := 0; j < n; j++ {
+		work[j] = d[j] + d[i] + temp
+		delta[j] = (d[j] - d[i]) - temp
+	}
+	var psi float64
+	for j := 0; j < i; j++ {
+		psi += z[j] * z[j] / (work[j] * delta[j])
+	}
+	var phi float64
+	for j := n - 1; j > i+1; j-- {
+		phi += z[j] * z[j] / (work[j] * delta[j])
+	}
+	c := rhoinv + psi + phi
+	w := c + z[i]*z[i]/(work[i]*delta[i]) + z[ip1]*z[ip1]/(work[ip1]*delta[ip1])
+
+	var (
+		orgati     bool
+		geomavg    bool
+		ii         int
+		tau        float64
+		sglb, sgub float64
+	)
+	if w > 0 {
+		// d[i]^2 < σ_i^2 < (d[i]^2+d[i+1]^2)/2. Choose d[i] as the origin.
+		orgati = true
+		ii = i
+		sglb = 0
+		sgub = delsq2 / (d[i] + sq2)
+		a := c*delsq + z[i]*z[i] + z[ip1]*z[ip1]
+		b := z[i] * z[i] * delsq
+		var tau2 float64
+		if a > 0 {
+			tau2 = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		} else {
+			tau2 = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		}
+		// tau2 is an estimate of σ_i^2 - d[i]^2 and tau the
+		// corresponding estimate of σ_i - d[i].
+		tau = tau2 / (d[i] + math.Sqrt(d[i]*d[i]+tau2))
+		temp = math.Sqrt(eps)
+		if d[i] <= temp*d[ip1] && math.Abs(z[i]) <= temp && d[i] > 0 {
+			tau = math.Min(10*d[i], sgub)
+			geomavg = true
+		}
+	} else {
+		// (d[i]^2+d[i+1]^2)/2 <= σ_i^2 < d[i+1]^2. Choose d[i+1] as the
+		// origin.
+		orgati = false
+		ii = ip1
+		sglb = -delsq2 / (d[ii] + sq2)
+		sgub = 0
+		a := c*delsq - z[i]*z[i] - z[ip1]*z[ip1]
+		b := z[ip1] * z[ip1] * delsq
+		var tau2 float64
+		if a < 0 {
+			tau2 = 2 * b / (a - math.Sqrt(math.Abs(a*a+4*b*c)))
+		} else {
+			tau2 = -(a + math.Sqrt(math.Abs(a*a+4*b*c))) / (2 * c)
+		}
+		// tau2 is an estimate of σ_i^2 - d[i+1]^2 and tau the
+		// corresponding estimate of σ_i - d[i+1].
+		tau = tau2 / (d[ip1] + math.Sqrt(math.Abs(d[ip1]*d[ip1]+tau2)))
+	}
+	sigma = d[ii] + tau
+	for j := 0; j < n; j++ {
+		work[j] = d[j] + d[ii] + tau
+		delta[j] = (d[j] - d[ii]) - tau
+	}
+	iim1 := ii - 1
+	iip1 := ii + 1
+
+	// evaluate computes the value of the secular function w, the parts
+	// psi and phi and their derivatives, the derivative dw and an error
+	// bound. It also returns the value of the secular function with its
+	// ii-th term removed.
+	var dpsi, dphi float64
+	evaluate := func() (w, dw, erretm, wrem float64) {
+		psi, dpsi = 0, 0
+		for j := 0; j < ii; j++ {
+			temp := z[j] / (work[j] * delta[j])
+			psi += z[j] * temp
+			dpsi += temp * temp
+			erretm += psi
+		}
+		erretm = math.Abs(erretm)
+		phi, dphi = 0, 0
+		for j := n - 1; j > ii; j-- {
+			temp := z[j] / (work[j] * delta[j])
+			phi += z[j] * temp
+			dphi += temp * temp
+			erretm += phi
+		}
+		wrem = rhoinv + phi + psi
+		temp := z[ii] / (work[ii] * delta[ii])
+		dw = dpsi + dphi + temp*temp
+		temp *= z[ii]
+		w = wrem + temp
+		erretm = 8*(phi-psi) + erretm + 2*rhoinv + 3*math.Abs(temp)
+		return w, dw, erretm, wrem
+	}
+	w, dw, erretm, wrem := evaluate()
+
+	swtch3 := false
+	if orgati {
+		swtch3 = wrem < 0
+	} else {
+		swtch3 = wrem > 0
+	}
+	if ii == 0 || ii == n-1 {
+		swtch3 = false
+	}
+
+	// twoPole computes the step using the two poles d[i] and d[i+1].
+	twoPole := func(swtch bool) float64 {
+		dtipsq := work[ip1] * delta[ip1]
+		dtisq := work[i] * delta[i]
+		var c float64
+		if !swtch {
+			if orgati {
+				temp := z[i] / dtisq
+				c = w - dtipsq*dw + delsq*temp*temp
+			} else {
+				temp := z[ip1] / dtipsq
+				c = w - dtisq*dw - delsq*temp*temp
+			}
+		} else {
+			temp := z[ii] / (work[ii] * delta[ii])
+			if orgati {
+				dpsi += temp * temp
+			} else {
+				dphi += temp * temp
+			}
+			c = w - dtisq*dpsi - dtipsq*dphi
+		}
+		a := (dtipsq+dtisq)*w - dtipsq*dtisq*dw
+		b := dtipsq * dtisq * w
+		switch {
+		case c == 0:
+			if a == 0 {
+				switch {
+				case swtch:
+					a = dtisq*dtisq*dpsi + dtipsq*dtipsq*dphi
+				case orgati:
+					a = z[i]*z[i] + dtipsq*dtipsq*(dpsi+dphi)
+				default:
+					a = z[ip1]*z[ip1] + dtisq*dtisq*(dpsi+dphi)
+				}
+			}
+			return b / a
+		case a <= 0:
+			return (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		default:
+			return 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		}
+	}
+
+	// threePole computes the step using the three most relevant poles.
+	threePole := func(swtch bool) (eta float64, ok bool) {
+		var zz, dd [3]float64
+		dtiim := work[iim1] * delta[iim1]
+		dtiip := work[iip1] * delta[iip1]
+		temp := rhoinv + psi + phi
+		var c float64
+		switch {
+		case swtch:
+			c = temp - dtiim*dpsi - dtiip*dphi
+			zz[0] = dtiim * dtiim * dpsi
+			zz[2] = dtiip * dtiip * dphi
+		case orgati:
+			temp1 := z[iim1] / dtiim
+			temp1 *= temp1
+			temp2 := (d[iim1] - d[iip1]) * (d[iim1] + d[iip1]) * temp1
+			c = temp - dtiip*(dpsi+dphi) - temp2
+			zz[0] = z[iim1] * z[iim1]
+			if dpsi < temp1 {
+				zz[2] = dtiip * dtiip * dphi
+			} else {
+				zz[2] = dtiip * dtiip * ((dpsi - temp1) + dphi)
+			}
+		default:
+			temp1 := z[iip1] / dtiip
+			temp1 *= temp1
+			temp2 := (d[iip1] - d[iim1]) * (d[iim1] + d[iip1]) * temp1
+			c = temp - dtiim*(dpsi+dphi) - temp2
+			if dphi < temp1 {
+				zz[0] = dtiim * dtiim * dpsi
+			} else {
+				zz[0] = dtiim * dtiim * (dpsi + (dphi - temp1))
+			}
+			zz[2] = z[iip1] * z[iip1]
+		}
+		zz[1] = z[ii] * z[ii]
+		dd[0] = dtiim
+		dd[1] = delta[ii] * work[ii]
+		dd[2] = dtiip
+		return impl.Dlaed6(niter, orgati, c, dd[:], zz[:], w)
+	}
+
+	// update applies the step eta, safeguarded by the bounds on the
+	// root, to tau, sigma and the arrays delta and work.
+	update := func(eta float64) {
+		// Note, eta should be positive if w is negative, and eta should
+		// be negative otherwise. However, if for some reason caused by
+		// roundoff, eta*w > 0, we simply use one Newton step instead.
+		// This way will guarantee eta*w < 0.
+		if w*eta >= 0 {
+			eta = -w / dw
+		}
+		eta /= sigma + math.Sqrt(sigma*sigma+eta)
+		if temp := tau + eta; temp > sgub || temp < sglb {
+			if w < 0 {
+				eta = (sgub - tau) / 2
+			} else {
+				eta = (sglb - tau) / 2
+			}
+			if geomavg {
+				if w < 0 {
+					if tau > 0 {
+						eta = math.Sqrt(sgub*tau) - tau
+					}
+				} else if sglb > 0 {
+					eta = math.Sqrt(sglb*tau) - tau
+				}
+			}
+		}
+		tau += eta
+		sigma += eta
+		for j := 0; j < n; j++ {
+			work[j] += eta
+			delta[j] -= eta
+		}
+	}
+
+	// Test for convergence.
+	if math.Abs(w) <= eps*erretm {
+		return sigma, true
+	}
+	if w <= 0 {
+		sglb = math.Max(sglb, tau)
+	} else {
+		sgub = math.Min(sgub, tau)
+	}
+
+	// Calculate the new step.
+	niter++
+	var eta float64
+	if swtch3 {
+		var ok bool
+		eta, ok = threePole(false)
+		if !ok {
+			// Dlaed6 failed, switch back to two pole interpolation.
+			swtch3 = false
+			eta = twoPole(false)
+		}
+	} else {
+		eta = twoPole(false)
+	}
+	prew := w
+	update(eta)
+	w, dw, erretm, _ = evaluate()
+	swtch := false
+	if orgati {
+		swtch = -w > math.Abs(prew)/10
+	} else {
+		swtch = w > math.Abs(prew)/10
+	}
+
+	// Main loop to update the values of the arrays delta and work.
+	for niter++; niter <= maxit; niter++ {
+		// Test for convergence.
+		if math.Abs(w) <= eps*erretm {
+			return sigma, true
+		}
+		if w <= 0 {
+			sglb = math.Max(sglb, tau)
+		} else {
+			sgub = math.Min(sgub, tau)
+		}
+
+		// Calculate the new step.
+		if swtch3 {
+			var ok bool
+			eta, ok = threePole(swtch)
+			if !ok {
+				// Dlaed6 failed, switch back to two pole
+				// interpolation.
+				swtch3 = false
+				eta = twoPole(swtch)
+			}
+		} else {
+			eta = twoPole(swtch)
+		}
+		update(eta)
+		prew = w
+		w, dw, erretm, _ = evaluate()
+		if w*prew > 0 && math.Abs(w) > math.Abs(prew)/10 {
+			swtch = !swtch
+		}
+	}
+	return sigma, false
+}
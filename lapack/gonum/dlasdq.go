@@ -0,0 +1,185 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dlasdq computes the singular value decomposition of a real upper or lower
+// bidiagonal matrix B with diagonal d and off-diagonal e. B is n×n if sqre == 0
+// and n×(n+1) if sqre == 1, in which case e has length n.
+//
+// The SVD of B is
+//  B = Q * S * P^T
+// where S is a diagonal matrix of singular values, Q is an orthogonal matrix of
+// left singular vectors, and P is an orthogonal matrix of right singular vectors.
+//
+// On exit, d contains the singular values in ascending order and e is
+// overwritten.
+//
+// If ncvt > 0, VT is a matrix of size n×ncvt, or (n+1)×ncvt if sqre == 1,
+// and is overwritten by P^T * VT.
+// If nru > 0, U is a matrix of size nru×n, or nru×(n+1) if uplo == blas.Lower
+// and sqre == 1, and is overwritten by U * Q.
+// If ncc > 0, C is a matrix of size n×ncc, or (n+1)×ncc if uplo == blas.Lower
+// and sqre == 1, and is overwritten by Q^T * C.
+//
+// work must have length at least 4*n.
+//
+// Dlasdq returns whether all the singular values converged.
+//
+// Dlasdq is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasdq(uplo blas.Uplo, sqre, n, ncvt, nru, ncc int, d, e, vt []float64, ldvt int, u []float64, ldu int, c []float64, ldc int, work []float64) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: bad sqre")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if ncvt < 0 || nru < 0 || ncc < 0 {
+		panic(nLT0)
+	}
+	np1 := n + 1
+	nr := n
+	if uplo == blas.Lower {
+		nr += sqre
+	}
+	if ncvt > 0 {
+		checkMatrix(n+sqre, ncvt, vt, ldvt)
+	}
+	if nru > 0 {
+		checkMatrix(nru, nr, u, ldu)
+	}
+	if ncc > 0 {
+		checkMatrix(nr, ncc, c, ldc)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1+sqre {
+		panic(badE)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+
+	rotate := ncvt > 0 || nru > 0 || ncc > 0
+	sqre1 := sqre
+
+	// If matrix is non-square upper bidiagonal, rotate to be lower
+	// bidiagonal. The rotations are on the right.
+	if uplo == blas.Upper && sqre1 == 1 {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if rotate {
+				work[i] = cs
+				work[n+i] = sn
+			}
+		}
+		cs, sn, r := impl.Dlartg(d[n-1], e[n-1])
+		d[n-1] = r
+		e[n-1] = 0
+		if rotate {
+			work[n-1] = cs
+			work[2*n-1] = sn
+		}
+		uplo = blas.Lower
+		sqre1 = 0
+
+		// Update singular vectors if desired.
+		if ncvt > 0 {
+			impl.Dlasr(blas.Left, lapack.Variable, lapack.Forward, np1, ncvt, work[:n], work[n:], vt, ldvt)
+		}
+	}
+
+	// If matrix is lower bidiagonal, rotate to be upper bidiagonal by
+	// applying Givens rotations on the left.
+	if uplo == blas.Lower {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if rotate {
+				work[i] = cs
+				work[n+i] = sn
+			}
+		}
+
+		// If matrix is (n+1)×n lower bidiagonal, one additional rotation
+		// is needed.
+		if sqre1 == 1 {
+			cs, sn, r := impl.Dlartg(d[n-1], e[n-1])
+			d[n-1] = r
+			if rotate {
+				work[n-1] = cs
+				work[2*n-1] = sn
+			}
+		}
+
+		// Update singular vectors if desired.
+		if nru > 0 {
+			if sqre1 == 0 {
+				impl.Dlasr(blas.Right, lapack.Variable, lapack.Forward, nru, n, work[:n], work[n:], u, ldu)
+			} else {
+				impl.Dlasr(blas.Right, lapack.Variable, lapack.Forward, nru, np1, work[:n], work[n:], u, ldu)
+			}
+		}
+		if ncc > 0 {
+			if sqre1 == 0 {
+				impl.Dlasr(blas.Left, lapack.Variable, lapack.Forward, n, ncc, work[:n], work[n:], c, ldc)
+			} else {
+				impl.Dlasr(blas.Left, lapack.Variable, lapack.Forward, np1, ncc, work[:n], work[n:], c, ldc)
+			}
+		}
+	}
+
+	// Call Dbdsqr to compute the SVD of the reduced real n×n upper
+	// bidiagonal matrix.
+	ok = impl.Dbdsqr(blas.Upper, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work)
+
+	// Sort the singular values into ascending order with only one
+	// transposition per singular vector.
+	bi := blas64.Implementation()
+	for i := 0; i < n; i++ {
+		// Scan for the smallest d[i].
+		isub := i
+		smin := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < smin {
+				isub = j
+				smin = d[j]
+			}
+		}
+		if isub == i {
+			continue
+		}
+		// Swap singular values and vectors.
+		d[isub] = d[i]
+		d[i] = smin
+		if ncvt > 0 {
+			bi.Dswap(ncvt, vt[isub*ldvt:], 1, vt[i*ldvt:], 1)
+		}
+		if nru > 0 {
+			bi.Dswap(nru, u[isub:], ldu, u[i:], ldu)
+		}
+		if ncc > 0 {
+			bi.Dswap(ncc, c[isub*ldc:], 1, c[i*ldc:], 1)
+		}
+	}
+	return ok
+}
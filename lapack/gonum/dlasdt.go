@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math"
+
+// Dlasdt creates a tree of subproblems for bidiagonal divide and conquer. The
+// nodes of the tree are numbered level by level starting from the root at
+// index 0, so the children of node i are 2*i+1 and 2*i+2.
+//
+// For each node i, inode[i] is the index of the row that is removed to split
+// the node into its children, and ndiml[i] and ndimr[i] are the sizes of the
+// left and right children respectively. msub is the maximum size of the
+// subproblems at the bottom of the tree.
+//
+// inode, ndiml and ndimr must have length at least n. Dlasdt returns the
+// number of levels lvl and the number of nodes nd of the tree.
+//
+// Dlasdt is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlasdt(n int, inode, ndiml, ndimr []int, msub int) (lvl, nd int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if msub < 1 {
+		panic("lapack: msub < 1")
+	}
+	if len(inode) < n || len(ndiml) < n || len(ndimr) < n {
+		panic(badSlice)
+	}
+
+	maxn := max(1, n)
+	temp := math.Log(float64(maxn)/float64(msub+1)) / math.Log(2)
+	lvl = int(temp) + 1
+
+	i := n / 2
+	inode[0] = i
+	ndiml[0] = i
+	ndimr[0] = n - i - 1
+	il := -1
+	ir := 0
+	llst := 1
+	for nlvl := 1; nlvl < lvl; nlvl++ {
+		// Construct the tree at level nlvl.
+		for i := 0; i < llst; i++ {
+			il += 2
+			ir += 2
+			ncrnt := llst + i - 1
+			ndiml[il] = ndiml[ncrnt] / 2
+			ndimr[il] = ndiml[ncrnt] - ndiml[il] - 1
+			inode[il] = inode[ncrnt] - ndimr[il] - 1
+			ndiml[ir] = ndimr[ncrnt] / 2
+			ndimr[ir] = ndimr[ncrnt] - ndiml[ir] - 1
+			inode[ir] = inode[ncrnt] + ndiml[ir] + 1
+		}
+		llst *= 2
+	}
+	return lvl, 2*llst - 1
+}
@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dorm2l multiplies a general matrix C by an orthogonal matrix from a QL factorization
+// determined by Dgeqlf.
+//  C = Q * C    if side == blas.Left and trans == blas.NoTrans
+//  C = Q^T * C  if side == blas.Left and trans == blas.Trans
+//  C = C * Q    if side == blas.Right and trans == blas.NoTrans
+//  C = C * Q^T  if side == blas.Right and trans == blas.Trans
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_{k-1} * ... * H_1 * H_0.
+// If side == blas.Left, a is a matrix of size m×k, and if side == blas.Right
+// a is of size n×k. The i-th column of a contains the vector which defines
+// the elementary reflector H_i.
+//
+// tau contains the Householder factors and is of length at least k and this function
+// will panic otherwise.
+//
+// work is temporary storage of length at least n if side == blas.Left
+// and at least m if side == blas.Right and this function will panic otherwise.
+//
+// Dorm2l is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dorm2l(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64) {
+	if side != blas.Left && side != blas.Right {
+		panic(badSide)
+	}
+	if trans != blas.Trans && trans != blas.NoTrans {
+		panic(badTrans)
+	}
+
+	left := side == blas.Left
+	notran := trans == blas.NoTrans
+	nq := n
+	if left {
+		// Q is m x m
+		nq = m
+		checkMatrix(m, k, a, lda)
+		if len(work) < n {
+			panic(badWork)
+		}
+	} else {
+		// Q is n x n
+		checkMatrix(n, k, a, lda)
+		if len(work) < m {
+			panic(badWork)
+		}
+	}
+	if k > nq {
+		panic(kGTN)
+	}
+	checkMatrix(m, n, c, ldc)
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+	if len(tau) < k {
+		panic(badTau)
+	}
+
+	forward := left == notran
+	mi, ni := m, n
+	for j := 0; j < k; j++ {
+		i := j
+		if !forward {
+			i = k - 1 - j
+		}
+		// H_i is applied to C[0:m-k+i+1, 0:n] if side == blas.Left and to
+		// C[0:m, 0:n-k+i+1] if side == blas.Right.
+		if left {
+			mi = m - k + i + 1
+		} else {
+			ni = n - k + i + 1
+		}
+		aii := a[(nq-k+i)*lda+i]
+		a[(nq-k+i)*lda+i] = 1
+		impl.Dlarf(side, mi, ni, a[i:], lda, tau[i], c, ldc, work)
+		a[(nq-k+i)*lda+i] = aii
+	}
+}
@@ -78,7 +78,17 @@ func (impl Implementation) Dormqr(side blas.Side, trans blas.Transpose, m, n, k
 		ldt   = nbmax
 		tsize = nbmax * ldt
 	)
-	opts := string(side) + string(trans)
+	var opts string
+	if side == blas.Left {
+		opts = "L"
+	} else {
+		opts = "R"
+	}
+	if trans == blas.Trans {
+		opts += "T"
+	} else {
+		opts += "N"
+	}
 	nb := min(nbmax, impl.Ilaenv(1, "DORMQR", opts, m, n, k, -1))
 	lworkopt := max(1, nw)*nb + tsize
 	if lwork == -1 {
@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dormtr multiplies an m×n matrix C by the orthogonal matrix Q defined as the
+// product of nq-1 elementary reflectors as returned by Dsytrd:
+//  C = Q * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Q,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^T,  if side == blas.Right and trans == blas.Trans,
+// where nq = m if side == blas.Left and nq = n if side == blas.Right.
+//
+// The construction of Q depends on the value of uplo:
+//  Q = H_{nq-2} * ... * H_1 * H_0  if uplo == blas.Upper
+//  Q = H_0 * H_1 * ... * H_{nq-2}  if uplo == blas.Lower
+// A is an nq×nq matrix containing the vectors which define the elementary
+// reflectors as returned by Dsytrd, and tau must have length at least nq-1.
+// See the documentation for Dsytrd for more information.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Dormtr will
+// panic. Larger values of lwork will generally give better performance. On
+// return, work[0] will contain the optimal value of lwork.
+//
+// If lwork is -1, instead of performing Dormtr, the optimal workspace size will
+// be stored into work[0].
+//
+// Dormtr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormtr(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case len(work) < lwork:
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	if lwork != -1 {
+		checkMatrix(nq, nq, a, lda)
+		checkMatrix(m, n, c, ldc)
+		if len(tau) < nq-1 {
+			panic(badTau)
+		}
+	}
+
+	if m == 0 || n == 0 || nq == 1 {
+		work[0] = float64(max(1, nw))
+		return
+	}
+
+	mi, ni := m, n
+	var i1, i2 int
+	if side == blas.Left {
+		mi = m - 1
+		i1 = 1
+	} else {
+		ni = n - 1
+		i2 = 1
+	}
+	if lwork == -1 {
+		if uplo == blas.Upper {
+			impl.Dormql(side, trans, mi, ni, nq-1, nil, lda, nil, nil, ldc, work, -1)
+		} else {
+			impl.Dormqr(side, trans, mi, ni, nq-1, nil, lda, nil, nil, ldc, work, -1)
+		}
+		return
+	}
+	if uplo == blas.Upper {
+		// Q was determined by a call to Dsytrd with uplo == blas.Upper.
+		impl.Dormql(side, trans, mi, ni, nq-1, a[1:], lda, tau[:nq-1], c, ldc, work, lwork)
+		return
+	}
+	// Q was determined by a call to Dsytrd with uplo == blas.Lower.
+	impl.Dormqr(side, trans, mi, ni, nq-1, a[lda:], lda, tau[:nq-1], c[i1*ldc+i2:], ldc, work, lwork)
+}
@@ -0,0 +1,33 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "gonum.org/v1/gonum/blas"
+
+// Dpbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix
+//  A = U^T * U  if ul == blas.Upper
+//  A = L * L^T  if ul == blas.Lower
+// where U is an upper triangular band matrix and L is lower triangular. kd is
+// the number of super- or sub-diagonals of A. See the documentation for Dpbtf2
+// for a description of the band storage scheme.
+//
+// On entry, ab contains the band of A in the triangle specified by ul. On
+// return, ab contains the band of U or L. Dpbtrf returns whether the
+// factorization was successfully completed. If it returns false, A is not
+// positive definite and the content of ab is undefined.
+//
+// The band matrices handled by Dpbtrf are typically narrow, so the work per
+// row is small and Dpbtrf uses the unblocked algorithm implemented in Dpbtf2.
+func (impl Implementation) Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBanded(ab, n, kd, ldab)
+	if n == 0 {
+		return true
+	}
+	return impl.Dpbtf2(ul, n, kd, ab, ldab)
+}
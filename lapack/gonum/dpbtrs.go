@@ -0,0 +1,51 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dpbtrs solves a system of linear equations
+//  A * X = B
+// with an n×n symmetric positive definite band matrix A using the Cholesky
+// factorization
+//  A = U^T * U  if ul == blas.Upper
+//  A = L * L^T  if ul == blas.Lower
+// computed by Dpbtrf. kd is the number of super- or sub-diagonals of A. ab
+// must contain the band of the factor U or L as returned by Dpbtrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, it
+// contains the solution matrix X.
+func (Implementation) Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBanded(ab, n, kd, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	if ul == blas.Upper {
+		// Solve A*X = B where A = U^T*U.
+		for j := 0; j < nrhs; j++ {
+			// Solve U^T*Y = B, overwriting B with Y.
+			bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kd, ab, ldab, b[j:], ldb)
+			// Solve U*X = Y, overwriting Y with X.
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, b[j:], ldb)
+		}
+		return
+	}
+	// Solve A*X = B where A = L*L^T.
+	for j := 0; j < nrhs; j++ {
+		// Solve L*Y = B, overwriting B with Y.
+		bi.Dtbsv(blas.Lower, blas.NoTrans, blas.NonUnit, n, kd, ab, ldab, b[j:], ldb)
+		// Solve L^T*X = Y, overwriting Y with X.
+		bi.Dtbsv(blas.Lower, blas.Trans, blas.NonUnit, n, kd, ab, ldab, b[j:], ldb)
+	}
+}
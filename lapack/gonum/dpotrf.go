@@ -24,7 +24,11 @@ func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok
 		return true
 	}
 
-	nb := impl.Ilaenv(1, "DPOTRF", string(ul), n, -1, -1, -1)
+	opts := "U"
+	if ul == blas.Lower {
+		opts = "L"
+	}
+	nb := impl.Ilaenv(1, "DPOTRF", opts, n, -1, -1, -1)
 	if nb <= 1 || n <= nb {
 		return impl.Dpotf2(ul, n, a, lda)
 	}
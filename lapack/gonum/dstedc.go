@@ -0,0 +1,193 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstedc computes all eigenvalues and, optionally, the eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method. The
+// eigenvectors of a full or band symmetric matrix can also be found if
+// Dsytrd has been used to reduce this matrix to tridiagonal form.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix. On
+// exit, d contains the eigenvalues in ascending order. d must have length n
+// and Dstedc will panic otherwise.
+//
+// e, on entry, contains the off-diagonal elements of the tridiagonal matrix
+// and is overwritten during the call to Dstedc. e must have length n-1 and
+// Dstedc will panic otherwise.
+//
+// z, on entry, contains the n×n orthogonal matrix used in the reduction to
+// tridiagonal form if compz == lapack.OriginalEV. On exit, if
+// compz == lapack.OriginalEV, z contains the orthonormal eigenvectors of the
+// original symmetric matrix, and if compz == lapack.TridiagEV, z contains the
+// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
+// if compz == lapack.None.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// If compz == lapack.None or n <= 1, lwork must be at least 1. Otherwise, if
+// n is not larger than the size of the subproblems solved directly,
+// lwork must be at least 2*(n-1). Otherwise, lwork must be at least
+// 1+4*n+n*n if compz == lapack.TridiagEV and at least 1+4*n+2*n*n if
+// compz == lapack.OriginalEV.
+//
+// iwork is integer temporary storage, and liwork specifies its usable length.
+// If compz == lapack.None, n <= 1 or n is not larger than the size of the
+// subproblems solved directly, liwork must be at least 1, otherwise liwork
+// must be at least 3+5*n.
+//
+// If lwork == -1 or liwork == -1, instead of performing Dstedc, the minimum
+// workspace sizes are stored into work[0] and iwork[0].
+//
+// Dstedc returns whether all the eigenvalues were found.
+func (impl Implementation) Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if compz != lapack.None && compz != lapack.TridiagEV && compz != lapack.OriginalEV {
+		panic(badEVComp)
+	}
+
+	smlsiz := impl.Ilaenv(9, "DSTEDC", " ", 0, 0, 0, 0)
+	lwmin := 1
+	liwmin := 1
+	if n > 1 && compz != lapack.None {
+		switch {
+		case n <= smlsiz:
+			lwmin = 2 * (n - 1)
+		case compz == lapack.TridiagEV:
+			lwmin = 1 + 4*n + n*n
+			liwmin = 3 + 5*n
+		default:
+			lwmin = 1 + 4*n + 2*n*n
+			liwmin = 3 + 5*n
+		}
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return
+	}
+
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if compz != lapack.None {
+		checkMatrix(n, n, z, ldz)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		if compz == lapack.TridiagEV {
+			z[0] = 1
+		}
+		return true
+	}
+	if compz == lapack.None {
+		return impl.Dsterf(n, d, e)
+	}
+	// If n is smaller than the minimum divide size, solve the problem with
+	// another solver.
+	if n <= smlsiz {
+		return impl.Dsteqr(compz, n, d, e, z, ldz, work)
+	}
+
+	// The eigenvectors of the tridiagonal matrix are computed into q. If
+	// compz == lapack.OriginalEV, z is kept and multiplied by q at the end.
+	q := z
+	ldq := ldz
+	wrk := work
+	if compz == lapack.OriginalEV {
+		q = work[:n*n]
+		ldq = n
+		wrk = work[n*n:]
+	}
+	impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		return true
+	}
+
+	eps := dlamchE
+	for start := 0; start < n; {
+		// Let finish be the position of the next subdiagonal entry such
+		// that e[finish] <= tiny or finish = n-1 if no such subdiagonal
+		// exists. The matrix identified by the elements between start
+		// and finish constitutes an independent subproblem.
+		finish := start
+		for finish < n-1 {
+			tiny := eps * math.Sqrt(math.Abs(d[finish])) * math.Sqrt(math.Abs(d[finish+1]))
+			if math.Abs(e[finish]) <= tiny {
+				break
+			}
+			finish++
+		}
+
+		// Subproblem determined. Compute its size and solve it.
+		m := finish - start + 1
+		if m > smlsiz {
+			// Scale.
+			orgnrm = impl.Dlanst(lapack.MaxAbs, m, d[start:], e[start:])
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m, 1, d[start:], 1)
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m-1, 1, e[start:], 1)
+			ok = impl.Dlaed0(m, d[start:], e[start:], q[start*ldq+start:], ldq, wrk, iwork)
+			if !ok {
+				return false
+			}
+			// Scale back.
+			impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, m, 1, d[start:], 1)
+		} else if m > 1 {
+			ok = impl.Dsteqr(lapack.TridiagEV, m, d[start:], e[start:], q[start*ldq+start:], ldq, wrk)
+			if !ok {
+				return false
+			}
+		}
+		start = finish + 1
+	}
+
+	// Use selection sort to minimize swaps of eigenvectors.
+	bi := blas64.Implementation()
+	for ii := 1; ii < n; ii++ {
+		i := ii - 1
+		k := i
+		p := d[i]
+		for j := ii; j < n; j++ {
+			if d[j] < p {
+				k = j
+				p = d[j]
+			}
+		}
+		if k != i {
+			d[k] = d[i]
+			d[i] = p
+			bi.Dswap(n, q[i:], ldq, q[k:], ldq)
+		}
+	}
+
+	if compz == lapack.OriginalEV {
+		// Form the eigenvectors of the original matrix.
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, n, n, 1, z, ldz, q, ldq, 0, wrk, n)
+		impl.Dlacpy(blas.All, n, n, wrk, n, z, ldz)
+	}
+	return true
+}
@@ -0,0 +1,386 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dstemr computes selected eigenvalues and, optionally, eigenvectors of the
+// n×n symmetric tridiagonal matrix T using the Multiple Relatively Robust
+// Representations (MRRR) algorithm. Any such unreduced matrix has a well
+// defined set of pairwise different real eigenvalues, the corresponding real
+// eigenvectors are pairwise orthogonal.
+//
+// The spectrum may be computed either completely or partially by specifying
+// either an interval (vl,vu] or a range of indices il:iu for the desired
+// eigenvalues:
+//  - if rng == lapack.AllEigenvalues, all eigenvalues are computed and vl, vu,
+//    il and iu are not referenced,
+//  - if rng == lapack.IntervalEigenvalues, the eigenvalues in the half-open
+//    interval (vl,vu] are computed, vl < vu must hold,
+//  - if rng == lapack.IndexEigenvalues, the eigenvalues with indices il
+//    through iu are computed, where the indices are 0-based and the
+//    eigenvalues are in ascending order. 0 <= il <= iu < n must hold.
+//
+// On entry, d contains the n diagonal elements of T and e[:n-1] the
+// subdiagonal elements of T. e must have length at least n, e[n-1] is used as
+// workspace. d and e are overwritten on exit.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of Z contain the
+// orthonormal eigenvectors of T, the i-th column corresponding to w[i].
+// Z must be n×n if rng is lapack.AllEigenvalues or lapack.IntervalEigenvalues
+// and n×(iu-il+1) if rng is lapack.IndexEigenvalues. isuppz contains the
+// (0-based) support of the eigenvectors, the i-th eigenvector is nonzero only
+// in rows isuppz[2*i] through isuppz[2*i+1]. isuppz must have length at least
+// twice the number of columns of Z. If jobz == lapack.None, z and isuppz are
+// not referenced.
+//
+// If tryrac is true, Dstemr checks whether the tridiagonal matrix defines its
+// eigenvalues to high relative accuracy, and if so, computes them to high
+// relative accuracy.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,18*n) if jobz == lapack.ComputeEV and at least
+// max(1,12*n) otherwise. iwork is integer temporary storage, and liwork
+// specifies its usable length. liwork must be at least max(1,10*n) if
+// jobz == lapack.ComputeEV and at least max(1,8*n) otherwise. Dstemr will
+// panic if the workspace is too small.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dstemr the minimum
+// work length is stored into work[0] and the minimum integer work length is
+// stored into iwork[0].
+//
+// Dstemr returns the number of computed eigenvalues m and whether the
+// computation was successful.
+func (impl Implementation) Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	const minrgp = 1e-3
+
+	wantz := jobz == lapack.ComputeEV
+	if !wantz && jobz != lapack.None {
+		panic(badEVJob)
+	}
+	var ncols int
+	switch rng {
+	default:
+		panic("lapack: bad eigenvalue range")
+	case lapack.AllEigenvalues:
+		ncols = n
+	case lapack.IntervalEigenvalues:
+		if vl >= vu {
+			panic("lapack: vl >= vu")
+		}
+		ncols = n
+	case lapack.IndexEigenvalues:
+		if n > 0 && (il < 0 || iu < il || n <= iu) {
+			panic("lapack: il or iu out of range")
+		}
+		ncols = iu - il + 1
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+
+	lwmin := max(1, 12*n)
+	liwmin := max(1, 8*n)
+	if wantz {
+		lwmin = max(1, 18*n)
+		liwmin = max(1, 10*n)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return 0, true
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n {
+		panic(badE)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if wantz {
+		checkMatrix(n, ncols, z, ldz)
+		if len(isuppz) < 2*ncols {
+			panic(badSlice)
+		}
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	wl := vl
+	wu := vu
+
+	if n == 1 {
+		if rng != lapack.IntervalEigenvalues || (wl < d[0] && wu >= d[0]) {
+			m = 1
+			w[0] = d[0]
+			if wantz {
+				z[0] = 1
+				isuppz[0] = 0
+				isuppz[1] = 0
+			}
+		}
+		return m, true
+	}
+
+	if n == 2 {
+		var r1, r2, cs, sn float64
+		if !wantz {
+			r1, r2 = impl.Dlae2(d[0], e[0], d[1])
+		} else {
+			r1, r2, cs, sn = impl.Dlaev2(d[0], e[0], d[1])
+		}
+		// Dlae2 and Dlaev2 outputs satisfy |r1| >= |r2|. However, the
+		// following code requires r1 >= r2. Hence, we correct the order
+		// of r1, r2, cs, sn if r1 < r2 before further processing.
+		laeswap := false
+		if r1 < r2 {
+			r1, r2 = r2, r1
+			laeswap = true
+		}
+		// setSupport sets the support of the m-th eigenvector. Note
+		// that at most one of its elements can be zero.
+		setSupport := func(m int) {
+			isuppz[2*m] = 0
+			isuppz[2*m+1] = 1
+			if z[m] == 0 {
+				isuppz[2*m] = 1
+			}
+			if z[ldz+m] == 0 {
+				isuppz[2*m+1] = 0
+			}
+		}
+		if rng == lapack.AllEigenvalues ||
+			(rng == lapack.IntervalEigenvalues && r2 > wl && r2 <= wu) ||
+			(rng == lapack.IndexEigenvalues && il == 0) {
+			w[m] = r2
+			if wantz {
+				if laeswap {
+					z[m] = cs
+					z[ldz+m] = sn
+				} else {
+					z[m] = -sn
+					z[ldz+m] = cs
+				}
+				setSupport(m)
+			}
+			m++
+		}
+		if rng == lapack.AllEigenvalues ||
+			(rng == lapack.IntervalEigenvalues && r1 > wl && r1 <= wu) ||
+			(rng == lapack.IndexEigenvalues && iu == 1) {
+			w[m] = r1
+			if wantz {
+				if laeswap {
+					z[m] = -sn
+					z[ldz+m] = cs
+				} else {
+					z[m] = cs
+					z[ldz+m] = sn
+				}
+				setSupport(m)
+			}
+			m++
+		}
+		return m, true
+	}
+
+	// Continue with general n.
+	indgrs := 0
+	inderr := 2 * n
+	indgp := 3 * n
+	indd := 4 * n
+	inde2 := 5 * n
+	indwrk := 6 * n
+
+	iinspl := 0
+	iindbl := n
+	iindw := 2 * n
+	iindwk := 3 * n
+
+	// Scale matrix to allowable range, if necessary. The allowable range
+	// is related to the pivmin parameter; see the comments in Dlarrd. The
+	// preference for scaling small values up is heuristic; we expect
+	// users' matrices not to be close to the rmax threshold.
+	bi := blas64.Implementation()
+	scale := 1.0
+	tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if tnrm > 0 && tnrm < rmin {
+		scale = rmin / tnrm
+	} else if tnrm > rmax {
+		scale = rmax / tnrm
+	}
+	if scale != 1 {
+		bi.Dscal(n, scale, d, 1)
+		bi.Dscal(n-1, scale, e, 1)
+		tnrm *= scale
+		if rng == lapack.IntervalEigenvalues {
+			// If eigenvalues in interval have to be found, scale
+			// (wl,wu] accordingly.
+			wl *= scale
+			wu *= scale
+		}
+	}
+
+	// Compute the desired eigenvalues of the tridiagonal after splitting
+	// into smaller subblocks if the corresponding off-diagonal elements are
+	// small. thresh is the splitting parameter for Dlarre. A negative
+	// thresh forces the old splitting criterion based on the size of the
+	// off-diagonal. A positive thresh switches to splitting which preserves
+	// relative accuracy.
+	if tryrac {
+		// Test whether the matrix warrants the more expensive relative
+		// approach.
+		tryrac = impl.Dlarrr(n, d, e)
+	}
+	thresh := -eps
+	if tryrac {
+		thresh = eps
+		// Copy original diagonal, needed to guarantee relative
+		// accuracy.
+		copy(work[indd:indd+n], d[:n])
+	}
+	// Store the squares of the off-diagonal values of T.
+	for j := 0; j < n-1; j++ {
+		work[inde2+j] = e[j] * e[j]
+	}
+
+	// Set the tolerance parameters for bisection.
+	var rtol1, rtol2 float64
+	if !wantz {
+		// Dlarre computes the eigenvalues to full precision.
+		rtol1 = 4 * eps
+		rtol2 = 4 * eps
+	} else {
+		// Dlarre computes the eigenvalues to less than full precision.
+		// Dlarrv will refine the eigenvalue approximations, and we
+		// need less accurate initial bisection in Dlarre. Note that
+		// these settings do only affect the subset case and Dlarre.
+		rtol1 = math.Sqrt(eps)
+		rtol2 = math.Max(math.Sqrt(eps)*5e-3, 4*eps)
+	}
+	isplit := iwork[iinspl : iinspl+n]
+	iblock := iwork[iindbl : iindbl+n]
+	indexw := iwork[iindw : iindw+n]
+	werr := work[inderr : inderr+n]
+	wgap := work[indgp : indgp+n]
+	gers := work[indgrs : indgrs+2*n]
+	wl, wu, nsplit, m, pivmin, ok := impl.Dlarre(rng, n, wl, wu, il, iu, d, e, work[inde2:inde2+n],
+		rtol1, rtol2, thresh, isplit, w, werr, wgap, iblock, indexw, gers, work[indwrk:])
+	if !ok {
+		return 0, false
+	}
+	if m == 0 {
+		// No eigenvalues lie in the requested interval.
+		return 0, true
+	}
+	// Note that if rng is not lapack.IntervalEigenvalues, Dlarre computes
+	// bounds on the desired part of the spectrum. All desired eigenvalues
+	// are contained in (wl,wu].
+
+	if wantz {
+		// Compute the desired eigenvectors corresponding to the
+		// computed eigenvalues.
+		ok = impl.Dlarrv(n, wl, wu, d, e, pivmin, isplit, m, minrgp, rtol1, rtol2,
+			w, werr, wgap, iblock, indexw, gers, z, ldz, isuppz, work[indwrk:], iwork[iindwk:])
+		if !ok {
+			return 0, false
+		}
+	} else {
+		// Dlarre computes eigenvalues of the (shifted) root
+		// representation. Dlarrv returns the eigenvalues of the
+		// unshifted matrix. However, if the eigenvectors are not
+		// desired by the user, we need to apply the corresponding
+		// shifts from Dlarre to obtain the eigenvalues of the original
+		// matrix.
+		for j := 0; j < m; j++ {
+			w[j] += e[isplit[iblock[j]]]
+		}
+	}
+
+	if tryrac {
+		// Refine computed eigenvalues so that they are relatively
+		// accurate with respect to the original matrix T.
+		ibegin := 0
+		wbegin := 0
+		for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+			iend := isplit[jblk]
+			in := iend - ibegin + 1
+			wend := wbegin - 1
+			// Check if any eigenvalues have to be refined in this
+			// block.
+			for wend < m-1 && iblock[wend+1] == jblk {
+				wend++
+			}
+			if wend < wbegin {
+				ibegin = iend + 1
+				continue
+			}
+			offset := indexw[wbegin]
+			ifirst := indexw[wbegin]
+			ilast := indexw[wend]
+			impl.Dlarrj(in, work[indd+ibegin:], work[inde2+ibegin:], ifirst, ilast, 4*eps, offset,
+				w[wbegin:], werr[wbegin:], pivmin, tnrm)
+			ibegin = iend + 1
+			wbegin = wend + 1
+		}
+	}
+
+	// If matrix was scaled, then rescale eigenvalues appropriately.
+	if scale != 1 {
+		bi.Dscal(m, 1/scale, w, 1)
+	}
+
+	// If eigenvalues are not in increasing order, then sort them, possibly
+	// along with eigenvectors.
+	if nsplit > 1 {
+		if !wantz {
+			impl.Dlasrt(lapack.SortIncreasing, m, w)
+			return m, true
+		}
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp {
+					i = jj
+					tmp = w[jj]
+				}
+			}
+			if i >= 0 {
+				w[i] = w[j]
+				w[j] = tmp
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	return m, true
+}
@@ -0,0 +1,147 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A. If eigenvectors are desired, it uses a divide and
+// conquer algorithm, which is considerably faster than Dsyev for large
+// matrices.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsyevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// If n <= 1, lwork must be at least 1. If jobz == lapack.None, lwork must be
+// at least 2*n+1, and if jobz == lapack.ComputeEV, lwork must be at least
+// 1+6*n+2*n*n. iwork is integer temporary storage, and liwork specifies its
+// usable length. If n <= 1 or jobz == lapack.None, liwork must be at least 1,
+// and if jobz == lapack.ComputeEV, liwork must be at least 3+5*n. Dsyevd will
+// panic if the workspace is too small.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dsyevd the optimal
+// work length is stored into work[0] and the minimum integer work length is
+// stored into iwork[0].
+//
+// Dsyevd returns whether all the eigenvalues were found.
+func (impl Implementation) Dsyevd(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	if !wantz && jobz != lapack.None {
+		panic(badEVJob)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, a, lda)
+
+	lwmin := 1
+	liwmin := 1
+	lopt := 1
+	if n > 1 {
+		if wantz {
+			lwmin = 1 + 6*n + 2*n*n
+			liwmin = 3 + 5*n
+		} else {
+			lwmin = 2*n + 1
+		}
+		opts := "L"
+		if uplo == blas.Upper {
+			opts = "U"
+		}
+		nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+		lopt = max(lwmin, 2*n+n*nb)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lopt)
+		iwork[0] = liwmin
+		return
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		w[0] = a[0]
+		if wantz {
+			a[0] = 1
+		}
+		return true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+	}
+
+	// Reduce the symmetric matrix to tridiagonal form.
+	inde := 0
+	indtau := inde + n
+	indwrk := indtau + n
+	llwork := lwork - indwrk
+	impl.Dsytrd(uplo, n, a, lda, w, work[inde:], work[indtau:], work[indwrk:], llwork)
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, first call
+	// Dorgtr to generate the orthogonal matrix, then call Dstedc.
+	if !wantz {
+		ok = impl.Dsterf(n, w, work[inde:])
+	} else {
+		impl.Dorgtr(uplo, n, a, lda, work[indtau:], work[indwrk:], llwork)
+		ok = impl.Dstedc(lapack.OriginalEV, n, w, work[inde:], a, lda, work[indwrk:], llwork, iwork, liwork)
+	}
+	if !ok {
+		return false
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi := blas64.Implementation()
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	work[0] = float64(lopt)
+	iwork[0] = liwmin
+	return true
+}
@@ -0,0 +1,211 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dsyevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. Eigenvalues and eigenvectors can be selected by
+// specifying either a range of values or a range of indices for the desired
+// eigenvalues.
+//
+// Dsyevr first reduces A to tridiagonal form T using Dsytrd. Then the
+// selected eigenvalues and eigenvectors of T are computed by the Multiple
+// Relatively Robust Representations (MRRR) algorithm in Dstemr, except that
+// Dsterf is used when all eigenvalues and no eigenvectors are wanted. Finally
+// the eigenvectors of T are transformed to the eigenvectors of A using Dormtr.
+//
+// The selection is specified by rng:
+//  - if rng == lapack.AllEigenvalues, all eigenvalues are computed and vl, vu,
+//    il and iu are not referenced,
+//  - if rng == lapack.IntervalEigenvalues, the eigenvalues in the half-open
+//    interval (vl,vu] are computed, vl < vu must hold,
+//  - if rng == lapack.IndexEigenvalues, the eigenvalues with indices il
+//    through iu are computed, where the indices are 0-based and the
+//    eigenvalues are in ascending order. 0 <= il <= iu < n must hold.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. On exit, the contents of a are destroyed.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of Z contain the
+// orthonormal eigenvectors of A, the i-th column corresponding to w[i].
+// Z must be n×n if rng is lapack.AllEigenvalues or lapack.IntervalEigenvalues
+// and n×(iu-il+1) if rng is lapack.IndexEigenvalues. isuppz contains the
+// (0-based) support of the eigenvectors, the i-th eigenvector is nonzero only
+// in rows isuppz[2*i] through isuppz[2*i+1]. The support is only computed if
+// Dstemr was used. isuppz must have length at least twice the number of
+// columns of Z. If jobz == lapack.None, z and isuppz are not referenced.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,26*n). iwork is integer temporary storage, and
+// liwork specifies its usable length. liwork must be at least max(1,10*n).
+// Dsyevr will panic if the workspace is too small.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dsyevr the optimal
+// work length is stored into work[0] and the minimum integer work length is
+// stored into iwork[0].
+//
+// Dsyevr returns the number of computed eigenvalues m and whether the
+// computation was successful.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	if !wantz && jobz != lapack.None {
+		panic(badEVJob)
+	}
+	var ncols int
+	switch rng {
+	default:
+		panic("lapack: bad eigenvalue range")
+	case lapack.AllEigenvalues:
+		ncols = n
+	case lapack.IntervalEigenvalues:
+		if vl >= vu {
+			panic("lapack: vl >= vu")
+		}
+		ncols = n
+	case lapack.IndexEigenvalues:
+		if n > 0 && (il < 0 || iu < il || n <= iu) {
+			panic("lapack: il or iu out of range")
+		}
+		ncols = iu - il + 1
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, a, lda)
+
+	lwmin := max(1, 26*n)
+	liwmin := max(1, 10*n)
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := max(impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1), impl.Ilaenv(1, "DORMTR", opts, n, -1, -1, -1))
+	lopt := max((nb+1)*n, lwmin)
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lopt)
+		iwork[0] = liwmin
+		return 0, true
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if wantz {
+		checkMatrix(n, ncols, z, ldz)
+		if len(isuppz) < 2*ncols {
+			panic(badSlice)
+		}
+	}
+
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if rng != lapack.IntervalEigenvalues || (vl < a[0] && vu >= a[0]) {
+			m = 1
+			w[0] = a[0]
+			if wantz {
+				z[0] = 1
+				isuppz[0] = 0
+				isuppz[1] = 0
+			}
+		}
+		return m, true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	vll := vl
+	vuu := vu
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if rng == lapack.IntervalEigenvalues {
+			vll = vl * sigma
+			vuu = vu * sigma
+		}
+	}
+
+	// Initialize indices into workspaces.
+	indtau := 0
+	indd := indtau + n
+	inde := indd + n
+	inddd := inde + n
+	indee := inddd + n
+	indwk := indee + n
+	llwork := lwork - indwk
+
+	// Reduce the symmetric matrix to tridiagonal form.
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwk:], llwork)
+
+	if rng == lapack.AllEigenvalues && !wantz {
+		// All eigenvalues and no eigenvectors are wanted, use Dsterf.
+		copy(w[:n], work[indd:indd+n])
+		copy(work[indee:indee+n-1], work[inde:inde+n-1])
+		if !impl.Dsterf(n, w, work[indee:]) {
+			return 0, false
+		}
+		m = n
+	} else {
+		// Compute the selected eigenvalues and eigenvectors of the
+		// tridiagonal matrix using Dstemr. Dstemr overwrites its input,
+		// so pass a copy.
+		copy(work[indee:indee+n-1], work[inde:inde+n-1])
+		copy(work[inddd:inddd+n], work[indd:indd+n])
+		m, ok = impl.Dstemr(jobz, rng, n, work[inddd:inddd+n], work[indee:indee+n], vll, vuu, il, iu,
+			w, z, ldz, isuppz, true, work[indwk:], llwork, iwork, liwork)
+		if !ok {
+			return 0, false
+		}
+		if wantz && m > 0 {
+			// Apply the orthogonal matrix used in the reduction to
+			// tridiagonal form.
+			impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, m, a, lda, work[indtau:indtau+n], z, ldz, work[inde:], lwork-inde)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi := blas64.Implementation()
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+	work[0] = float64(lopt)
+	iwork[0] = liwmin
+	return m, true
+}
@@ -0,0 +1,426 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Dtgevc computes all the right and/or left eigenvectors of a pair of real
+// n×n matrices (S,P) in generalized Schur form, where S is upper
+// quasi-triangular and P is upper triangular. Matrix pairs of this type are
+// produced by the generalized Schur factorization of a real matrix pair (A,B)
+//  A = Q * S * Z^T,
+//  B = Q * P * Z^T,
+// as computed by Dgghrd followed by Dhgeqz.
+//
+// The right eigenvector x of (S,P) corresponding to an eigenvalue λ is
+// defined by
+//  S x = λ P x,
+// and the left eigenvector y is defined by
+//  y^H S = λ y^H P,
+// where y^H is the conjugate transpose of y. The eigenvalues are not input to
+// this routine but are computed directly from the diagonal blocks of S and P.
+// It is assumed that the 2×2 diagonal blocks of P corresponding to the 2×2
+// diagonal blocks of S are diagonal, as returned by Dhgeqz.
+//
+// This routine returns the matrices X and/or Y of right and left eigenvectors
+// of (S,P), or the products Z*X and/or Q*Y, where Z and Q are input matrices.
+// If Q and Z are the orthogonal factors from the generalized Schur
+// factorization of a matrix pair (A,B), then Z*X and Q*Y are the matrices of
+// right and left eigenvectors of (A,B).
+//
+// If side == lapack.RightEV, only right eigenvectors will be computed.
+// If side == lapack.LeftEV, only left eigenvectors will be computed.
+// If side == lapack.RightLeftEV, both right and left eigenvectors will be computed.
+// For other values of side, Dtgevc will panic.
+//
+// If howmny == lapack.AllEV, all right and/or left eigenvectors of (S,P) will
+// be computed.
+// If howmny == lapack.AllEVMulQ, all right and/or left eigenvectors will be
+// computed and multiplied from the left by the matrices in VR and/or VL. On
+// entry VL must then contain the n×n matrix Q and VR the n×n matrix Z.
+// For other values of howmny, Dtgevc will panic.
+//
+// VL is not referenced if side == lapack.RightEV and VR is not referenced if
+// side == lapack.LeftEV.
+//
+// On return, the eigenvectors are stored in the columns of VL and VR in the
+// same order as their eigenvalues. A complex eigenvector corresponding to a
+// complex conjugate pair of eigenvalues is stored in two consecutive columns,
+// the first holding the real part, and the second the imaginary part of the
+// eigenvector corresponding to the eigenvalue with the positive imaginary
+// part. Each eigenvector is normalized so that the element of largest
+// magnitude has magnitude 1, where the magnitude of a complex number (x,y) is
+// taken to be |x| + |y|.
+//
+// work must have length at least 4*n, otherwise Dtgevc will panic.
+//
+// Dtgevc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgevc(side lapack.EVSide, howmny lapack.HowMany, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, work []float64) {
+	var wantl, wantr bool
+	switch side {
+	default:
+		panic(badEVSide)
+	case lapack.RightEV:
+		wantr = true
+	case lapack.LeftEV:
+		wantl = true
+	case lapack.RightLeftEV:
+		wantl = true
+		wantr = true
+	}
+	var backtr bool
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV:
+	case lapack.AllEVMulQ:
+		backtr = true
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, s, lds)
+	checkMatrix(n, n, p, ldp)
+	if wantl {
+		checkMatrix(n, n, vl, ldvl)
+	}
+	if wantr {
+		checkMatrix(n, n, vr, ldvr)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	const safety = 100
+	safmin := dlamchS
+	ulp := dlamchP
+	bignum := 1 / (safmin * float64(n))
+
+	// Compute the 1-norms of S and P. The elements of S below the first
+	// subdiagonal and of P below the diagonal are zero.
+	var snorm, pnorm float64
+	for j := 0; j < n; j++ {
+		var ssum, psum float64
+		for i := 0; i <= min(j+1, n-1); i++ {
+			ssum += math.Abs(s[i*lds+j])
+		}
+		for i := 0; i <= j; i++ {
+			psum += math.Abs(p[i*ldp+j])
+		}
+		snorm = math.Max(snorm, ssum)
+		pnorm = math.Max(pnorm, psum)
+	}
+	snorm = math.Max(snorm, safmin)
+	pnorm = math.Max(pnorm, safmin)
+
+	// The eigenvector of (S,P) is computed in complex arithmetic with its
+	// real and imaginary parts held in xr and xi, respectively. The
+	// back-transformed eigenvector is held in yr and yi.
+	xr := work[:n]
+	xi := work[n : 2*n]
+	yr := work[2*n : 3*n]
+	yi := work[3*n : 4*n]
+	x := func(i int) complex128 { return complex(xr[i], xi[i]) }
+	setx := func(i int, v complex128) { xr[i], xi[i] = real(v), imag(v) }
+
+	// eigenvalue returns the eigenvalue of the diagonal block of (S,P) at j
+	// of size bs as the pair (alpha,beta) with λ = alpha/beta, where alpha
+	// has a positive imaginary part if the block is 2×2.
+	eigenvalue := func(j, bs int) (alpha complex128, beta float64) {
+		if bs == 2 {
+			scale, _, wr, _, wi := impl.Dlag2(s[j*lds+j:], lds, p[j*ldp+j:], ldp, safmin*safety)
+			return complex(wr, wi), scale
+		}
+		a := s[j*lds+j]
+		b := p[j*ldp+j]
+		scale := math.Max(math.Abs(a), math.Abs(b))
+		if scale == 0 {
+			return 0, 0
+		}
+		return complex(a/scale, 0), b / scale
+	}
+	// pencil returns the (i,j) element of beta*S - alpha*P.
+	pencil := func(i, j int, alpha complex128, beta float64) complex128 {
+		return complex(beta*s[i*lds+j], 0) - alpha*complex(p[i*ldp+j], 0)
+	}
+	// scaleToUnit scales the complex vector with real part re[lo:hi] and
+	// imaginary part im[lo:hi] so that its element of largest magnitude has
+	// magnitude 1.
+	scaleToUnit := func(re, im []float64, lo, hi int) {
+		var vmax float64
+		for i := lo; i < hi; i++ {
+			vmax = math.Max(vmax, math.Abs(re[i])+math.Abs(im[i]))
+		}
+		if vmax == 0 {
+			return
+		}
+		for i := lo; i < hi; i++ {
+			re[i] /= vmax
+			im[i] /= vmax
+		}
+	}
+
+	bi := blas64.Implementation()
+
+	if wantr {
+		// Compute the right eigenvectors, starting with the last
+		// eigenvalue.
+		for je := n - 1; je >= 0; je-- {
+			// Find the diagonal block of the eigenvalue.
+			k, bs := je, 1
+			if je > 0 && s[je*lds+je-1] != 0 {
+				k, bs = je-1, 2
+			}
+			alpha, beta := eigenvalue(k, bs)
+			smin := math.Max(ulp*(math.Abs(beta)*snorm+abs1(alpha)*pnorm), safmin)
+
+			for i := 0; i <= je; i++ {
+				xr[i] = 0
+				xi[i] = 0
+			}
+			if alpha == 0 && beta == 0 {
+				// Singular pencil, return a unit eigenvector.
+				xr[je] = 1
+			} else {
+				if bs == 1 {
+					xr[je] = 1
+				} else {
+					// Compute the null vector of the 2×2 block of
+					// beta*S - alpha*P from its row of larger
+					// magnitude.
+					m00 := pencil(k, k, alpha, beta)
+					m01 := pencil(k, k+1, alpha, beta)
+					m10 := pencil(k+1, k, alpha, beta)
+					m11 := pencil(k+1, k+1, alpha, beta)
+					if abs1(m00)+abs1(m01) >= abs1(m10)+abs1(m11) {
+						setx(k, -m01)
+						setx(k+1, m00)
+					} else {
+						setx(k, -m11)
+						setx(k+1, m10)
+					}
+					scaleToUnit(xr, xi, k, je+1)
+				}
+
+				// Solve the upper quasi-triangular system
+				//  (beta*S - alpha*P)[:k,:k] * x[:k] = -(beta*S - alpha*P)[:k,k:je+1] * x[k:je+1]
+				// by back substitution.
+				for i := k - 1; i >= 0; {
+					ib, ibs := i, 1
+					if i > 0 && s[i*lds+i-1] != 0 {
+						ib, ibs = i-1, 2
+					}
+					var rhs [2]complex128
+					for r := 0; r < ibs; r++ {
+						var sum complex128
+						for l := i + 1; l <= je; l++ {
+							sum += pencil(ib+r, l, alpha, beta) * x(l)
+						}
+						rhs[r] = -sum
+					}
+					if ibs == 1 {
+						setx(i, rhs[0]/safePivot(pencil(i, i, alpha, beta), smin))
+					} else {
+						x0, x1 := solve2x2Complex(
+							pencil(ib, ib, alpha, beta), pencil(ib, ib+1, alpha, beta),
+							pencil(ib+1, ib, alpha, beta), pencil(ib+1, ib+1, alpha, beta),
+							rhs[0], rhs[1], smin)
+						setx(ib, x0)
+						setx(ib+1, x1)
+					}
+					// Rescale to avoid overflow.
+					for l := ib; l < ib+ibs; l++ {
+						if abs1(x(l)) > bignum {
+							scaleToUnit(xr, xi, 0, je+1)
+							break
+						}
+					}
+					i = ib - 1
+				}
+			}
+
+			// Back-transform and normalize the eigenvector.
+			if backtr {
+				bi.Dgemv(blas.NoTrans, n, je+1, 1, vr, ldvr, xr, 1, 0, yr, 1)
+				bi.Dgemv(blas.NoTrans, n, je+1, 1, vr, ldvr, xi, 1, 0, yi, 1)
+			} else {
+				for i := 0; i < n; i++ {
+					if i <= je {
+						yr[i] = xr[i]
+						yi[i] = xi[i]
+					} else {
+						yr[i] = 0
+						yi[i] = 0
+					}
+				}
+			}
+			scaleToUnit(yr, yi, 0, n)
+			bi.Dcopy(n, yr, 1, vr[k:], ldvr)
+			if bs == 2 {
+				bi.Dcopy(n, yi, 1, vr[k+1:], ldvr)
+			}
+			je = k
+		}
+	}
+
+	if wantl {
+		// Compute the left eigenvectors, starting with the first
+		// eigenvalue.
+		for je := 0; je < n; je++ {
+			// Find the diagonal block of the eigenvalue.
+			bs := 1
+			if je < n-1 && s[(je+1)*lds+je] != 0 {
+				bs = 2
+			}
+			alpha, beta := eigenvalue(je, bs)
+			smin := math.Max(ulp*(math.Abs(beta)*snorm+abs1(alpha)*pnorm), safmin)
+			// pencilH returns the (i,j) element of the conjugate
+			// transpose of beta*S - alpha*P.
+			pencilH := func(i, j int) complex128 {
+				v := pencil(j, i, alpha, beta)
+				return complex(real(v), -imag(v))
+			}
+
+			for i := je; i < n; i++ {
+				xr[i] = 0
+				xi[i] = 0
+			}
+			if alpha == 0 && beta == 0 {
+				// Singular pencil, return a unit eigenvector.
+				xr[je] = 1
+			} else {
+				if bs == 1 {
+					xr[je] = 1
+				} else {
+					// Compute the null vector of the 2×2 block of
+					// (beta*S - alpha*P)^H from its row of larger
+					// magnitude.
+					m00 := pencilH(je, je)
+					m01 := pencilH(je, je+1)
+					m10 := pencilH(je+1, je)
+					m11 := pencilH(je+1, je+1)
+					if abs1(m00)+abs1(m01) >= abs1(m10)+abs1(m11) {
+						setx(je, -m01)
+						setx(je+1, m00)
+					} else {
+						setx(je, -m11)
+						setx(je+1, m10)
+					}
+					scaleToUnit(xr, xi, je, je+2)
+				}
+
+				// Solve the lower quasi-triangular system
+				//  (beta*S - alpha*P)^H[k:,k:] * y[k:] = -(beta*S - alpha*P)^H[k:,je:k] * y[je:k]
+				// with k = je+bs by forward substitution.
+				for i := je + bs; i < n; {
+					ibs := 1
+					if i < n-1 && s[(i+1)*lds+i] != 0 {
+						ibs = 2
+					}
+					var rhs [2]complex128
+					for r := 0; r < ibs; r++ {
+						var sum complex128
+						for l := je; l < i; l++ {
+							sum += pencilH(i+r, l) * x(l)
+						}
+						rhs[r] = -sum
+					}
+					if ibs == 1 {
+						setx(i, rhs[0]/safePivot(pencilH(i, i), smin))
+					} else {
+						x0, x1 := solve2x2Complex(
+							pencilH(i, i), pencilH(i, i+1),
+							pencilH(i+1, i), pencilH(i+1, i+1),
+							rhs[0], rhs[1], smin)
+						setx(i, x0)
+						setx(i+1, x1)
+					}
+					// Rescale to avoid overflow.
+					for l := i; l < i+ibs; l++ {
+						if abs1(x(l)) > bignum {
+							scaleToUnit(xr, xi, je, n)
+							break
+						}
+					}
+					i += ibs
+				}
+			}
+
+			// Back-transform and normalize the eigenvector.
+			if backtr {
+				bi.Dgemv(blas.NoTrans, n, n-je, 1, vl[je:], ldvl, xr[je:], 1, 0, yr, 1)
+				bi.Dgemv(blas.NoTrans, n, n-je, 1, vl[je:], ldvl, xi[je:], 1, 0, yi, 1)
+			} else {
+				for i := 0; i < n; i++ {
+					if i >= je {
+						yr[i] = xr[i]
+						yi[i] = xi[i]
+					} else {
+						yr[i] = 0
+						yi[i] = 0
+					}
+				}
+			}
+			scaleToUnit(yr, yi, 0, n)
+			bi.Dcopy(n, yr, 1, vl[je:], ldvl)
+			if bs == 2 {
+				bi.Dcopy(n, yi, 1, vl[je+1:], ldvl)
+			}
+			je += bs - 1
+		}
+	}
+}
+
+// abs1 returns |re(z)| + |im(z)|.
+func abs1(z complex128) float64 {
+	return math.Abs(real(z)) + math.Abs(imag(z))
+}
+
+// safePivot returns d, or smin if d is smaller than smin in magnitude.
+func safePivot(d complex128, smin float64) complex128 {
+	if abs1(d) < smin {
+		return complex(smin, 0)
+	}
+	return d
+}
+
+// solve2x2Complex solves the complex 2×2 system
+//  [ a b ] * [ x0 ] = [ r0 ]
+//  [ c d ]   [ x1 ]   [ r1 ]
+// by Gaussian elimination with complete pivoting. Pivots smaller than smin in
+// magnitude are perturbed to smin.
+func solve2x2Complex(a, b, c, d, r0, r1 complex128, smin float64) (x0, x1 complex128) {
+	// Move the element of largest magnitude to the top left.
+	swapRows := math.Max(abs1(c), abs1(d)) > math.Max(abs1(a), abs1(b))
+	if swapRows {
+		a, b, c, d = c, d, a, b
+		r0, r1 = r1, r0
+	}
+	swapCols := abs1(b) > abs1(a)
+	if swapCols {
+		a, b, c, d = b, a, d, c
+	}
+	a = safePivot(a, smin)
+	l := c / a
+	d = safePivot(d-l*b, smin)
+	r1 -= l * r0
+	x1 = r1 / d
+	x0 = (r0 - b*x1) / a
+	if swapCols {
+		x0, x1 = x1, x0
+	}
+	return x0, x1
+}
@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+// Dtrsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where op(A) = A or A^T depending on trana, op(B) = B or B^T depending on
+// tranb, A is an m×m and B is an n×n upper quasi-triangular matrix in Schur
+// canonical form, and C and X are m×n matrices. Matrices in Schur canonical
+// form are block upper triangular with 1×1 and 2×2 diagonal blocks where each
+// 2×2 diagonal block has its diagonal elements equal and its off-diagonal
+// elements of opposite sign. Such matrices are typically returned by Dhseqr.
+//
+// trana and tranb must be blas.NoTrans or blas.Trans, and isgn must be 1 or
+// -1, otherwise Dtrsyl will panic.
+//
+// On entry, c contains the right-hand side matrix C. On return, c is
+// overwritten by the solution matrix X.
+//
+// Dtrsyl returns a scale factor, less than or equal to 1, chosen to avoid
+// overflow in X. If ok is false, A and -isgn*B have common or very close
+// eigenvalues and perturbed values were used to solve the equation. In this
+// case the equation is singular or nearly singular and the solution may be
+// inaccurate.
+//
+// The equation is solved by a block substitution that uses Dlasy2 to solve
+// the Sylvester equations with the 1×1 and 2×2 diagonal blocks of A and B.
+func (impl Implementation) Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool) {
+	if trana != blas.NoTrans && trana != blas.Trans {
+		panic(badTrans)
+	}
+	if tranb != blas.NoTrans && tranb != blas.Trans {
+		panic(badTrans)
+	}
+	if isgn != 1 && isgn != -1 {
+		panic("lapack: bad isgn")
+	}
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
+
+	// Quick return if possible.
+	scale = 1
+	ok = true
+	if m == 0 || n == 0 {
+		return scale, ok
+	}
+
+	checkMatrix(m, n, c, ldc)
+
+	notrna := trana == blas.NoTrans
+	notrnb := tranb == blas.NoTrans
+	sgn := float64(isgn)
+
+	// Find the starting indices of the diagonal blocks of A and B.
+	ablk := schurBlocks(m, a, lda)
+	bblk := schurBlocks(n, b, ldb)
+
+	bi := blas64.Implementation()
+	var vec, x [4]float64
+	// The columns of X are computed from left to right if op(B) is upper
+	// quasi-triangular and from right to left otherwise. Within a block
+	// column, the rows of X are computed from bottom to top if op(A) is
+	// upper quasi-triangular and from top to bottom otherwise.
+	for jb := 0; jb < len(bblk)-1; jb++ {
+		lb := jb
+		if !notrnb {
+			lb = len(bblk) - 2 - jb
+		}
+		l1 := bblk[lb]
+		l2 := bblk[lb+1] - 1
+		for ib := 0; ib < len(ablk)-1; ib++ {
+			kb := len(ablk) - 2 - ib
+			if !notrna {
+				kb = ib
+			}
+			k1 := ablk[kb]
+			k2 := ablk[kb+1] - 1
+
+			// Compute the right-hand side of the Sylvester equation for
+			// the current block using the parts of X already computed.
+			n1 := k2 - k1 + 1
+			n2 := l2 - l1 + 1
+			for i := k1; i <= k2; i++ {
+				for j := l1; j <= l2; j++ {
+					var suml, sumr float64
+					if notrna {
+						if k2 < m-1 {
+							suml = bi.Ddot(m-k2-1, a[i*lda+k2+1:], 1, c[(k2+1)*ldc+j:], ldc)
+						}
+					} else {
+						suml = bi.Ddot(k1, a[i:], lda, c[j:], ldc)
+					}
+					if notrnb {
+						sumr = bi.Ddot(l1, c[i*ldc:], 1, b[j:], ldb)
+					} else {
+						if l2 < n-1 {
+							sumr = bi.Ddot(n-l2-1, c[i*ldc+l2+1:], 1, b[j*ldb+l2+1:], 1)
+						}
+					}
+					vec[(i-k1)*2+j-l1] = c[i*ldc+j] - (suml + sgn*sumr)
+				}
+			}
+
+			scaloc, _, okloc := impl.Dlasy2(!notrna, !notrnb, isgn, n1, n2,
+				a[k1*lda+k1:], lda, b[l1*ldb+l1:], ldb, vec[:], 2, x[:], 2)
+			if !okloc {
+				ok = false
+			}
+			if scaloc != 1 {
+				for i := 0; i < m; i++ {
+					bi.Dscal(n, scaloc, c[i*ldc:], 1)
+				}
+				scale *= scaloc
+			}
+			for i := 0; i < n1; i++ {
+				for j := 0; j < n2; j++ {
+					c[(k1+i)*ldc+l1+j] = x[i*2+j]
+				}
+			}
+		}
+	}
+	return scale, ok
+}
+
+// schurBlocks returns the starting indices of the 1×1 and 2×2 diagonal blocks
+// of the n×n upper quasi-triangular matrix T, followed by n.
+func schurBlocks(n int, t []float64, ldt int) []int {
+	blk := make([]int, 0, n+1)
+	for k := 0; k < n; {
+		blk = append(blk, k)
+		if k < n-1 && t[(k+1)*ldt+k] != 0 {
+			k += 2
+		} else {
+			k++
+		}
+	}
+	return append(blk, n)
+}
@@ -2,9 +2,13 @@
 // Use of this source code is governed by a BSD-style
 // license that can be found in the LICENSE file.
 
+//go:generate ./single_precision.bash
+
 package gonum
 
 import (
+	"math"
+
 	"gonum.org/v1/gonum/lapack"
 )
 
@@ -13,7 +17,11 @@ import (
 // this code is in pure Go, the underlying BLAS implementation may not be.
 type Implementation struct{}
 
-var _ lapack.Float64 = Implementation{}
+var (
+	_ lapack.Float64    = Implementation{}
+	_ lapack.Float32    = Implementation{}
+	_ lapack.Complex128 = Implementation{}
+)
 
 // This list is duplicated in lapack/cgo. Keep in sync.
 const (
@@ -26,6 +34,8 @@ const (
 	badDiag         = "lapack: bad diag"
 	badDims         = "lapack: bad input dimensions"
 	badDirect       = "lapack: bad direct"
+	badDL           = "lapack: dl has insufficient length"
+	badDU           = "lapack: du has insufficient length"
 	badE            = "lapack: e has insufficient length"
 	badEVComp       = "lapack: bad EVComp"
 	badEVJob        = "lapack: bad EVJob"
@@ -97,6 +107,62 @@ func checkVector(n int, v []float64, inc int) {
 	}
 }
 
+// checkMatrix32 verifies the parameters of a float32 matrix input.
+func checkMatrix32(m, n int, a []float32, lda int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < n {
+		panic("lapack: stride less than number of columns")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+func checkVector32(n int, v []float32, inc int) {
+	if n < 0 {
+		panic("lapack: negative vector length")
+	}
+	if (inc > 0 && (n-1)*inc >= len(v)) || (inc < 0 && (1-n)*inc >= len(v)) {
+		panic("lapack: insufficient vector slice length")
+	}
+}
+
+// checkZMatrix verifies the parameters of a complex128 matrix input.
+func checkZMatrix(m, n int, a []complex128, lda int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < n {
+		panic("lapack: stride less than number of columns")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+func checkZVector(n int, v []complex128, inc int) {
+	if n < 0 {
+		panic("lapack: negative vector length")
+	}
+	if (inc > 0 && (n-1)*inc >= len(v)) || (inc < 0 && (1-n)*inc >= len(v)) {
+		panic("lapack: insufficient vector slice length")
+	}
+}
+
+// cabs1 returns |real(z)|+|imag(z)|, a cheap approximation to |z| that is
+// used in the complex routines in place of the modulus.
+func cabs1(z complex128) float64 {
+	return math.Abs(real(z)) + math.Abs(imag(z))
+}
+
 func checkSymBanded(ab []float64, n, kd, lda int) {
 	if n < 0 {
 		panic("lapack: negative banded length")
@@ -112,6 +178,21 @@ func checkSymBanded(ab []float64, n, kd, lda int) {
 	}
 }
 
+func checkBanded(ab []float64, m, n, kl, ku, lda int) {
+	if m < 0 || n < 0 {
+		panic("lapack: negative banded length")
+	}
+	if kl < 0 || ku < 0 {
+		panic("lapack: negative bandwidth value")
+	}
+	if lda < kl+ku+1 {
+		panic("lapack: stride less than number of bands")
+	}
+	if rows := min(m, n+kl); rows > 0 && len(ab) < (rows-1)*lda+kl+ku+1 {
+		panic("lapack: insufficient banded vector length")
+	}
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -141,3 +222,15 @@ const (
 	// For IEEE this is 2^{-1022}.
 	dlamchS = 1.0 / (1 << 256) / (1 << 256) / (1 << 256) / (1 << 254)
 )
+
+const (
+	// slamchE is the single precision machine epsilon. For IEEE this is 2^{-24}.
+	slamchE float32 = 1.0 / (1 << 24)
+
+	// slamchP is base * eps.
+	slamchP float32 = dlamchB * slamchE
+
+	// slamchS is the single precision "safe minimum". For IEEE this
+	// is 2^{-126}.
+	slamchS float32 = 1.0 / (1 << 126)
+)
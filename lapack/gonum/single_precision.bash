@@ -0,0 +1,54 @@
+#!/usr/bin/env bash
+
+# Copyright ©2017 The gonum Authors. All rights reserved.
+# Use of this source code is governed by a BSD-style
+# license that can be found in the LICENSE file.
+
+WARNING='//\
+// Float32 implementations are autogenerated and not directly tested.\
+'
+
+# Routines needed for the Cholesky, LU and QR factorizations and
+# their solvers, in order of dependency.
+ROUTINES="lapy2 iladlc iladlr laswp larfg larf larft larfb getf2 getrf getrs geqr2 geqrf org2r orgqr orm2r ormqr potf2 potrf trtrs"
+
+RENAME=""
+for r in $ROUTINES; do
+	case $r in
+	iladlc) RENAME="$RENAME -e s/\bIladlc\b/Ilaslc/g" ;;
+	iladlr) RENAME="$RENAME -e s/\bIladlr\b/Ilaslr/g" ;;
+	*)      RENAME="$RENAME -e s/\bD${r}\b/S${r}/g" ;;
+	esac
+done
+
+for r in $ROUTINES; do
+	case $r in
+	iladlc) out=ilaslc.go ;;
+	iladlr) out=ilaslr.go ;;
+	*)      out=s$r.go ;;
+	esac
+	echo Generating $out
+	echo -e '// Code generated by "go generate gonum.org/v1/gonum/lapack/gonum"; DO NOT EDIT.\n' > $out
+	src=d$r.go
+	if [ $r = iladlc ] || [ $r = iladlr ]; then
+		src=$r.go
+	fi
+	cat $src \
+	| gofmt -r 'float64 -> float32' \
+	\
+	| gofmt -r 'checkMatrix -> checkMatrix32' \
+	| gofmt -r 'checkVector -> checkVector32' \
+	| gofmt -r 'dlamchE -> slamchE' \
+	| gofmt -r 'dlamchP -> slamchP' \
+	| gofmt -r 'dlamchS -> slamchS' \
+	\
+	| sed $RENAME \
+	    -e "s_^\(func (\(impl \)\?Implementation) \)\([SI]\)_$WARNING\1\3_" \
+	    -e 's/\bbi\.D/bi.S/g' \
+	    -e 's/\bbi\.Idamax\b/bi.Isamax/g' \
+	    -e 's/"D\([A-Z0-9]*\)"/"S\1"/g' \
+	    -e 's_"gonum.org/v1/gonum/blas/blas64"_"gonum.org/v1/gonum/blas/blas32"_' \
+	    -e 's/\bblas64\.Implementation\b/blas32.Implementation/g' \
+	    -e 's_"math"_math "gonum.org/v1/gonum/internal/math32"_' \
+	>> $out
+done
@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// zgebd2 reduces an m×n complex matrix A with m >= n to real upper bidiagonal
+// form B by a unitary transformation
+//  Q^H * A * P = B.
+//
+// On exit, d and e contain the diagonal and superdiagonal of B, and the
+// elements of a below the diagonal and above the superdiagonal, together with
+// tauQ and tauP, represent Q and P as products of elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{n-1}
+//  P = G_0 * G_1 * ... * G_{n-2}
+//  H_i = I - tauQ[i] * v_i * v_i^H
+//  G_i = I - tauP[i] * u_i * u_i^H
+// where v_i[0:i] = 0, v_i[i] = 1 and v_i[i+1:m] is stored in a[i+1:m, i], and
+// u_i[0:i+1] = 0, u_i[i+1] = 1 and the conjugate of u_i[i+2:n] is stored in
+// a[i, i+2:n].
+//
+// d, tauQ and tauP must have length at least n, e must have length at least
+// n-1 and work must have length at least m.
+func (impl Implementation) zgebd2(m, n int, a []complex128, lda int, d, e []float64, tauQ, tauP, work []complex128) {
+	for i := 0; i < n; i++ {
+		// Generate elementary reflector H_i to annihilate a[i+1:m, i].
+		beta, tq := impl.Zlarfg(m-i, a[i*lda+i], a[min(i+1, m-1)*lda+i:], lda)
+		d[i] = real(beta)
+		tauQ[i] = tq
+		// Apply H_i^H to a[i:m, i+1:n] from the left.
+		if i < n-1 {
+			a[i*lda+i] = 1
+			impl.Zlarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, cmplx.Conj(tq), a[i*lda+i+1:], lda, work)
+		}
+		a[i*lda+i] = beta
+		if i == n-1 {
+			tauP[i] = 0
+			continue
+		}
+		// Generate elementary reflector G_i to annihilate a[i, i+2:n].
+		zlacgv(n-i-1, a[i*lda+i+1:], 1)
+		beta, tp := impl.Zlarfg(n-i-1, a[i*lda+i+1], a[i*lda+min(i+2, n-1):], 1)
+		e[i] = real(beta)
+		tauP[i] = tp
+		// Apply G_i to a[i+1:m, i+1:n] from the right.
+		a[i*lda+i+1] = 1
+		impl.Zlarf(blas.Right, m-i-1, n-i-1, a[i*lda+i+1:], 1, tp, a[(i+1)*lda+i+1:], lda, work)
+		zlacgv(n-i-1, a[i*lda+i+1:], 1)
+		a[i*lda+i+1] = beta
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Zgeev computes the eigenvalues and, optionally, the left and/or right
+// eigenvectors for an n×n complex nonsymmetric matrix A.
+//
+// The right eigenvector v_j of A corresponding to an eigenvalue λ_j
+// is defined by
+//  A v_j = λ_j v_j,
+// and the left eigenvector u_j corresponding to an eigenvalue λ_j is defined by
+//  u_j^H A = λ_j u_j^H,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A will be overwritten and the left and right eigenvectors will be
+// stored, respectively, in the columns of the n×n matrices VL and VR in the
+// same order as their eigenvalues in w. The computed eigenvectors are
+// normalized to have Euclidean norm equal to 1 and largest component real.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Zgeev will panic.
+//
+// w contains the computed eigenvalues and must have length n, and Zgeev will
+// panic otherwise.
+//
+// work must have length at least lwork and lwork must be at least max(1,2*n).
+// If lwork == -1, instead of performing Zgeev, the function only calculates
+// the optimal value of lwork and stores it into work[0]. rwork is real
+// temporary storage and must have length at least 2*n.
+//
+// Zgeev reduces A to upper Hessenberg form and computes its Schur
+// factorization with the single-shift complex QR algorithm. The matrix is
+// not balanced.
+//
+// On return, first is the index of the first valid eigenvalue. If first == 0,
+// all eigenvalues and eigenvectors have been computed. If first is positive,
+// Zgeev failed to compute all the eigenvalues, no eigenvectors have been
+// computed and w[first:] contains those eigenvalues which have converged.
+func (impl Implementation) Zgeev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []complex128, lda int, w []complex128, vl []complex128, ldvl int, vr []complex128, ldvr int, work []complex128, lwork int, rwork []float64) (first int) {
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	minwrk := max(1, 2*n)
+	if lwork == -1 {
+		work[0] = complex(float64(minwrk), 0)
+		return 0
+	}
+	checkZMatrix(n, n, a, lda)
+	if wantvl {
+		checkZMatrix(n, n, vl, ldvl)
+	}
+	if wantvr {
+		checkZMatrix(n, n, vr, ldvr)
+	}
+	switch {
+	case len(w) != n:
+		panic("lapack: bad length of w")
+	case lwork < minwrk:
+		panic(badWork)
+	case len(rwork) < 2*n:
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0
+	}
+
+	// Get machine constants.
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum,bignum].
+	var anrm float64
+	for i := 0; i < n; i++ {
+		for _, v := range a[i*lda : i*lda+n] {
+			anrm = math.Max(anrm, cmplx.Abs(v))
+		}
+	}
+	var scalea bool
+	var cscale float64
+	if 0 < anrm && anrm < smlnum {
+		scalea = true
+		cscale = smlnum
+	} else if anrm > bignum {
+		scalea = true
+		cscale = bignum
+	}
+	if scalea {
+		f := complex(cscale/anrm, 0)
+		for i := 0; i < n; i++ {
+			row := a[i*lda : i*lda+n]
+			for j := range row {
+				row[j] *= f
+			}
+		}
+	}
+
+	// Reduce to upper Hessenberg form.
+	tau := work[:n-1]
+	wrk := work[n : 2*n]
+	impl.zgehd2(n, a, lda, tau, wrk)
+
+	if wantvl || wantvr {
+		q, ldq := vl, ldvl
+		if !wantvl {
+			q, ldq = vr, ldvr
+		}
+		// Copy the Householder vectors to q, shifted one column to the
+		// right, and generate the unitary matrix Q.
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				q[i*ldq+j] = 0
+			}
+		}
+		q[0] = 1
+		for i := 2; i < n; i++ {
+			copy(q[i*ldq+1:i*ldq+i], a[i*lda:i*lda+i-1])
+		}
+		if n > 1 {
+			impl.Zungqr(n-1, n-1, n-1, q[ldq+1:], ldq, tau, wrk, n)
+		}
+
+		// Perform QR iteration, accumulating Schur vectors in q.
+		first = impl.zlahqr(true, true, n, 0, n-1, a, lda, w, 0, n-1, q, ldq)
+		if wantvl && wantvr {
+			// Copy Schur vectors to vr.
+			for i := 0; i < n; i++ {
+				copy(vr[i*ldvr:i*ldvr+n], vl[i*ldvl:i*ldvl+n])
+			}
+		}
+	} else {
+		// Compute eigenvalues only.
+		first = impl.zlahqr(false, false, n, 0, n-1, a, lda, w, 0, 0, nil, 1)
+	}
+
+	if first == 0 && (wantvl || wantvr) {
+		// Compute eigenvectors and normalize them.
+		impl.ztrevc(wantvr, wantvl, n, a, lda, vl, ldvl, vr, ldvr, work[:n])
+		if wantvl {
+			znrmev(n, vl, ldvl, rwork)
+		}
+		if wantvr {
+			znrmev(n, vr, ldvr, rwork)
+		}
+	}
+
+	// Undo scaling if necessary.
+	if scalea {
+		f := complex(anrm/cscale, 0)
+		for i := first; i < n; i++ {
+			w[i] *= f
+		}
+	}
+	work[0] = complex(float64(minwrk), 0)
+	return first
+}
+
+// znrmev normalizes the n columns of the n×n matrix v to have Euclidean norm
+// equal to 1 and largest component real. rwork must have length at least n.
+func znrmev(n int, v []complex128, ldv int, rwork []float64) {
+	bi := cblas128.Implementation()
+	for j := 0; j < n; j++ {
+		col := v[j:]
+		bi.Zdscal(n, 1/bi.Dznrm2(n, col, ldv), col, ldv)
+		for k := 0; k < n; k++ {
+			z := col[k*ldv]
+			rwork[k] = real(z)*real(z) + imag(z)*imag(z)
+		}
+		k := blas64.Implementation().Idamax(n, rwork, 1)
+		bi.Zscal(n, cmplx.Conj(col[k*ldv])/complex(math.Sqrt(rwork[k]), 0), col, ldv)
+		col[k*ldv] = complex(real(col[k*ldv]), 0)
+	}
+}
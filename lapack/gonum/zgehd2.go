@@ -0,0 +1,37 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// zgehd2 reduces an n×n complex matrix A to upper Hessenberg form H by a
+// unitary similarity transformation
+//  Q^H * A * Q = H.
+//
+// On exit, the upper triangle and the first subdiagonal of a contain H, and
+// the elements below the first subdiagonal, together with tau, represent Q
+// as a product of n-1 elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{n-2}
+//  H_i = I - tau[i] * v_i * v_i^H
+// where v_i[0:i+1] = 0, v_i[i+1] = 1 and v_i[i+2:n] is stored in a[i+2:n, i].
+//
+// tau must have length at least n-1 and work must have length at least n.
+func (impl Implementation) zgehd2(n int, a []complex128, lda int, tau, work []complex128) {
+	for i := 0; i < n-1; i++ {
+		// Compute elementary reflector H_i to annihilate a[i+2:n, i].
+		beta, t := impl.Zlarfg(n-i-1, a[(i+1)*lda+i], a[min(i+2, n-1)*lda+i:], lda)
+		tau[i] = t
+		a[(i+1)*lda+i] = 1
+		// Apply H_i to a[0:n, i+1:n] from the right.
+		impl.Zlarf(blas.Right, n, n-i-1, a[(i+1)*lda+i:], lda, t, a[i+1:], lda, work)
+		// Apply H_i^H to a[i+1:n, i+1:n] from the left.
+		impl.Zlarf(blas.Left, n-i-1, n-i-1, a[(i+1)*lda+i:], lda, cmplx.Conj(t), a[(i+1)*lda+i+1:], lda, work)
+		a[(i+1)*lda+i] = beta
+	}
+}
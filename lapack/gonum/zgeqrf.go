@@ -0,0 +1,60 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+// Zgeqrf computes the QR factorization of the m×n complex matrix A.
+//
+// On exit, the elements on and above the diagonal of a contain the min(m,n)×n
+// upper trapezoidal matrix R. The elements below the diagonal, together with
+// tau, represent the unitary matrix Q as a product of min(m,n) elementary
+// reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+//  H_i = I - tau[i] * v_i * v_i^H
+// where v_i[0:i] = 0, v_i[i] = 1 and v_i[i+1:m] is stored in a[i+1:m, i].
+//
+// tau must have length at least min(m,n), and this function will panic otherwise.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// The length of work must be at least max(1, lwork) and lwork must be -1
+// or at least n, otherwise this function will panic. If lwork == -1, instead
+// of performing Zgeqrf, the optimal work length will be stored into work[0].
+//
+// Zgeqrf uses the unblocked algorithm since the complex level 3 triangular
+// BLAS routines are not yet available in the native implementation.
+func (impl Implementation) Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int) {
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(max(1, n)), 0)
+		return
+	}
+	checkZMatrix(m, n, a, lda)
+	if lwork < n {
+		panic(badWork)
+	}
+	k := min(m, n)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	for i := 0; i < k; i++ {
+		// Generate elementary reflector H_i to annihilate a[i+1:m, i].
+		beta, t := impl.Zlarfg(m-i, a[i*lda+i], a[min(i+1, m-1)*lda+i:], lda)
+		tau[i] = t
+		if i < n-1 {
+			// Apply H_i^H to a[i:m, i+1:n] from the left.
+			a[i*lda+i] = 1
+			impl.Zlarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, cmplx.Conj(t), a[i*lda+i+1:], lda, work)
+		}
+		a[i*lda+i] = beta
+	}
+	work[0] = complex(float64(max(1, n)), 0)
+}
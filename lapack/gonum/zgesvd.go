@@ -0,0 +1,245 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+const noZSVDO = "zgesvd: not coded for overwrite"
+
+// Zgesvd computes the singular value decomposition of the complex input
+// matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^H
+// where Sigma is an m×n real diagonal matrix containing the singular values
+// of A, U is an m×m unitary matrix and V is an n×n unitary matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDInPlace   The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^H. lapack.SVDOverwrite
+// is not supported and Zgesvd will panic if it is passed for either job.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to
+// Zgesvd the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDInPlace u is
+// of size m×min(m,n). If jobU == lapack.SVDNone, u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobVT == lapack.SVDAll, vt is of size n×n. If jobVT == lapack.SVDInPlace vt
+// is of size min(m,n)×n. If jobVT == lapack.SVDNone, vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size
+// of the slice. lwork must be at least 2*n+m+m*n if m >= n and at least
+// 2*m+n+2*m*n+m*m+n*n if m < n. If lwork == -1, instead of performing Zgesvd,
+// the optimal work length will be stored into work[0]. rwork is real temporary
+// storage and must have length at least 2*min(m,n)^2+5*min(m,n). Zgesvd will
+// panic if the working memory has insufficient storage.
+//
+// Zgesvd reduces A to real bidiagonal form and computes the singular value
+// decomposition of the bidiagonal matrix with Dbdsqr. If m < n, the
+// decomposition of A^H is computed.
+//
+// Zgesvd returns whether the decomposition successfully completed.
+func (impl Implementation) Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool) {
+	for _, job := range []lapack.SVDJob{jobU, jobVT} {
+		switch job {
+		default:
+			panic(badJob)
+		case lapack.SVDOverwrite:
+			panic(noZSVDO)
+		case lapack.SVDAll, lapack.SVDInPlace, lapack.SVDNone:
+		}
+	}
+	minmn := min(m, n)
+	checkZMatrix(m, n, a, lda)
+	if jobU == lapack.SVDAll {
+		checkZMatrix(m, m, u, ldu)
+	} else if jobU == lapack.SVDInPlace {
+		checkZMatrix(m, minmn, u, ldu)
+	}
+	if jobVT == lapack.SVDAll {
+		checkZMatrix(n, n, vt, ldvt)
+	} else if jobVT == lapack.SVDInPlace {
+		checkZMatrix(minmn, n, vt, ldvt)
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+	minwork := max(1, 2*n+m+m*n)
+	if m < n {
+		minwork = 2*m + n + 2*m*n + m*m + n*n
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(minwork), 0)
+		return
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwork {
+		panic(badWork)
+	}
+	if len(rwork) < 2*minmn*minmn+5*minmn {
+		panic(badWork)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	if m >= n {
+		ok = impl.zgesvdTall(jobU, jobVT, m, n, a, lda, s, u, ldu, vt, ldvt, work, rwork)
+		work[0] = complex(float64(minwork), 0)
+		return ok
+	}
+
+	// Compute the decomposition A^H = V * Sigma * U^H, so that the left
+	// singular vectors of A^H are the right singular vectors of A and
+	// vice versa.
+	b := work[:n*m]
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			b[j*m+i] = cmplx.Conj(a[i*lda+j])
+		}
+	}
+	wrk := work[n*m:]
+	var ncv int
+	switch jobVT {
+	case lapack.SVDAll:
+		ncv = n
+	case lapack.SVDInPlace:
+		ncv = m
+	}
+	v := wrk[:n*ncv]
+	ldv := max(1, ncv)
+	wrk = wrk[n*ncv:]
+	var uh []complex128
+	if jobU != lapack.SVDNone {
+		uh = wrk[:m*m]
+		wrk = wrk[m*m:]
+	}
+	ok = impl.zgesvdTall(jobVT, jobU, n, m, b, m, s, v, ldv, uh, m, wrk, rwork)
+	if !ok {
+		return false
+	}
+	if jobU != lapack.SVDNone {
+		for i := 0; i < m; i++ {
+			for j := 0; j < m; j++ {
+				u[i*ldu+j] = cmplx.Conj(uh[j*m+i])
+			}
+		}
+	}
+	for i := 0; i < ncv; i++ {
+		for j := 0; j < n; j++ {
+			vt[i*ldvt+j] = cmplx.Conj(v[j*ldv+i])
+		}
+	}
+	work[0] = complex(float64(minwork), 0)
+	return true
+}
+
+// zgesvdTall computes the singular value decomposition of the m×n matrix A
+// with m >= n. The parameters are as for Zgesvd, except that work must have
+// length at least 2*n+m+m*n.
+func (impl Implementation) zgesvdTall(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, rwork []float64) (ok bool) {
+	wantu := jobU != lapack.SVDNone
+	wantvt := jobVT != lapack.SVDNone
+
+	// Reduce A to real bidiagonal form B = Q^H * A * P.
+	tauQ := work[:n]
+	tauP := work[n : 2*n]
+	wrk := work[2*n:]
+	e := rwork[:n]
+	impl.zgebd2(m, n, a, lda, s, e, tauQ, tauP, wrk)
+
+	if wantu {
+		// Generate the columns of Q in u.
+		ncu := n
+		if jobU == lapack.SVDAll {
+			ncu = m
+		}
+		for i := 1; i < m; i++ {
+			copy(u[i*ldu:i*ldu+min(i, n)], a[i*lda:i*lda+min(i, n)])
+		}
+		impl.Zungqr(m, ncu, n, u, ldu, tauQ, wrk, len(wrk))
+	}
+	if wantvt {
+		// Generate P in vt. The vectors defining the reflectors are stored
+		// conjugated in the rows of a and are shifted by one column.
+		vt[0] = 1
+		for j := 1; j < n; j++ {
+			vt[j] = 0
+			vt[j*ldvt] = 0
+		}
+		for i := 0; i < n-1; i++ {
+			for j := i + 2; j < n; j++ {
+				vt[j*ldvt+i+1] = cmplx.Conj(a[i*lda+j])
+			}
+		}
+		if n > 1 {
+			impl.Zungqr(n-1, n-1, n-1, vt[ldvt+1:], ldvt, tauP, wrk, len(wrk))
+		}
+		// Overwrite P with P^H.
+		for i := 0; i < n; i++ {
+			vt[i*ldvt+i] = cmplx.Conj(vt[i*ldvt+i])
+			for j := i + 1; j < n; j++ {
+				vt[i*ldvt+j], vt[j*ldvt+i] = cmplx.Conj(vt[j*ldvt+i]), cmplx.Conj(vt[i*ldvt+j])
+			}
+		}
+	}
+
+	// Compute the singular value decomposition of B = Ub * Sigma * Vb^T
+	// with the real singular vectors of B accumulated from the identity.
+	var nru, ncvt int
+	var ub, vbt []float64
+	rwrk := rwork[n:]
+	if wantu {
+		nru = n
+		ub = rwrk[:n*n]
+		impl.Dlaset(blas.All, n, n, 0, 1, ub, n)
+	}
+	rwrk = rwrk[n*n:]
+	if wantvt {
+		ncvt = n
+		vbt = rwrk[:n*n]
+		impl.Dlaset(blas.All, n, n, 0, 1, vbt, n)
+	}
+	rwrk = rwrk[n*n:]
+	ok = impl.Dbdsqr(blas.Upper, n, ncvt, nru, 0, s, e, vbt, n, ub, n, nil, 1, rwrk)
+	if !ok {
+		return false
+	}
+
+	// A = (Q * Ub) * Sigma * (Vb^T * P^H).
+	if wantu {
+		tmp := wrk[:m*n]
+		zlacrm(m, n, n, u, ldu, ub, n, tmp, n)
+		for i := 0; i < m; i++ {
+			copy(u[i*ldu:i*ldu+n], tmp[i*n:i*n+n])
+		}
+	}
+	if wantvt {
+		tmp := wrk[:n*n]
+		zlarcm(n, n, n, vbt, n, vt, ldvt, tmp, n)
+		for i := 0; i < n; i++ {
+			copy(vt[i*ldvt:i*ldvt+n], tmp[i*n:i*n+n])
+		}
+	}
+	return true
+}
@@ -0,0 +1,93 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zgetrs solves a system of equations using an LU factorization.
+// The system of equations solved is
+//  A * X = B   if trans == blas.NoTrans
+//  A^T * X = B if trans == blas.Trans
+//  A^H * X = B if trans == blas.ConjTrans
+// A is a general n×n matrix with stride lda. B is a general matrix of size n×nrhs.
+//
+// On entry b contains the elements of the matrix B. On exit, b contains the
+// elements of X, the solution to the system of equations.
+//
+// a and ipiv contain the LU factorization of A and the permutation indices as
+// computed by Zgetrf. ipiv is zero-indexed.
+func (impl Implementation) Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int) {
+	checkZMatrix(n, n, a, lda)
+	checkZMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic(badTrans)
+	}
+	if trans == blas.NoTrans {
+		// Solve A * X = B.
+		impl.Zlaswp(nrhs, b, ldb, 0, n-1, ipiv[:n], 1)
+		// Solve L * X = B, updating b.
+		ztrsmLeft(blas.Lower, blas.NoTrans, blas.Unit, n, nrhs, a, lda, b, ldb)
+		// Solve U * X = B, updating b.
+		ztrsmLeft(blas.Upper, blas.NoTrans, blas.NonUnit, n, nrhs, a, lda, b, ldb)
+		return
+	}
+	// Solve A^T * X = B or A^H * X = B.
+	// Solve U^T * X = B or U^H * X = B, updating b.
+	ztrsmLeft(blas.Upper, trans, blas.NonUnit, n, nrhs, a, lda, b, ldb)
+	// Solve L^T * X = B or L^H * X = B, updating b.
+	ztrsmLeft(blas.Lower, trans, blas.Unit, n, nrhs, a, lda, b, ldb)
+	impl.Zlaswp(nrhs, b, ldb, 0, n-1, ipiv[:n], -1)
+}
+
+// ztrsmLeft solves op(A) * X = B for X where A is an n×n triangular matrix
+// and B is an n×nrhs matrix. On exit b is overwritten by X. It performs
+// the same operation as Ztrsm with side == blas.Left and alpha == 1 using
+// row operations on b.
+func ztrsmLeft(ul blas.Uplo, trans blas.Transpose, d blas.Diag, n, nrhs int, a []complex128, lda int, b []complex128, ldb int) {
+	bi := cblas128.Implementation()
+	// opA returns element (i, j) of op(A).
+	opA := func(i, j int) complex128 {
+		switch trans {
+		case blas.Trans:
+			return a[j*lda+i]
+		case blas.ConjTrans:
+			return cmplx.Conj(a[j*lda+i])
+		}
+		return a[i*lda+j]
+	}
+	nonUnit := d == blas.NonUnit
+	if (ul == blas.Lower) == (trans == blas.NoTrans) {
+		// op(A) is lower triangular, use forward substitution.
+		for k := 0; k < n; k++ {
+			if nonUnit {
+				bi.Zscal(nrhs, 1/opA(k, k), b[k*ldb:], 1)
+			}
+			for i := k + 1; i < n; i++ {
+				bi.Zaxpy(nrhs, -opA(i, k), b[k*ldb:], 1, b[i*ldb:], 1)
+			}
+		}
+		return
+	}
+	// op(A) is upper triangular, use back substitution.
+	for k := n - 1; k >= 0; k-- {
+		if nonUnit {
+			bi.Zscal(nrhs, 1/opA(k, k), b[k*ldb:], 1)
+		}
+		for i := 0; i < k; i++ {
+			bi.Zaxpy(nrhs, -opA(i, k), b[k*ldb:], 1, b[i*ldb:], 1)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+// Zheev computes all eigenvalues and, optionally, the eigenvectors of a
+// complex Hermitian matrix A.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Zheev will panic otherwise.
+//
+// On entry, a contains the elements of the Hermitian matrix A in the triangular
+// portion specified by uplo. The imaginary parts of the diagonal elements are
+// assumed to be zero. If jobz == lapack.ComputeEV a contains the orthonormal
+// eigenvectors of A on exit, otherwise a is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= max(1, 2*n-1) if jobz == lapack.None and lwork >= n*n+2*n
+// if jobz == lapack.ComputeEV, and Zheev will panic otherwise. If lwork == -1,
+// instead of computing Zheev the optimal work length is stored into work[0].
+//
+// rwork is real temporary storage and must have length at least max(1, 3*n-2)
+// if jobz == lapack.None and at least n*n+3*n-2 if jobz == lapack.ComputeEV.
+//
+// Zheev returns whether the eigendecomposition successfully completed.
+func (impl Implementation) Zheev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	if !wantz && jobz != lapack.None {
+		panic(badEVJob)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkZMatrix(n, n, a, lda)
+	minwork := max(1, 2*n-1)
+	minrwork := max(1, 3*n-2)
+	if wantz {
+		minwork = n*n + 2*n
+		minrwork = n*n + 3*n - 2
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(minwork), 0)
+		return
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwork {
+		panic(badWork)
+	}
+	if len(rwork) < minrwork {
+		panic(badWork)
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		w[0] = real(a[0])
+		work[0] = 1
+		if wantz {
+			a[0] = 1
+		}
+		return true
+	}
+
+	// Make both triangles of a hold A.
+	for i := 0; i < n; i++ {
+		a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+		for j := i + 1; j < n; j++ {
+			if uplo == blas.Upper {
+				a[j*lda+i] = cmplx.Conj(a[i*lda+j])
+			} else {
+				a[i*lda+j] = cmplx.Conj(a[j*lda+i])
+			}
+		}
+	}
+
+	// Scale matrix to allowable range, if necessary.
+	smlnum := dlamchS / dlamchP
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(1 / smlnum)
+	var anrm float64
+	for i := 0; i < n; i++ {
+		for _, v := range a[i*lda : i*lda+n] {
+			anrm = math.Max(anrm, cmplx.Abs(v))
+		}
+	}
+	var sigma float64
+	scaled := false
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		for i := 0; i < n; i++ {
+			row := a[i*lda : i*lda+n]
+			for j := range row {
+				row[j] *= complex(sigma, 0)
+			}
+		}
+	}
+
+	// Reduce A to real symmetric tridiagonal form T = Q^H * A * Q.
+	e := rwork[:n-1]
+	tau := work[:n-1]
+	impl.zhetd2(n, a, lda, w, e, tau)
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, generate Q,
+	// compute the eigenvectors Z of T with Dsteqr and form Q * Z.
+	if !wantz {
+		ok = impl.Dsterf(n, w, e)
+	} else {
+		// Shift the vectors defining the elementary reflectors one column
+		// to the right, and set the first row and column of Q to those of
+		// the unit matrix.
+		for j := n - 1; j >= 1; j-- {
+			a[j] = 0
+			for i := j + 1; i < n; i++ {
+				a[i*lda+j] = a[i*lda+j-1]
+			}
+		}
+		a[0] = 1
+		for i := 1; i < n; i++ {
+			a[i*lda] = 0
+		}
+		impl.Zungqr(n-1, n-1, n-1, a[lda+1:], lda, tau, work[n:2*n], n)
+
+		z := rwork[n : n+n*n]
+		ok = impl.Dsteqr(lapack.TridiagEV, n, w, e, z, n, rwork[n+n*n:])
+		if ok {
+			qz := work[2*n : 2*n+n*n]
+			zlacrm(n, n, n, a, lda, z, n, qz, n)
+			for i := 0; i < n; i++ {
+				copy(a[i*lda:i*lda+n], qz[i*n:i*n+n])
+			}
+		}
+	}
+	if !ok {
+		return false
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi := blas64.Implementation()
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	work[0] = complex(float64(minwork), 0)
+	return true
+}
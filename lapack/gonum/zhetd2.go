@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// zhetd2 reduces an n×n Hermitian matrix A to real symmetric tridiagonal form
+// T by a unitary similarity transformation
+//  Q^H * A * Q = T.
+// Both triangles of a must contain A on entry.
+//
+// On exit, d and e contain the diagonal and subdiagonal of T, and the elements
+// below the first subdiagonal of a, together with tau, represent Q as a
+// product of n-1 elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{n-2}
+//  H_i = I - tau[i] * v_i * v_i^H
+// where v_i[0:i+1] = 0, v_i[i+1] = 1 and v_i[i+2:n] is stored in a[i+2:n, i].
+// The remaining elements of a are overwritten.
+//
+// d must have length at least n, and e and tau must have length at least n-1.
+//
+// zhetd2 updates the full matrix at each step since the complex Hermitian
+// level 2 BLAS routines are not yet available in the native implementation.
+func (impl Implementation) zhetd2(n int, a []complex128, lda int, d, e []float64, tau []complex128) {
+	if n == 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	for i := 0; i < n-1; i++ {
+		// Generate elementary reflector H_i to annihilate a[i+2:n, i].
+		beta, taui := impl.Zlarfg(n-i-1, a[(i+1)*lda+i], a[min(i+2, n-1)*lda+i:], lda)
+		e[i] = real(beta)
+		if taui != 0 {
+			// Apply H_i from both sides to a[i+1:n, i+1:n].
+			a[(i+1)*lda+i] = 1
+			v := a[(i+1)*lda+i:]
+			a22 := a[(i+1)*lda+i+1:]
+			nv := n - i - 1
+
+			// Compute x = tau * A * v, storing x in tau[i:n-1].
+			x := tau[i:]
+			bi.Zgemv(blas.NoTrans, nv, nv, taui, a22, lda, v, lda, 0, x, 1)
+
+			// Compute w = x - 1/2 * tau * (x^H * v) * v.
+			alpha := -0.5 * taui * bi.Zdotc(nv, x, 1, v, lda)
+			bi.Zaxpy(nv, alpha, v, lda, x, 1)
+
+			// Apply the transformation as a rank-2 update
+			//  A = A - v * w^H - w * v^H.
+			bi.Zgerc(nv, nv, -1, v, lda, x, 1, a22, lda)
+			bi.Zgerc(nv, nv, -1, x, 1, v, lda, a22, lda)
+		}
+		a[(i+1)*lda+i] = complex(e[i], 0)
+		d[i] = real(a[i*lda+i])
+		tau[i] = taui
+	}
+	d[n-1] = real(a[(n-1)*lda+n-1])
+}
@@ -0,0 +1,14 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import "math/cmplx"
+
+// zlacgv conjugates the n elements of the complex vector x in place.
+func zlacgv(n int, x []complex128, incX int) {
+	for i := 0; i < n; i++ {
+		x[i*incX] = cmplx.Conj(x[i*incX])
+	}
+}
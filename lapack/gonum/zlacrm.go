@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+// zlacrm computes the m×n matrix
+//  C = A * B
+// where A is an m×k complex matrix and B is a k×n real matrix.
+func zlacrm(m, n, k int, a []complex128, lda int, b []float64, ldb int, c []complex128, ldc int) {
+	for i := 0; i < m; i++ {
+		ci := c[i*ldc : i*ldc+n]
+		for j := range ci {
+			ci[j] = 0
+		}
+		for l, ail := range a[i*lda : i*lda+k] {
+			if ail == 0 {
+				continue
+			}
+			for j, blj := range b[l*ldb : l*ldb+n] {
+				ci[j] += ail * complex(blj, 0)
+			}
+		}
+	}
+}
+
+// zlarcm computes the m×n matrix
+//  C = A * B
+// where A is an m×k real matrix and B is a k×n complex matrix.
+func zlarcm(m, n, k int, a []float64, lda int, b []complex128, ldb int, c []complex128, ldc int) {
+	for i := 0; i < m; i++ {
+		ci := c[i*ldc : i*ldc+n]
+		for j := range ci {
+			ci[j] = 0
+		}
+		for l, ail := range a[i*lda : i*lda+k] {
+			if ail == 0 {
+				continue
+			}
+			for j, blj := range b[l*ldb : l*ldb+n] {
+				ci[j] += complex(ail, 0) * blj
+			}
+		}
+	}
+}
@@ -0,0 +1,312 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// zlahqr computes the eigenvalues and optionally the Schur factorization of
+// an n×n complex upper Hessenberg matrix H using the single-shift complex QR
+// algorithm. It is the complex counterpart of Dlahqr and the parameters have
+// the same meaning, with w holding the computed eigenvalues. On return with
+// wantt true, H[ilo:ihi+1, ilo:ihi+1] is upper triangular and its diagonal
+// holds the eigenvalues.
+//
+// zlahqr returns the index of the first eigenvalue that was computed
+// successfully. If unconverged is positive, w[unconverged:ihi+1] contain the
+// eigenvalues that have converged.
+func (impl Implementation) zlahqr(wantt, wantz bool, n, ilo, ihi int, h []complex128, ldh int, w []complex128, iloz, ihiz int, z []complex128, ldz int) (unconverged int) {
+	if n == 0 {
+		return 0
+	}
+	if ilo == ihi {
+		w[ilo] = h[ilo*ldh+ilo]
+		return 0
+	}
+	bi := cblas128.Implementation()
+
+	// Clear out the trash.
+	for j := ilo; j < ihi-2; j++ {
+		h[(j+2)*ldh+j] = 0
+		h[(j+3)*ldh+j] = 0
+	}
+	if ilo <= ihi-2 {
+		h[ihi*ldh+ihi-2] = 0
+	}
+	var jlo, jhi int
+	if wantt {
+		jlo, jhi = 0, n-1
+	} else {
+		jlo, jhi = ilo, ihi
+	}
+
+	// Ensure that the subdiagonal entries are real.
+	for i := ilo + 1; i <= ihi; i++ {
+		if imag(h[i*ldh+i-1]) == 0 {
+			continue
+		}
+		sc := h[i*ldh+i-1] / complex(cabs1(h[i*ldh+i-1]), 0)
+		sc = cmplx.Conj(sc) / complex(cmplx.Abs(sc), 0)
+		h[i*ldh+i-1] = complex(cmplx.Abs(h[i*ldh+i-1]), 0)
+		bi.Zscal(jhi-i+1, sc, h[i*ldh+i:], 1)
+		bi.Zscal(min(jhi, i+1)-jlo+1, cmplx.Conj(sc), h[jlo*ldh+i:], ldh)
+		if wantz {
+			bi.Zscal(ihiz-iloz+1, cmplx.Conj(sc), z[iloz*ldz+i:], ldz)
+		}
+	}
+
+	nh := ihi - ilo + 1
+	nz := ihiz - iloz + 1
+	ulp := dlamchP
+	smlnum := dlamchS * (float64(nh) / ulp)
+
+	// i1 and i2 are the indices of the first row and last column of H to
+	// which transformations must be applied. If eigenvalues only are being
+	// computed, i1 and i2 are set inside the main loop.
+	var i1, i2 int
+	if wantt {
+		i1, i2 = 0, n-1
+	}
+
+	itmax := 30 * max(10, nh)
+
+	// kdefl counts the number of iterations since a deflation.
+	var kdefl int
+
+	// The main loop begins here. i is the loop index and decreases from ihi
+	// to ilo in steps of 1. Each iteration of the loop works with the active
+	// submatrix in rows and columns l to i. Eigenvalues i+1 to ihi have
+	// already converged. Either l = ilo, or h[l,l-1] is negligible so that
+	// the matrix splits.
+	const (
+		kexsh = 10
+		dat1  = 0.75
+	)
+	var v [2]complex128
+	i := ihi
+	for i >= ilo {
+		l := ilo
+		converged := false
+		for its := 0; its <= itmax; its++ {
+			// Look for a single small subdiagonal element.
+			k := i
+			for ; k > l; k-- {
+				if cabs1(h[k*ldh+k-1]) <= smlnum {
+					break
+				}
+				tst := cabs1(h[(k-1)*ldh+k-1]) + cabs1(h[k*ldh+k])
+				if tst == 0 {
+					if k-2 >= ilo {
+						tst += math.Abs(real(h[(k-1)*ldh+k-2]))
+					}
+					if k+1 <= ihi {
+						tst += math.Abs(real(h[(k+1)*ldh+k]))
+					}
+				}
+				// The following is a conservative small subdiagonal
+				// deflation criterion due to Ahues & Kahan (1997). It
+				// has better mathematical foundation and improves
+				// accuracy in some examples.
+				if math.Abs(real(h[k*ldh+k-1])) <= ulp*tst {
+					ab := math.Max(cabs1(h[k*ldh+k-1]), cabs1(h[(k-1)*ldh+k]))
+					ba := math.Min(cabs1(h[k*ldh+k-1]), cabs1(h[(k-1)*ldh+k]))
+					aa := math.Max(cabs1(h[k*ldh+k]), cabs1(h[(k-1)*ldh+k-1]-h[k*ldh+k]))
+					bb := math.Min(cabs1(h[k*ldh+k]), cabs1(h[(k-1)*ldh+k-1]-h[k*ldh+k]))
+					s := aa + ab
+					if ba*(ab/s) <= math.Max(smlnum, ulp*(bb*(aa/s))) {
+						break
+					}
+				}
+			}
+			l = k
+			if l > ilo {
+				// h[l,l-1] is negligible.
+				h[l*ldh+l-1] = 0
+			}
+			if l >= i {
+				// A single eigenvalue has converged.
+				converged = true
+				break
+			}
+			kdefl++
+
+			// Now the active submatrix is in rows and columns l to i.
+			if !wantt {
+				i1, i2 = l, i
+			}
+
+			var t complex128
+			switch {
+			case kdefl%(2*kexsh) == 0:
+				// Exceptional shift.
+				s := dat1 * math.Abs(real(h[i*ldh+i-1]))
+				t = complex(s, 0) + h[i*ldh+i]
+			case kdefl%kexsh == 0:
+				// Exceptional shift.
+				s := dat1 * math.Abs(real(h[(l+1)*ldh+l]))
+				t = complex(s, 0) + h[l*ldh+l]
+			default:
+				// Wilkinson's shift.
+				t = h[i*ldh+i]
+				u := cmplx.Sqrt(h[(i-1)*ldh+i]) * cmplx.Sqrt(h[i*ldh+i-1])
+				s := cabs1(u)
+				if s != 0 {
+					x := 0.5 * (h[(i-1)*ldh+i-1] - t)
+					sx := cabs1(x)
+					s = math.Max(s, sx)
+					cs := complex(s, 0)
+					y := cs * cmplx.Sqrt((x/cs)*(x/cs)+(u/cs)*(u/cs))
+					if sx > 0 {
+						xs := x / complex(sx, 0)
+						if real(xs)*real(y)+imag(xs)*imag(y) < 0 {
+							y = -y
+						}
+					}
+					t -= u * (u / (x + y))
+				}
+			}
+
+			// Look for two consecutive small subdiagonal elements.
+			m := i - 1
+			for ; m > l; m-- {
+				h11 := h[m*ldh+m]
+				h22 := h[(m+1)*ldh+m+1]
+				h11s := h11 - t
+				h21 := real(h[(m+1)*ldh+m])
+				s := cabs1(h11s) + math.Abs(h21)
+				h11s /= complex(s, 0)
+				h21 /= s
+				v[0] = h11s
+				v[1] = complex(h21, 0)
+				h10 := real(h[m*ldh+m-1])
+				if math.Abs(h10)*math.Abs(h21) <= ulp*(cabs1(h11s)*(cabs1(h11)+cabs1(h22))) {
+					break
+				}
+			}
+			if m == l {
+				h11 := h[l*ldh+l]
+				h11s := h11 - t
+				h21 := real(h[(l+1)*ldh+l])
+				s := cabs1(h11s) + math.Abs(h21)
+				h11s /= complex(s, 0)
+				h21 /= s
+				v[0] = h11s
+				v[1] = complex(h21, 0)
+			}
+
+			// Single-shift QR step.
+			for k := m; k < i; k++ {
+				// The first iteration of this loop determines a reflection
+				// G from the vector v and applies it from left and right
+				// to H, thus creating a nonzero bulge below the
+				// subdiagonal.
+				//
+				// Each subsequent iteration determines a reflection G to
+				// restore the Hessenberg form in the (k-1)th column, and
+				// thus chases the bulge one step toward the bottom of the
+				// active submatrix.
+				//
+				// v[1] is always real before the call to Zlarfg, and hence
+				// after the call t2 (= t1*v[1]) is also real.
+				if k > m {
+					v[0] = h[k*ldh+k-1]
+					v[1] = h[(k+1)*ldh+k-1]
+				}
+				var t1 complex128
+				v[0], t1 = impl.Zlarfg(2, v[0], v[1:], 1)
+				if k > m {
+					h[k*ldh+k-1] = v[0]
+					h[(k+1)*ldh+k-1] = 0
+				}
+				v2 := v[1]
+				t2 := real(t1 * v2)
+
+				// Apply G from the left to transform the rows of the
+				// matrix in columns k to i2.
+				for j := k; j <= i2; j++ {
+					sum := cmplx.Conj(t1)*h[k*ldh+j] + complex(t2, 0)*h[(k+1)*ldh+j]
+					h[k*ldh+j] -= sum
+					h[(k+1)*ldh+j] -= sum * v2
+				}
+
+				// Apply G from the right to transform the columns of the
+				// matrix in rows i1 to min(k+2,i).
+				for j := i1; j <= min(k+2, i); j++ {
+					sum := t1*h[j*ldh+k] + complex(t2, 0)*h[j*ldh+k+1]
+					h[j*ldh+k] -= sum
+					h[j*ldh+k+1] -= sum * cmplx.Conj(v2)
+				}
+
+				if wantz {
+					// Accumulate transformations in the matrix z.
+					for j := iloz; j <= ihiz; j++ {
+						sum := t1*z[j*ldz+k] + complex(t2, 0)*z[j*ldz+k+1]
+						z[j*ldz+k] -= sum
+						z[j*ldz+k+1] -= sum * cmplx.Conj(v2)
+					}
+				}
+
+				if k == m && m > l {
+					// If the QR step was started at row m > l because
+					// two consecutive small subdiagonals were found,
+					// then extra scaling must be performed to ensure
+					// that h[m,m-1] remains real.
+					temp := 1 - t1
+					temp /= complex(cmplx.Abs(temp), 0)
+					h[(m+1)*ldh+m] *= cmplx.Conj(temp)
+					if m+2 <= i {
+						h[(m+2)*ldh+m+1] *= temp
+					}
+					for j := m; j <= i; j++ {
+						if j == m+1 {
+							continue
+						}
+						if i2 > j {
+							bi.Zscal(i2-j, temp, h[j*ldh+j+1:], 1)
+						}
+						bi.Zscal(j-i1, cmplx.Conj(temp), h[i1*ldh+j:], ldh)
+						if wantz {
+							bi.Zscal(nz, cmplx.Conj(temp), z[iloz*ldz+j:], ldz)
+						}
+					}
+				}
+			}
+
+			// Ensure that h[i,i-1] is real.
+			temp := h[i*ldh+i-1]
+			if imag(temp) != 0 {
+				rtemp := cmplx.Abs(temp)
+				h[i*ldh+i-1] = complex(rtemp, 0)
+				temp /= complex(rtemp, 0)
+				if i2 > i {
+					bi.Zscal(i2-i, cmplx.Conj(temp), h[i*ldh+i+1:], 1)
+				}
+				bi.Zscal(i-i1, temp, h[i1*ldh+i:], ldh)
+				if wantz {
+					bi.Zscal(nz, temp, z[iloz*ldz+i:], ldz)
+				}
+			}
+		}
+
+		if !converged {
+			// The QR iteration failed to converge.
+			return i + 1
+		}
+
+		// h[i,i-1] is negligible: one eigenvalue has converged.
+		w[i] = h[i*ldh+i]
+
+		// Reset deflation counter.
+		kdefl = 0
+
+		// Return to start of the main loop with new value of i.
+		i = l - 1
+	}
+	return 0
+}
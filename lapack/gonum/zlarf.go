@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zlarf applies an elementary reflector to a general rectangular matrix c.
+// This computes
+//  c = h * c if side == Left
+//  c = c * h if side == Right
+// where
+//  h = 1 - tau * v * v^H
+// and c is an m * n matrix. To apply h^H instead of h, the caller passes
+// the conjugate of tau.
+//
+// work is temporary storage of length at least n if side == Left and at least
+// m if side == Right. This function will panic if this length requirement is not met.
+//
+// Zlarf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zlarf(side blas.Side, m, n int, v []complex128, incv int, tau complex128, c []complex128, ldc int, work []complex128) {
+	applyleft := side == blas.Left
+	if (applyleft && len(work) < n) || (!applyleft && len(work) < m) {
+		panic(badWork)
+	}
+	checkZMatrix(m, n, c, ldc)
+
+	if tau == 0 || m == 0 || n == 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	if applyleft {
+		checkZVector(m, v, incv)
+		// w = c^H * v
+		bi.Zgemv(blas.ConjTrans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+		// c = c - tau * v * w^H
+		bi.Zgerc(m, n, -tau, v, incv, work, 1, c, ldc)
+		return
+	}
+	checkZVector(n, v, incv)
+	// w = c * v
+	bi.Zgemv(blas.NoTrans, m, n, 1, c, ldc, v, incv, 0, work, 1)
+	// c = c - tau * w * v^H
+	bi.Zgerc(m, n, -tau, work, 1, v, incv, c, ldc)
+}
@@ -0,0 +1,70 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zlarfg generates a complex elementary reflector for a Householder matrix.
+// It creates a reflector of order n such that
+//  H^H * (alpha) = (beta)
+//        (    x)   (   0)
+//  H^H * H = I
+// where beta is real. H is represented in the form
+//  H = 1 - tau * (1; v) * (1 v^H)
+// where tau is a complex scalar with 1 <= real(tau) <= 2 and |tau-1| <= 1.
+// If the elements of x are all zero and alpha is real, tau is zero and H is
+// the identity.
+//
+// On entry, x contains the vector x, on exit it contains v. The returned beta
+// has zero imaginary part except when n == 0, in which case alpha is returned
+// unchanged.
+//
+// Zlarfg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Zlarfg(n int, alpha complex128, x []complex128, incX int) (beta, tau complex128) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return alpha, 0
+	}
+	checkZVector(n-1, x, incX)
+	bi := cblas128.Implementation()
+	xnorm := bi.Dznrm2(n-1, x, incX)
+	alphr := real(alpha)
+	alphi := imag(alpha)
+	if xnorm == 0 && alphi == 0 {
+		return alpha, 0
+	}
+	beta0 := -math.Copysign(dlapy3(alphr, alphi, xnorm), alphr)
+	safmin := dlamchS / dlamchE
+	rsafmn := 1 / safmin
+	var knt int
+	if math.Abs(beta0) < safmin {
+		// xnorm and beta may be inaccurate, scale x and recompute.
+		for {
+			knt++
+			bi.Zdscal(n-1, rsafmn, x, incX)
+			beta0 *= rsafmn
+			alphi *= rsafmn
+			alphr *= rsafmn
+			if math.Abs(beta0) >= safmin || knt >= 20 {
+				break
+			}
+		}
+		xnorm = bi.Dznrm2(n-1, x, incX)
+		alpha = complex(alphr, alphi)
+		beta0 = -math.Copysign(dlapy3(alphr, alphi, xnorm), alphr)
+	}
+	tau = complex((beta0-alphr)/beta0, -alphi/beta0)
+	bi.Zscal(n-1, 1/(alpha-complex(beta0, 0)), x, incX)
+	for j := 0; j < knt; j++ {
+		beta0 *= safmin
+	}
+	return complex(beta0, 0), tau
+}
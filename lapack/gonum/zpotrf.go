@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zpotrf computes the Cholesky decomposition of the Hermitian positive definite
+// matrix a. If ul == blas.Upper, then a is stored as an upper-triangular matrix,
+// and a = U^H U is stored in place into a. If ul == blas.Lower, then a = L L^H
+// is computed and stored in-place into a. Only the real parts of the diagonal
+// elements of a are referenced. If a is not positive definite, false is
+// returned.
+//
+// Zpotrf uses the unblocked algorithm since the complex level 3 triangular
+// BLAS routines are not yet available in the native implementation.
+func (impl Implementation) Zpotrf(ul blas.Uplo, n int, a []complex128, lda int) (ok bool) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkZMatrix(n, n, a, lda)
+
+	if n == 0 {
+		return true
+	}
+	bi := cblas128.Implementation()
+	if ul == blas.Upper {
+		for j := 0; j < n; j++ {
+			// Compute U[j,j] and test for non-positive-definiteness.
+			ajj := real(a[j*lda+j]) - real(bi.Zdotc(j, a[j:], lda, a[j:], lda))
+			if ajj <= 0 || math.IsNaN(ajj) {
+				a[j*lda+j] = complex(ajj, 0)
+				return false
+			}
+			ajj = math.Sqrt(ajj)
+			a[j*lda+j] = complex(ajj, 0)
+			// Compute elements j+1:n of row j.
+			if j < n-1 {
+				zlacgv(j, a[j:], lda)
+				bi.Zgemv(blas.Trans, j, n-j-1, -1, a[j+1:], lda, a[j:], lda, 1, a[j*lda+j+1:], 1)
+				zlacgv(j, a[j:], lda)
+				bi.Zdscal(n-j-1, 1/ajj, a[j*lda+j+1:], 1)
+			}
+		}
+		return true
+	}
+	for j := 0; j < n; j++ {
+		// Compute L[j,j] and test for non-positive-definiteness.
+		ajj := real(a[j*lda+j]) - real(bi.Zdotc(j, a[j*lda:], 1, a[j*lda:], 1))
+		if ajj <= 0 || math.IsNaN(ajj) {
+			a[j*lda+j] = complex(ajj, 0)
+			return false
+		}
+		ajj = math.Sqrt(ajj)
+		a[j*lda+j] = complex(ajj, 0)
+		// Compute elements j+1:n of column j.
+		if j < n-1 {
+			zlacgv(j, a[j*lda:], 1)
+			bi.Zgemv(blas.NoTrans, n-j-1, j, -1, a[(j+1)*lda:], lda, a[j*lda:], 1, 1, a[(j+1)*lda+j:], lda)
+			zlacgv(j, a[j*lda:], 1)
+			bi.Zdscal(n-j-1, 1/ajj, a[(j+1)*lda+j:], lda)
+		}
+	}
+	return true
+}
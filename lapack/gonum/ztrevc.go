@@ -0,0 +1,108 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// ztrevc computes all right and/or left eigenvectors of an n×n complex upper
+// triangular matrix T, such as the Schur form returned by zlahqr, and
+// back-transforms them by the matrices in vr and vl.
+//
+// The right eigenvector x and the left eigenvector y of T corresponding to an
+// eigenvalue λ are defined by
+//  T * x = λ * x
+//  y^H * T = λ * y^H.
+//
+// If wantr is true, on entry vr must contain an n×n matrix Q, usually the
+// unitary matrix of Schur vectors, and on exit the j-th column of vr contains
+// Q * x_j where x_j is the right eigenvector for the eigenvalue T[j,j]. If
+// wantl is true, vl is treated in the same way for the left eigenvectors.
+// The eigenvectors are not normalized.
+//
+// Diagonal elements of T - λ*I that are smaller than a small multiple of |λ|
+// are perturbed during the triangular solves so that the eigenvectors of
+// repeated eigenvalues can be computed.
+//
+// work must have length at least n.
+func (impl Implementation) ztrevc(wantr, wantl bool, n int, t []complex128, ldt int, vl []complex128, ldvl int, vr []complex128, ldvr int, work []complex128) {
+	if n == 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	ulp := dlamchP
+	smlnum := dlamchS * (float64(n) / ulp)
+
+	if wantr {
+		for ki := n - 1; ki >= 0; ki-- {
+			lambda := t[ki*ldt+ki]
+			smin := math.Max(ulp*cabs1(lambda), smlnum)
+
+			// Solve the upper triangular system
+			//  (T[0:ki,0:ki] - λ*I) * x = -T[0:ki,ki]
+			// by back substitution, with x[ki] = 1.
+			x := work[:ki+1]
+			x[ki] = 1
+			for k := 0; k < ki; k++ {
+				x[k] = -t[k*ldt+ki]
+			}
+			for k := ki - 1; k >= 0; k-- {
+				d := t[k*ldt+k] - lambda
+				if cabs1(d) < smin {
+					d = complex(smin, 0)
+				}
+				x[k] /= d
+				xk := x[k]
+				for j := 0; j < k; j++ {
+					x[j] -= t[j*ldt+k] * xk
+				}
+			}
+
+			// Back-transform, vr[:,ki] = vr[:,0:ki+1] * x. The columns
+			// of vr to the left of ki have not yet been overwritten.
+			if ki > 0 {
+				bi.Zgemv(blas.NoTrans, n, ki, 1, vr, ldvr, x, 1, 1, vr[ki:], ldvr)
+			}
+		}
+	}
+
+	if wantl {
+		for ki := 0; ki < n; ki++ {
+			lambda := t[ki*ldt+ki]
+			smin := math.Max(ulp*cabs1(lambda), smlnum)
+
+			// Solve the lower triangular system
+			//  (T[ki+1:n,ki+1:n] - λ*I)^H * y = -T[ki,ki+1:n]^H
+			// by forward substitution, with y[ki] = 1.
+			y := work[:n]
+			y[ki] = 1
+			for k := ki + 1; k < n; k++ {
+				y[k] = -cmplx.Conj(t[ki*ldt+k])
+			}
+			for k := ki + 1; k < n; k++ {
+				d := cmplx.Conj(t[k*ldt+k] - lambda)
+				if cabs1(d) < smin {
+					d = complex(smin, 0)
+				}
+				y[k] /= d
+				yk := y[k]
+				for j := k + 1; j < n; j++ {
+					y[j] -= cmplx.Conj(t[k*ldt+j]) * yk
+				}
+			}
+
+			// Back-transform, vl[:,ki] = vl[:,ki:n] * y[ki:n]. The columns
+			// of vl to the right of ki have not yet been overwritten.
+			if ki < n-1 {
+				bi.Zgemv(blas.NoTrans, n, n-ki-1, 1, vl[ki+1:], ldvl, y[ki+1:], 1, 1, vl[ki:], ldvl)
+			}
+		}
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gonum
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// Zungqr generates an m×n complex matrix Q with orthonormal columns defined
+// by the product of elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as computed by Zgeqrf. On entry, the i-th column of a must contain the
+// vector defining H_i as returned by Zgeqrf in its first k columns. On exit,
+// a contains Q.
+//
+// The length of tau must be at least k. It also must be that 0 <= k <= n and
+// 0 <= n <= m.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// The length of work must be at least max(1, lwork) and lwork must be -1
+// or at least n, otherwise this function will panic. If lwork == -1, instead
+// of computing Zungqr the optimal work length is stored into work[0].
+//
+// Zungqr uses the unblocked algorithm since the complex level 3 triangular
+// BLAS routines are not yet available in the native implementation.
+func (impl Implementation) Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int) {
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		work[0] = complex(float64(max(1, n)), 0)
+		return
+	}
+	checkZMatrix(m, n, a, lda)
+	if k < 0 {
+		panic(kLT0)
+	}
+	if k > n {
+		panic(kGTN)
+	}
+	if n > m {
+		panic(mLTN)
+	}
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if lwork < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return
+	}
+	bi := cblas128.Implementation()
+	// Initialize columns k:n to columns of the unit matrix.
+	for l := 0; l < m; l++ {
+		for j := k; j < n; j++ {
+			a[l*lda+j] = 0
+		}
+	}
+	for j := k; j < n; j++ {
+		a[j*lda+j] = 1
+	}
+	for i := k - 1; i >= 0; i-- {
+		// Apply H_i to a[i:m, i:n] from the left.
+		if i < n-1 {
+			a[i*lda+i] = 1
+			impl.Zlarf(blas.Left, m-i, n-i-1, a[i*lda+i:], lda, tau[i], a[i*lda+i+1:], lda, work)
+		}
+		if i < m-1 {
+			bi.Zscal(m-i-1, -tau[i], a[(i+1)*lda+i:], lda)
+		}
+		a[i*lda+i] = 1 - tau[i]
+		// Set a[0:i, i] to zero.
+		for l := 0; l < i; l++ {
+			a[l*lda+i] = 0
+		}
+	}
+	work[0] = complex(float64(max(1, n)), 0)
+}
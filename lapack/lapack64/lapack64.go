@@ -6,7 +6,9 @@
 // calls, as specified in the netlib standard (www.netlib.org).
 //
 // The native Go routines are used by default, and the Use function can be used
-// to set an alternative implementation.
+// to set an alternative implementation. Implementations can be made available
+// by name with Register, and With sets an implementation for the duration of a
+// function call.
 //
 // If the type of matrix (General, Symmetric, etc.) is known and fixed, it is
 // used in the wrapper signature. In many cases, however, the type of the matrix
@@ -29,11 +31,23 @@ import (
 var lapack64 lapack.Float64 = gonum.Implementation{}
 
 // Use sets the LAPACK float64 implementation to be used by subsequent BLAS calls.
-// The default implementation is native.Implementation.
+// The default implementation is gonum.Implementation. If l is nil, the
+// default implementation is restored.
 func Use(l lapack.Float64) {
+	if l == nil {
+		l = gonum.Implementation{}
+	}
 	lapack64 = l
 }
 
+// Implementation returns the current LAPACK float64 implementation.
+//
+// Implementation allows direct calls to the current LAPACK float64
+// implementation giving finer control of parameters.
+func Implementation() lapack.Float64 {
+	return lapack64
+}
+
 // Potrf computes the Cholesky factorization of a.
 // The factorization has the form
 //  A = U^T * U if a.Uplo == blas.Upper, or
@@ -126,6 +140,40 @@ func Geqrf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgeqrf(a.Rows, a.Cols, a.Data, a.Stride, tau, work, lwork)
 }
 
+// Orgqr generates an m×n matrix Q with orthonormal columns defined by the
+// product of the first k elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as computed by Geqrf. On entry, the i-th column of a must contain the vector
+// defining the reflector H_i, and on return a contains the matrix Q. tau must
+// have length at least k. It must hold that 0 <= k <= n <= m.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// At minimum, lwork >= n and this function will panic otherwise. If
+// lwork == -1, instead of performing Orgqr, the optimal work length will be
+// stored into work[0].
+func Orgqr(k int, a blas64.General, tau, work []float64, lwork int) {
+	lapack64.Dorgqr(a.Rows, a.Cols, k, a.Data, a.Stride, tau, work, lwork)
+}
+
+// Geqp3 computes a QR factorization with column pivoting of the m×n matrix A
+//  A * P = Q * R
+// where P is a permutation matrix, Q is an orthogonal matrix and R is upper
+// trapezoidal. On return, a contains R in its upper trapezoid and Q stored as
+// elementary reflectors below the diagonal, and tau holds the scalar factors of
+// the reflectors. tau must have length min(m,n).
+//
+// On entry, if jpvt[j] is at least zero, the jth column of A is permuted to the
+// front of A*P, and if jpvt[j] is -1 the jth column of A is a free column. On
+// return, the jth column of A*P was the jpvt[j] column of A. jpvt must have
+// length n.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least 3*n+1. If lwork == -1, instead of performing Geqp3,
+// the optimal work length will be stored into work[0].
+func Geqp3(a blas64.General, jpvt []int, tau, work []float64, lwork int) {
+	lapack64.Dgeqp3(a.Rows, a.Cols, a.Data, a.Stride, jpvt, tau, work, lwork)
+}
+
 // Gelqf computes the LQ factorization of the m×n matrix A using a blocked
 // algorithm. A is modified to contain the information to construct L and Q. The
 // lower triangle of a contains the matrix L. The elements above the diagonal
@@ -192,6 +240,44 @@ func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt blas64.General, s, work []float64
 	return lapack64.Dgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork)
 }
 
+// Gesdd computes the singular value decomposition of the input matrix A using
+// a divide and conquer method, which is considerably faster than Gesvd for
+// large matrices when the singular vectors are wanted.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz is the option for computing the singular vectors. The behavior is as
+// follows
+//  jobz == lapack.SVDAll       All m columns of U and all n rows of V^T are
+//                              returned in u and vt
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U and rows of V^T
+//                              are returned in u and vt
+//  jobz == lapack.SVDNone      The singular vectors are not computed.
+// Gesdd will panic if jobz == lapack.SVDOverwrite.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesdd
+// the data is overwritten.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If jobz == lapack.SVDNone, lwork must be at least
+// 7*min(m,n) + max(m,n). Otherwise lwork must be at least
+// 4*min(m,n)*min(m,n) + 7*min(m,n) + max(m,n).
+// If lwork == -1, instead of performing Gesdd, the optimal work length will be
+// stored into work[0]. iwork must have length at least 8*min(m,n).
+//
+// Gesdd returns whether the decomposition successfully completed.
+func Gesdd(jobz lapack.SVDJob, a, u, vt blas64.General, s, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgesdd(jobz, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork, iwork)
+}
+
 // Getrf computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of A into
 //  A = P * L * U
@@ -472,6 +558,51 @@ func Syev(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int) (
 	return lapack64.Dsyev(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork)
 }
 
+// Syevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A. If eigenvectors are desired, it uses a divide and
+// conquer algorithm, which is considerably faster than Syev for large
+// matrices.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Syevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If lwork == -1 or liwork == -1, instead of computing Syevd
+// the optimal work length is stored into work[0] and the minimum integer work
+// length is stored into iwork[0].
+func Syevd(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	return lapack64.Dsyevd(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork, iwork, liwork)
+}
+
+// Syevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A using the Multiple Relatively Robust Representations
+// algorithm. The eigenvalues can be selected by specifying either the
+// half-open interval (vl,vu] that contains them or the range il through iu of
+// their 0-based indices, as determined by rng.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by a.Uplo. On exit, the contents of a are destroyed.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order and, if jobz == lapack.ComputeEV, the first m columns of z
+// contain the corresponding orthonormal eigenvectors. z must be n×n if rng is
+// lapack.AllEigenvalues or lapack.IntervalEigenvalues and n×(iu-il+1) if rng
+// is lapack.IndexEigenvalues. isuppz contains the support of the eigenvectors
+// and must have length at least twice the number of columns of z.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If lwork == -1 or liwork == -1, instead of computing Syevr
+// the optimal work length is stored into work[0] and the minimum integer work
+// length is stored into iwork[0].
+func Syevr(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, w []float64, z blas64.General, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	return lapack64.Dsyevr(jobz, rng, a.Uplo, a.N, a.Data, a.Stride, vl, vu, il, iu, w, z.Data, z.Stride, isuppz, work, lwork, iwork, liwork)
+}
+
 // Trcon estimates the reciprocal of the condition number of a triangular matrix A.
 // The condition number computed may be based on the 1-norm or the ∞-norm.
 //
@@ -558,3 +689,346 @@ func Geev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a blas64.General, wr,
 	}
 	return lapack64.Dgeev(jobvl, jobvr, n, a.Data, a.Stride, wr, wi, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
 }
+
+// Ggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// The right eigenvector v_j corresponding to the eigenvalue λ_j of (A,B)
+// satisfies
+//  A v_j = λ_j B v_j,
+// and the left eigenvector u_j corresponding to the eigenvalue λ_j satisfies
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR in
+// the same order as their eigenvalues. The eigenvectors are stored in the same
+// way as by Geev, but each eigenvector is scaled so that its largest component
+// has |real part| + |imaginary part| equal to 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None.
+// Right eigenvectors will be computed only if jobvr == lapack.ComputeRightEV,
+// otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Ggev will panic.
+//
+// On return, the eigenvalues of (A,B) are
+//  λ_j = (alphar[j] + i*alphai[j]) / beta[j],
+// where beta[j] is non-negative and may be zero, in which case λ_j is an
+// infinite eigenvalue. Complex conjugate pairs of eigenvalues appear
+// consecutively with the eigenvalue having the positive imaginary part first.
+// alphar, alphai and beta must have length n, and Ggev will panic otherwise.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n),
+// otherwise Ggev will panic. On return, optimal value of lwork will be stored
+// in work[0]. If lwork == -1, instead of performing Ggev, the function only
+// calculates the optimal value of lwork and stores it into work[0].
+//
+// On return, first will be the index of the first valid eigenvalue.
+// If first == 0, all eigenvalues and eigenvectors have been computed.
+// If first is positive, Ggev failed to compute all the eigenvalues, no
+// eigenvectors have been computed and alphar[first:], alphai[first:] and
+// beta[first:] contain those eigenvalues which have converged.
+func Ggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a, b blas64.General, alphar, alphai, beta []float64, vl, vr blas64.General, work []float64, lwork int) (first int) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if b.Rows != n || b.Cols != n {
+		panic("lapack64: bad size of B")
+	}
+	if jobvl == lapack.ComputeLeftEV && (vl.Rows != n || vl.Cols != n) {
+		panic("lapack64: bad size of VL")
+	}
+	if jobvr == lapack.ComputeRightEV && (vr.Rows != n || vr.Cols != n) {
+		panic("lapack64: bad size of VR")
+	}
+	return lapack64.Dggev(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
+}
+
+// Gehrd reduces a block of a real n×n general matrix A to upper Hessenberg
+// form H by an orthogonal similarity transformation Q^T * A * Q = H.
+//
+// The matrix Q is represented as a product of (ihi-ilo) elementary
+// reflectors
+//  Q = H_{ilo} H_{ilo+1} ... H_{ihi-1}.
+// Each H_i has the form
+//  H_i = I - tau[i] * v * v^T
+// where v is a real vector with v[0:i+1] = 0, v[i+1] = 1 and v[ihi+1:n] = 0.
+// v[i+2:ihi+1] is stored on exit in A[i+2:ihi+1,i].
+//
+// On return, the upper triangle and the first subdiagonal of A will be
+// overwritten with the upper Hessenberg matrix H, and the elements below the
+// first subdiagonal, with the slice tau, represent the orthogonal matrix Q.
+//
+// ilo and ihi determine the block of A that will be reduced to upper Hessenberg
+// form. It must hold that 0 <= ilo <= ihi < n if n > 0, and ilo == 0 and ihi ==
+// -1 if n == 0, otherwise Gehrd will panic. tau must have length n-1 if n > 0.
+//
+// work must have length at least lwork and lwork must be at least max(1,n),
+// otherwise Gehrd will panic. On return, work[0] contains the optimal value of
+// lwork. If lwork == -1, instead of performing Gehrd, only the optimal value of
+// lwork will be stored in work[0].
+func Gehrd(a blas64.General, ilo, ihi int, tau, work []float64, lwork int) {
+	if a.Rows != a.Cols {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dgehrd(a.Rows, ilo, ihi, a.Data, a.Stride, tau, work, lwork)
+}
+
+// Orghr generates an n×n orthogonal matrix Q which is defined as the product
+// of ihi-ilo elementary reflectors
+//  Q = H_{ilo} H_{ilo+1} ... H_{ihi-1}
+// as returned by Gehrd. On entry, a contains the elementary reflectors and on
+// return it is overwritten by Q.
+//
+// ilo, ihi and tau must have the same values as in the previous call of Gehrd.
+//
+// work must have length at least max(1,lwork) and lwork must be at least
+// ihi-ilo. On return, work[0] will contain the optimal value of lwork. If
+// lwork == -1, instead of performing Orghr, only the optimal value of lwork
+// will be stored into work[0].
+func Orghr(ilo, ihi int, a blas64.General, tau, work []float64, lwork int) {
+	if a.Rows != a.Cols {
+		panic("lapack64: matrix not square")
+	}
+	lapack64.Dorghr(a.Rows, ilo, ihi, a.Data, a.Stride, tau, work, lwork)
+}
+
+// Hseqr computes the eigenvalues of an n×n Hessenberg matrix H and,
+// optionally, the matrices T and Z from the Schur decomposition
+//  H = Z T Z^T,
+// where T is an n×n upper quasi-triangular matrix (the Schur form), and Z is
+// the n×n orthogonal matrix of Schur vectors.
+//
+// If compz == lapack.OriginalEV, on entry z is assumed to contain the
+// orthogonal matrix Q that reduced a matrix A to the Hessenberg form H, and on
+// return z will be updated to the product Q*Z so that
+//  A = (QZ) T (QZ)^T.
+// If compz == lapack.None, z is not referenced.
+//
+// See the documentation of Dhseqr in the lapack/gonum package for the
+// description of the remaining parameters.
+//
+// unconverged indicates whether Hseqr computed all the eigenvalues. If it is
+// zero, the real and imaginary parts of the eigenvalues are stored in wr and
+// wi, respectively.
+func Hseqr(job lapack.EVJob, compz lapack.EVComp, h blas64.General, ilo, ihi int, wr, wi []float64, z blas64.General, work []float64, lwork int) (unconverged int) {
+	if h.Rows != h.Cols {
+		panic("lapack64: matrix not square")
+	}
+	if compz != lapack.None && (z.Rows != h.Rows || z.Cols != h.Cols) {
+		panic("lapack64: bad size of Z")
+	}
+	return lapack64.Dhseqr(job, compz, h.Rows, ilo, ihi, h.Data, h.Stride, wr, wi, z.Data, z.Stride, work, lwork)
+}
+
+// Trexc reorders the real Schur factorization of a n×n real matrix
+//  A = Q*T*Q^T
+// so that the diagonal block of T with row index ifst is moved to row ilst.
+//
+// If compq is lapack.UpdateSchur, on return the matrix Q of Schur vectors will
+// be updated by postmultiplying it with the orthogonal transformation used to
+// reorder T. If compq is lapack.None, q is not referenced.
+//
+// ifstOut will point to the first row of the moved block in its original
+// position and ilstOut will point to the first row of the block in its final
+// position. If ok is false, two adjacent blocks were too close to swap because
+// the problem is very ill-conditioned and T may have been partially reordered.
+//
+// work must have length at least n, otherwise Trexc will panic.
+func Trexc(compq lapack.EVComp, t, q blas64.General, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
+	if t.Rows != t.Cols {
+		panic("lapack64: matrix not square")
+	}
+	if compq != lapack.None && (q.Rows != t.Rows || q.Cols != t.Cols) {
+		panic("lapack64: bad size of Q")
+	}
+	return lapack64.Dtrexc(compq, t.Rows, t.Data, t.Stride, q.Data, q.Stride, ifst, ilst, work)
+}
+
+// Trsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where op(A) = A or A^T depending on trana, op(B) = B or B^T depending on
+// tranb, A is an m×m and B is an n×n upper quasi-triangular matrix in Schur
+// canonical form, and C and X are m×n matrices.
+//
+// On entry, c contains the right-hand side matrix C. On return, c is
+// overwritten by the solution matrix X.
+//
+// Trsyl returns a scale factor, less than or equal to 1, chosen to avoid
+// overflow in X. If ok is false, A and -isgn*B have common or very close
+// eigenvalues and perturbed values were used to solve the equation.
+func Trsyl(trana, tranb blas.Transpose, isgn int, a, b, c blas64.General) (scale float64, ok bool) {
+	if a.Rows != a.Cols || b.Rows != b.Cols {
+		panic("lapack64: matrix not square")
+	}
+	if c.Rows != a.Rows || c.Cols != b.Rows {
+		panic("lapack64: bad size of C")
+	}
+	return lapack64.Dtrsyl(trana, tranb, isgn, a.Rows, b.Rows, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride)
+}
+
+// Sytrf computes the Bunch-Kaufman factorization of a real symmetric matrix A
+//  A = U * D * U^T,  if a.Uplo == blas.Upper,
+//  A = L * D * L^T,  if a.Uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks. On return, a contains D and the multipliers used to obtain U or L,
+// and ipiv contains details of the interchanges and the block structure of D.
+// ipiv must have length at least a.N, and Sytrf will panic otherwise.
+//
+// Sytrf returns whether D is nonsingular.
+func Sytrf(a blas64.Symmetric, ipiv []int) (ok bool) {
+	return lapack64.Dsytrf(a.Uplo, a.N, a.Data, a.Stride, ipiv)
+}
+
+// Sytrs solves a system of linear equations A * X = B with a real symmetric
+// matrix A using the factorization computed by Sytrf. On entry, b contains the
+// right-hand side matrix B and on return it contains the solution matrix X.
+func Sytrs(a blas64.Symmetric, ipiv []int, b blas64.General) {
+	lapack64.Dsytrs(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Sycon estimates the reciprocal of the condition number of a real symmetric
+// matrix A in the 1-norm using the factorization computed by Sytrf.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Sycon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Sycon will panic otherwise.
+func Sycon(a blas64.Symmetric, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dsycon(a.Uplo, a.N, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
+// Pbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix
+//  A = U^T * U  if a.Uplo == blas.Upper
+//  A = L * L^T  if a.Uplo == blas.Lower
+// where U is an upper triangular band matrix and L is lower triangular. The
+// factor U or L is stored in-place into a.Data and t is a view of the
+// factor. Pbtrf returns whether the factorization was successfully completed.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, a.Stride)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
+// Pbtrs solves a system of linear equations A * X = B with an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization of A
+// stored in t as computed by Pbtrf. On entry, b contains the right-hand side
+// matrix B and on return it contains the solution matrix X.
+func Pbtrs(t blas64.TriangularBand, b blas64.General) {
+	lapack64.Dpbtrs(t.Uplo, t.N, t.K, b.Cols, t.Data, t.Stride, b.Data, b.Stride)
+}
+
+// Pbcon estimates the reciprocal of the condition number of a symmetric
+// positive definite band matrix A in the 1-norm, given the Cholesky
+// factorization of A stored in t as computed by Pbtrf.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Pbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Pbcon will panic otherwise.
+func Pbcon(t blas64.TriangularBand, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dpbcon(t.Uplo, t.N, t.K, t.Data, t.Stride, anorm, work, iwork)
+}
+
+// Lansb computes the specified norm of a symmetric band matrix A. If
+// norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum, work must have
+// length at least a.N.
+func Lansb(norm lapack.MatrixNorm, a blas64.SymmetricBand, work []float64) float64 {
+	return lapack64.Dlansb(norm, a.Uplo, a.N, a.K, a.Data, a.Stride, work)
+}
+
+// Gbtrf computes the LU factorization of an m×n band matrix A with a.KL
+// sub-diagonals and a.KU super-diagonals using partial pivoting with row
+// interchanges. a.Stride must be at least 2*a.KL+a.KU+1 to provide space for
+// the fill-in of U, see the documentation for Dgbtrf for a description of the
+// storage scheme. On return, a.Data contains the factorization and ipiv
+// contains the pivot indices. ipiv must have length at least min(m,n).
+//
+// Gbtrf returns whether the matrix U is nonsingular.
+func Gbtrf(a blas64.Band, ipiv []int) (ok bool) {
+	return lapack64.Dgbtrf(a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv)
+}
+
+// Gbtrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// with an n×n band matrix A using the LU factorization of A stored in a and
+// ipiv as computed by Gbtrf. On entry, b contains the right-hand side matrix B
+// and on return it contains the solution matrix X.
+func Gbtrs(trans blas.Transpose, a blas64.Band, b blas64.General, ipiv []int) {
+	lapack64.Dgbtrs(trans, a.Cols, a.KL, a.KU, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Gbcon estimates the reciprocal of the condition number of an n×n band
+// matrix A given the LU factorization of A stored in a and ipiv as computed
+// by Gbtrf. The condition number computed may be based on the 1-norm or the
+// ∞-norm.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Gbcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Gbcon will panic otherwise.
+func Gbcon(norm lapack.MatrixNorm, a blas64.Band, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgbcon(norm, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
+// Langb computes the specified norm of an m×n band matrix A. If
+// norm == lapack.MaxColumnSum, work must have length at least a.Cols.
+func Langb(norm lapack.MatrixNorm, a blas64.Band, work []float64) float64 {
+	return lapack64.Dlangb(norm, a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, work)
+}
+
+// Gtsv solves one of the equations
+//  A * X = B    if trans == blas.NoTrans
+//  A^T * X = B  if trans == blas.Trans
+// where A is an n×n tridiagonal matrix with sub-diagonal dl, diagonal d and
+// super-diagonal du, using Gaussian elimination with partial pivoting. On
+// return, dl, d and du are overwritten by the factorization of A and, if ok is
+// true, b contains the solution matrix X.
+//
+// Gtsv returns whether the solution has been computed. ok is false if A is
+// exactly singular.
+func Gtsv(trans blas.Transpose, dl, d, du []float64, b blas64.General) (ok bool) {
+	if trans != blas.NoTrans {
+		dl, du = du, dl
+	}
+	return lapack64.Dgtsv(len(d), b.Cols, dl, d, du, b.Data, b.Stride)
+}
+
+// Steqr computes the eigenvalues and optionally the eigenvectors of a
+// symmetric tridiagonal matrix with diagonal d and off-diagonal e using the
+// implicit QL or QR method. On return, d contains the eigenvalues in ascending
+// order and e is overwritten.
+//
+// If compz == lapack.TridiagEV, z contains the orthonormal eigenvectors of the
+// tridiagonal matrix on return. If compz == lapack.OriginalEV, z must contain
+// the orthogonal matrix used to reduce the original matrix to tridiagonal form
+// on entry and contains the eigenvectors of the original matrix on return.
+// work must have length at least max(1, 2*n-2) if the eigenvectors are computed.
+//
+// Steqr returns whether the algorithm converged.
+func Steqr(compz lapack.EVComp, d, e []float64, z blas64.General, work []float64) (ok bool) {
+	return lapack64.Dsteqr(compz, len(d), d, e, z.Data, z.Stride, work)
+}
+
+// Sterf computes all eigenvalues of a symmetric tridiagonal matrix with
+// diagonal d and off-diagonal e. On return, d contains the eigenvalues in
+// ascending order and e is overwritten.
+//
+// Sterf returns whether the algorithm converged.
+func Sterf(d, e []float64) (ok bool) {
+	return lapack64.Dsterf(len(d), d, e)
+}
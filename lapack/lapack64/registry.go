@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack64
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/gonum"
+)
+
+// Reference is the name under which the pure Go reference implementation,
+// gonum.Implementation, is registered.
+const Reference = "gonum"
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]lapack.Float64{Reference: gonum.Implementation{}}
+)
+
+// Register makes the LAPACK float64 implementation l available under the
+// given name. Register panics if name is empty or already registered, or if l
+// is nil.
+func Register(name string, l lapack.Float64) {
+	if name == "" {
+		panic("lapack64: empty implementation name")
+	}
+	if l == nil {
+		panic("lapack64: nil implementation")
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[name]; ok {
+		panic("lapack64: implementation " + name + " already registered")
+	}
+	registry[name] = l
+}
+
+// Registered returns the names of the registered LAPACK float64
+// implementations in sorted order. The reference implementation is always
+// registered.
+func Registered() []string {
+	registryMu.RLock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	registryMu.RUnlock()
+	sort.Strings(names)
+	return names
+}
+
+// Lookup returns the LAPACK float64 implementation registered under the given
+// name, and whether the name is registered.
+func Lookup(name string) (l lapack.Float64, ok bool) {
+	registryMu.RLock()
+	l, ok = registry[name]
+	registryMu.RUnlock()
+	return l, ok
+}
+
+// Name returns the name under which the current LAPACK float64 implementation
+// is registered. If the current implementation is not registered, Name returns
+// the empty string.
+func Name() string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	if !reflect.TypeOf(lapack64).Comparable() {
+		return ""
+	}
+	for name, l := range registry {
+		if reflect.TypeOf(l).Comparable() && l == lapack64 {
+			return name
+		}
+	}
+	return ""
+}
+
+// With sets the LAPACK float64 implementation to l for the duration of the
+// call to fn, and restores the previous implementation when fn returns or
+// panics. If l is nil, the reference implementation is used.
+//
+// Like Use, With changes the implementation used by all LAPACK calls, so it
+// must not be called concurrently with other LAPACK calls. The LAPACK
+// implementation in gonum.org/v1/gonum/lapack/gonum performs its BLAS calls
+// through blas64, so blas64.With can be used to select the BLAS implementation
+// it uses.
+func With(l lapack.Float64, fn func()) {
+	prev := lapack64
+	defer func() { lapack64 = prev }()
+	Use(l)
+	fn()
+}
@@ -0,0 +1,161 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dbdsdcer interface {
+	Dbdsdc(uplo blas.Uplo, compq lapack.EVComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool)
+	Dbdsqrer
+}
+
+func DbdsdcTest(t *testing.T, impl Dbdsdcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, ldu, ldvt int
+		}{
+			{0, 0, 0},
+			{1, 0, 0},
+			{2, 0, 0},
+			{5, 0, 0},
+			{25, 0, 0},
+			{26, 0, 0},
+			{60, 0, 0},
+			{151, 0, 0},
+
+			{5, 7, 8},
+			{26, 30, 31},
+			{151, 160, 170},
+		} {
+			// kind selects the bidiagonal matrix: random entries, a matrix
+			// with many equal diagonal elements and a matrix that splits
+			// into independent blocks, the latter two exercising deflation.
+			for kind := 0; kind < 3; kind++ {
+				n := test.n
+				ldu := test.ldu
+				if ldu == 0 {
+					ldu = max(1, n)
+				}
+				ldvt := test.ldvt
+				if ldvt == 0 {
+					ldvt = max(1, n)
+				}
+				prefix := fmt.Sprintf("uplo=%v,n=%v,ldu=%v,ldvt=%v,kind=%v", uplo == blas.Upper, n, ldu, ldvt, kind)
+
+				d := make([]float64, n)
+				e := make([]float64, max(0, n-1))
+				for i := range d {
+					if kind == 1 {
+						d[i] = 1
+					} else {
+						d[i] = rnd.NormFloat64()
+					}
+				}
+				for i := range e {
+					switch kind {
+					case 0:
+						e[i] = rnd.NormFloat64()
+					case 1:
+						e[i] = 1e-3 * rnd.NormFloat64()
+					case 2:
+						if i%7 != 6 {
+							e[i] = rnd.NormFloat64()
+						}
+					}
+				}
+				if n == 0 {
+					ok := impl.Dbdsdc(uplo, lapack.BidiagSV, n, d, e, nil, ldu, nil, ldvt, nil, nil)
+					if !ok {
+						t.Errorf("%v: unexpected failure", prefix)
+					}
+					continue
+				}
+				bMat := constructBidiagonal(uplo, n, d, e)
+				dCopy := make([]float64, len(d))
+				copy(dCopy, d)
+				eCopy := make([]float64, len(e))
+				copy(eCopy, e)
+
+				u := make([]float64, n*ldu)
+				for i := range u {
+					u[i] = rnd.NormFloat64()
+				}
+				vt := make([]float64, n*ldvt)
+				for i := range vt {
+					vt[i] = rnd.NormFloat64()
+				}
+				work := make([]float64, 3*n*n+4*n)
+				iwork := make([]int, 8*n)
+				ok := impl.Dbdsdc(uplo, lapack.BidiagSV, n, d, e, u, ldu, vt, ldvt, work, iwork)
+				if !ok {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+
+				if !sort.IsSorted(sort.Reverse(sort.Float64Slice(d))) {
+					t.Errorf("%v: singular values not sorted", prefix)
+				}
+				for _, v := range d {
+					if v < 0 {
+						t.Errorf("%v: negative singular value", prefix)
+						break
+					}
+				}
+				uMat := blas64.General{Rows: n, Cols: n, Stride: ldu, Data: u}
+				vtMat := blas64.General{Rows: n, Cols: n, Stride: ldvt, Data: vt}
+				if !isOrthonormal(uMat) {
+					t.Errorf("%v: U not orthonormal", prefix)
+				}
+				if !isOrthonormal(vtMat) {
+					t.Errorf("%v: VT not orthonormal", prefix)
+				}
+
+				// Check that U * S * VT reconstructs B.
+				us := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+				copyGeneral(us, uMat)
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						us.Data[i*n+j] *= d[j]
+					}
+				}
+				ans := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+				blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vtMat, 0, ans)
+				if !floats.EqualApprox(ans.Data, bMat.Data, 1e-12) {
+					t.Errorf("%v: decomposition mismatch", prefix)
+				}
+
+				// Compare the singular values with those computed by Dbdsqr
+				// and by Dbdsdc without singular vectors.
+				dAns := make([]float64, n)
+				copy(dAns, dCopy)
+				eAns := make([]float64, len(eCopy))
+				copy(eAns, eCopy)
+				impl.Dbdsqr(uplo, n, 0, 0, 0, dAns, eAns, nil, 1, nil, 1, nil, 1, make([]float64, 4*n))
+				if !floats.EqualApprox(d, dAns, 1e-12) {
+					t.Errorf("%v: singular value mismatch with Dbdsqr", prefix)
+				}
+				copy(eAns, eCopy)
+				ok = impl.Dbdsdc(uplo, lapack.None, n, dCopy, eAns, nil, 1, nil, 1, make([]float64, 4*n), iwork)
+				if !ok {
+					t.Errorf("%v: unexpected failure without singular vectors", prefix)
+				}
+				if !floats.EqualApprox(d, dCopy, 1e-12) {
+					t.Errorf("%v: singular value mismatch without singular vectors", prefix)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgbconer interface {
+	Dgbtrfer
+	Dgetrier
+	Dlangber
+	Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DgbconTest(t *testing.T, impl Dgbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, n := range []int{0, 1, 2, 5, 10, 23} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, extra := range []int{0, 3} {
+						testDgbcon(t, impl, norm, n, kl, ku, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbcon(t *testing.T, impl Dgbconer, norm lapack.MatrixNorm, n, kl, ku, extra int, rnd *rand.Rand) {
+	prefix := fmt.Sprintf("Case norm=%v,n=%v,kl=%v,ku=%v,extra=%v", norm, n, kl, ku, extra)
+
+	a, band := randBand(n, n, kl, ku, 2*kl+ku+1+extra, rnd)
+	work := make([]float64, n)
+	anorm := impl.Dlangb(norm, n, n, kl, ku, band.Data, band.Stride, work)
+
+	// Compute the exact reciprocal condition number using the explicit
+	// inverse of A.
+	want := 1.0
+	if n > 0 {
+		ipiv := make([]int, n)
+		ok := impl.Dgetrf(n, n, a.Data, a.Stride, ipiv)
+		if !ok {
+			t.Errorf("%v: bad test, matrix is singular", prefix)
+			return
+		}
+		work = make([]float64, 1)
+		impl.Dgetri(n, a.Data, a.Stride, ipiv, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dgetri(n, a.Data, a.Stride, ipiv, work, len(work))
+		want = 1 / anorm / impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+	}
+
+	ipiv := make([]int, n)
+	impl.Dgbtrf(n, n, kl, ku, band.Data, band.Stride, ipiv)
+	got := impl.Dgbcon(norm, n, kl, ku, band.Data, band.Stride, ipiv, anorm, nanSlice(2*n), make([]int, n))
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimate of the reciprocal condition number must not be smaller
+	// than the exact value.
+	const tol = 1e-10
+	if got < want*(1-tol) {
+		t.Errorf("%v: estimate smaller than the exact value; got %v, want %v", prefix, got, want)
+	}
+	if got > 10*want {
+		t.Errorf("%v: estimate too far from the exact value; got %v, want %v", prefix, got, want)
+	}
+}
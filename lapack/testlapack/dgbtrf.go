@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dgbtrfer interface {
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgetf2er
+}
+
+func DgbtrfTest(t *testing.T, impl Dgbtrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 5, 10, 23} {
+		for _, n := range []int{0, 1, 2, 5, 10, 23} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, extra := range []int{0, 3} {
+						testDgbtrf(t, impl, m, n, kl, ku, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrf(t *testing.T, impl Dgbtrfer, m, n, kl, ku, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	prefix := fmt.Sprintf("Case m=%v,n=%v,kl=%v,ku=%v,extra=%v", m, n, kl, ku, extra)
+
+	ldab := 2*kl + ku + 1 + extra
+	a, band := randBand(m, n, kl, ku, ldab, rnd)
+
+	// The band factorization uses the same pivoting strategy as the
+	// unblocked full factorization, so the results must match.
+	mn := min(m, n)
+	wantIpiv := make([]int, mn)
+	wantOk := true
+	if mn > 0 {
+		wantOk = impl.Dgetf2(m, n, a.Data, a.Stride, wantIpiv)
+	}
+
+	ipiv := make([]int, mn)
+	ok := impl.Dgbtrf(m, n, kl, ku, band.Data, band.Stride, ipiv)
+	if ok != wantOk {
+		t.Errorf("%v: unexpected ok; got %v, want %v", prefix, ok, wantOk)
+	}
+	for i := range ipiv {
+		if ipiv[i] != wantIpiv[i] {
+			t.Errorf("%v: unexpected ipiv; got %v, want %v", prefix, ipiv, wantIpiv)
+			break
+		}
+	}
+
+	// Dgetf2 applies the row interchanges to the whole matrix while Dgbtrf
+	// does not apply them to the multipliers already computed, so only the
+	// factor U can be compared directly. The multipliers are checked by
+	// DgbtrsTest.
+	kv := kl + ku
+	for i := 0; i < mn; i++ {
+		for j := i; j <= min(n-1, i+kv); j++ {
+			got := band.Data[i*ldab+j-i+kl]
+			want := a.Data[i*a.Stride+j]
+			if math.Abs(got-want) > tol*math.Max(1, math.Abs(want)) {
+				t.Errorf("%v: unexpected element of U at (%v,%v); got %v, want %v", prefix, i, j, got, want)
+			}
+		}
+	}
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := kl + kv + 1; j < ldab; j++ {
+			if !math.IsNaN(band.Data[i*ldab+j]) {
+				t.Errorf("%v: out-of-range write to ab at (%v,%v)", prefix, i, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dgbtrser interface {
+	Dgbtrfer
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+}
+
+func DgbtrsTest(t *testing.T, impl Dgbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 5, 10, 50} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 3} {
+					for _, nrhs := range []int{1, 3} {
+						for _, extra := range []int{0, 3} {
+							testDgbtrs(t, impl, trans, n, kl, ku, nrhs, extra, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrs(t *testing.T, impl Dgbtrser, trans blas.Transpose, n, kl, ku, nrhs, extra int, rnd *rand.Rand) {
+	const tol = 1e-10
+
+	prefix := fmt.Sprintf("Case trans=%v,n=%v,kl=%v,ku=%v,nrhs=%v,extra=%v", trans, n, kl, ku, nrhs, extra)
+
+	a, band := randBand(n, n, kl, ku, 2*kl+ku+1+extra, rnd)
+	// Make A diagonally dominant so that it is well conditioned.
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] += float64(2 * (kl + ku + 1))
+		band.Data[i*band.Stride+kl] = a.Data[i*a.Stride+i]
+	}
+
+	want := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := zeros(n, nrhs, want.Stride)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, want, 0, b)
+	}
+
+	ipiv := make([]int, n)
+	ok := impl.Dgbtrf(n, n, kl, ku, band.Data, band.Stride, ipiv)
+	if !ok {
+		t.Fatalf("%v: bad test, matrix is singular", prefix)
+	}
+	impl.Dgbtrs(trans, n, kl, ku, nrhs, band.Data, band.Stride, ipiv, b.Data, b.Stride)
+
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to b", prefix)
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("%v: unexpected solution", prefix)
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgesdder interface {
+	Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+	Dgesvder
+}
+
+func DgesddTest(t *testing.T, impl Dgesdder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldu, ldvt int
+	}{
+		{1, 1, 0, 0, 0},
+		{1, 4, 0, 0, 0},
+		{4, 1, 0, 0, 0},
+		{5, 5, 0, 0, 0},
+		{6, 5, 0, 0, 0},
+		{5, 6, 0, 0, 0},
+		{9, 5, 0, 0, 0},
+		{5, 9, 0, 0, 0},
+		{30, 5, 0, 0, 0},
+		{5, 30, 0, 0, 0},
+
+		{5, 5, 10, 11, 12},
+		{9, 5, 10, 11, 12},
+		{5, 9, 10, 11, 12},
+		{30, 5, 10, 31, 32},
+		{5, 30, 31, 32, 33},
+
+		{60, 60, 0, 0, 0},
+		{100, 70, 0, 0, 0},
+		{70, 100, 0, 0, 0},
+		{100, 40, 0, 0, 0},
+		{40, 100, 0, 0, 0},
+
+		{100, 70, 110, 120, 130},
+		{70, 100, 110, 120, 130},
+		{100, 40, 110, 120, 130},
+		{40, 100, 110, 120, 130},
+	} {
+		for _, job := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace, lapack.SVDNone} {
+			m := test.m
+			n := test.n
+			minmn := min(m, n)
+			lda := test.lda
+			if lda == 0 {
+				lda = n
+			}
+			ldu := test.ldu
+			if ldu == 0 {
+				ldu = m
+				if job == lapack.SVDInPlace {
+					ldu = minmn
+				}
+			}
+			ldvt := test.ldvt
+			if ldvt == 0 {
+				ldvt = n
+			}
+			prefix := fmt.Sprintf("job=%c,m=%v,n=%v,lda=%v,ldu=%v,ldvt=%v", job, m, n, lda, ldu, ldvt)
+
+			a := make([]float64, m*lda)
+			for i := range a {
+				a[i] = rnd.NormFloat64()
+			}
+			aCopy := make([]float64, len(a))
+			copy(aCopy, a)
+
+			ucol := m
+			vtrow := n
+			if job == lapack.SVDInPlace {
+				ucol = minmn
+				vtrow = minmn
+			}
+			var u, vt []float64
+			if job != lapack.SVDNone {
+				u = make([]float64, m*ldu)
+				for i := range u {
+					u[i] = rnd.NormFloat64()
+				}
+				// vt is allocated with n rows since svdCheck reads
+				// beyond the first min(m,n) rows.
+				vt = make([]float64, n*ldvt)
+				for i := range vt {
+					vt[i] = rnd.NormFloat64()
+				}
+			}
+			s := make([]float64, minmn)
+			iwork := make([]int, 8*minmn)
+
+			work := make([]float64, 1)
+			impl.Dgesdd(job, m, n, a, lda, s, u, ldu, vt, ldvt, work, -1, iwork)
+			if !floats.Equal(a, aCopy) {
+				t.Errorf("%v: a changed during call to get work length", prefix)
+			}
+			optWork := int(work[0])
+			// Test with both the optimal and a small amount of workspace.
+			for _, wl := range []worklen{optimumWork, minimumWork} {
+				copy(a, aCopy)
+				var lwork int
+				if wl == optimumWork {
+					lwork = optWork
+				} else {
+					lwork = dgesddMinWork(job, m, n)
+				}
+				ok := impl.Dgesdd(job, m, n, a, lda, s, u, ldu, vt, ldvt, make([]float64, lwork), lwork, iwork)
+				if !ok {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+
+				// Compare the singular values with those computed by Dgesvd.
+				sWant := make([]float64, minmn)
+				copy(a, aCopy)
+				impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a, lda, sWant, nil, 1, nil, 1, work, -1)
+				impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, a, lda, sWant, nil, 1, nil, 1, make([]float64, int(work[0])), int(work[0]))
+				if !floats.EqualApprox(s, sWant, 1e-12) {
+					t.Errorf("%v: singular value mismatch with Dgesvd", prefix)
+				}
+				if job == lapack.SVDNone {
+					continue
+				}
+
+				if !hasOrthonormalColumns(m, ucol, u, ldu) {
+					t.Errorf("%v: U does not have orthonormal columns", prefix)
+				}
+				if !hasOrthonormalRows(vtrow, n, vt, ldvt) {
+					t.Errorf("%v: VT does not have orthonormal rows", prefix)
+				}
+				svdCheck(t, job == lapack.SVDInPlace, prefix, m, n, s, a, u, ldu, vt, ldvt, aCopy, lda)
+			}
+		}
+	}
+}
+
+// dgesddMinWork returns the minimum workspace length documented by Dgesdd.
+func dgesddMinWork(job lapack.SVDJob, m, n int) int {
+	minmn := min(m, n)
+	if job == lapack.SVDNone {
+		return 7*minmn + max(m, n)
+	}
+	return 4*minmn*minmn + 7*minmn + max(m, n)
+}
+
+// hasOrthonormalRows returns whether the m×n matrix stored in a has
+// orthonormal rows.
+func hasOrthonormalRows(m, n int, a []float64, lda int) bool {
+	for i := 0; i < m; i++ {
+		for j := i; j < m; j++ {
+			dot := blas64.Dot(n,
+				blas64.Vector{Inc: 1, Data: a[i*lda:]},
+				blas64.Vector{Inc: 1, Data: a[j*lda:]},
+			)
+			if i == j {
+				dot--
+			}
+			if math.Abs(dot) > 1e-10 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dggever interface {
+	Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int,
+		alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) int
+}
+
+func DggevTest(t *testing.T, impl Dggever) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, jobvl := range []lapack.LeftEVJob{lapack.ComputeLeftEV, lapack.None} {
+		for _, jobvr := range []lapack.RightEVJob{lapack.ComputeRightEV, lapack.None} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 18, 31, 50} {
+				for _, extra := range []int{0, 11} {
+					for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+						for _, singular := range []bool{false, true} {
+							testDggev(t, impl, rnd, jobvl, jobvr, n, extra, wl, singular)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggev(t *testing.T, impl Dggever, rnd *rand.Rand, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n, extra int, wl worklen, singular bool) {
+	const tol = 1e-12
+
+	wantvl := jobvl == lapack.ComputeLeftEV
+	wantvr := jobvr == lapack.ComputeRightEV
+
+	a := randomGeneral(n, n, n+extra, rnd)
+	b := randomGeneral(n, n, n+extra, rnd)
+	if singular && n > 0 {
+		// Zero out a row of B so that the pair has an infinite
+		// eigenvalue.
+		i := rnd.Intn(n)
+		for j := 0; j < n; j++ {
+			b.Data[i*b.Stride+j] = 0
+		}
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	vl := blas64.General{Stride: 1}
+	if wantvl {
+		vl = nanGeneral(n, n, n+extra)
+	}
+	vr := blas64.General{Stride: 1}
+	if wantvr {
+		vr = nanGeneral(n, n, n+extra)
+	}
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 8*n)
+	case mediumWork:
+		work := make([]float64, 1)
+		impl.Dggev(jobvl, jobvr, n, nil, max(1, n), nil, max(1, n), nil, nil, nil, nil, max(1, n), nil, max(1, n), work, -1)
+		lwork = (int(work[0]) + max(1, 8*n)) / 2
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dggev(jobvl, jobvr, n, nil, max(1, n), nil, max(1, n), nil, nil, nil, nil, max(1, n), nil, max(1, n), work, -1)
+		lwork = int(work[0])
+	}
+	work := make([]float64, lwork)
+
+	first := impl.Dggev(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
+
+	prefix := fmt.Sprintf("Case jobvl=%v, jobvr=%v, n=%v, extra=%v, work=%v, singular=%v",
+		jobvl, jobvr, n, extra, wl, singular)
+
+	if first > 0 {
+		t.Logf("%v: QZ iteration failed to converge, first=%v", prefix, first)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+
+	// Check the eigenvalues.
+	var ninf int
+	alpha := make([]complex128, n)
+	for j := 0; j < n; j++ {
+		alpha[j] = complex(alphar[j], alphai[j])
+		if beta[j] < 0 {
+			t.Errorf("%v: beta[%v] is negative", prefix, j)
+		}
+		if beta[j] <= tol*cmplx.Abs(alpha[j]) {
+			ninf++
+		}
+		if alphai[j] > 0 {
+			if j == n-1 || alphai[j+1] >= 0 {
+				t.Errorf("%v: complex eigenvalue %v is not followed by its conjugate", prefix, j)
+			}
+			continue
+		}
+		if alphai[j] < 0 && (j == 0 || alphai[j-1] <= 0) {
+			t.Errorf("%v: complex eigenvalue %v is not preceded by its conjugate", prefix, j)
+		}
+	}
+	if singular && ninf == 0 {
+		t.Errorf("%v: no infinite eigenvalue for singular B", prefix)
+	}
+
+	// Check the eigenvectors.
+	for _, v := range []struct {
+		want bool
+		vecs blas64.General
+		left bool
+	}{
+		{wantvr, vr, false},
+		{wantvl, vl, true},
+	} {
+		if !v.want {
+			continue
+		}
+		if !generalOutsideAllNaN(v.vecs) {
+			t.Errorf("%v: out-of-range write to eigenvector matrix", prefix)
+		}
+		for j := 0; j < n; j++ {
+			xRe := columnOf(v.vecs, j)
+			var xIm []float64
+			if alphai[j] > 0 {
+				xIm = columnOf(v.vecs, j+1)
+			} else if alphai[j] < 0 {
+				xRe = columnOf(v.vecs, j-1)
+				xIm = columnOf(v.vecs, j)
+				floats.Scale(-1, xIm)
+			}
+
+			resid := generalizedEigenvectorResidual(aCopy, bCopy, xRe, xIm, alpha[j], beta[j], v.left)
+			if resid > tol {
+				t.Errorf("%v: left=%v, eigenvector %v: unexpected residual %v", prefix, v.left, j, resid)
+			}
+
+			var xmax float64
+			for i := range xRe {
+				xi := math.Abs(xRe[i])
+				if xIm != nil {
+					xi += math.Abs(xIm[i])
+				}
+				xmax = math.Max(xmax, xi)
+			}
+			if math.Abs(xmax-1) > tol {
+				t.Errorf("%v: left=%v, eigenvector %v is not normalized, max magnitude %v", prefix, v.left, j, xmax)
+			}
+		}
+	}
+}
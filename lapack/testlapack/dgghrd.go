@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgghrder interface {
+	Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int)
+}
+
+func DgghrdTest(t *testing.T, impl Dgghrder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compq := range []lapack.EVComp{lapack.None, lapack.HessEV, lapack.OriginalEV} {
+		for _, compz := range []lapack.EVComp{lapack.None, lapack.HessEV, lapack.OriginalEV} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 18} {
+				for _, extra := range []int{0, 11} {
+					testDgghrd(t, impl, rnd, compq, compz, n, extra)
+				}
+			}
+		}
+	}
+}
+
+func testDgghrd(t *testing.T, impl Dgghrder, rnd *rand.Rand, compq, compz lapack.EVComp, n, extra int) {
+	const tol = 1e-13
+
+	a := randomGeneral(n, n, n+extra, rnd)
+	b := randomGeneral(n, n, n+extra, rnd)
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			// Dgghrd must not reference the lower triangle of B.
+			b.Data[i*b.Stride+j] = rnd.NormFloat64()
+		}
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			bCopy.Data[i*bCopy.Stride+j] = 0
+		}
+	}
+
+	// If Q and Z are updated, start from random orthogonal matrices Q0 and
+	// Z0 and check the reduction of the pair Q0*A*Z0^T.
+	q0 := eye(n, max(1, n))
+	z0 := eye(n, max(1, n))
+	q := blas64.General{Stride: max(1, n)}
+	z := blas64.General{Stride: max(1, n)}
+	switch compq {
+	case lapack.HessEV:
+		q = nanGeneral(n, n, n+extra)
+	case lapack.OriginalEV:
+		q0 = randomOrthogonal(n, rnd)
+		q = zeros(n, n, n+extra)
+		copyGeneral(q, q0)
+	}
+	switch compz {
+	case lapack.HessEV:
+		z = nanGeneral(n, n, n+extra)
+	case lapack.OriginalEV:
+		z0 = randomOrthogonal(n, rnd)
+		z = zeros(n, n, n+extra)
+		copyGeneral(z, z0)
+	}
+
+	impl.Dgghrd(compq, compz, n, 0, n-1, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride)
+
+	prefix := fmt.Sprintf("Case compq=%v, compz=%v, n=%v, extra=%v", compq, compz, n, extra)
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+	if !isUpperHessenberg(a) {
+		t.Errorf("%v: A is not upper Hessenberg", prefix)
+	}
+	if !isUpperTriangular(b) {
+		t.Errorf("%v: B is not upper triangular", prefix)
+	}
+	if n == 0 || compq == lapack.None || compz == lapack.None {
+		return
+	}
+	if !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+
+	// Check that Q^T * (Q0 * A * Z0^T) * Z == H and
+	// Q^T * (Q0 * B * Z0^T) * Z == T.
+	for _, m := range []struct {
+		name string
+		orig blas64.General
+		got  blas64.General
+	}{
+		{"A", aCopy, a},
+		{"B", bCopy, b},
+	} {
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, m.orig, z0, 0, tmp)
+		m0 := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q0, tmp, 0, m0)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, m0, z, 0, tmp)
+		res := zeros(n, n, n)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, tmp, 0, res)
+		if !equalApproxGeneral(res, m.got, tol) {
+			t.Errorf("%v: Q^T*%v*Z does not match the reduced matrix", prefix, m.name)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dgtsver interface {
+	Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+	Dlange(norm lapack.MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+}
+
+func DgtsvTest(t *testing.T, impl Dgtsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+		for _, nrhs := range []int{1, 3} {
+			for _, extra := range []int{0, 3} {
+				for _, zeroDiag := range []bool{false, true} {
+					testDgtsv(t, impl, n, nrhs, extra, zeroDiag, rnd)
+				}
+			}
+		}
+	}
+
+	// Singular matrix.
+	dl := []float64{1, 0}
+	d := []float64{1, 1, 0}
+	du := []float64{1, 0}
+	b := []float64{1, 2, 3}
+	if impl.Dgtsv(3, 1, dl, d, du, b, 1) {
+		t.Errorf("singular matrix not detected")
+	}
+}
+
+func testDgtsv(t *testing.T, impl Dgtsver, n, nrhs, extra int, zeroDiag bool, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	prefix := fmt.Sprintf("Case n=%v,nrhs=%v,extra=%v,zeroDiag=%v", n, nrhs, extra, zeroDiag)
+
+	dl := make([]float64, max(0, n-1))
+	d := make([]float64, n)
+	du := make([]float64, max(0, n-1))
+	a := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		if !zeroDiag || i == n-1 {
+			d[i] = rnd.NormFloat64()
+		}
+		a.Data[i*a.Stride+i] = d[i]
+		if i < n-1 {
+			dl[i] = rnd.NormFloat64()
+			du[i] = rnd.NormFloat64()
+			a.Data[(i+1)*a.Stride+i] = dl[i]
+			a.Data[i*a.Stride+i+1] = du[i]
+		}
+	}
+
+	bOrig := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := cloneGeneral(bOrig)
+	ok := impl.Dgtsv(n, nrhs, dl, d, du, b.Data, b.Stride)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Compute the residual A*X - B and check that
+	//  |A*X - B| / (|A| * |X| * n) < tol.
+	r := cloneGeneral(bOrig)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, b, -1, r)
+	work := make([]float64, max(n, nrhs))
+	rNorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, r.Data, r.Stride, work)
+	aNorm := impl.Dlange(lapack.MaxColumnSum, n, n, a.Data, a.Stride, work)
+	xNorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, b.Data, b.Stride, work)
+	resid := rNorm / aNorm / xNorm / float64(n)
+	if math.IsNaN(resid) || resid > tol {
+		t.Errorf("%v: unexpected residual: got %v want <= %v", prefix, resid, tol)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dhgeqzer interface {
+	Dhgeqz(job lapack.EVJob, compq, compz lapack.EVComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int, alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int) int
+}
+
+func DhgeqzTest(t *testing.T, impl Dhgeqzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31, 50} {
+		for _, extra := range []int{0, 11} {
+			for _, singular := range []bool{false, true} {
+				for cas := 0; cas < 10; cas++ {
+					testDhgeqz(t, impl, rnd, n, extra, singular)
+				}
+			}
+		}
+	}
+}
+
+func testDhgeqz(t *testing.T, impl Dhgeqzer, rnd *rand.Rand, n, extra int, singular bool) {
+	const tol = 1e-12
+
+	h := randomHessenberg(n, n+extra, rnd)
+	tt := nanGeneral(n, n, n+extra)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			tt.Data[i*tt.Stride+j] = 0
+		}
+		for j := i; j < n; j++ {
+			tt.Data[i*tt.Stride+j] = rnd.NormFloat64()
+		}
+	}
+	if singular && n > 0 {
+		// Make T singular so that the pair has infinite eigenvalues.
+		for k := 0; k < 1+n/4; k++ {
+			i := rnd.Intn(n)
+			tt.Data[i*tt.Stride+i] = 0
+		}
+	}
+	hCopy := cloneGeneral(h)
+	tCopy := cloneGeneral(tt)
+
+	q := nanGeneral(n, n, n+extra)
+	z := nanGeneral(n, n, n+extra)
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	prefix := fmt.Sprintf("Case n=%v, extra=%v, singular=%v", n, extra, singular)
+
+	unconverged := impl.Dhgeqz(lapack.EigenvaluesAndSchur, lapack.HessEV, lapack.HessEV, n, 0, n-1,
+		h.Data, h.Stride, tt.Data, tt.Stride, alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride)
+	if unconverged > 0 {
+		t.Logf("%v: Dhgeqz did not converge, unconverged=%v", prefix, unconverged)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(h) {
+		t.Errorf("%v: out-of-range write to H", prefix)
+	}
+	if !generalOutsideAllNaN(tt) {
+		t.Errorf("%v: out-of-range write to T", prefix)
+	}
+	if !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+	if !isUpperTriangular(tt) {
+		t.Errorf("%v: P is not upper triangular", prefix)
+	}
+
+	// Check the structure of (S,P) and the eigenvalues computed from its
+	// diagonal blocks.
+	for j := 0; j < n; {
+		if beta[j] < 0 {
+			t.Errorf("%v: beta[%v] is negative", prefix, j)
+		}
+		for i := j + 2; i < n; i++ {
+			if h.Data[i*h.Stride+j] != 0 {
+				t.Errorf("%v: S is not quasi-triangular", prefix)
+			}
+		}
+		if j == n-1 || h.Data[(j+1)*h.Stride+j] == 0 {
+			// 1×1 block.
+			s := h.Data[j*h.Stride+j]
+			p := tt.Data[j*tt.Stride+j]
+			if p < 0 {
+				t.Errorf("%v: P[%v,%v] is negative", prefix, j, j)
+			}
+			if alphai[j] != 0 {
+				t.Errorf("%v: unexpected complex eigenvalue at %v", prefix, j)
+			}
+			if math.Abs(alphar[j]-s) > tol*math.Max(1, math.Abs(s)) || math.Abs(beta[j]-p) > tol*math.Max(1, p) {
+				t.Errorf("%v: eigenvalue %v does not match 1×1 block", prefix, j)
+			}
+			j++
+			continue
+		}
+
+		// 2×2 block.
+		if j+2 < n && h.Data[(j+2)*h.Stride+j+1] != 0 {
+			t.Errorf("%v: S has consecutive non-zero subdiagonal elements at %v", prefix, j)
+		}
+		p11 := tt.Data[j*tt.Stride+j]
+		p12 := tt.Data[j*tt.Stride+j+1]
+		p22 := tt.Data[(j+1)*tt.Stride+j+1]
+		if p12 != 0 || p11 <= 0 || p22 <= 0 {
+			t.Errorf("%v: 2×2 block of P at %v is not positive diagonal", prefix, j)
+		}
+		if alphai[j] <= 0 || alphai[j+1] >= 0 {
+			t.Errorf("%v: invalid signs of imaginary parts of complex pair at %v", prefix, j)
+		}
+		w1 := complex(alphar[j], alphai[j]) * complex(beta[j+1], 0)
+		w2 := complex(alphar[j+1], -alphai[j+1]) * complex(beta[j], 0)
+		if cmplx.Abs(w1-w2) > tol*cmplx.Abs(w1) {
+			t.Errorf("%v: eigenvalues at %v are not complex conjugates", prefix, j)
+		}
+		for k := j; k < j+2; k++ {
+			// det(beta*S_jj - alpha*P_jj) must be zero.
+			alpha := complex(alphar[k], alphai[k])
+			b := complex(beta[k], 0)
+			m11 := b*complex(h.Data[j*h.Stride+j], 0) - alpha*complex(p11, 0)
+			m12 := b * complex(h.Data[j*h.Stride+j+1], 0)
+			m21 := b * complex(h.Data[(j+1)*h.Stride+j], 0)
+			m22 := b*complex(h.Data[(j+1)*h.Stride+j+1], 0) - alpha*complex(p22, 0)
+			mnorm := math.Max(math.Max(cmplx.Abs(m11), cmplx.Abs(m12)), math.Max(cmplx.Abs(m21), cmplx.Abs(m22)))
+			if cmplx.Abs(m11*m22-m12*m21) > tol*math.Max(1, mnorm*mnorm) {
+				t.Errorf("%v: eigenvalue %v does not match 2×2 block", prefix, k)
+			}
+		}
+		j += 2
+	}
+
+	// Check that Q*S*Z^T == H and Q*P*Z^T == T.
+	for _, m := range []struct {
+		name string
+		orig blas64.General
+		got  blas64.General
+	}{
+		{"H", hCopy, h},
+		{"T", tCopy, tt},
+	} {
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, m.got, z, 0, tmp)
+		res := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, tmp, 0, res)
+		if !equalApproxGeneral(res, m.orig, tol*float64(n)) {
+			t.Errorf("%v: Q*%v*Z^T does not match the original matrix", prefix, m.name)
+		}
+	}
+
+	// Check that computing only the eigenvalues gives the same result.
+	h = cloneGeneral(hCopy)
+	tt = cloneGeneral(tCopy)
+	alpharOnly := nanSlice(n)
+	alphaiOnly := nanSlice(n)
+	betaOnly := nanSlice(n)
+	unconverged = impl.Dhgeqz(lapack.EigenvaluesOnly, lapack.None, lapack.None, n, 0, n-1,
+		h.Data, h.Stride, tt.Data, tt.Stride, alpharOnly, alphaiOnly, betaOnly, nil, 1, nil, 1)
+	if unconverged > 0 {
+		t.Logf("%v: Dhgeqz did not converge when computing only eigenvalues, unconverged=%v", prefix, unconverged)
+		return
+	}
+	for j := 0; j < n; j++ {
+		a1 := complex(alphar[j], alphai[j])
+		a2 := complex(alpharOnly[j], alphaiOnly[j])
+		b1 := complex(beta[j], 0)
+		b2 := complex(betaOnly[j], 0)
+		scale := (cmplx.Abs(a1) + beta[j]) * (cmplx.Abs(a2) + betaOnly[j])
+		if cmplx.Abs(a1*b2-a2*b1) > 1e-10*scale {
+			t.Errorf("%v: eigenvalue %v differs when computing only eigenvalues: (%v,%v) vs (%v,%v)",
+				prefix, j, a1, beta[j], a2, betaOnly[j])
+		}
+	}
+}
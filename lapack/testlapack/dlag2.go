@@ -0,0 +1,76 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Dlag2er interface {
+	Dlag2(a []float64, lda int, b []float64, ldb int, safmin float64) (scale1, scale2, wr1, wr2, wi float64)
+}
+
+func Dlag2Test(t *testing.T, impl Dlag2er) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for k := 0; k < 1000; k++ {
+		a := []float64{rnd.NormFloat64(), rnd.NormFloat64(), rnd.NormFloat64(), rnd.NormFloat64()}
+		b := []float64{rnd.NormFloat64(), rnd.NormFloat64(), 0, rnd.NormFloat64()}
+		switch k % 10 {
+		case 1:
+			// Scaled A.
+			for i := range a {
+				a[i] *= 1e10
+			}
+		case 2:
+			// Scaled B.
+			for i := range b {
+				b[i] *= 1e-10
+			}
+		case 3:
+			// Real eigenvalues.
+			a[2] = 0
+		}
+		prefix := fmt.Sprintf("Case %d: a=%v, b=%v", k, a, b)
+
+		scale1, scale2, wr1, wr2, wi := impl.Dlag2(a, 2, b, 2, dlamchS)
+		if scale1 <= 0 || scale2 <= 0 {
+			t.Errorf("%v: non-positive scale factors: scale1=%v, scale2=%v", prefix, scale1, scale2)
+			continue
+		}
+		if wi < 0 {
+			t.Errorf("%v: negative imaginary part %v", prefix, wi)
+		}
+		if wi != 0 && (wr1 != wr2 || scale1 != scale2) {
+			t.Errorf("%v: complex eigenvalues are not conjugate", prefix)
+		}
+
+		// Check that the matrix scale*A - w*B is singular relative to
+		// the size of its elements.
+		for _, ev := range []struct {
+			scale float64
+			w     complex128
+		}{
+			{scale1, complex(wr1, wi)},
+			{scale2, complex(wr2, -wi)},
+		} {
+			var m [4]complex128
+			var mnorm float64
+			for i, v := range a {
+				m[i] = complex(ev.scale*v, 0) - ev.w*complex(b[i], 0)
+				mnorm = math.Max(mnorm, cmplx.Abs(m[i]))
+			}
+			det := m[0]*m[3] - m[1]*m[2]
+			if cmplx.Abs(det) > tol*mnorm*mnorm {
+				t.Errorf("%v: scale*A - w*B is not singular for scale=%v, w=%v: det=%v",
+					prefix, ev.scale, ev.w, det)
+			}
+		}
+	}
+}
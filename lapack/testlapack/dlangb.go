@@ -0,0 +1,46 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlangber interface {
+	Dlanger
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlangbTest(t *testing.T, impl Dlangber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, m := range []int{0, 1, 2, 5, 10} {
+			for _, n := range []int{0, 1, 2, 5, 10} {
+				for _, kl := range []int{0, 1, 2, 4} {
+					for _, ku := range []int{0, 1, 3} {
+						for _, extra := range []int{0, 3} {
+							a, band := randBand(m, n, kl, ku, kl+ku+1+extra, rnd)
+							work := nanSlice(max(m, n))
+							got := impl.Dlangb(norm, m, n, kl, ku, band.Data, band.Stride, work)
+							var want float64
+							if m > 0 && n > 0 {
+								want = impl.Dlange(norm, m, n, a.Data, a.Stride, work)
+							}
+							if math.Abs(got-want) > 1e-14*math.Max(1, want) {
+								prefix := fmt.Sprintf("Case norm=%v,m=%v,n=%v,kl=%v,ku=%v,extra=%v", norm, m, n, kl, ku, extra)
+								t.Errorf("%v: unexpected norm; got %v, want %v", prefix, got, want)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
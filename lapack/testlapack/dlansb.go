@@ -0,0 +1,42 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dlansber interface {
+	Dlansyer
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+}
+
+func DlansbTest(t *testing.T, impl Dlansber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{1, 2, 5, 10} {
+				for _, kd := range []int{0, 1, 2, n - 1} {
+					for _, extra := range []int{0, 3} {
+						sym, band := randSymBand(uplo, n, kd+1+extra, kd, rnd)
+						work := nanSlice(n)
+						got := impl.Dlansb(norm, uplo, n, kd, band.Data, band.Stride, work)
+						want := impl.Dlansy(norm, uplo, n, sym.Data, sym.Stride, work)
+						if math.Abs(got-want) > 1e-14*math.Max(1, want) {
+							prefix := fmt.Sprintf("Case norm=%v,uplo=%v,n=%v,kd=%v,extra=%v", norm, uplo, n, kd, extra)
+							t.Errorf("%v: unexpected norm; got %v, want %v", prefix, got, want)
+						}
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dormtrer interface {
+	Dormtr(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+
+	Dorgtrer
+}
+
+func DormtrTest(t *testing.T, impl Dormtrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, m := range []int{1, 2, 3, 4, 5, 8, 10, 23, 80} {
+					for _, n := range []int{1, 2, 3, 4, 5, 8, 10, 23} {
+						for _, extra := range []int{0, 11} {
+							testDormtr(t, impl, side, uplo, trans, m, n, extra, true, rnd)
+							testDormtr(t, impl, side, uplo, trans, m, n, extra, false, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDormtr(t *testing.T, impl Dormtrer, side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n, extra int, optwork bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	var nq, nw int
+	switch side {
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+
+	// Reduce a random symmetric matrix to tridiagonal form.
+	a := randomGeneral(nq, nq, nq+extra, rnd)
+	d := make([]float64, nq)
+	e := make([]float64, max(0, nq-1))
+	tau := make([]float64, max(0, nq-1))
+	work := make([]float64, 1)
+	impl.Dsytrd(uplo, nq, a.Data, a.Stride, d, e, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dsytrd(uplo, nq, a.Data, a.Stride, d, e, tau, work, len(work))
+
+	// Construct Q explicitly.
+	q := cloneGeneral(a)
+	if nq > 0 {
+		impl.Dorgtr(uplo, nq, q.Data, q.Stride, tau, work, len(work))
+	}
+
+	c := randomGeneral(m, n, n+extra, rnd)
+
+	// Compute the product of Q and C explicitly.
+	qc := randomGeneral(m, n, n+extra, rnd)
+	if side == blas.Left {
+		blas64.Gemm(trans, blas.NoTrans, 1, q, c, 0, qc)
+	} else {
+		blas64.Gemm(blas.NoTrans, trans, 1, c, q, 0, qc)
+	}
+
+	// Compute the product of Q and C using Dormtr.
+	if optwork {
+		work = nanSlice(1)
+		impl.Dormtr(side, uplo, trans, m, n, nil, a.Stride, nil, nil, c.Stride, work, -1)
+		work = nanSlice(int(work[0]))
+	} else {
+		work = nanSlice(max(1, nw))
+	}
+	impl.Dormtr(side, uplo, trans, m, n, a.Data, a.Stride, tau, c.Data, c.Stride, work, len(work))
+
+	prefix := fmt.Sprintf("Case side=%c, uplo=%c, trans=%c, m=%v, n=%v, extra=%v, optwork=%v",
+		side, uplo, trans, m, n, extra, optwork)
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v: out-of-range write to C", prefix)
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			cij := c.Data[i*c.Stride+j]
+			qcij := qc.Data[i*qc.Stride+j]
+			if math.Abs(cij-qcij) > tol {
+				t.Errorf("%v: unexpected value of the QC product at [%v,%v]: want %v, got %v", prefix, i, j, qcij, cij)
+			}
+		}
+	}
+}
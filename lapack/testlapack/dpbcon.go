@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dpbconer interface {
+	Dpbtrfer
+	Dgetrier
+	Dlanger
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+	Dpbcon(ul blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DpbconTest(t *testing.T, impl Dpbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 23} {
+		for _, kd := range []int{0, 1, 2, 5, n - 1} {
+			if kd > n-1 {
+				continue
+			}
+			for _, extra := range []int{0, 3} {
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					testDpbcon(t, impl, ul, n, kd, extra, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDpbcon(t *testing.T, impl Dpbconer, ul blas.Uplo, n, kd, extra int, rnd *rand.Rand) {
+	prefix := fmt.Sprintf("Case n=%v,kd=%v,extra=%v,uplo=%v", n, kd, extra, ul)
+
+	sym, band := randSymBand(ul, n, kd+1+extra, kd, rnd)
+	work := make([]float64, n)
+	anorm := impl.Dlansb(lapack.MaxColumnSum, ul, n, kd, band.Data, band.Stride, work)
+
+	// Compute the exact reciprocal condition number using the explicit
+	// inverse of A. The Data field of sym holds both triangles of A.
+	ipiv := make([]int, n)
+	ainv := make([]float64, n*n)
+	copy(ainv, sym.Data)
+	impl.Dgetrf(n, n, ainv, n, ipiv)
+	work = make([]float64, 1)
+	impl.Dgetri(n, ainv, n, ipiv, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgetri(n, ainv, n, ipiv, work, len(work))
+	want := 1 / anorm / impl.Dlange(lapack.MaxColumnSum, n, n, ainv, n, work)
+
+	ok := impl.Dpbtrf(ul, n, kd, band.Data, band.Stride)
+	if !ok {
+		t.Fatalf("%v: bad test, matrix not positive definite", prefix)
+	}
+	got := impl.Dpbcon(ul, n, kd, band.Data, band.Stride, anorm, nanSlice(2*n), make([]int, n))
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimate of the reciprocal condition number must not be smaller
+	// than the exact value.
+	const tol = 1e-10
+	if got < want*(1-tol) {
+		t.Errorf("%v: estimate smaller than the exact value; got %v, want %v", prefix, got, want)
+	}
+	if got > 10*want {
+		t.Errorf("%v: estimate too far from the exact value; got %v, want %v", prefix, got, want)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dpbtrfer interface {
+	Dpbtrf(ul blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+}
+
+func DpbtrfTest(t *testing.T, impl Dpbtrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 20, 50} {
+		for _, kd := range []int{0, 1, 2, 5, n - 1} {
+			if kd > n-1 {
+				continue
+			}
+			for _, extra := range []int{0, 4} {
+				for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+					ldab := kd + 1 + extra
+					sym, band := randSymBand(ul, n, ldab, kd, rnd)
+
+					prefix := fmt.Sprintf("Case n=%v,kd=%v,extra=%v,uplo=%v", n, kd, extra, ul)
+					ok := impl.Dpbtrf(band.Uplo, band.N, band.K, band.Data, band.Stride)
+					if !ok {
+						t.Errorf("%v: unexpected failure", prefix)
+						continue
+					}
+
+					// Reconstruct A from the factor and compare
+					// it to the original matrix. The Data field
+					// of sym holds both triangles of A.
+					f := symBandToSym(ul, band.Data, n, kd, ldab)
+					tri := blas64.General{
+						Rows:   n,
+						Cols:   n,
+						Stride: n,
+						Data:   f.Data,
+					}
+					got := zeros(n, n, n)
+					if ul == blas.Upper {
+						blas64.Gemm(blas.Trans, blas.NoTrans, 1, tri, tri, 0, got)
+					} else {
+						blas64.Gemm(blas.NoTrans, blas.Trans, 1, tri, tri, 0, got)
+					}
+					want := blas64.General{
+						Rows:   n,
+						Cols:   n,
+						Stride: sym.Stride,
+						Data:   sym.Data,
+					}
+					if !equalApproxGeneral(got, want, 1e-12*float64(n)) {
+						t.Errorf("%v: unexpected reconstruction of A from its factor", prefix)
+					}
+				}
+			}
+		}
+	}
+
+	// A matrix that is not positive definite.
+	ab := []float64{
+		1, 2,
+		1, 0,
+	}
+	if impl.Dpbtrf(blas.Upper, 2, 1, ab, 2) {
+		t.Errorf("indefinite matrix not detected")
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dpbtrser interface {
+	Dpbtrfer
+	Dlanger
+	Dpbtrs(ul blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+}
+
+func DpbtrsTest(t *testing.T, impl Dpbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 50} {
+		for _, kd := range []int{0, 1, 2, 5, n - 1} {
+			if kd > n-1 {
+				continue
+			}
+			for _, nrhs := range []int{1, 3} {
+				for _, extra := range []int{0, 3} {
+					for _, ul := range []blas.Uplo{blas.Upper, blas.Lower} {
+						testDpbtrs(t, impl, ul, n, kd, nrhs, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDpbtrs(t *testing.T, impl Dpbtrser, ul blas.Uplo, n, kd, nrhs, extra int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	prefix := fmt.Sprintf("Case n=%v,kd=%v,nrhs=%v,extra=%v,uplo=%v", n, kd, nrhs, extra, ul)
+
+	sym, band := randSymBand(ul, n, kd+1+extra, kd, rnd)
+	// The Data field of sym holds both triangles of A.
+	full := blas64.General{
+		Rows:   n,
+		Cols:   n,
+		Stride: sym.Stride,
+		Data:   sym.Data,
+	}
+
+	want := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := zeros(n, nrhs, want.Stride)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, want, 0, b)
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dpbtrf(ul, n, kd, band.Data, band.Stride)
+	if !ok {
+		t.Fatalf("%v: bad test, matrix not positive definite", prefix)
+	}
+	impl.Dpbtrs(ul, n, kd, nrhs, band.Data, band.Stride, b.Data, b.Stride)
+
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to b", prefix)
+	}
+
+	// Compute the backward error
+	//  |A*X - B| / (|A| * |X| * n).
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, b, -1, bCopy)
+	work := make([]float64, max(n, nrhs))
+	rnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, bCopy.Data, bCopy.Stride, work)
+	anorm := impl.Dlange(lapack.MaxColumnSum, n, n, full.Data, full.Stride, work)
+	xnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, b.Data, b.Stride, work)
+	resid := rnorm / anorm / xnorm / float64(n)
+	if resid > tol {
+		t.Errorf("%v: unexpected backward error; got %v, want <= %v", prefix, resid, tol)
+	}
+}
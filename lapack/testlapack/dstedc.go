@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstedcer interface {
+	Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsterfer
+}
+
+func DstedcTest(t *testing.T, impl Dstedcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compz := range []lapack.EVComp{lapack.OriginalEV, lapack.TridiagEV} {
+		for _, test := range []struct {
+			n, ldz int
+		}{
+			{1, 0},
+			{2, 0},
+			{4, 0},
+			{25, 0},
+			{26, 0},
+			{50, 0},
+			{133, 0},
+
+			{4, 10},
+			{26, 30},
+			{133, 140},
+		} {
+			// kind selects the tridiagonal matrix: random entries, a
+			// matrix with many equal diagonal elements and a matrix
+			// that splits into independent blocks, the latter two
+			// exercising deflation.
+			for kind := 0; kind < 3; kind++ {
+				n := test.n
+				ldz := test.ldz
+				if ldz == 0 {
+					ldz = n
+				}
+				prefix := fmt.Sprintf("compz=%v,n=%v,ldz=%v,kind=%v", compz, n, ldz, kind)
+
+				d := make([]float64, n)
+				e := make([]float64, max(0, n-1))
+				for i := range d {
+					if kind == 1 {
+						d[i] = float64(i % 3)
+					} else {
+						d[i] = rnd.NormFloat64()
+					}
+				}
+				for i := range e {
+					switch kind {
+					case 0:
+						e[i] = rnd.NormFloat64()
+					case 1:
+						e[i] = 1
+					case 2:
+						if i%7 != 6 {
+							e[i] = rnd.NormFloat64()
+						}
+					}
+				}
+
+				// orig is the tridiagonal matrix T.
+				orig := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+				for i := 0; i < n; i++ {
+					orig.Data[i*n+i] = d[i]
+					if i < n-1 {
+						orig.Data[i*n+i+1] = e[i]
+						orig.Data[(i+1)*n+i] = e[i]
+					}
+				}
+				z := make([]float64, n*ldz)
+				if compz == lapack.OriginalEV {
+					// Use a random orthogonal Q as the matrix of the
+					// reduction, so that the eigenvectors are those of
+					// Q * T * Q^T.
+					q := randomOrthogonal(n, rnd)
+					copyGeneral(blas64.General{Rows: n, Cols: n, Stride: ldz, Data: z}, q)
+					qt := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, orig, 0, qt)
+					blas64.Gemm(blas.NoTrans, blas.Trans, 1, qt, q, 0, orig)
+				} else {
+					// z is set to the identity by Dstedc.
+					for i := range z {
+						z[i] = rnd.NormFloat64()
+					}
+				}
+				dCopy := make([]float64, len(d))
+				copy(dCopy, d)
+				eCopy := make([]float64, len(e))
+				copy(eCopy, e)
+
+				work := make([]float64, 1)
+				iwork := make([]int, 1)
+				impl.Dstedc(compz, n, d, e, z, ldz, work, -1, iwork, -1)
+				work = make([]float64, int(work[0]))
+				iwork = make([]int, iwork[0])
+				ok := impl.Dstedc(compz, n, d, e, z, ldz, work, len(work), iwork, len(iwork))
+				if !ok {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+
+				for i := 1; i < n; i++ {
+					if d[i] < d[i-1] {
+						t.Errorf("%v: eigenvalues not sorted", prefix)
+						break
+					}
+				}
+				v := blas64.General{Rows: n, Cols: n, Stride: ldz, Data: z}
+				if !isOrthonormal(v) {
+					t.Errorf("%v: eigenvectors not orthonormal", prefix)
+				}
+				if !eigenDecompCorrect(d, orig, v) {
+					t.Errorf("%v: eigen decomposition mismatch", prefix)
+				}
+
+				// Compare the eigenvalues with those computed by Dsterf.
+				impl.Dsterf(n, dCopy, eCopy)
+				if !floats.EqualApprox(d, dCopy, 1e-10) {
+					t.Errorf("%v: eigenvalue mismatch with Dsterf", prefix)
+				}
+			}
+		}
+	}
+}
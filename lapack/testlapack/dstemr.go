@@ -0,0 +1,249 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dstemrer interface {
+	Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsterfer
+}
+
+func DstemrTest(t *testing.T, impl Dstemrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25, 50, 100, 133} {
+		// kind selects the tridiagonal matrix: random entries, a matrix
+		// with many equal diagonal elements, a matrix that splits into
+		// independent blocks and a Wilkinson matrix with pairs of very
+		// close eigenvalues.
+		for kind := 0; kind < 4; kind++ {
+			d := make([]float64, n)
+			e := make([]float64, n)
+			for i := range d {
+				switch kind {
+				case 1:
+					d[i] = float64(i % 3)
+				case 3:
+					d[i] = math.Abs(float64(i - n/2))
+				default:
+					d[i] = rnd.NormFloat64()
+				}
+			}
+			for i := 0; i < n-1; i++ {
+				switch kind {
+				case 0:
+					e[i] = rnd.NormFloat64()
+				case 2:
+					if i%7 != 6 {
+						e[i] = rnd.NormFloat64()
+					}
+				default:
+					e[i] = 1
+				}
+			}
+
+			// Compute reference eigenvalues using Dsterf.
+			want := make([]float64, n)
+			copy(want, d)
+			eCopy := make([]float64, n)
+			copy(eCopy, e)
+			impl.Dsterf(n, want, eCopy)
+
+			type rangeCase struct {
+				rng    lapack.EVRange
+				vl, vu float64
+				il, iu int
+			}
+			cases := []rangeCase{
+				{rng: lapack.AllEigenvalues},
+				{rng: lapack.IndexEigenvalues, il: 0, iu: 0},
+				{rng: lapack.IndexEigenvalues, il: n - 1, iu: n - 1},
+				{rng: lapack.IndexEigenvalues, il: n / 3, iu: 2 * n / 3},
+			}
+			if n > 1 {
+				// Choose the interval so that its ends are well
+				// separated from the eigenvalues.
+				lo := n / 4
+				hi := 3 * n / 4
+				if want[lo+1]-want[lo] > 1e-6 && (hi == n-1 || want[hi+1]-want[hi] > 1e-6) {
+					cases = append(cases, rangeCase{
+						rng: lapack.IntervalEigenvalues,
+						vl:  (want[lo] + want[lo+1]) / 2,
+						vu:  hiBound(want, hi),
+					})
+				}
+				// Intervals that contain no eigenvalues, above the
+				// spectrum and inside a gap between two eigenvalues.
+				cases = append(cases, rangeCase{
+					rng: lapack.IntervalEigenvalues,
+					vl:  want[n-1] + 1,
+					vu:  want[n-1] + 2,
+				})
+				if gap := want[lo+1] - want[lo]; gap > 1e-6 {
+					cases = append(cases, rangeCase{
+						rng: lapack.IntervalEigenvalues,
+						vl:  want[lo] + gap/4,
+						vu:  want[lo+1] - gap/4,
+					})
+				}
+			}
+
+			for _, rc := range cases {
+				for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+					for _, tryrac := range []bool{false, true} {
+						prefix := fmt.Sprintf("n=%v,kind=%v,rng=%c,vl=%v,vu=%v,il=%v,iu=%v,jobz=%c,tryrac=%v",
+							n, kind, rc.rng, rc.vl, rc.vu, rc.il, rc.iu, jobz, tryrac)
+						testDstemr(t, impl, prefix, jobz, rc.rng, n, d, e, rc.vl, rc.vu, rc.il, rc.iu, tryrac, want)
+					}
+				}
+			}
+		}
+	}
+	dstemrEmptyIntervalTest(t, impl)
+}
+
+// dstemrEmptyIntervalTest checks that Dstemr returns no eigenvalues for
+// intervals that contain none of the eigenvalues of matrices that split into
+// several blocks.
+func dstemrEmptyIntervalTest(t *testing.T, impl Dstemrer) {
+	for _, test := range []struct {
+		d, e   []float64
+		vl, vu float64
+	}{
+		{d: []float64{2, 3, 4}, e: []float64{0, 0, 0}, vl: 2.2, vu: 2.8},
+		{d: []float64{2, 3, 4}, e: []float64{0, 0, 0}, vl: 10, vu: 20},
+		{d: []float64{1, 1, 5, 5}, e: []float64{1, 0, 2, 0}, vl: 5.5, vu: 6.5},
+		{d: []float64{1, 1, 5, 5}, e: []float64{1, 0, 2, 0}, vl: 100, vu: 200},
+	} {
+		n := len(test.d)
+		want := make([]float64, n)
+		copy(want, test.d)
+		eCopy := make([]float64, n)
+		copy(eCopy, test.e)
+		impl.Dsterf(n, want, eCopy)
+		for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+			for _, tryrac := range []bool{false, true} {
+				prefix := fmt.Sprintf("d=%v,e=%v,vl=%v,vu=%v,jobz=%c,tryrac=%v",
+					test.d, test.e, test.vl, test.vu, jobz, tryrac)
+				testDstemr(t, impl, prefix, jobz, lapack.IntervalEigenvalues, n, test.d, test.e,
+					test.vl, test.vu, 0, 0, tryrac, want)
+			}
+		}
+	}
+}
+
+// hiBound returns a value between want[hi] and the next larger eigenvalue, or
+// above want[hi] if it is the largest.
+func hiBound(want []float64, hi int) float64 {
+	if hi == len(want)-1 {
+		return want[hi] + 1
+	}
+	return (want[hi] + want[hi+1]) / 2
+}
+
+func testDstemr(t *testing.T, impl Dstemrer, prefix string, jobz lapack.EVJob, rng lapack.EVRange, n int, dOrig, eOrig []float64, vl, vu float64, il, iu int, tryrac bool, want []float64) {
+	const tol = 1e-10
+
+	d := make([]float64, n)
+	copy(d, dOrig)
+	e := make([]float64, n)
+	copy(e, eOrig)
+
+	var ldz, ncols int
+	switch rng {
+	case lapack.IndexEigenvalues:
+		ncols = iu - il + 1
+		want = want[il : iu+1]
+	case lapack.IntervalEigenvalues:
+		ncols = n
+		var sub []float64
+		for _, v := range want {
+			if vl < v && v <= vu {
+				sub = append(sub, v)
+			}
+		}
+		want = sub
+	default:
+		ncols = n
+	}
+	ldz = ncols
+	wantz := jobz == lapack.ComputeEV
+	var z []float64
+	var isuppz []int
+	if wantz {
+		z = make([]float64, n*ldz)
+		isuppz = make([]int, 2*ncols)
+	}
+	w := make([]float64, n)
+
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dstemr(jobz, rng, n, d, e, vl, vu, il, iu, w, z, ldz, isuppz, tryrac, work, -1, iwork, -1)
+	work = make([]float64, int(work[0]))
+	iwork = make([]int, iwork[0])
+	m, ok := impl.Dstemr(jobz, rng, n, d, e, vl, vu, il, iu, w, z, ldz, isuppz, tryrac, work, len(work), iwork, len(iwork))
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if m != len(want) {
+		t.Errorf("%v: unexpected number of eigenvalues: got %v, want %v", prefix, m, len(want))
+		return
+	}
+	if !floats.EqualApprox(w[:m], want, tol) {
+		t.Errorf("%v: eigenvalue mismatch", prefix)
+	}
+	if !wantz || m == 0 {
+		return
+	}
+
+	// Check that the eigenvectors are orthonormal.
+	zm := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+	ztz := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, zm, zm, 0, ztz)
+	for i := 0; i < m; i++ {
+		ztz.Data[i*m+i] -= 1
+	}
+	for _, v := range ztz.Data {
+		if math.Abs(v) > tol || math.IsNaN(v) {
+			t.Errorf("%v: eigenvectors not orthonormal", prefix)
+			break
+		}
+	}
+
+	// Check that T*z_j = w_j*z_j and that the support is correct.
+	for j := 0; j < m; j++ {
+		var resid float64
+		for i := 0; i < n; i++ {
+			r := dOrig[i]*z[i*ldz+j] - w[j]*z[i*ldz+j]
+			if i > 0 {
+				r += eOrig[i-1] * z[(i-1)*ldz+j]
+			}
+			if i < n-1 {
+				r += eOrig[i] * z[(i+1)*ldz+j]
+			}
+			resid = math.Max(resid, math.Abs(r))
+		}
+		if resid > 1e-8 {
+			t.Errorf("%v: residual of eigenvector %v too large: %v", prefix, j, resid)
+		}
+		for i := 0; i < n; i++ {
+			if (i < isuppz[2*j] || isuppz[2*j+1] < i) && z[i*ldz+j] != 0 {
+				t.Errorf("%v: nonzero element of eigenvector %v outside of support", prefix, j)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyconer interface {
+	Dsytrfer
+	Dgetrier
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+}
+
+func DsyconTest(t *testing.T, impl Dsyconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 23} {
+			for _, extra := range []int{0, 5} {
+				for cas := 0; cas < 5; cas++ {
+					testDsycon(t, impl, uplo, n, extra, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDsycon(t *testing.T, impl Dsyconer, uplo blas.Uplo, n, extra int, rnd *rand.Rand) {
+	prefix := fmt.Sprintf("Case uplo=%v,n=%v,extra=%v", uplo, n, extra)
+
+	// Generate a random symmetric matrix.
+	a := randomGeneral(n, n, n+extra, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			a.Data[i*a.Stride+j] = a.Data[j*a.Stride+i]
+		}
+	}
+
+	// Compute the exact reciprocal condition number using the explicit
+	// inverse of A.
+	var want float64
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, make([]float64, n))
+	if n == 0 {
+		want = 1
+	} else {
+		ainv := cloneGeneral(a)
+		ipiv := make([]int, n)
+		ok := impl.Dgetrf(n, n, ainv.Data, ainv.Stride, ipiv)
+		if !ok {
+			t.Errorf("%v: bad test, matrix is singular", prefix)
+			return
+		}
+		work := make([]float64, 1)
+		impl.Dgetri(n, ainv.Data, ainv.Stride, ipiv, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dgetri(n, ainv.Data, ainv.Stride, ipiv, work, len(work))
+		ainvnorm := impl.Dlange(lapack.MaxColumnSum, n, n, ainv.Data, ainv.Stride, work)
+		want = 1 / anorm / ainvnorm
+	}
+
+	ipiv := make([]int, n)
+	impl.Dsytrf(uplo, n, a.Data, a.Stride, ipiv)
+	work := nanSlice(2 * n)
+	iwork := make([]int, n)
+	got := impl.Dsycon(uplo, n, a.Data, a.Stride, ipiv, anorm, work, iwork)
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimate of the reciprocal condition number must not be smaller
+	// than the exact value. It is usually within a small factor.
+	const tol = 1e-10
+	if got < want*(1-tol) {
+		t.Errorf("%v: estimate smaller than the exact value; got %v, want %v", prefix, got, want)
+	}
+	if got > 10*want {
+		t.Errorf("%v: estimate too far from the exact value; got %v, want %v", prefix, got, want)
+	}
+}
@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsyevrer interface {
+	Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsyever
+}
+
+func DsyevrTest(t *testing.T, impl Dsyevrer) {
+	const tol = 1e-10
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+		for _, test := range []struct {
+			n, lda, ldz int
+		}{
+			{1, 0, 0},
+			{2, 0, 0},
+			{5, 0, 0},
+			{10, 0, 0},
+			{30, 0, 0},
+			{100, 0, 0},
+
+			{1, 5, 5},
+			{2, 5, 6},
+			{5, 10, 11},
+			{10, 20, 15},
+			{100, 110, 120},
+		} {
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = n
+			}
+			a := make([]float64, n*lda)
+			for i := range a {
+				a[i] = rnd.NormFloat64()
+			}
+
+			// orig is the full symmetric matrix A.
+			orig := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+			for i := 0; i < n; i++ {
+				for j := i; j < n; j++ {
+					v := a[j*lda+i]
+					if uplo == blas.Upper {
+						v = a[i*lda+j]
+					}
+					orig.Data[i*n+j] = v
+					orig.Data[j*n+i] = v
+				}
+			}
+
+			// Compute reference eigenvalues using Dsyev.
+			want := make([]float64, n)
+			aCopy := make([]float64, len(orig.Data))
+			copy(aCopy, orig.Data)
+			work := make([]float64, max(1, 3*n))
+			impl.Dsyev(lapack.None, uplo, n, aCopy, max(1, n), want, work, len(work))
+
+			type rangeCase struct {
+				rng    lapack.EVRange
+				vl, vu float64
+				il, iu int
+			}
+			cases := []rangeCase{
+				{rng: lapack.AllEigenvalues},
+				{rng: lapack.IndexEigenvalues, il: 0, iu: n - 1},
+				{rng: lapack.IndexEigenvalues, il: n / 2, iu: n / 2},
+				{rng: lapack.IndexEigenvalues, il: n / 4, iu: 3 * n / 4},
+				{rng: lapack.IntervalEigenvalues, vl: want[0] - 1, vu: want[n-1] + 1},
+				{rng: lapack.IntervalEigenvalues, vl: want[n-1] + 1, vu: want[n-1] + 2},
+			}
+			if n > 1 {
+				cases = append(cases, rangeCase{
+					rng: lapack.IntervalEigenvalues,
+					vl:  (want[n/4] + want[n/4+1]) / 2,
+					vu:  want[n-1] + 1,
+				})
+			}
+
+			for _, rc := range cases {
+				for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+					prefix := fmt.Sprintf("uplo=%c,n=%v,lda=%v,ldz=%v,rng=%c,vl=%v,vu=%v,il=%v,iu=%v,jobz=%c",
+						uplo, n, lda, test.ldz, rc.rng, rc.vl, rc.vu, rc.il, rc.iu, jobz)
+
+					wantSub := want
+					ncols := n
+					switch rc.rng {
+					case lapack.IndexEigenvalues:
+						wantSub = want[rc.il : rc.iu+1]
+						ncols = rc.iu - rc.il + 1
+					case lapack.IntervalEigenvalues:
+						wantSub = nil
+						for _, v := range want {
+							if rc.vl < v && v <= rc.vu {
+								wantSub = append(wantSub, v)
+							}
+						}
+					}
+					ldz := test.ldz
+					if ldz == 0 {
+						ldz = ncols
+					}
+
+					aWork := make([]float64, len(a))
+					copy(aWork, a)
+					w := make([]float64, n)
+					z := make([]float64, n*ldz)
+					for i := range z {
+						z[i] = rnd.NormFloat64()
+					}
+					isuppz := make([]int, 2*ncols)
+
+					work := make([]float64, 1)
+					iwork := make([]int, 1)
+					impl.Dsyevr(jobz, rc.rng, uplo, n, aWork, lda, rc.vl, rc.vu, rc.il, rc.iu, w, z, ldz, isuppz, work, -1, iwork, -1)
+					work = make([]float64, int(work[0]))
+					iwork = make([]int, iwork[0])
+					m, ok := impl.Dsyevr(jobz, rc.rng, uplo, n, aWork, lda, rc.vl, rc.vu, rc.il, rc.iu, w, z, ldz, isuppz, work, len(work), iwork, len(iwork))
+					if !ok {
+						t.Errorf("%v: unexpected failure", prefix)
+						continue
+					}
+					if m != len(wantSub) {
+						t.Errorf("%v: unexpected number of eigenvalues: got %v, want %v", prefix, m, len(wantSub))
+						continue
+					}
+					if !floats.EqualApprox(w[:m], wantSub, tol) {
+						t.Errorf("%v: eigenvalue mismatch", prefix)
+					}
+					if jobz == lapack.None || m == 0 {
+						continue
+					}
+
+					// Check that the eigenvectors are orthonormal.
+					zm := blas64.General{Rows: n, Cols: m, Stride: ldz, Data: z}
+					ztz := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+					blas64.Gemm(blas.Trans, blas.NoTrans, 1, zm, zm, 0, ztz)
+					for i := 0; i < m; i++ {
+						ztz.Data[i*m+i] -= 1
+					}
+					if floats.Norm(ztz.Data, math.Inf(1)) > tol {
+						t.Errorf("%v: eigenvectors not orthonormal", prefix)
+					}
+
+					// Check that A*Z = Z*diag(w).
+					az := blas64.General{Rows: n, Cols: m, Stride: m, Data: make([]float64, n*m)}
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, orig, zm, 0, az)
+					for i := 0; i < n; i++ {
+						for j := 0; j < m; j++ {
+							az.Data[i*m+j] -= w[j] * z[i*ldz+j]
+						}
+					}
+					if floats.Norm(az.Data, math.Inf(1)) > 1e-8 {
+						t.Errorf("%v: eigen decomposition mismatch", prefix)
+					}
+				}
+			}
+		}
+	}
+	dsyevrEmptyIntervalTest(t, impl)
+}
+
+// dsyevrEmptyIntervalTest checks that Dsyevr returns no eigenvalues for
+// intervals that do not contain any eigenvalues of a matrix whose tridiagonal
+// form splits into several blocks.
+func dsyevrEmptyIntervalTest(t *testing.T, impl Dsyevrer) {
+	for _, test := range []struct {
+		n      int
+		a      []float64
+		vl, vu float64
+	}{
+		{
+			n: 3,
+			a: []float64{
+				2, 0, 0,
+				0, 3, 0,
+				0, 0, 4,
+			},
+			vl: 2.2, vu: 2.8,
+		},
+		{
+			n: 4,
+			a: []float64{
+				1, 1, 0, 0,
+				1, 1, 0, 0,
+				0, 0, 5, 2,
+				0, 0, 2, 5,
+			},
+			vl: 5.5, vu: 6.5,
+		},
+		{
+			n: 4,
+			a: []float64{
+				1, 1, 0, 0,
+				1, 1, 0, 0,
+				0, 0, 5, 2,
+				0, 0, 2, 5,
+			},
+			vl: 100, vu: 200,
+		},
+	} {
+		n := test.n
+		for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+			for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+				prefix := fmt.Sprintf("uplo=%c,n=%v,vl=%v,vu=%v,jobz=%c", uplo, n, test.vl, test.vu, jobz)
+
+				a := make([]float64, len(test.a))
+				copy(a, test.a)
+				w := make([]float64, n)
+				z := make([]float64, n*n)
+				isuppz := make([]int, 2*n)
+				work := make([]float64, 1)
+				iwork := make([]int, 1)
+				impl.Dsyevr(jobz, lapack.IntervalEigenvalues, uplo, n, a, n, test.vl, test.vu, 0, 0, w, z, n, isuppz, work, -1, iwork, -1)
+				work = make([]float64, int(work[0]))
+				iwork = make([]int, iwork[0])
+				m, ok := impl.Dsyevr(jobz, lapack.IntervalEigenvalues, uplo, n, a, n, test.vl, test.vu, 0, 0, w, z, n, isuppz, work, len(work), iwork, len(iwork))
+				if !ok {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+				if m != 0 {
+					t.Errorf("%v: unexpected number of eigenvalues: got %v, want 0", prefix, m)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dsytrfer interface {
+	Dlanger
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+}
+
+func DsytrfTest(t *testing.T, impl Dsytrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 23, 50} {
+			for _, extra := range []int{0, 5} {
+				for _, kind := range []string{"random", "zero diagonal", "singular"} {
+					if kind == "zero diagonal" && n < 2 {
+						// A 1×1 zero matrix is singular.
+						continue
+					}
+					testDsytrf(t, impl, uplo, n, extra, kind, rnd)
+				}
+			}
+		}
+	}
+
+	// A matrix that requires a 2×2 pivot block at the first step.
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		a := []float64{
+			0, 1,
+			1, 0,
+		}
+		ipiv := make([]int, 2)
+		ok := impl.Dsytrf(uplo, 2, a, 2, ipiv)
+		if !ok {
+			t.Errorf("uplo=%v: unexpected singular factorization", uplo)
+		}
+		if ipiv[0] >= 0 || ipiv[0] != ipiv[1] {
+			t.Errorf("uplo=%v: expected a 2×2 pivot block, got ipiv=%v", uplo, ipiv)
+		}
+	}
+}
+
+func testDsytrf(t *testing.T, impl Dsytrfer, uplo blas.Uplo, n, extra int, kind string, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	prefix := fmt.Sprintf("Case uplo=%v,n=%v,extra=%v,kind=%v", uplo, n, extra, kind)
+
+	// Generate a random symmetric indefinite matrix A with NaN in the
+	// triangle not referenced by uplo.
+	full := randomGeneral(n, n, n, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			full.Data[i*full.Stride+j] = full.Data[j*full.Stride+i]
+		}
+		switch kind {
+		case "zero diagonal":
+			full.Data[i*full.Stride+i] = 0
+		case "singular":
+			if i == n/2 {
+				for j := 0; j < n; j++ {
+					full.Data[i*full.Stride+j] = 0
+					full.Data[j*full.Stride+i] = 0
+				}
+			}
+		}
+	}
+	a := nanGeneral(n, n, n+extra)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				a.Data[i*a.Stride+j] = full.Data[i*full.Stride+j]
+			}
+		}
+	}
+
+	ipiv := make([]int, n)
+	ok := impl.Dsytrf(uplo, n, a.Data, a.Stride, ipiv)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to a", prefix)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && j > i) {
+				if !math.IsNaN(a.Data[i*a.Stride+j]) {
+					t.Errorf("%v: write to the wrong triangle of a at (%v,%v)", prefix, i, j)
+				}
+			}
+		}
+	}
+	if kind == "singular" {
+		if n > 0 && ok {
+			t.Errorf("%v: singular matrix not detected", prefix)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("%v: unexpected singular factorization", prefix)
+		return
+	}
+
+	// Check the block structure encoded in ipiv.
+	for k := 0; k < n; {
+		kp := ipiv[k]
+		if kp >= 0 {
+			if kp >= n {
+				t.Errorf("%v: ipiv[%v]=%v out of range", prefix, k, kp)
+			}
+			k++
+			continue
+		}
+		if k == n-1 || ipiv[k+1] != kp {
+			t.Errorf("%v: unpaired 2×2 block at ipiv[%v]", prefix, k)
+			break
+		}
+		if -kp-1 >= n {
+			t.Errorf("%v: ipiv[%v]=%v out of range", prefix, k, kp)
+		}
+		k += 2
+	}
+
+	// Check the factorization by solving a system of equations with
+	// Dsytrs and computing the backward error
+	//  |A*X - B| / (|A| * |X| * n)
+	nrhs := 3
+	want := randomGeneral(n, nrhs, nrhs+extra, rnd)
+	b := zeros(n, nrhs, nrhs+extra)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, want, 0, b)
+	x := cloneGeneral(b)
+	impl.Dsytrs(uplo, n, nrhs, a.Data, a.Stride, ipiv, x.Data, x.Stride)
+	if !generalOutsideAllNaN(x) {
+		t.Errorf("%v: out-of-range write to b", prefix)
+	}
+	if n == 0 {
+		return
+	}
+	r := cloneGeneral(b)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, full, x, -1, r)
+	work := make([]float64, max(n, nrhs))
+	rnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, r.Data, r.Stride, work)
+	anorm := impl.Dlange(lapack.MaxColumnSum, n, n, full.Data, full.Stride, work)
+	xnorm := impl.Dlange(lapack.MaxColumnSum, n, nrhs, x.Data, x.Stride, work)
+	resid := rnorm / anorm / xnorm / float64(n)
+	if resid > tol {
+		t.Errorf("%v: unexpected backward error; got %v, want <= %v", prefix, resid, tol)
+	}
+}
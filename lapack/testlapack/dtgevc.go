@@ -0,0 +1,189 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Dtgevcer interface {
+	Dtgevc(side lapack.EVSide, howmny lapack.HowMany, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, work []float64)
+}
+
+func DtgevcTest(t *testing.T, impl Dtgevcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []lapack.EVSide{lapack.RightEV, lapack.LeftEV, lapack.RightLeftEV} {
+		for _, howmny := range []lapack.HowMany{lapack.AllEV, lapack.AllEVMulQ} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 5; cas++ {
+						testDtgevc(t, impl, rnd, side, howmny, n, extra)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtgevc(t *testing.T, impl Dtgevcer, rnd *rand.Rand, side lapack.EVSide, howmny lapack.HowMany, n, extra int) {
+	const tol = 1e-12
+
+	s, p, alpha, beta := randomGeneralizedSchur(n, n+extra, rnd)
+
+	// The pair (A,B) whose eigenvectors are computed. If howmny is
+	// lapack.AllEV, it is the pair (S,P), otherwise it is (Q*S*Z^T, Q*P*Z^T)
+	// for random orthogonal Q and Z.
+	a := cloneGeneral(s)
+	b := cloneGeneral(p)
+	var vl, vr blas64.General
+	if howmny == lapack.AllEVMulQ {
+		q := randomOrthogonal(n, rnd)
+		z := randomOrthogonal(n, rnd)
+		for _, m := range []blas64.General{a, b} {
+			tmp := zeros(n, n, n)
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, m, z, 0, tmp)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, tmp, 0, m)
+		}
+		vl = zeros(n, n, n+extra)
+		copyGeneral(vl, q)
+		vr = zeros(n, n, n+extra)
+		copyGeneral(vr, z)
+	} else {
+		vl = nanGeneral(n, n, n+extra)
+		vr = nanGeneral(n, n, n+extra)
+	}
+	sCopy := cloneGeneral(s)
+	pCopy := cloneGeneral(p)
+
+	work := nanSlice(4 * n)
+	impl.Dtgevc(side, howmny, n, s.Data, s.Stride, p.Data, p.Stride, vl.Data, vl.Stride, vr.Data, vr.Stride, work)
+
+	prefix := fmt.Sprintf("Case side=%v, howmny=%v, n=%v, extra=%v", side, howmny, n, extra)
+
+	if !equalApproxGeneral(s, sCopy, 0) {
+		t.Errorf("%v: unexpected modification of S", prefix)
+	}
+	if !equalApproxGeneral(p, pCopy, 0) {
+		t.Errorf("%v: unexpected modification of P", prefix)
+	}
+
+	var vecs []blas64.General
+	var left []bool
+	if side == lapack.RightEV || side == lapack.RightLeftEV {
+		vecs = append(vecs, vr)
+		left = append(left, false)
+	}
+	if side == lapack.LeftEV || side == lapack.RightLeftEV {
+		vecs = append(vecs, vl)
+		left = append(left, true)
+	}
+	for k, v := range vecs {
+		if !generalOutsideAllNaN(v) {
+			t.Errorf("%v: out-of-range write to eigenvector matrix", prefix)
+		}
+		for j := 0; j < n; j++ {
+			xRe := columnOf(v, j)
+			var xIm []float64
+			if imag(alpha[j]) > 0 {
+				xIm = columnOf(v, j+1)
+			} else if imag(alpha[j]) < 0 {
+				xRe = columnOf(v, j-1)
+				xIm = columnOf(v, j)
+				floats.Scale(-1, xIm)
+			}
+
+			resid := generalizedEigenvectorResidual(a, b, xRe, xIm, alpha[j], beta[j], left[k])
+			if resid > tol {
+				t.Errorf("%v: left=%v, eigenvector %v: unexpected residual %v", prefix, left[k], j, resid)
+			}
+
+			var xmax float64
+			for i := range xRe {
+				xi := math.Abs(xRe[i])
+				if xIm != nil {
+					xi += math.Abs(xIm[i])
+				}
+				xmax = math.Max(xmax, xi)
+			}
+			if math.Abs(xmax-1) > tol {
+				t.Errorf("%v: left=%v, eigenvector %v is not normalized, max magnitude %v", prefix, left[k], j, xmax)
+			}
+		}
+	}
+}
+
+// randomGeneralizedSchur returns a random n×n matrix pair (S,P) in generalized
+// Schur form together with its eigenvalues alpha[j]/beta[j]. S is upper
+// quasi-triangular with 1×1 and 2×2 diagonal blocks, and P is upper triangular
+// with the 2×2 diagonal blocks corresponding to the 2×2 blocks of S diagonal
+// and positive. The 2×2 blocks of S have complex conjugate eigenvalues. Some
+// of the 1×1 blocks of P are zero and correspond to infinite eigenvalues.
+func randomGeneralizedSchur(n, stride int, rnd *rand.Rand) (s, p blas64.General, alpha []complex128, beta []float64) {
+	s = zeros(n, n, stride)
+	p = zeros(n, n, stride)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			s.Data[i*s.Stride+j] = rnd.NormFloat64()
+			p.Data[i*p.Stride+j] = rnd.NormFloat64()
+		}
+	}
+	alpha = make([]complex128, n)
+	beta = make([]float64, n)
+	for j := 0; j < n; {
+		if j == n-1 || rnd.Float64() < 0.5 {
+			// 1×1 block.
+			sjj := 1 + rnd.Float64()
+			if rnd.Float64() < 0.5 {
+				sjj *= -1
+			}
+			s.Data[j*s.Stride+j] = sjj
+			if rnd.Float64() < 0.2 {
+				// Infinite eigenvalue.
+				p.Data[j*p.Stride+j] = 0
+				alpha[j] = complex(sjj, 0)
+				beta[j] = 0
+			} else {
+				pjj := 0.5 + rnd.Float64()
+				p.Data[j*p.Stride+j] = pjj
+				alpha[j] = complex(sjj/pjj, 0)
+				beta[j] = 1
+			}
+			j++
+			continue
+		}
+
+		// 2×2 block with complex conjugate eigenvalues.
+		p1 := 0.5 + rnd.Float64()
+		p2 := 0.5 + rnd.Float64()
+		s11 := rnd.NormFloat64()
+		s22 := s11 * p2 / p1
+		s12 := 0.5 + rnd.Float64()
+		s21 := -(0.5 + rnd.Float64())
+		s.Data[j*s.Stride+j] = s11
+		s.Data[j*s.Stride+j+1] = s12
+		s.Data[(j+1)*s.Stride+j] = s21
+		s.Data[(j+1)*s.Stride+j+1] = s22
+		p.Data[j*p.Stride+j] = p1
+		p.Data[j*p.Stride+j+1] = 0
+		p.Data[(j+1)*p.Stride+j+1] = p2
+		// The eigenvalues are the roots of det(S_jj - λ*P_jj) == 0.
+		re := s11 / p1
+		im := math.Sqrt(-s12 * s21 / (p1 * p2))
+		alpha[j] = complex(re, im)
+		alpha[j+1] = complex(re, -im)
+		beta[j] = 1
+		beta[j+1] = 1
+		j += 2
+	}
+	return s, p, alpha, beta
+}
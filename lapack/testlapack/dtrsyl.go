@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+type Dtrsyler interface {
+	Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
+}
+
+func DtrsylTest(t *testing.T, impl Dtrsyler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trana := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tranb := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, isgn := range []int{1, -1} {
+				for _, m := range []int{0, 1, 2, 3, 4, 5, 10} {
+					for _, n := range []int{0, 1, 2, 3, 4, 5, 10} {
+						for _, extra := range []int{0, 3} {
+							for cas := 0; cas < 5; cas++ {
+								testDtrsyl(t, impl, trana, tranb, isgn, m, n, extra, rnd)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtrsyl(t *testing.T, impl Dtrsyler, trana, tranb blas.Transpose, isgn, m, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-11
+
+	a := randomSchurCanonical(m, m+extra, rnd)
+	b := randomSchurCanonical(n, n+extra, rnd)
+	// Shift the diagonal of B so that A and -isgn*B are unlikely to have
+	// close eigenvalues.
+	for i := 0; i < n; i++ {
+		b.Data[i*b.Stride+i] += float64(isgn) * 10
+	}
+	c := randomGeneral(m, n, n+extra, rnd)
+	cCopy := cloneGeneral(c)
+
+	scale, ok := impl.Dtrsyl(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride)
+
+	prefix := fmt.Sprintf("Case trana=%v, tranb=%v, isgn=%v, m=%v, n=%v, extra=%v", trana, tranb, isgn, m, n, extra)
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v: out-of-range write to c\n%v", prefix, c.Data)
+	}
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: invalid value of scale, want in (0,1], got %v", prefix, scale)
+	}
+	if !ok {
+		t.Errorf("%v: unexpected perturbation of the equation", prefix)
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	// Compute op(A)*X + isgn*X*op(B) - scale*C.
+	resid := cloneGeneral(cCopy)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			resid.Data[i*resid.Stride+j] *= -scale
+		}
+	}
+	blas64.Gemm(trana, blas.NoTrans, 1, a, c, 1, resid)
+	blas64.Gemm(blas.NoTrans, tranb, float64(isgn), c, b, 1, resid)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if diff := resid.Data[i*resid.Stride+j]; math.Abs(diff) > tol {
+				t.Errorf("%v: unexpected result, residual[%v,%v]=%v", prefix, i, j, diff)
+			}
+		}
+	}
+}
@@ -1000,6 +1000,34 @@ func randSymBand(ul blas.Uplo, n, ldab, kb int, rnd *rand.Rand) (blas64.Symmetri
 	return sym, band
 }
 
+// randBand creates a random m×n band matrix with kl sub-diagonals and ku
+// super-diagonals stored in rows of length ldab with the diagonal in column kl,
+// and returns both the band matrix and the equivalent General matrix. The
+// elements of the band storage outside of the band are NaN.
+func randBand(m, n, kl, ku, ldab int, rnd *rand.Rand) (blas64.General, blas64.Band) {
+	a := zeros(m, n, n)
+	rows := min(m, n+kl)
+	var band []float64
+	if rows > 0 {
+		band = nanSlice((rows-1)*ldab + ldab)
+	}
+	for i := 0; i < rows; i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			v := rnd.NormFloat64()
+			a.Data[i*a.Stride+j] = v
+			band[i*ldab+j-i+kl] = v
+		}
+	}
+	return a, blas64.Band{
+		Rows:   m,
+		Cols:   n,
+		KL:     kl,
+		KU:     ku,
+		Stride: ldab,
+		Data:   band,
+	}
+}
+
 // symToSymBand takes the data in a Symmetric matrix and returns a
 // SymmetricBanded matrix.
 func symToSymBand(ul blas.Uplo, a []float64, n, lda, kb, ldab int) []float64 {
@@ -1514,3 +1542,51 @@ func constructGSVPresults(n, p, m, k, l int, a, b blas64.General) (zeroA, zeroB
 
 	return zeroA, zeroB
 }
+
+// generalizedEigenvectorResidual returns the relative residual
+//  ‖β*A*x - α*B*x‖_∞ / ((|β|*‖A‖_∞ + |α|*‖B‖_∞) * ‖x‖_∞)
+// of the right generalized eigenvector x = xRe+i*xIm of the pair (A,B)
+// corresponding to the eigenvalue α/β, where i is the imaginary unit. If left is
+// true, x is treated as the left generalized eigenvector that satisfies
+//  x^H * (β*A - α*B) = 0
+// instead. xIm may be nil if the eigenvector is real.
+func generalizedEigenvectorResidual(a, b blas64.General, xRe, xIm []float64, alpha complex128, beta float64, left bool) float64 {
+	n := a.Rows
+	at := func(m blas64.General, i, j int) float64 {
+		if left {
+			return m.Data[j*m.Stride+i]
+		}
+		return m.Data[i*m.Stride+j]
+	}
+	if left {
+		// (β*A - α*B)^H * x = (β*A^T - conj(α)*B^T) * x for real A and B.
+		alpha = cmplx.Conj(alpha)
+	}
+	var resid, anorm, bnorm, xnorm float64
+	for i := 0; i < n; i++ {
+		var r complex128
+		var asum, bsum float64
+		for j := 0; j < n; j++ {
+			xj := complex(xRe[j], 0)
+			if xIm != nil {
+				xj = complex(xRe[j], xIm[j])
+			}
+			r += complex(beta*at(a, i, j), 0)*xj - alpha*complex(at(b, i, j), 0)*xj
+			asum += math.Abs(at(a, i, j))
+			bsum += math.Abs(at(b, i, j))
+		}
+		resid = math.Max(resid, cmplx.Abs(r))
+		anorm = math.Max(anorm, asum)
+		bnorm = math.Max(bnorm, bsum)
+		xi := math.Abs(xRe[i])
+		if xIm != nil {
+			xi = cmplx.Abs(complex(xRe[i], xIm[i]))
+		}
+		xnorm = math.Max(xnorm, xi)
+	}
+	den := (math.Abs(beta)*anorm + cmplx.Abs(alpha)*bnorm) * xnorm
+	if den == 0 {
+		return resid
+	}
+	return resid / den
+}
@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zgeever interface {
+	Zgeev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []complex128, lda int, w []complex128, vl []complex128, ldvl int, vr []complex128, ldvr int, work []complex128, lwork int, rwork []float64) (first int)
+}
+
+func ZgeevTest(t *testing.T, impl Zgeever) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+
+	// A matrix with known eigenvalues.
+	{
+		a := []complex128{
+			2, 1i, 0,
+			0, 1 + 1i, 3,
+			0, 0, -1i,
+		}
+		w := make([]complex128, 3)
+		work := make([]complex128, 6)
+		first := impl.Zgeev(lapack.None, lapack.None, 3, a, 3, w, nil, 1, nil, 1, work, len(work), make([]float64, 6))
+		if first != 0 {
+			t.Errorf("triangular: unexpected failure")
+		}
+		for _, want := range []complex128{2, 1 + 1i, -1i} {
+			if found, _ := containsComplex(w, want, tol); !found {
+				t.Errorf("triangular: eigenvalue %v not found in %v", want, w)
+			}
+		}
+	}
+
+	for _, test := range []struct {
+		n, lda int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{3, 0},
+		{5, 0},
+		{10, 0},
+		{30, 0},
+		{3, 5},
+		{10, 12},
+		{30, 35},
+	} {
+		n := test.n
+		lda := test.lda
+		if lda == 0 {
+			lda = max(1, n)
+		}
+		a := randomZGeneral(n, n, lda, rnd)
+
+		// Compute the eigenvalues only.
+		aCopy := make([]complex128, len(a))
+		copy(aCopy, a)
+		wWant := make([]complex128, n)
+		work := make([]complex128, 1)
+		impl.Zgeev(lapack.None, lapack.None, n, aCopy, lda, wWant, nil, 1, nil, 1, work, -1, nil)
+		work = make([]complex128, int(real(work[0])))
+		rwork := make([]float64, 2*n)
+		first := impl.Zgeev(lapack.None, lapack.None, n, aCopy, lda, wWant, nil, 1, nil, 1, work, len(work), rwork)
+		if first != 0 {
+			t.Errorf("n=%d: unexpected failure", n)
+			continue
+		}
+
+		for _, jobvl := range []lapack.LeftEVJob{lapack.None, lapack.ComputeLeftEV} {
+			for _, jobvr := range []lapack.RightEVJob{lapack.None, lapack.ComputeRightEV} {
+				prefix := fmt.Sprintf("jobvl=%c,jobvr=%c,n=%d,lda=%d", jobvl, jobvr, n, lda)
+
+				ldv := max(1, n)
+				var vl, vr []complex128
+				if jobvl == lapack.ComputeLeftEV {
+					vl = randomZGeneral(n, n, ldv, rnd)
+				}
+				if jobvr == lapack.ComputeRightEV {
+					vr = randomZGeneral(n, n, ldv, rnd)
+				}
+				copy(aCopy, a)
+				w := make([]complex128, n)
+				first := impl.Zgeev(jobvl, jobvr, n, aCopy, lda, w, vl, ldv, vr, ldv, work, len(work), rwork)
+				if first != 0 {
+					t.Errorf("%v: unexpected failure", prefix)
+					continue
+				}
+				for _, wi := range wWant {
+					if found, _ := containsComplex(w, wi, tol); !found {
+						t.Errorf("%v: eigenvalue %v not found", prefix, wi)
+						break
+					}
+				}
+				if n == 0 {
+					continue
+				}
+
+				anorm := math.Max(1, zmaxAbs(n, n, a, lda))
+				if jobvr == lapack.ComputeRightEV {
+					// Check that A * v_j = w_j * v_j.
+					av := zmul(blas.NoTrans, blas.NoTrans, n, n, n, a, lda, vr, ldv)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							av[i*n+j] -= w[j] * vr[i*ldv+j]
+						}
+					}
+					if resid := zmaxAbs(n, n, av, n); resid > tol*anorm {
+						t.Errorf("%v: residual |A*VR-VR*W| too large: %v", prefix, resid)
+					}
+					checkZEVNormalization(t, prefix+",right", n, vr, ldv, tol)
+				}
+				if jobvl == lapack.ComputeLeftEV {
+					// Check that u_j^H * A = w_j * u_j^H.
+					uha := zmul(blas.ConjTrans, blas.NoTrans, n, n, n, vl, ldv, a, lda)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							uha[i*n+j] -= w[i] * cmplx.Conj(vl[j*ldv+i])
+						}
+					}
+					if resid := zmaxAbs(n, n, uha, n); resid > tol*anorm {
+						t.Errorf("%v: residual |VL^H*A-W*VL^H| too large: %v", prefix, resid)
+					}
+					checkZEVNormalization(t, prefix+",left", n, vl, ldv, tol)
+				}
+			}
+		}
+	}
+}
+
+// checkZEVNormalization checks that the columns of the n×n matrix V have unit
+// Euclidean norm and that their component of largest magnitude is real.
+func checkZEVNormalization(t *testing.T, prefix string, n int, v []complex128, ldv int, tol float64) {
+	bi := cblas128.Implementation()
+	for j := 0; j < n; j++ {
+		nrm := bi.Dznrm2(n, v[j:], ldv)
+		if math.Abs(nrm-1) > tol {
+			t.Errorf("%v: column %d not normalized: |v|=%v", prefix, j, nrm)
+		}
+		var imax int
+		var vmax float64
+		for i := 0; i < n; i++ {
+			if abs := cmplx.Abs(v[i*ldv+j]); abs > vmax {
+				imax = i
+				vmax = abs
+			}
+		}
+		if math.Abs(imag(v[imax*ldv+j])) > tol {
+			t.Errorf("%v: largest component of column %d not real: %v", prefix, j, v[imax*ldv+j])
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+// randomZGeneral allocates a new m×n complex matrix with stride lda filled
+// with random values. Elements outside the matrix are set to NaN.
+func randomZGeneral(m, n, lda int, rnd *rand.Rand) []complex128 {
+	a := make([]complex128, max(1, (m-1)*lda+n))
+	for i := range a {
+		a[i] = cmplx.NaN()
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			a[i*lda+j] = complex(rnd.NormFloat64(), rnd.NormFloat64())
+		}
+	}
+	return a
+}
+
+// randomHermitian allocates a new n×n random Hermitian matrix with stride
+// lda. Both triangles of the matrix are set.
+func randomHermitian(n, lda int, rnd *rand.Rand) []complex128 {
+	a := randomZGeneral(n, n, lda, rnd)
+	for i := 0; i < n; i++ {
+		a[i*lda+i] = complex(real(a[i*lda+i]), 0)
+		for j := i + 1; j < n; j++ {
+			a[j*lda+i] = cmplx.Conj(a[i*lda+j])
+		}
+	}
+	return a
+}
+
+// zmul returns the m×n product op(A) * op(B) with stride n where op(A) is
+// m×k and op(B) is k×n.
+func zmul(tA, tB blas.Transpose, m, n, k int, a []complex128, lda int, b []complex128, ldb int) []complex128 {
+	c := make([]complex128, m*n)
+	if m == 0 || n == 0 {
+		return c
+	}
+	cblas128.Implementation().Zgemm(tA, tB, m, n, k, 1, a, lda, b, ldb, 0, c, max(1, n))
+	return c
+}
+
+// zEqualApprox returns whether the m×n matrices A and B are elementwise
+// equal within tol.
+func zEqualApprox(m, n int, a []complex128, lda int, b []complex128, ldb int, tol float64) bool {
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if cmplx.Abs(a[i*lda+j]-b[i*ldb+j]) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// zHasOrthonormalColumns returns whether the m×n matrix Q satisfies
+//  Q^H * Q = I
+// within tol.
+func zHasOrthonormalColumns(m, n int, q []complex128, ldq int, tol float64) bool {
+	if n == 0 {
+		return true
+	}
+	qhq := zmul(blas.ConjTrans, blas.NoTrans, n, n, m, q, ldq, q, ldq)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			want := complex(0, 0)
+			if i == j {
+				want = 1
+			}
+			if cmplx.Abs(qhq[i*n+j]-want) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// zmaxAbs returns the largest absolute value of the elements of the m×n
+// matrix A.
+func zmaxAbs(m, n int, a []complex128, lda int) float64 {
+	var v float64
+	for i := 0; i < m; i++ {
+		for _, aij := range a[i*lda : i*lda+n] {
+			v = math.Max(v, cmplx.Abs(aij))
+		}
+	}
+	return v
+}
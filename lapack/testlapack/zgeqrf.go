@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgeqrfer interface {
+	Zgeqrf(m, n int, a []complex128, lda int, tau, work []complex128, lwork int)
+	Zungqr(m, n, k int, a []complex128, lda int, tau, work []complex128, lwork int)
+}
+
+func ZgeqrfTest(t *testing.T, impl Zgeqrfer) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{3, 1, 0},
+		{1, 3, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{50, 20, 0},
+		{20, 50, 0},
+		{10, 5, 15},
+		{5, 10, 15},
+		{50, 20, 60},
+	} {
+		m := test.m
+		n := test.n
+		lda := test.lda
+		if lda == 0 {
+			lda = max(1, n)
+		}
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, lda)
+
+		a := randomZGeneral(m, n, lda, rnd)
+		aCopy := make([]complex128, len(a))
+		copy(aCopy, a)
+		k := min(m, n)
+		tau := make([]complex128, k)
+
+		work := make([]complex128, 1)
+		impl.Zgeqrf(m, n, a, lda, tau, work, -1)
+		lwork := int(real(work[0]))
+		work = make([]complex128, lwork)
+		impl.Zgeqrf(m, n, a, lda, tau, work, lwork)
+		if m == 0 || n == 0 {
+			continue
+		}
+
+		// Extract R and form the first k columns of Q.
+		r := make([]complex128, k*n)
+		for i := 0; i < k; i++ {
+			copy(r[i*n+i:i*n+n], a[i*lda+i:i*lda+n])
+		}
+		q := make([]complex128, m*k)
+		for i := 0; i < m; i++ {
+			copy(q[i*k:i*k+min(i, k)], a[i*lda:i*lda+min(i, k)])
+		}
+		work = make([]complex128, k)
+		impl.Zungqr(m, k, k, q, k, tau, work, len(work))
+
+		if !zHasOrthonormalColumns(m, k, q, k, tol*float64(m)) {
+			t.Errorf("%v: Q does not have orthonormal columns", prefix)
+		}
+		qr := zmul(blas.NoTrans, blas.NoTrans, m, n, k, q, k, r, n)
+		if !zEqualApprox(m, n, qr, n, aCopy, lda, tol*float64(m*n)) {
+			t.Errorf("%v: Q*R != A", prefix)
+		}
+		for i := 0; i < k; i++ {
+			if imag(r[i*n+i]) != 0 {
+				t.Errorf("%v: R[%d,%d] not real: %v", prefix, i, i, r[i*n+i])
+			}
+			if tau[i] != 0 && (real(tau[i]) < 1 || real(tau[i]) > 2 || cmplx.Abs(tau[i]-1) > 1+tol) {
+				t.Errorf("%v: tau[%d] out of range: %v", prefix, i, tau[i])
+			}
+		}
+	}
+}
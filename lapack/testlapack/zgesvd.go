@@ -0,0 +1,147 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zgesvder interface {
+	Zgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []complex128, lda int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64) (ok bool)
+}
+
+func ZgesvdTest(t *testing.T, impl Zgesvder) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{3, 1, 0},
+		{1, 3, 0},
+		{5, 5, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{20, 20, 0},
+		{40, 15, 0},
+		{15, 40, 0},
+		{10, 5, 12},
+		{5, 10, 12},
+		{20, 20, 25},
+	} {
+		m := test.m
+		n := test.n
+		lda := test.lda
+		if lda == 0 {
+			lda = max(1, n)
+		}
+		minmn := min(m, n)
+		a := randomZGeneral(m, n, lda, rnd)
+
+		// Compute the reference singular values.
+		aCopy := make([]complex128, len(a))
+		copy(aCopy, a)
+		sWant := make([]float64, minmn)
+		rwork := make([]float64, 2*minmn*minmn+5*minmn)
+		work := make([]complex128, 1)
+		impl.Zgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aCopy, lda, sWant, nil, 1, nil, 1, work, -1, rwork)
+		work = make([]complex128, int(real(work[0])))
+		ok := impl.Zgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aCopy, lda, sWant, nil, 1, nil, 1, work, len(work), rwork)
+		if !ok {
+			t.Errorf("m=%d,n=%d: Zgesvd did not converge", m, n)
+			continue
+		}
+		for i := 1; i < minmn; i++ {
+			if sWant[i] > sWant[i-1] {
+				t.Errorf("m=%d,n=%d: singular values not in decreasing order", m, n)
+				break
+			}
+		}
+
+		for _, jobU := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace, lapack.SVDNone} {
+			for _, jobVT := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace, lapack.SVDNone} {
+				prefix := fmt.Sprintf("jobU=%c,jobVT=%c,m=%d,n=%d,lda=%d", jobU, jobVT, m, n, lda)
+
+				ucol := m
+				if jobU == lapack.SVDInPlace {
+					ucol = minmn
+				}
+				ldu := max(1, ucol)
+				var u []complex128
+				if jobU != lapack.SVDNone {
+					u = randomZGeneral(m, ucol, ldu, rnd)
+				}
+				vtrow := n
+				if jobVT == lapack.SVDInPlace {
+					vtrow = minmn
+				}
+				ldvt := max(1, n)
+				var vt []complex128
+				if jobVT != lapack.SVDNone {
+					vt = randomZGeneral(vtrow, n, ldvt, rnd)
+				}
+
+				copy(aCopy, a)
+				s := make([]float64, minmn)
+				work := make([]complex128, 1)
+				impl.Zgesvd(jobU, jobVT, m, n, aCopy, lda, s, u, ldu, vt, ldvt, work, -1, rwork)
+				work = make([]complex128, int(real(work[0])))
+				ok := impl.Zgesvd(jobU, jobVT, m, n, aCopy, lda, s, u, ldu, vt, ldvt, work, len(work), rwork)
+				if !ok {
+					t.Errorf("%v: Zgesvd did not converge", prefix)
+					continue
+				}
+				if minmn == 0 {
+					continue
+				}
+
+				for i, v := range s {
+					if math.Abs(v-sWant[i]) > tol*math.Max(1, sWant[0]) {
+						t.Errorf("%v: singular values mismatch", prefix)
+						break
+					}
+				}
+				if jobU != lapack.SVDNone && !zHasOrthonormalColumns(m, ucol, u, ldu, tol*float64(m)) {
+					t.Errorf("%v: U does not have orthonormal columns", prefix)
+				}
+				if jobVT != lapack.SVDNone {
+					// The rows of VT are orthonormal if the columns of VT^T
+					// are, since the conjugate does not change orthonormality.
+					vtt := make([]complex128, n*vtrow)
+					for i := 0; i < vtrow; i++ {
+						for j := 0; j < n; j++ {
+							vtt[j*vtrow+i] = vt[i*ldvt+j]
+						}
+					}
+					if !zHasOrthonormalColumns(n, vtrow, vtt, vtrow, tol*float64(n)) {
+						t.Errorf("%v: VT does not have orthonormal rows", prefix)
+					}
+				}
+				if jobU == lapack.SVDNone || jobVT == lapack.SVDNone {
+					continue
+				}
+
+				// Check that U * Sigma * VT = A.
+				us := make([]complex128, m*minmn)
+				for i := 0; i < m; i++ {
+					for j := 0; j < minmn; j++ {
+						us[i*minmn+j] = u[i*ldu+j] * complex(s[j], 0)
+					}
+				}
+				usvt := zmul(blas.NoTrans, blas.NoTrans, m, n, minmn, us, minmn, vt, ldvt)
+				if !zEqualApprox(m, n, usvt, n, a, lda, tol*float64(m*n)) {
+					t.Errorf("%v: U*Sigma*VT != A", prefix)
+				}
+			}
+		}
+	}
+}
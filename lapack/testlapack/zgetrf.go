@@ -0,0 +1,108 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgetrfer interface {
+	Zgetrf(m, n int, a []complex128, lda int, ipiv []int) bool
+}
+
+func ZgetrfTest(t *testing.T, impl Zgetrfer) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{100, 5, 0},
+		{3, 100, 0},
+		{50, 50, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{10, 10, 20},
+		{50, 50, 60},
+	} {
+		m := test.m
+		n := test.n
+		lda := test.lda
+		if lda == 0 {
+			lda = max(1, n)
+		}
+		prefix := fmt.Sprintf("m=%d,n=%d,lda=%d", m, n, lda)
+
+		a := randomZGeneral(m, n, lda, rnd)
+		aCopy := make([]complex128, len(a))
+		copy(aCopy, a)
+		mn := min(m, n)
+		ipiv := make([]int, mn)
+
+		ok := impl.Zgetrf(m, n, a, lda, ipiv)
+		if !ok {
+			t.Errorf("%v: unexpected singular matrix", prefix)
+			continue
+		}
+
+		// Check the size of the multipliers and that P * L * U = A.
+		l := make([]complex128, m*mn)
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, mn); j++ {
+				l[i*mn+j] = a[i*lda+j]
+				// Pivoting on |re|+|im| bounds the modulus of the
+				// multipliers by √2.
+				if cmplx.Abs(a[i*lda+j]) > math.Sqrt2+tol {
+					t.Errorf("%v: unexpected magnitude of L[%d,%d]", prefix, i, j)
+				}
+			}
+			if i < mn {
+				l[i*mn+i] = 1
+			}
+		}
+		u := make([]complex128, mn*n)
+		for i := 0; i < mn; i++ {
+			copy(u[i*n+i:i*n+n], a[i*lda+i:i*lda+n])
+		}
+		var lu []complex128
+		if mn > 0 {
+			lu = zmul(blas.NoTrans, blas.NoTrans, m, n, mn, l, mn, u, max(1, n))
+		}
+		// Undo the row interchanges applied to lu.
+		for i := mn - 1; i >= 0; i-- {
+			p := ipiv[i]
+			if p < i || p >= m {
+				t.Errorf("%v: invalid pivot index ipiv[%d]=%d", prefix, i, p)
+				continue
+			}
+			for j := 0; j < n; j++ {
+				lu[i*n+j], lu[p*n+j] = lu[p*n+j], lu[i*n+j]
+			}
+		}
+		if !zEqualApprox(m, n, lu, n, aCopy, lda, tol*float64(max(m, n))) {
+			t.Errorf("%v: P*L*U != A", prefix)
+		}
+	}
+
+	// A singular matrix.
+	a := []complex128{
+		1, 2i, 3,
+		2, 4i, 6,
+		1 + 1i, 0, 1,
+	}
+	if impl.Zgetrf(3, 3, a, 3, make([]int, 3)) {
+		t.Errorf("unexpected success for singular matrix")
+	}
+}
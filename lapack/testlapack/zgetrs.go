@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zgetrser interface {
+	Zgetrfer
+	Zgetrs(trans blas.Transpose, n, nrhs int, a []complex128, lda int, ipiv []int, b []complex128, ldb int)
+}
+
+func ZgetrsTest(t *testing.T, impl Zgetrser) {
+	const tol = 1e-10
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{0, 0, 0, 0},
+			{1, 1, 0, 0},
+			{3, 1, 0, 0},
+			{3, 4, 0, 0},
+			{10, 5, 0, 0},
+			{50, 3, 0, 0},
+			{3, 1, 5, 5},
+			{10, 5, 20, 12},
+			{50, 3, 55, 10},
+		} {
+			n := test.n
+			nrhs := test.nrhs
+			lda := test.lda
+			if lda == 0 {
+				lda = max(1, n)
+			}
+			ldb := test.ldb
+			if ldb == 0 {
+				ldb = max(1, nrhs)
+			}
+			prefix := fmt.Sprintf("trans=%v,n=%d,nrhs=%d,lda=%d,ldb=%d", trans, n, nrhs, lda, ldb)
+
+			a := randomZGeneral(n, n, lda, rnd)
+			aCopy := make([]complex128, len(a))
+			copy(aCopy, a)
+			b := randomZGeneral(n, nrhs, ldb, rnd)
+			bCopy := make([]complex128, len(b))
+			copy(bCopy, b)
+
+			ipiv := make([]int, n)
+			if !impl.Zgetrf(n, n, a, lda, ipiv) {
+				t.Errorf("%v: unexpected singular matrix", prefix)
+				continue
+			}
+			impl.Zgetrs(trans, n, nrhs, a, lda, ipiv, b, ldb)
+			if n == 0 || nrhs == 0 {
+				continue
+			}
+
+			// Check that op(A) * X = B.
+			ax := zmul(trans, blas.NoTrans, n, nrhs, n, aCopy, lda, b, ldb)
+			if !zEqualApprox(n, nrhs, ax, nrhs, bCopy, ldb, tol) {
+				t.Errorf("%v: unexpected solution", prefix)
+			}
+		}
+	}
+}
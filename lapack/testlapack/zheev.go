@@ -0,0 +1,108 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack"
+)
+
+type Zheever interface {
+	Zheev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []complex128, lda int, w []float64, work []complex128, lwork int, rwork []float64) (ok bool)
+}
+
+func ZheevTest(t *testing.T, impl Zheever) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{0, 0},
+			{1, 0},
+			{2, 0},
+			{3, 0},
+			{5, 0},
+			{10, 0},
+			{40, 0},
+			{3, 5},
+			{10, 15},
+			{40, 50},
+		} {
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = max(1, n)
+			}
+			prefix := fmt.Sprintf("uplo=%v,n=%d,lda=%d", uplo, n, lda)
+
+			aFull := randomHermitian(n, lda, rnd)
+			// Only the uplo triangle is referenced, so spoil the other.
+			a := make([]complex128, len(aFull))
+			copy(a, aFull)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i > j) || (uplo == blas.Lower && i < j) {
+						a[i*lda+j] = cmplx.NaN()
+					}
+				}
+			}
+
+			var w [2][]float64
+			for k, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+				aCopy := make([]complex128, len(a))
+				copy(aCopy, a)
+				w[k] = make([]float64, n)
+				work := make([]complex128, 1)
+				impl.Zheev(jobz, uplo, n, aCopy, lda, w[k], work, -1, nil)
+				work = make([]complex128, int(real(work[0])))
+				rwork := make([]float64, max(1, 3*n-2))
+				if jobz == lapack.ComputeEV {
+					rwork = make([]float64, max(1, n*n+3*n-2))
+				}
+				ok := impl.Zheev(jobz, uplo, n, aCopy, lda, w[k], work, len(work), rwork)
+				if !ok {
+					t.Errorf("%v: Zheev did not converge", prefix)
+					continue
+				}
+				for i := 1; i < n; i++ {
+					if w[k][i] < w[k][i-1] {
+						t.Errorf("%v,jobz=%c: eigenvalues not in ascending order", prefix, jobz)
+						break
+					}
+				}
+				if jobz != lapack.ComputeEV || n == 0 {
+					continue
+				}
+
+				if !zHasOrthonormalColumns(n, n, aCopy, lda, tol*float64(n)) {
+					t.Errorf("%v: eigenvectors not orthonormal", prefix)
+				}
+				// Check that A * V = V * diag(w).
+				av := zmul(blas.NoTrans, blas.NoTrans, n, n, n, aFull, lda, aCopy, lda)
+				for i := 0; i < n; i++ {
+					for j := 0; j < n; j++ {
+						av[i*n+j] -= aCopy[i*lda+j] * complex(w[k][j], 0)
+					}
+				}
+				if resid := zmaxAbs(n, n, av, max(1, n)); resid > tol*float64(n)*math.Max(1, zmaxAbs(n, n, aFull, lda)) {
+					t.Errorf("%v: residual |A*V-V*W| too large: %v", prefix, resid)
+				}
+			}
+			for i := 0; i < n; i++ {
+				if math.Abs(w[0][i]-w[1][i]) > tol*float64(n) {
+					t.Errorf("%v: eigenvalue mismatch between jobz values", prefix)
+					break
+				}
+			}
+		}
+	}
+}
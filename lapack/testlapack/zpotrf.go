@@ -0,0 +1,106 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+)
+
+type Zpotrfer interface {
+	Zpotrf(ul blas.Uplo, n int, a []complex128, lda int) (ok bool)
+}
+
+func ZpotrfTest(t *testing.T, impl Zpotrfer) {
+	const tol = 1e-12
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{0, 0},
+			{1, 0},
+			{2, 0},
+			{5, 0},
+			{10, 0},
+			{50, 0},
+			{5, 10},
+			{10, 15},
+			{50, 60},
+		} {
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = max(1, n)
+			}
+			prefix := fmt.Sprintf("uplo=%v,n=%d,lda=%d", uplo, n, lda)
+
+			// Construct a Hermitian positive definite matrix
+			//  A = B^H * B + n * I.
+			b := randomZGeneral(n, n, n, rnd)
+			bhb := zmul(blas.ConjTrans, blas.NoTrans, n, n, n, b, max(1, n), b, max(1, n))
+			a := make([]complex128, max(1, (n-1)*lda+n))
+			for i := range a {
+				a[i] = cmplx.NaN()
+			}
+			for i := 0; i < n; i++ {
+				copy(a[i*lda:i*lda+n], bhb[i*n:i*n+n])
+				a[i*lda+i] += complex(float64(n), 0)
+			}
+			aCopy := make([]complex128, len(a))
+			copy(aCopy, a)
+
+			ok := impl.Zpotrf(uplo, n, a, lda)
+			if !ok {
+				t.Errorf("%v: unexpected failure for positive definite matrix", prefix)
+				continue
+			}
+
+			// Check that the other triangle has not been modified.
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i > j) || (uplo == blas.Lower && i < j) {
+						if a[i*lda+j] != aCopy[i*lda+j] {
+							t.Errorf("%v: unexpected modification of A[%d,%d]", prefix, i, j)
+						}
+					}
+				}
+			}
+
+			// Check the factorization.
+			f := make([]complex128, n*n)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+						f[i*n+j] = a[i*lda+j]
+					}
+				}
+			}
+			var got []complex128
+			if uplo == blas.Upper {
+				got = zmul(blas.ConjTrans, blas.NoTrans, n, n, n, f, max(1, n), f, max(1, n))
+			} else {
+				got = zmul(blas.NoTrans, blas.ConjTrans, n, n, n, f, max(1, n), f, max(1, n))
+			}
+			if !zEqualApprox(n, n, got, n, aCopy, lda, tol*float64(n*n)) {
+				t.Errorf("%v: unexpected factorization", prefix)
+			}
+		}
+
+		// A Hermitian indefinite matrix.
+		a := []complex128{
+			1, 2 + 1i, 0,
+			2 - 1i, 1, 1i,
+			0, -1i, 3,
+		}
+		if impl.Zpotrf(uplo, 3, a, 3) {
+			t.Errorf("uplo=%v: unexpected success for indefinite matrix", uplo)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// BiCGStab implements the preconditioned BiConjugate Gradient Stabilized
+// method for solving systems of linear equations
+//  A * x = b,
+// where A is a nonsymmetric nonsingular matrix. The method is a variant
+// of BiCG that avoids products with the transpose of A and has smoother
+// convergence.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.8 BiConjugate Gradient Stabilized
+//    (Bi-CGSTAB). In Templates for the Solution of Linear Systems: Building
+//    Blocks for Iterative Methods (2nd ed.) (pp. 24-25). Philadelphia, PA: SIAM.
+type BiCGStab struct {
+	r, rt, p, phat, v, s, shat, t *mat.VecDense
+
+	rho, rhoPrev, alpha, omega float64
+	first                      bool
+	resume                     int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (b *BiCGStab) Init(x, residual *mat.VecDense) {
+	n := residual.Len()
+	b.r = reuseVec(b.r, n)
+	b.r.CopyVec(residual)
+	b.rt = reuseVec(b.rt, n)
+	b.rt.CopyVec(residual)
+	b.p = reuseVec(b.p, n)
+	b.phat = reuseVec(b.phat, n)
+	b.v = reuseVec(b.v, n)
+	b.s = reuseVec(b.s, n)
+	b.shat = reuseVec(b.shat, n)
+	b.t = reuseVec(b.t, n)
+	b.first = true
+	b.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// BiCGStab will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (b *BiCGStab) Iterate(ctx *Context) (Operation, error) {
+	switch b.resume {
+	case 1:
+		b.rho = mat.Dot(b.rt, b.r)
+		if b.rho == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		if b.first {
+			b.p.CopyVec(b.r)
+			b.first = false
+		} else {
+			if b.omega == 0 {
+				return NoOperation, ErrBreakdown
+			}
+			beta := (b.rho / b.rhoPrev) * (b.alpha / b.omega)
+			// p = r + beta*(p - omega*v)
+			b.p.AddScaledVec(b.p, -b.omega, b.v)
+			b.p.AddScaledVec(b.r, beta, b.p)
+		}
+		// Solve M p^ = p.
+		ctx.Src = b.p
+		ctx.Dst = b.phat
+		b.resume = 2
+		return PreconSolve, nil
+	case 2:
+		// Compute A p^.
+		ctx.Src = b.phat
+		ctx.Dst = b.v
+		b.resume = 3
+		return MulVec, nil
+	case 3:
+		rtv := mat.Dot(b.rt, b.v)
+		if rtv == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		b.alpha = b.rho / rtv
+		b.s.AddScaledVec(b.r, -b.alpha, b.v)
+		// Check for early convergence.
+		ctx.ResidualNorm = mat.Norm(b.s, 2)
+		b.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		if ctx.Converged {
+			ctx.X.AddScaledVec(ctx.X, b.alpha, b.phat)
+			b.rhoPrev = b.rho
+			b.resume = 1
+			return MajorIteration, nil
+		}
+		// Solve M s^ = s.
+		ctx.Src = b.s
+		ctx.Dst = b.shat
+		b.resume = 5
+		return PreconSolve, nil
+	case 5:
+		// Compute A s^.
+		ctx.Src = b.shat
+		ctx.Dst = b.t
+		b.resume = 6
+		return MulVec, nil
+	case 6:
+		tt := mat.Dot(b.t, b.t)
+		if tt == 0 {
+			return NoOperation, ErrBreakdown
+		}
+		b.omega = mat.Dot(b.t, b.s) / tt
+		ctx.X.AddScaledVec(ctx.X, b.alpha, b.phat)
+		ctx.X.AddScaledVec(ctx.X, b.omega, b.shat)
+		b.r.AddScaledVec(b.s, -b.omega, b.t)
+		ctx.ResidualNorm = mat.Norm(b.r, 2)
+		b.resume = 7
+		return CheckResidualNorm, nil
+	case 7:
+		b.rhoPrev = b.rho
+		b.resume = 1
+		return MajorIteration, nil
+	default:
+		panic("linsolve: BiCGStab.Init not called")
+	}
+}
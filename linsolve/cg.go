@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"gonum.org/v1/gonum/mat"
+)
+
+// CG implements the preconditioned Conjugate Gradient method for solving
+// systems of linear equations
+//  A * x = b,
+// where A is symmetric positive definite. The preconditioner must also be
+// symmetric positive definite.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.1 Conjugate Gradient Method (CG).
+//    In Templates for the Solution of Linear Systems: Building Blocks
+//    for Iterative Methods (2nd ed.) (pp. 12-15). Philadelphia, PA: SIAM.
+type CG struct {
+	r, z, p, ap *mat.VecDense
+
+	rho, rhoPrev float64
+	first        bool
+	resume       int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (cg *CG) Init(x, residual *mat.VecDense) {
+	n := residual.Len()
+	cg.r = reuseVec(cg.r, n)
+	cg.r.CopyVec(residual)
+	cg.z = reuseVec(cg.z, n)
+	cg.p = reuseVec(cg.p, n)
+	cg.ap = reuseVec(cg.ap, n)
+	cg.first = true
+	cg.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// CG will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (cg *CG) Iterate(ctx *Context) (Operation, error) {
+	switch cg.resume {
+	case 1:
+		// Solve M z = r.
+		ctx.Src = cg.r
+		ctx.Dst = cg.z
+		cg.resume = 2
+		return PreconSolve, nil
+	case 2:
+		cg.rho = mat.Dot(cg.r, cg.z)
+		if cg.first {
+			cg.p.CopyVec(cg.z)
+			cg.first = false
+		} else {
+			if cg.rhoPrev == 0 {
+				return NoOperation, ErrBreakdown
+			}
+			beta := cg.rho / cg.rhoPrev
+			cg.p.AddScaledVec(cg.z, beta, cg.p)
+		}
+		// Compute A p.
+		ctx.Src = cg.p
+		ctx.Dst = cg.ap
+		cg.resume = 3
+		return MulVec, nil
+	case 3:
+		pap := mat.Dot(cg.p, cg.ap)
+		if pap <= 0 {
+			// A is not positive definite.
+			return NoOperation, ErrBreakdown
+		}
+		alpha := cg.rho / pap
+		ctx.X.AddScaledVec(ctx.X, alpha, cg.p)
+		cg.r.AddScaledVec(cg.r, -alpha, cg.ap)
+		ctx.ResidualNorm = mat.Norm(cg.r, 2)
+		cg.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		cg.rhoPrev = cg.rho
+		cg.resume = 1
+		return MajorIteration, nil
+	default:
+		panic("linsolve: CG.Init not called")
+	}
+}
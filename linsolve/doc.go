@@ -0,0 +1,11 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package linsolve implements iterative methods for solving linear systems
+// of equations
+//  A * x = b,
+// where A is accessed only through matrix-vector products. The methods are
+// suited to large, sparse systems for which a dense factorization is not
+// feasible.
+package linsolve // import "gonum.org/v1/gonum/linsolve"
@@ -0,0 +1,226 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// GMRES implements the restarted Generalized Minimal Residual method with
+// right preconditioning for solving systems of linear equations
+//  A * x = b,
+// where A is a nonsymmetric nonsingular matrix. The Arnoldi basis is
+// orthogonalized by the modified Gram-Schmidt process and the least-squares
+// problem is updated with Givens rotations.
+//
+// The approximate solution is only updated at the end of each restart cycle
+// or when the estimate of the residual norm indicates convergence.
+//
+// References:
+//  - Barrett, R. et al. (1994). Section 2.3.4 Generalized Minimal Residual
+//    (GMRES). In Templates for the Solution of Linear Systems: Building Blocks
+//    for Iterative Methods (2nd ed.) (pp. 17-19). Philadelphia, PA: SIAM.
+//  - Saad, Y., and Schultz, M. (1986). GMRES: A generalized minimal residual
+//    algorithm for solving nonsymmetric linear systems. SIAM J. Sci. Stat.
+//    Comput., 7(3), 856-869.
+type GMRES struct {
+	// Restart is the restart parameter, that is, the maximum dimension
+	// of the Krylov subspace before the method is restarted. If Restart
+	// is zero, min(n, 30) is used where n is the dimension of the system.
+	// Restart must not be negative.
+	Restart int
+
+	m       int             // Effective restart parameter.
+	v       []*mat.VecDense // Arnoldi basis vectors.
+	h       *mat.Dense      // Upper Hessenberg matrix reduced to upper triangular form.
+	z, w, u *mat.VecDense
+
+	cs, sn, g, y []float64
+
+	j      int
+	resume int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (g *GMRES) Init(x, residual *mat.VecDense) {
+	if g.Restart < 0 {
+		panic("linsolve: negative GMRES restart")
+	}
+	n := residual.Len()
+	g.m = g.Restart
+	if g.m == 0 {
+		g.m = min(n, 30)
+	}
+	if g.m > n {
+		g.m = n
+	}
+	m := g.m
+
+	if cap(g.v) < m+1 {
+		g.v = make([]*mat.VecDense, m+1)
+	}
+	g.v = g.v[:m+1]
+	for i := range g.v {
+		g.v[i] = reuseVec(g.v[i], n)
+	}
+	if g.h == nil {
+		g.h = mat.NewDense(m+1, m, nil)
+	} else if r, c := g.h.Dims(); r != m+1 || c != m {
+		g.h = mat.NewDense(m+1, m, nil)
+	}
+	g.z = reuseVec(g.z, n)
+	g.w = reuseVec(g.w, n)
+	g.u = reuseVec(g.u, n)
+	g.cs = reuseFloats(g.cs, m)
+	g.sn = reuseFloats(g.sn, m)
+	g.g = reuseFloats(g.g, m+1)
+	g.y = reuseFloats(g.y, m)
+
+	g.initCycle(residual)
+	g.resume = 1
+}
+
+// initCycle prepares the start of a restart cycle from the residual r.
+func (g *GMRES) initCycle(r *mat.VecDense) {
+	beta := mat.Norm(r, 2)
+	g.v[0].ScaleVec(1/beta, r)
+	for i := range g.g {
+		g.g[i] = 0
+	}
+	g.g[0] = beta
+	g.j = 0
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// GMRES will command the following operations:
+//  MulVec
+//  PreconSolve
+//  ComputeResidual
+//  CheckResidualNorm
+//  MajorIteration
+func (g *GMRES) Iterate(ctx *Context) (Operation, error) {
+	switch g.resume {
+	case 1:
+		// Solve M z = v_j.
+		ctx.Src = g.v[g.j]
+		ctx.Dst = g.z
+		g.resume = 2
+		return PreconSolve, nil
+	case 2:
+		// Compute A z.
+		ctx.Src = g.z
+		ctx.Dst = g.w
+		g.resume = 3
+		return MulVec, nil
+	case 3:
+		j := g.j
+		// Modified Gram-Schmidt orthogonalization.
+		for i := 0; i <= j; i++ {
+			hij := mat.Dot(g.w, g.v[i])
+			g.h.Set(i, j, hij)
+			g.w.AddScaledVec(g.w, -hij, g.v[i])
+		}
+		hj1 := mat.Norm(g.w, 2)
+		if hj1 != 0 {
+			g.v[j+1].ScaleVec(1/hj1, g.w)
+		}
+		// Apply the previous rotations to the new column of H.
+		for i := 0; i < j; i++ {
+			hi := g.h.At(i, j)
+			hi1 := g.h.At(i+1, j)
+			g.h.Set(i, j, g.cs[i]*hi+g.sn[i]*hi1)
+			g.h.Set(i+1, j, -g.sn[i]*hi+g.cs[i]*hi1)
+		}
+		// Compute and apply the rotation eliminating H[j+1,j].
+		hjj := g.h.At(j, j)
+		c, s := givens(hjj, hj1)
+		g.cs[j] = c
+		g.sn[j] = s
+		g.h.Set(j, j, c*hjj+s*hj1)
+		g.h.Set(j+1, j, 0)
+		g.g[j+1] = -s * g.g[j]
+		g.g[j] = c * g.g[j]
+		g.j++
+
+		ctx.ResidualNorm = math.Abs(g.g[g.j])
+		g.resume = 4
+		return CheckResidualNorm, nil
+	case 4:
+		if !ctx.Converged && g.j < g.m {
+			g.resume = 1
+			return MajorIteration, nil
+		}
+		// Update the solution at the end of the cycle.
+		k := g.j
+		for i := k - 1; i >= 0; i-- {
+			sum := g.g[i]
+			for l := i + 1; l < k; l++ {
+				sum -= g.h.At(i, l) * g.y[l]
+			}
+			hii := g.h.At(i, i)
+			if hii == 0 {
+				return NoOperation, ErrBreakdown
+			}
+			g.y[i] = sum / hii
+		}
+		g.u.ScaleVec(g.y[0], g.v[0])
+		for i := 1; i < k; i++ {
+			g.u.AddScaledVec(g.u, g.y[i], g.v[i])
+		}
+		// Solve M z = V*y.
+		ctx.Src = g.u
+		ctx.Dst = g.z
+		g.resume = 5
+		return PreconSolve, nil
+	case 5:
+		ctx.X.AddVec(ctx.X, g.z)
+		if ctx.Converged {
+			g.resume = 1
+			return MajorIteration, nil
+		}
+		// Restart from the true residual.
+		ctx.Dst = g.u
+		g.resume = 6
+		return ComputeResidual, nil
+	case 6:
+		ctx.ResidualNorm = mat.Norm(g.u, 2)
+		g.resume = 7
+		return CheckResidualNorm, nil
+	case 7:
+		if !ctx.Converged {
+			g.initCycle(g.u)
+		}
+		g.resume = 1
+		return MajorIteration, nil
+	default:
+		panic("linsolve: GMRES.Init not called")
+	}
+}
+
+// givens returns the cosine and sine of the plane rotation that eliminates b
+// in the vector [a, b].
+func givens(a, b float64) (c, s float64) {
+	if b == 0 {
+		return 1, 0
+	}
+	r := math.Hypot(a, b)
+	return a / r, b / r
+}
+
+// reuseFloats returns a zeroed slice of length n, reusing s if it has
+// sufficient capacity.
+func reuseFloats(s []float64, n int) []float64 {
+	if cap(s) < n {
+		return make([]float64, n)
+	}
+	s = s[:n]
+	for i := range s {
+		s[i] = 0
+	}
+	return s
+}
@@ -0,0 +1,368 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+const defaultTolerance = 1e-8
+
+var (
+	// ErrIterationLimit is returned when the maximum number of
+	// iterations is reached before convergence.
+	ErrIterationLimit = errors.New("linsolve: iteration limit reached")
+
+	// ErrBreakdown is returned when a method cannot continue
+	// because a scalar it divides by has become zero, or a
+	// quantity that should be positive is not.
+	ErrBreakdown = errors.New("linsolve: method breakdown")
+)
+
+// MulVecToer represents a linear operator A that can compute the product of
+// itself or its transpose with a vector.
+type MulVecToer interface {
+	// MulVecTo computes A*x or A^T*x, if trans is true, and stores the
+	// result into dst. dst will have the correct length and will not
+	// alias x.
+	MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense)
+}
+
+// MulVecFunc is a function type that implements the MulVecToer interface.
+type MulVecFunc func(dst *mat.VecDense, trans bool, x *mat.VecDense)
+
+// MulVecTo calls f(dst, trans, x).
+func (f MulVecFunc) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	f(dst, trans, x)
+}
+
+// MatrixOperator returns a MulVecToer that multiplies vectors by the matrix a.
+func MatrixOperator(a mat.Matrix) MulVecToer {
+	return matrixOperator{a}
+}
+
+type matrixOperator struct {
+	a mat.Matrix
+}
+
+func (m matrixOperator) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	if trans {
+		dst.MulVec(m.a.T(), x)
+		return
+	}
+	dst.MulVec(m.a, x)
+}
+
+// Operation represents the set of operations commanded by Method at each
+// iteration. It is a bitmap of various Iteration and Evaluation constants.
+// Individual constants must NOT be combined together by the binary OR operator
+// except for the Trans flag.
+type Operation uint64
+
+// Supported Operations.
+const (
+	// NoOperation specifies that no action should be taken.
+	NoOperation Operation = 0
+
+	// MulVec specifies that the product A*Src, or A^T*Src if combined
+	// with Trans, should be stored into Dst.
+	MulVec Operation = 1 << (iota - 1)
+
+	// PreconSolve specifies that the preconditioner system M*Dst = Src,
+	// or M^T*Dst = Src if combined with Trans, should be solved.
+	PreconSolve
+
+	// Trans indicates that MulVec or PreconSolve should be performed
+	// with the transpose of the operator.
+	Trans
+
+	// ComputeResidual specifies that the residual b - A*X should be
+	// computed and stored into Dst.
+	ComputeResidual
+
+	// CheckResidualNorm specifies that convergence should be checked
+	// using ResidualNorm and the result stored into Converged.
+	CheckResidualNorm
+
+	// MajorIteration indicates that an iteration of the method has
+	// been completed. If Converged is true, X holds the solution and
+	// the solve terminates.
+	MajorIteration
+)
+
+func (op Operation) String() string {
+	s, ok := operationNames[op&^Trans]
+	if !ok {
+		return fmt.Sprintf("Operation(%d)", op)
+	}
+	if op&Trans != 0 {
+		s += "|Trans"
+	}
+	return s
+}
+
+var operationNames = map[Operation]string{
+	NoOperation:       "NoOperation",
+	MulVec:            "MulVec",
+	PreconSolve:       "PreconSolve",
+	ComputeResidual:   "ComputeResidual",
+	CheckResidualNorm: "CheckResidualNorm",
+	MajorIteration:    "MajorIteration",
+}
+
+// Context mediates the communication between a Method and the caller.
+// A Method must not modify fields of Context other than those it is
+// required to set for a returned Operation.
+type Context struct {
+	// X is the current approximate solution. It is updated by the Method.
+	X *mat.VecDense
+
+	// ResidualNorm is the estimate of the norm of the residual at the
+	// current X, set by the Method before CheckResidualNorm.
+	ResidualNorm float64
+
+	// Converged is set by the caller in response to CheckResidualNorm.
+	Converged bool
+
+	// Src and Dst are the source and destination vectors of the
+	// commanded Operation. They are set by the Method.
+	Src, Dst *mat.VecDense
+}
+
+// Method is an iterative method for solving linear systems.
+//
+// A Method uses a reverse-communication interface between the iterative
+// method and the caller. Method acts as a client that asks the caller to
+// perform needed operations, such as matrix-vector products and
+// preconditioner solves, via Operation returned from Iterate. This makes the
+// Method independent of the representation of the linear operator.
+type Method interface {
+	// Init initializes the method for solving a linear system with an
+	// n×n matrix. x is the initial approximate solution and residual is
+	// the corresponding residual b - A*x. Init must not retain or
+	// modify x or residual.
+	Init(x, residual *mat.VecDense)
+
+	// Iterate retrieves data from ctx, performs an iteration step of the
+	// method and returns the next operation.
+	Iterate(ctx *Context) (Operation, error)
+}
+
+// Settings holds the settings for solving a linear system.
+// In general, users should use DefaultSettings rather than constructing
+// a Settings literal.
+type Settings struct {
+	// InitX holds the initial guess. If it is nil, the zero vector
+	// is used.
+	InitX *mat.VecDense
+
+	// Dst, if not nil, is used to store the solution.
+	Dst *mat.VecDense
+
+	// Tolerance specifies the convergence threshold for the residual.
+	// The iteration converges when
+	//  ‖r_i‖ < Tolerance * ‖b‖,
+	// where r_i is the residual at the i-th iteration. If ‖b‖ is zero,
+	// ‖b‖ is replaced by one. Tolerance must be positive and smaller
+	// than one. If it is zero, a default value of 1e-8 is used.
+	Tolerance float64
+
+	// MaxIterations is the maximum number of iterations allowed.
+	// ErrIterationLimit is returned if the number of iterations
+	// equals or exceeds this value. If it equals zero, a default
+	// value of 4 times the dimension of the system is used.
+	MaxIterations int
+
+	// PreconSolve solves the preconditioner system
+	//  M * dst = rhs
+	// or its transpose if trans is true. If PreconSolve is nil, the
+	// identity preconditioner M = I is used.
+	PreconSolve func(dst *mat.VecDense, trans bool, rhs *mat.VecDense) error
+}
+
+// DefaultSettings returns a new Settings struct containing the default settings.
+func DefaultSettings() *Settings {
+	return &Settings{
+		Tolerance: defaultTolerance,
+	}
+}
+
+// Result holds the result of an iterative solve.
+type Result struct {
+	// X is the approximate solution.
+	X *mat.VecDense
+
+	// ResidualNorm is the estimate of the norm of the residual at X
+	// reported by the Method.
+	ResidualNorm float64
+
+	// ResidualHistory holds the residual norm estimate at the end of
+	// each major iteration.
+	ResidualHistory []float64
+
+	Stats
+}
+
+// Stats contains the statistics of the run.
+type Stats struct {
+	Iterations  int           // Total number of major iterations
+	MulVec      int           // Number of matrix-vector products
+	PreconSolve int           // Number of preconditioner solves
+	Runtime     time.Duration // Total runtime of the solve
+}
+
+// Iterative finds an approximate solution of the system of n linear equations
+//  A * x = b,
+// where A is a nonsingular n×n matrix represented by a, and b is the right-hand
+// side vector, using the iterative method m. If m is nil, the default method GMRES
+// is used. If settings is nil, the default settings returned by DefaultSettings
+// are used.
+//
+// Iterative returns a non-nil result with the most recent approximate solution
+// even if it also returns an error.
+func Iterative(a MulVecToer, b *mat.VecDense, m Method, settings *Settings) (*Result, error) {
+	n := b.Len()
+	if n == 0 {
+		panic("linsolve: dimension is zero")
+	}
+
+	var s Settings
+	if settings != nil {
+		s = *settings
+	}
+	if s.Tolerance == 0 {
+		s.Tolerance = defaultTolerance
+	}
+	if s.Tolerance < 0 || 1 <= s.Tolerance {
+		panic("linsolve: invalid tolerance")
+	}
+	if s.MaxIterations == 0 {
+		s.MaxIterations = 4 * n
+	}
+	if s.MaxIterations < 0 {
+		panic("linsolve: negative iteration limit")
+	}
+	if s.InitX != nil && s.InitX.Len() != n {
+		panic("linsolve: mismatched length of initial guess")
+	}
+	if s.Dst != nil && s.Dst.Len() != n {
+		panic("linsolve: mismatched length of destination")
+	}
+	if m == nil {
+		m = &GMRES{}
+	}
+
+	start := time.Now()
+
+	x := s.Dst
+	if x == nil {
+		x = mat.NewVecDense(n, nil)
+	}
+	var stats Stats
+	r := mat.NewVecDense(n, nil)
+	if s.InitX != nil {
+		x.CopyVec(s.InitX)
+		computeResidual(r, a, b, x, &stats)
+	} else {
+		for i := 0; i < n; i++ {
+			x.SetVec(i, 0)
+		}
+		r.CopyVec(b)
+	}
+
+	bNorm := mat.Norm(b, 2)
+	if bNorm == 0 {
+		bNorm = 1
+	}
+	threshold := s.Tolerance * bNorm
+
+	result := &Result{X: x}
+	result.ResidualNorm = mat.Norm(r, 2)
+	if result.ResidualNorm < threshold {
+		stats.Runtime = time.Since(start)
+		result.Stats = stats
+		return result, nil
+	}
+
+	m.Init(x, r)
+	ctx := &Context{X: x}
+	var err error
+	for {
+		var op Operation
+		op, err = m.Iterate(ctx)
+		if err != nil {
+			break
+		}
+		trans := op&Trans != 0
+		switch op &^ Trans {
+		case NoOperation:
+		case MulVec:
+			stats.MulVec++
+			a.MulVecTo(ctx.Dst, trans, ctx.Src)
+		case PreconSolve:
+			stats.PreconSolve++
+			if s.PreconSolve == nil {
+				ctx.Dst.CopyVec(ctx.Src)
+				break
+			}
+			err = s.PreconSolve(ctx.Dst, trans, ctx.Src)
+		case ComputeResidual:
+			computeResidual(ctx.Dst, a, b, ctx.X, &stats)
+		case CheckResidualNorm:
+			ctx.Converged = ctx.ResidualNorm < threshold
+		case MajorIteration:
+			stats.Iterations++
+			result.ResidualHistory = append(result.ResidualHistory, ctx.ResidualNorm)
+			if ctx.Converged {
+				result.ResidualNorm = ctx.ResidualNorm
+				stats.Runtime = time.Since(start)
+				result.Stats = stats
+				return result, nil
+			}
+			if stats.Iterations >= s.MaxIterations {
+				err = ErrIterationLimit
+			}
+		default:
+			panic("linsolve: invalid operation")
+		}
+		if err != nil {
+			break
+		}
+	}
+	result.ResidualNorm = ctx.ResidualNorm
+	stats.Runtime = time.Since(start)
+	result.Stats = stats
+	return result, err
+}
+
+// computeResidual stores b - A*x into dst.
+func computeResidual(dst *mat.VecDense, a MulVecToer, b, x *mat.VecDense, stats *Stats) {
+	stats.MulVec++
+	a.MulVecTo(dst, false, x)
+	dst.SubVec(b, dst)
+}
+
+// reuseVec returns a vector of length n, reusing v if it has the correct
+// length.
+func reuseVec(v *mat.VecDense, n int) *mat.VecDense {
+	if v == nil || v.Len() != n {
+		return mat.NewVecDense(n, nil)
+	}
+	return v
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// dlamchE is the machine epsilon.
+const dlamchE = 1.0 / (1 << 53)
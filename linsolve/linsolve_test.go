@@ -0,0 +1,242 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// laplacian2D returns the k²×k² matrix of the five-point finite difference
+// discretization of the negative Laplacian on a k×k grid with an added
+// first-order convection term of strength conv and a diagonal shift.
+func laplacian2D(k int, conv, shift float64) *mat.CSR {
+	n := k * k
+	t := mat.NewTriplet(n, n)
+	for i := 0; i < k; i++ {
+		for j := 0; j < k; j++ {
+			row := i*k + j
+			t.Append(row, row, 4-shift)
+			if i > 0 {
+				t.Append(row, row-k, -1-conv)
+			}
+			if i < k-1 {
+				t.Append(row, row+k, -1+conv)
+			}
+			if j > 0 {
+				t.Append(row, row-1, -1-conv)
+			}
+			if j < k-1 {
+				t.Append(row, row+1, -1+conv)
+			}
+		}
+	}
+	return t.ToCSR()
+}
+
+type preconditioner interface {
+	PreconSolve(dst *mat.VecDense, trans bool, rhs *mat.VecDense) error
+}
+
+func TestIterative(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+
+	spd := laplacian2D(8, 0, 0)
+	nonsym := laplacian2D(8, 0.4, 0)
+	indef := laplacian2D(8, 0, 2.1)
+
+	jacobi := func(a *mat.CSR) preconditioner {
+		p, err := NewJacobi(a)
+		if err != nil {
+			t.Fatalf("unexpected error creating Jacobi preconditioner: %v", err)
+		}
+		return p
+	}
+	ilu0 := func(a *mat.CSR) preconditioner {
+		p, err := NewILU0(a)
+		if err != nil {
+			t.Fatalf("unexpected error creating ILU0 preconditioner: %v", err)
+		}
+		return p
+	}
+	ic0 := func(a *mat.CSR) preconditioner {
+		p, err := NewIC0(a)
+		if err != nil {
+			t.Fatalf("unexpected error creating IC0 preconditioner: %v", err)
+		}
+		return p
+	}
+
+	for _, test := range []struct {
+		name    string
+		a       *mat.CSR
+		method  func() Method
+		precon  func(*mat.CSR) preconditioner
+		residTo float64
+	}{
+		{name: "CG SPD", a: spd, method: func() Method { return &CG{} }},
+		{name: "CG SPD Jacobi", a: spd, method: func() Method { return &CG{} }, precon: jacobi},
+		{name: "CG SPD IC0", a: spd, method: func() Method { return &CG{} }, precon: ic0},
+		{name: "MINRES SPD", a: spd, method: func() Method { return &MINRES{} }},
+		{name: "MINRES SPD IC0", a: spd, method: func() Method { return &MINRES{} }, precon: ic0, residTo: 1e-6},
+		{name: "MINRES indefinite", a: indef, method: func() Method { return &MINRES{} }},
+		{name: "MINRES indefinite Jacobi", a: indef, method: func() Method { return &MINRES{} }, precon: jacobi, residTo: 1e-6},
+		{name: "GMRES SPD", a: spd, method: func() Method { return &GMRES{} }},
+		{name: "GMRES nonsymmetric", a: nonsym, method: func() Method { return &GMRES{} }},
+		{name: "GMRES nonsymmetric restart 5", a: nonsym, method: func() Method { return &GMRES{Restart: 5} }},
+		{name: "GMRES nonsymmetric ILU0", a: nonsym, method: func() Method { return &GMRES{} }, precon: ilu0},
+		{name: "GMRES indefinite", a: indef, method: func() Method { return &GMRES{} }},
+		{name: "BiCGStab SPD", a: spd, method: func() Method { return &BiCGStab{} }},
+		{name: "BiCGStab nonsymmetric", a: nonsym, method: func() Method { return &BiCGStab{} }},
+		{name: "BiCGStab nonsymmetric Jacobi", a: nonsym, method: func() Method { return &BiCGStab{} }, precon: jacobi},
+		{name: "BiCGStab nonsymmetric ILU0", a: nonsym, method: func() Method { return &BiCGStab{} }, precon: ilu0},
+		{name: "default method", a: nonsym},
+	} {
+		n, _ := test.a.Dims()
+		want := mat.NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			want.SetVec(i, rnd.NormFloat64())
+		}
+		b := mat.NewVecDense(n, nil)
+		b.MulVec(test.a, want)
+
+		settings := DefaultSettings()
+		settings.MaxIterations = 10 * n
+		if test.precon != nil {
+			settings.PreconSolve = test.precon(test.a).PreconSolve
+		}
+		var m Method
+		if test.method != nil {
+			m = test.method()
+		}
+		for _, useFunc := range []bool{false, true} {
+			prefix := fmt.Sprintf("%s (MulVecFunc=%t)", test.name, useFunc)
+			op := MatrixOperator(test.a)
+			if useFunc {
+				op = MulVecFunc(func(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+					if trans {
+						dst.MulVec(test.a.T(), x)
+					} else {
+						dst.MulVec(test.a, x)
+					}
+				})
+			}
+			result, err := Iterative(op, b, m, settings)
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", prefix, err)
+				continue
+			}
+			if len(result.ResidualHistory) != result.Iterations {
+				t.Errorf("%s: mismatched residual history length: got %d want %d",
+					prefix, len(result.ResidualHistory), result.Iterations)
+			}
+			if result.MulVec == 0 || result.PreconSolve == 0 {
+				t.Errorf("%s: unexpected stats: %+v", prefix, result.Stats)
+			}
+
+			residTo := test.residTo
+			if residTo == 0 {
+				residTo = 10 * settings.Tolerance
+			}
+			var r mat.VecDense
+			r.MulVec(test.a, result.X)
+			r.SubVec(b, &r)
+			if resid := mat.Norm(&r, 2) / mat.Norm(b, 2); resid > residTo {
+				t.Errorf("%s: relative residual too large: got %v want <= %v", prefix, resid, residTo)
+			}
+		}
+	}
+}
+
+func TestIterativeSettings(t *testing.T) {
+	a := laplacian2D(5, 0, 0)
+	n, _ := a.Dims()
+	want := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		want.SetVec(i, float64(i))
+	}
+	b := mat.NewVecDense(n, nil)
+	b.MulVec(a, want)
+
+	// An exact initial guess must not start the iteration.
+	settings := DefaultSettings()
+	settings.InitX = want
+	settings.Dst = mat.NewVecDense(n, nil)
+	result, err := Iterative(MatrixOperator(a), b, &CG{}, settings)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Iterations != 0 {
+		t.Errorf("unexpected number of iterations for exact initial guess: got %d want 0", result.Iterations)
+	}
+	if result.X != settings.Dst {
+		t.Errorf("result not stored into Dst")
+	}
+	if !mat.EqualApprox(result.X, want, 1e-14) {
+		t.Errorf("unexpected solution for exact initial guess")
+	}
+
+	// Reaching the iteration limit must be reported.
+	settings = DefaultSettings()
+	settings.MaxIterations = 2
+	result, err = Iterative(MatrixOperator(a), b, &CG{}, settings)
+	if err != ErrIterationLimit {
+		t.Errorf("unexpected error: got %v want %v", err, ErrIterationLimit)
+	}
+	if result.Iterations != 2 || len(result.ResidualHistory) != 2 {
+		t.Errorf("unexpected number of iterations: got %d want 2", result.Iterations)
+	}
+
+	// CG must report breakdown for an indefinite matrix.
+	indef := mat.NewCSR(2, 2, []int{0, 1, 2}, []int{0, 1}, []float64{1, -1})
+	_, err = Iterative(MatrixOperator(indef), mat.NewVecDense(2, []float64{1, 1}), &CG{}, nil)
+	if err != ErrBreakdown {
+		t.Errorf("unexpected error for indefinite CG: got %v want %v", err, ErrBreakdown)
+	}
+}
+
+func TestILU0Tridiagonal(t *testing.T) {
+	// ILU(0) of a tridiagonal matrix is its exact LU factorization.
+	const n = 10
+	tr := mat.NewTriplet(n, n)
+	for i := 0; i < n; i++ {
+		tr.Append(i, i, 4+float64(i))
+		if i > 0 {
+			tr.Append(i, i-1, -1)
+		}
+		if i < n-1 {
+			tr.Append(i, i+1, 2)
+		}
+	}
+	a := tr.ToCSR()
+	p, err := NewILU0(a)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	rhs := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		rhs.SetVec(i, float64(i+1))
+	}
+	for _, trans := range []bool{false, true} {
+		x := mat.NewVecDense(n, nil)
+		p.PreconSolve(x, trans, rhs)
+		var got mat.VecDense
+		if trans {
+			got.MulVec(a.T(), x)
+		} else {
+			got.MulVec(a, x)
+		}
+		if !mat.EqualApprox(&got, rhs, 1e-12) {
+			t.Errorf("unexpected ILU0 solve result (trans=%t)", trans)
+		}
+	}
+
+	if _, err := NewILU0(mat.NewCSR(2, 2, []int{0, 1, 1}, []int{1}, []float64{1})); err == nil {
+		t.Errorf("expected error for missing diagonal")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// MINRES implements the preconditioned Minimal Residual method for solving
+// systems of linear equations
+//  A * x = b,
+// where A is symmetric, possibly indefinite. The preconditioner must be
+// symmetric positive definite.
+//
+// The residual norm reported by MINRES is an estimate of ‖r‖ in the norm
+// induced by the inverse of the preconditioner. When no preconditioner is
+// used, it is an estimate of the Euclidean norm of the residual.
+//
+// References:
+//  - Paige, C. C., and Saunders, M. A. (1975). Solution of sparse indefinite
+//    systems of linear equations. SIAM J. Numer. Anal., 12(4), 617-629.
+type MINRES struct {
+	r1, r2, y, v *mat.VecDense
+	w, w1, w2    *mat.VecDense
+
+	alfa, beta, oldb float64
+	dbar, epsln      float64
+	phibar           float64
+	cs, sn           float64
+	first            bool
+	resume           int
+}
+
+// Init initializes the data for a linear solve. See the Method interface for more details.
+func (m *MINRES) Init(x, residual *mat.VecDense) {
+	n := residual.Len()
+	m.r1 = reuseVec(m.r1, n)
+	m.r1.CopyVec(residual)
+	m.r2 = reuseVec(m.r2, n)
+	m.r2.CopyVec(residual)
+	m.y = reuseVec(m.y, n)
+	m.v = reuseVec(m.v, n)
+	m.w = reuseVec(m.w, n)
+	m.w1 = reuseVec(m.w1, n)
+	m.w2 = reuseVec(m.w2, n)
+	for _, w := range []*mat.VecDense{m.w, m.w1, m.w2} {
+		for i := 0; i < n; i++ {
+			w.SetVec(i, 0)
+		}
+	}
+	m.resume = 1
+}
+
+// Iterate performs an iteration of the linear solve. See the Method interface for more details.
+//
+// MINRES will command the following operations:
+//  MulVec
+//  PreconSolve
+//  CheckResidualNorm
+//  MajorIteration
+func (m *MINRES) Iterate(ctx *Context) (Operation, error) {
+	switch m.resume {
+	case 1:
+		// Solve M y = r.
+		ctx.Src = m.r2
+		ctx.Dst = m.y
+		m.resume = 2
+		return PreconSolve, nil
+	case 2:
+		beta1 := mat.Dot(m.r1, m.y)
+		if beta1 <= 0 {
+			// The preconditioner is not positive definite.
+			return NoOperation, ErrBreakdown
+		}
+		beta1 = math.Sqrt(beta1)
+		m.beta = beta1
+		m.oldb = 0
+		m.dbar = 0
+		m.epsln = 0
+		m.phibar = beta1
+		m.cs = -1
+		m.sn = 0
+		m.first = true
+		fallthrough
+	case 3:
+		// Compute A v where v = y/beta.
+		m.v.ScaleVec(1/m.beta, m.y)
+		ctx.Src = m.v
+		ctx.Dst = m.y
+		m.resume = 4
+		return MulVec, nil
+	case 4:
+		// Lanczos step.
+		if !m.first {
+			m.y.AddScaledVec(m.y, -m.beta/m.oldb, m.r1)
+		}
+		m.first = false
+		m.alfa = mat.Dot(m.v, m.y)
+		m.y.AddScaledVec(m.y, -m.alfa/m.beta, m.r2)
+		m.r1, m.r2, m.y = m.r2, m.y, m.r1
+		// Solve M y = r2.
+		ctx.Src = m.r2
+		ctx.Dst = m.y
+		m.resume = 5
+		return PreconSolve, nil
+	case 5:
+		m.oldb = m.beta
+		beta := mat.Dot(m.r2, m.y)
+		if beta < 0 {
+			// The preconditioner is not positive definite.
+			return NoOperation, ErrBreakdown
+		}
+		m.beta = math.Sqrt(beta)
+
+		// Apply the previous rotation and compute the next one.
+		oldeps := m.epsln
+		delta := m.cs*m.dbar + m.sn*m.alfa
+		gbar := m.sn*m.dbar - m.cs*m.alfa
+		m.epsln = m.sn * m.beta
+		m.dbar = -m.cs * m.beta
+		gamma := math.Max(math.Hypot(gbar, m.beta), dlamchE)
+		m.cs = gbar / gamma
+		m.sn = m.beta / gamma
+		phi := m.cs * m.phibar
+		m.phibar *= m.sn
+
+		// Update the search direction and the solution.
+		m.w1, m.w2, m.w = m.w2, m.w, m.w1
+		m.w.AddScaledVec(m.v, -oldeps, m.w1)
+		m.w.AddScaledVec(m.w, -delta, m.w2)
+		m.w.ScaleVec(1/gamma, m.w)
+		ctx.X.AddScaledVec(ctx.X, phi, m.w)
+
+		ctx.ResidualNorm = m.phibar
+		m.resume = 6
+		return CheckResidualNorm, nil
+	case 6:
+		if m.beta == 0 {
+			// The Krylov subspace is exhausted and the
+			// solution cannot be improved further.
+			if !ctx.Converged {
+				return NoOperation, ErrBreakdown
+			}
+		}
+		m.resume = 3
+		return MajorIteration, nil
+	default:
+		panic("linsolve: MINRES.Init not called")
+	}
+}
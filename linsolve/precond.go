@@ -0,0 +1,228 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package linsolve
+
+import (
+	"errors"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	errZeroDiagonal = errors.New("linsolve: zero diagonal element")
+	errNotPositive  = errors.New("linsolve: factorization not positive definite")
+)
+
+// Jacobi is the diagonal (Jacobi) preconditioner
+//  M = diag(A).
+type Jacobi struct {
+	inv []float64
+}
+
+// NewJacobi returns a Jacobi preconditioner for the square matrix a.
+// NewJacobi returns an error if a diagonal element of a is zero.
+func NewJacobi(a mat.Matrix) (*Jacobi, error) {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrSquare)
+	}
+	inv := make([]float64, r)
+	for i := range inv {
+		d := a.At(i, i)
+		if d == 0 {
+			return nil, errZeroDiagonal
+		}
+		inv[i] = 1 / d
+	}
+	return &Jacobi{inv: inv}, nil
+}
+
+// PreconSolve solves M * dst = rhs. Since M is diagonal, trans is ignored.
+// PreconSolve is suitable for use as Settings.PreconSolve.
+func (p *Jacobi) PreconSolve(dst *mat.VecDense, trans bool, rhs *mat.VecDense) error {
+	if rhs.Len() != len(p.inv) || dst.Len() != len(p.inv) {
+		panic(mat.ErrShape)
+	}
+	for i, v := range p.inv {
+		dst.SetVec(i, v*rhs.At(i, 0))
+	}
+	return nil
+}
+
+// ILU0 is the incomplete LU factorization preconditioner with no fill-in,
+//  M = L * U,
+// where L is unit lower triangular, U is upper triangular, and L+U has the
+// same sparsity pattern as A.
+//
+// References:
+//  - Saad, Y. (2003). Section 10.3.2 Zero Fill-in ILU (ILU(0)). In Iterative
+//    Methods for Sparse Linear Systems (2nd ed.) (pp. 307-311). Philadelphia,
+//    PA: SIAM.
+type ILU0 struct {
+	n      int
+	indptr []int
+	ind    []int
+	data   []float64 // Strictly lower part holds L, the rest holds U.
+	diag   []int     // Positions of the diagonal elements in data.
+}
+
+// NewILU0 returns an ILU0 preconditioner for the square matrix a. The
+// diagonal of a must be stored explicitly. NewILU0 returns an error if a
+// zero pivot is encountered during the factorization.
+func NewILU0(a *mat.CSR) (*ILU0, error) {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrSquare)
+	}
+	indptr, ind, data := a.RawCSR()
+	p := &ILU0{
+		n:      r,
+		indptr: append([]int(nil), indptr...),
+		ind:    append([]int(nil), ind...),
+		data:   append([]float64(nil), data...),
+		diag:   make([]int, r),
+	}
+	for i := 0; i < r; i++ {
+		p.diag[i] = -1
+		for k := p.indptr[i]; k < p.indptr[i+1]; k++ {
+			if p.ind[k] == i {
+				p.diag[i] = k
+				break
+			}
+		}
+		if p.diag[i] < 0 {
+			return nil, errZeroDiagonal
+		}
+	}
+
+	// pos maps column indices of the current row to their position in data.
+	pos := make([]int, r)
+	for i := range pos {
+		pos[i] = -1
+	}
+	for i := 0; i < r; i++ {
+		for k := p.indptr[i]; k < p.indptr[i+1]; k++ {
+			pos[p.ind[k]] = k
+		}
+		for k := p.indptr[i]; k < p.diag[i]; k++ {
+			col := p.ind[k]
+			ukk := p.data[p.diag[col]]
+			if ukk == 0 {
+				return nil, errZeroDiagonal
+			}
+			p.data[k] /= ukk
+			lik := p.data[k]
+			for l := p.diag[col] + 1; l < p.indptr[col+1]; l++ {
+				if q := pos[p.ind[l]]; q >= 0 {
+					p.data[q] -= lik * p.data[l]
+				}
+			}
+		}
+		if p.data[p.diag[i]] == 0 {
+			return nil, errZeroDiagonal
+		}
+		for k := p.indptr[i]; k < p.indptr[i+1]; k++ {
+			pos[p.ind[k]] = -1
+		}
+	}
+	return p, nil
+}
+
+// PreconSolve solves M * dst = rhs, or M^T * dst = rhs if trans is true.
+// PreconSolve is suitable for use as Settings.PreconSolve.
+func (p *ILU0) PreconSolve(dst *mat.VecDense, trans bool, rhs *mat.VecDense) error {
+	if rhs.Len() != p.n || dst.Len() != p.n {
+		panic(mat.ErrShape)
+	}
+	if dst != rhs {
+		dst.CopyVec(rhs)
+	}
+	if trans {
+		p.solveUT(dst)
+		p.solveLT(dst)
+		return nil
+	}
+	p.solveL(dst)
+	p.solveU(dst)
+	return nil
+}
+
+// solveL solves L * x = b in place.
+func (p *ILU0) solveL(x *mat.VecDense) {
+	for i := 0; i < p.n; i++ {
+		sum := x.At(i, 0)
+		for k := p.indptr[i]; k < p.diag[i]; k++ {
+			sum -= p.data[k] * x.At(p.ind[k], 0)
+		}
+		x.SetVec(i, sum)
+	}
+}
+
+// solveU solves U * x = b in place.
+func (p *ILU0) solveU(x *mat.VecDense) {
+	for i := p.n - 1; i >= 0; i-- {
+		sum := x.At(i, 0)
+		for k := p.diag[i] + 1; k < p.indptr[i+1]; k++ {
+			sum -= p.data[k] * x.At(p.ind[k], 0)
+		}
+		x.SetVec(i, sum/p.data[p.diag[i]])
+	}
+}
+
+// solveUT solves U^T * x = b in place.
+func (p *ILU0) solveUT(x *mat.VecDense) {
+	for i := 0; i < p.n; i++ {
+		xi := x.At(i, 0) / p.data[p.diag[i]]
+		x.SetVec(i, xi)
+		for k := p.diag[i] + 1; k < p.indptr[i+1]; k++ {
+			j := p.ind[k]
+			x.SetVec(j, x.At(j, 0)-p.data[k]*xi)
+		}
+	}
+}
+
+// solveLT solves L^T * x = b in place.
+func (p *ILU0) solveLT(x *mat.VecDense) {
+	for i := p.n - 1; i >= 0; i-- {
+		xi := x.At(i, 0)
+		for k := p.indptr[i]; k < p.diag[i]; k++ {
+			j := p.ind[k]
+			x.SetVec(j, x.At(j, 0)-p.data[k]*xi)
+		}
+	}
+}
+
+// IC0 is the incomplete Cholesky factorization preconditioner with no
+// fill-in,
+//  M = L * D * L^T,
+// where L is unit lower triangular with the same sparsity pattern as the
+// lower triangle of A and D is diagonal with positive elements. IC0 is
+// suitable for use with CG and MINRES.
+type IC0 struct {
+	ilu *ILU0
+}
+
+// NewIC0 returns an IC0 preconditioner for the symmetric matrix a. Both
+// triangles and the diagonal of a must be stored explicitly. NewIC0 returns
+// an error if the incomplete factorization is not positive definite.
+func NewIC0(a *mat.CSR) (*IC0, error) {
+	ilu, err := NewILU0(a)
+	if err != nil {
+		return nil, err
+	}
+	for _, k := range ilu.diag {
+		if ilu.data[k] <= 0 {
+			return nil, errNotPositive
+		}
+	}
+	return &IC0{ilu: ilu}, nil
+}
+
+// PreconSolve solves M * dst = rhs. Since M is symmetric, trans is ignored.
+// PreconSolve is suitable for use as Settings.PreconSolve.
+func (p *IC0) PreconSolve(dst *mat.VecDense, trans bool, rhs *mat.VecDense) error {
+	// For a symmetric matrix the ILU(0) factors satisfy U = D * L^T.
+	return p.ilu.PreconSolve(dst, false, rhs)
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/floats"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// backendOps are matrix operations whose results must not depend on the BLAS
+// and LAPACK implementations in use beyond floating point error.
+var backendOps = []struct {
+	name string
+	fn   func(a, b *Dense, s *SymDense) []float64
+}{
+	{
+		name: "Mul",
+		fn: func(a, b *Dense, _ *SymDense) []float64 {
+			var c Dense
+			c.Mul(a, b)
+			return c.RawMatrix().Data
+		},
+	},
+	{
+		name: "Cholesky",
+		fn: func(_, _ *Dense, s *SymDense) []float64 {
+			var chol Cholesky
+			if !chol.Factorize(s) {
+				return nil
+			}
+			var inv SymDense
+			chol.InverseTo(&inv)
+			return inv.RawSymmetric().Data
+		},
+	},
+	{
+		name: "Solve",
+		fn: func(a, b *Dense, _ *SymDense) []float64 {
+			var x Dense
+			if err := x.Solve(a, b); err != nil {
+				return nil
+			}
+			return x.RawMatrix().Data
+		},
+	},
+	{
+		name: "EigenSym",
+		fn: func(_, _ *Dense, s *SymDense) []float64 {
+			var eig EigenSym
+			if !eig.Factorize(s, false) {
+				return nil
+			}
+			return eig.Values(nil)
+		},
+	},
+	{
+		name: "SVD",
+		fn: func(a, _ *Dense, _ *SymDense) []float64 {
+			var svd SVD
+			if !svd.Factorize(a, SVDNone) {
+				return nil
+			}
+			return svd.Values(nil)
+		},
+	},
+}
+
+func TestBackends(t *testing.T) {
+	const n = 40
+	rnd := rand.New(rand.NewSource(1))
+	a := NewDense(n, n, nil)
+	b := NewDense(n, 3, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Set(i, j, rnd.NormFloat64())
+		}
+		for j := 0; j < 3; j++ {
+			b.Set(i, j, rnd.NormFloat64())
+		}
+	}
+	s := NewSymDense(n, nil)
+	s.SymOuterK(1, a)
+	for i := 0; i < n; i++ {
+		s.SetSym(i, i, s.At(i, i)+1)
+	}
+
+	ref, _ := blas64.Lookup(blas64.Reference)
+	want := make([][]float64, len(backendOps))
+	blas64.With(ref, func() {
+		lapack64.With(nil, func() {
+			for i, op := range backendOps {
+				want[i] = op.fn(a, b, s)
+				if want[i] == nil {
+					t.Fatalf("%s: unexpected failure with reference implementation", op.name)
+				}
+			}
+		})
+	})
+
+	instr := blas64.NewInstrumented(ref)
+	impls := map[string]blas.Float64{"instrumented": instr}
+	for _, name := range blas64.Registered() {
+		impls[name], _ = blas64.Lookup(name)
+	}
+	for bname, impl := range impls {
+		for _, lname := range lapack64.Registered() {
+			limpl, _ := lapack64.Lookup(lname)
+			blas64.With(impl, func() {
+				lapack64.With(limpl, func() {
+					for i, op := range backendOps {
+						got := op.fn(a, b, s)
+						if !floats.EqualApprox(got, want[i], 1e-10) {
+							t.Errorf("%s: result mismatch with BLAS %q and LAPACK %q", op.name, bname, lname)
+						}
+					}
+				})
+			})
+		}
+	}
+
+	stats := instr.Stats()
+	for _, routine := range []string{"Dgemm", "Dtrsm", "Dsyrk"} {
+		if stats[routine].Calls == 0 || stats[routine].Flops == 0 {
+			t.Errorf("no calls to %s recorded by instrumented implementation", routine)
+		}
+	}
+}
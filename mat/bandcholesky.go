@@ -0,0 +1,175 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBandCholesky = "mat: invalid band Cholesky factorization"
+
+// BandCholesky is a type for creating and using the Cholesky factorization of
+// a symmetric positive definite band matrix. The factorization has the form
+//  A = U^T * U
+// where U is an upper triangular band matrix with the same bandwidth as A.
+//
+// BandCholesky methods may only be called on a value that has been
+// successfully initialized by a call to Factorize that has returned true.
+// Calls to methods of an unsuccessful BandCholesky factorization will panic.
+type BandCholesky struct {
+	// chol holds the band of the factor U.
+	chol blas64.TriangularBand
+	cond float64
+}
+
+// updateCond updates the condition number of the factorization. norm is the
+// 1-norm of the original matrix A.
+func (c *BandCholesky) updateCond(norm float64) {
+	n := c.chol.N
+	work := getFloats(2*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+	v := lapack64.Pbcon(c.chol, norm, work, iwork)
+	c.cond = 1 / v
+}
+
+// Factorize calculates the Cholesky factorization of the symmetric band
+// matrix A and returns whether the matrix is positive definite. If Factorize
+// returns false, the factorization must not be used.
+func (c *BandCholesky) Factorize(a SymBanded) (ok bool) {
+	n := a.Symmetric()
+	_, k := a.Bandwidth()
+	sym := blas64.SymmetricBand{
+		N:      n,
+		K:      k,
+		Stride: k + 1,
+		Data:   use(c.chol.Data, n*(k+1)),
+		Uplo:   blas.Upper,
+	}
+	if rb, isRaw := a.(RawSymBander); isRaw && rb.RawSymBand().Uplo == blas.Upper {
+		raw := rb.RawSymBand()
+		for i := 0; i < n; i++ {
+			copy(sym.Data[i*sym.Stride:i*sym.Stride+min(k, n-i-1)+1], raw.Data[i*raw.Stride:])
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			for j := i; j <= min(i+k, n-1); j++ {
+				sym.Data[i*sym.Stride+j-i] = a.At(i, j)
+			}
+		}
+	}
+
+	work := getFloats(n, false)
+	norm := lapack64.Lansb(CondNorm, sym, work)
+	putFloats(work)
+	c.chol, ok = lapack64.Pbtrf(sym)
+	if ok {
+		c.updateCond(norm)
+	} else {
+		c.Reset()
+	}
+	return ok
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (c *BandCholesky) Reset() {
+	c.chol.N = 0
+	c.chol.K = 0
+	c.chol.Data = c.chol.Data[:0]
+	c.cond = math.Inf(1)
+}
+
+func (c *BandCholesky) valid() bool {
+	return c.chol.N != 0
+}
+
+// Cond returns the condition number of the factorized matrix.
+func (c *BandCholesky) Cond() float64 {
+	return c.cond
+}
+
+// Size returns the dimension of the factorized matrix.
+func (c *BandCholesky) Size() int {
+	if !c.valid() {
+		panic(badBandCholesky)
+	}
+	return c.chol.N
+}
+
+// Det returns the determinant of the matrix that has been factorized.
+func (c *BandCholesky) Det() float64 {
+	return math.Exp(c.LogDet())
+}
+
+// LogDet returns the log of the determinant of the matrix that has been factorized.
+func (c *BandCholesky) LogDet() float64 {
+	if !c.valid() {
+		panic(badBandCholesky)
+	}
+	var det float64
+	for i := 0; i < c.chol.N; i++ {
+		det += 2 * math.Log(c.chol.Data[i*c.chol.Stride])
+	}
+	return det
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented
+// by the band Cholesky factorization, placing the result in m.
+//
+// If A is near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (c *BandCholesky) Solve(m *Dense, b Matrix) error {
+	if !c.valid() {
+		panic(badBandCholesky)
+	}
+	n := c.chol.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(bm, bn)
+	if b != m {
+		m.Copy(b)
+	}
+	lapack64.Pbtrs(c.chol, m.mat)
+	if c.cond > ConditionTolerance {
+		return Condition(c.cond)
+	}
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented
+// by the band Cholesky factorization, placing the result in v.
+//
+// If A is near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (c *BandCholesky) SolveVec(v, b *VecDense) error {
+	if !c.valid() {
+		panic(badBandCholesky)
+	}
+	n := c.chol.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	v.reuseAs(n)
+	if v != b {
+		v.CopyVec(b)
+	}
+	lapack64.Pbtrs(c.chol, v.asGeneral())
+	if c.cond > ConditionTolerance {
+		return Condition(c.cond)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randSymBandDense returns a random symmetric positive definite band matrix
+// with bandwidth k.
+func randSymBandDense(n, k int, rnd *rand.Rand) *SymBandDense {
+	a := NewSymBandDense(n, k, nil)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j <= min(i+k, n-1); j++ {
+			a.SetSymBand(i, j, rnd.NormFloat64())
+		}
+	}
+	// Make the matrix diagonally dominant.
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := max(0, i-k); j <= min(i+k, n-1); j++ {
+			if j != i {
+				sum += math.Abs(a.At(i, j))
+			}
+		}
+		a.SetSymBand(i, i, sum+1+rnd.Float64())
+	}
+	return a
+}
+
+func TestBandCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		for _, k := range []int{0, 1, 2, 3, 7} {
+			if k >= n {
+				continue
+			}
+			a := randSymBandDense(n, k, rnd)
+			prefix := fmt.Sprintf("n=%d,k=%d", n, k)
+
+			var want Cholesky
+			if !want.Factorize(a) {
+				t.Fatalf("%s: unexpected dense Cholesky failure", prefix)
+			}
+
+			for _, src := range []SymBanded{a, (*basicSymBand)(a)} {
+				var chol BandCholesky
+				ok := chol.Factorize(src)
+				if !ok {
+					t.Errorf("%s: unexpected failure of band Cholesky", prefix)
+					continue
+				}
+				if chol.Size() != n {
+					t.Errorf("%s: unexpected size: got %d want %d", prefix, chol.Size(), n)
+				}
+				if got := chol.LogDet(); math.Abs(got-want.LogDet()) > 1e-12*math.Max(1, math.Abs(got)) {
+					t.Errorf("%s: unexpected log determinant: got %v want %v", prefix, got, want.LogDet())
+				}
+				if got := chol.Cond(); math.Abs(got-want.Cond()) > 1e-12*want.Cond() {
+					t.Errorf("%s: unexpected condition number: got %v want %v", prefix, got, want.Cond())
+				}
+
+				for _, nrhs := range []int{1, 3} {
+					b := NewDense(n, nrhs, nil)
+					for i := range b.mat.Data {
+						b.mat.Data[i] = rnd.NormFloat64()
+					}
+					var x, xWant Dense
+					err := chol.Solve(&x, b)
+					if err != nil {
+						t.Errorf("%s: unexpected error from Solve: %v", prefix, err)
+					}
+					want.Solve(&xWant, b)
+					if !EqualApprox(&x, &xWant, 1e-12) {
+						t.Errorf("%s: unexpected solution for nrhs=%d", prefix, nrhs)
+					}
+				}
+
+				bv := NewVecDense(n, nil)
+				for i := 0; i < n; i++ {
+					bv.SetVec(i, rnd.NormFloat64())
+				}
+				var xv, xvWant VecDense
+				err := chol.SolveVec(&xv, bv)
+				if err != nil {
+					t.Errorf("%s: unexpected error from SolveVec: %v", prefix, err)
+				}
+				want.SolveVec(&xvWant, bv)
+				if !EqualApprox(&xv, &xvWant, 1e-12) {
+					t.Errorf("%s: unexpected vector solution", prefix)
+				}
+			}
+		}
+	}
+
+	// An indefinite matrix must not be factorized.
+	a := NewSymBandDense(3, 1, []float64{
+		1, 2,
+		1, 3,
+		1, 0,
+	})
+	var chol BandCholesky
+	if chol.Factorize(a) {
+		t.Errorf("unexpected success factorizing indefinite matrix")
+	}
+	if panicked, _ := panics(func() { chol.LogDet() }); !panicked {
+		t.Errorf("expected panic using failed factorization")
+	}
+}
+
+type basicSymBand SymBandDense
+
+var _ SymBanded = &basicSymBand{}
+
+func (m *basicSymBand) At(r, c int) float64 {
+	return (*SymBandDense)(m).At(r, c)
+}
+
+func (m *basicSymBand) Dims() (r, c int) {
+	return (*SymBandDense)(m).Dims()
+}
+
+func (m *basicSymBand) T() Matrix {
+	return m
+}
+
+func (m *basicSymBand) Symmetric() int {
+	return (*SymBandDense)(m).Symmetric()
+}
+
+func (m *basicSymBand) Bandwidth() (kl, ku int) {
+	return (*SymBandDense)(m).Bandwidth()
+}
@@ -0,0 +1,227 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBandLU = "mat: invalid band LU factorization"
+
+// BandLU is a type for creating and using the LU factorization of a square
+// band matrix. The factorization is computed with partial pivoting and has
+// the form
+//  A = P * L * U
+// where P is a permutation matrix, L is unit lower triangular with kl
+// sub-diagonals and U is upper triangular with kl+ku super-diagonals.
+// The storage required by the factorization is proportional to the
+// size of the band of A.
+type BandLU struct {
+	// lu holds the factors in the storage format
+	// described in the documentation for Dgbtrf.
+	lu    blas64.Band
+	pivot []int
+	ok    bool
+	cond  float64
+}
+
+// updateCond updates the stored condition number of the matrix. norm is the
+// 1-norm of the original matrix.
+func (lu *BandLU) updateCond(norm float64) {
+	if !lu.ok {
+		lu.cond = math.Inf(1)
+		return
+	}
+	n := lu.lu.Cols
+	work := getFloats(2*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+	v := lapack64.Gbcon(CondNorm, lu.lu, lu.pivot, norm, work, iwork)
+	lu.cond = 1 / v
+}
+
+// Factorize computes the LU factorization of the square band matrix a and
+// stores the result. The LU decomposition will complete regardless of the
+// singularity of a.
+func (lu *BandLU) Factorize(a Banded) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	kl, ku := a.Bandwidth()
+	stride := 2*kl + ku + 1
+	lu.lu = blas64.Band{
+		Rows:   r,
+		Cols:   c,
+		KL:     kl,
+		KU:     ku,
+		Stride: stride,
+		Data:   use(lu.lu.Data, r*stride),
+	}
+	if rb, isRaw := a.(RawBander); isRaw {
+		raw := rb.RawBand()
+		for i := 0; i < r; i++ {
+			copy(lu.lu.Data[i*stride:i*stride+kl+ku+1], raw.Data[i*raw.Stride:i*raw.Stride+kl+ku+1])
+		}
+	} else {
+		for i := 0; i < r; i++ {
+			for j := max(0, i-kl); j <= min(c-1, i+ku); j++ {
+				lu.lu.Data[i*stride+j-i+kl] = a.At(i, j)
+			}
+		}
+	}
+	if cap(lu.pivot) < r {
+		lu.pivot = make([]int, r)
+	}
+	lu.pivot = lu.pivot[:r]
+
+	work := getFloats(r, false)
+	anorm := lapack64.Langb(CondNorm, lu.lu, work)
+	putFloats(work)
+	lu.ok = lapack64.Gbtrf(lu.lu, lu.pivot)
+	lu.updateCond(anorm)
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *BandLU) Reset() {
+	lu.lu.Rows = 0
+	lu.lu.Cols = 0
+	lu.lu.Data = lu.lu.Data[:0]
+	lu.pivot = lu.pivot[:0]
+	lu.ok = false
+}
+
+func (lu *BandLU) isZero() bool {
+	return len(lu.pivot) == 0
+}
+
+// Cond returns the condition number of the factorized matrix. If the
+// matrix is singular, Cond returns +Inf.
+func (lu *BandLU) Cond() float64 {
+	if lu.isZero() {
+		panic(badBandLU)
+	}
+	return lu.cond
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+func (lu *BandLU) Det() float64 {
+	det, sign := lu.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+func (lu *BandLU) LogDet() (det float64, sign float64) {
+	if lu.isZero() {
+		panic(badBandLU)
+	}
+	sign = 1.0
+	for i, p := range lu.pivot {
+		v := lu.lu.Data[i*lu.lu.Stride+lu.lu.KL]
+		if v < 0 {
+			sign *= -1
+		}
+		if p != i {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v))
+	}
+	return det, sign
+}
+
+// Solve solves a system of linear equations using the band LU decomposition
+// of a matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into m.
+//
+// If A is singular or near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (lu *BandLU) Solve(m *Dense, trans bool, b Matrix) error {
+	if lu.isZero() {
+		panic(badBandLU)
+	}
+	n := lu.lu.Cols
+	br, bc := b.Dims()
+	if br != n {
+		panic(ErrShape)
+	}
+	if !lu.ok {
+		return Condition(math.Inf(1))
+	}
+
+	m.reuseAs(n, bc)
+	bU, _ := untranspose(b)
+	var restore func()
+	if m == bU {
+		m, restore = m.isolatedWorkspace(bU)
+		defer restore()
+	} else if rm, ok := bU.(RawMatrixer); ok {
+		m.checkOverlap(rm.RawMatrix())
+	}
+
+	m.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack64.Gbtrs(t, lu.lu, m.mat, lu.pivot)
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
+
+// SolveVec solves a system of linear equations using the band LU decomposition
+// of a matrix. It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the vector x is
+// stored into v.
+//
+// If A is singular or near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (lu *BandLU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	if lu.isZero() {
+		panic(badBandLU)
+	}
+	n := lu.lu.Cols
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	if !lu.ok {
+		return Condition(math.Inf(1))
+	}
+
+	v.reuseAs(n)
+	var restore func()
+	if v == b {
+		v, restore = v.isolatedWorkspace(b)
+		defer restore()
+	}
+	v.CopyVec(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack64.Gbtrs(t, lu.lu, v.asGeneral(), lu.pivot)
+	if lu.cond > ConditionTolerance {
+		return Condition(lu.cond)
+	}
+	return nil
+}
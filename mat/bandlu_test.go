@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestBandLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 31} {
+		for _, bw := range []struct{ kl, ku int }{
+			{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 1}, {1, 3}, {4, 4},
+		} {
+			kl, ku := bw.kl, bw.ku
+			if kl >= n || ku >= n {
+				continue
+			}
+			a := NewBandDense(n, n, kl, ku, nil)
+			for i := 0; i < n; i++ {
+				for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+					a.SetBand(i, j, rnd.NormFloat64())
+				}
+			}
+			prefix := fmt.Sprintf("n=%d,kl=%d,ku=%d", n, kl, ku)
+
+			var want LU
+			want.Factorize(a)
+			wantDet, wantSign := want.LogDet()
+
+			for _, src := range []Banded{a, (*basicBand)(a)} {
+				var lu BandLU
+				lu.Factorize(src)
+				det, sign := lu.LogDet()
+				if sign != wantSign || math.Abs(det-wantDet) > 1e-12*math.Max(1, math.Abs(det)) {
+					t.Errorf("%s: unexpected log determinant: got %v,%v want %v,%v", prefix, det, sign, wantDet, wantSign)
+				}
+				if got := lu.Cond(); math.Abs(got-want.cond) > 1e-10*want.cond {
+					t.Errorf("%s: unexpected condition number: got %v want %v", prefix, got, want.cond)
+				}
+
+				for _, trans := range []bool{false, true} {
+					for _, nrhs := range []int{1, 3} {
+						b := NewDense(n, nrhs, nil)
+						for i := range b.mat.Data {
+							b.mat.Data[i] = rnd.NormFloat64()
+						}
+						var x, xWant Dense
+						err := lu.Solve(&x, trans, b)
+						errWant := want.Solve(&xWant, trans, b)
+						if (err == nil) != (errWant == nil) {
+							t.Errorf("%s: mismatched errors: got %v want %v", prefix, err, errWant)
+						}
+						if err == nil && !EqualApprox(&x, &xWant, 1e-10) {
+							t.Errorf("%s: unexpected solution for trans=%t, nrhs=%d", prefix, trans, nrhs)
+						}
+					}
+
+					bv := NewVecDense(n, nil)
+					for i := 0; i < n; i++ {
+						bv.SetVec(i, rnd.NormFloat64())
+					}
+					var xv, xvWant VecDense
+					err := lu.SolveVec(&xv, trans, bv)
+					errWant := want.SolveVec(&xvWant, trans, bv)
+					if (err == nil) != (errWant == nil) {
+						t.Errorf("%s: mismatched vector errors: got %v want %v", prefix, err, errWant)
+					}
+					if err == nil && !EqualApprox(&xv, &xvWant, 1e-10) {
+						t.Errorf("%s: unexpected vector solution for trans=%t", prefix, trans)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestBandLUSingular(t *testing.T) {
+	a := NewBandDense(4, 4, 1, 1, []float64{
+		0, 1, 2,
+		3, 4, 0,
+		0, 0, 0,
+		6, 7, 0,
+	})
+	var lu BandLU
+	lu.Factorize(a)
+	if !math.IsInf(lu.Cond(), 1) {
+		t.Errorf("unexpected condition number for singular matrix: got %v", lu.Cond())
+	}
+	if det := lu.Det(); det != 0 {
+		t.Errorf("unexpected determinant for singular matrix: got %v", det)
+	}
+	var x Dense
+	err := lu.Solve(&x, false, NewDense(4, 1, nil))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("expected Condition error for singular matrix, got %v", err)
+	}
+}
+
+type basicBand BandDense
+
+var _ Banded = &basicBand{}
+
+func (m *basicBand) At(r, c int) float64 {
+	return (*BandDense)(m).At(r, c)
+}
+
+func (m *basicBand) Dims() (r, c int) {
+	return (*BandDense)(m).Dims()
+}
+
+func (m *basicBand) T() Matrix {
+	return Transpose{m}
+}
+
+func (m *basicBand) Bandwidth() (kl, ku int) {
+	return (*BandDense)(m).Bandwidth()
+}
+
+func (m *basicBand) TBand() Banded {
+	return TransposeBand{m}
+}
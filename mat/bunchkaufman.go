@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badBunchKaufman = "mat: invalid Bunch-Kaufman factorization"
+
+// BunchKaufman is a type for creating and using the Bunch-Kaufman
+// factorization of a symmetric, possibly indefinite, matrix. The factorization
+// has the form
+//  A = U * D * U^T
+// where U is a product of permutation and unit upper triangular matrices, and
+// D is symmetric and block diagonal with 1×1 and 2×2 diagonal blocks.
+//
+// BunchKaufman methods may only be called on a value that has been successfully
+// initialized by a call to Factorize that has returned true. Calls to methods
+// of an unsuccessful BunchKaufman factorization will panic.
+type BunchKaufman struct {
+	fact *SymDense
+	ipiv []int
+	cond float64
+}
+
+// updateCond updates the condition number of the factorization. norm is the
+// 1-norm of the original matrix A.
+func (bk *BunchKaufman) updateCond(norm float64) {
+	n := bk.fact.mat.N
+	work := getFloats(2*n, false)
+	defer putFloats(work)
+	iwork := getInts(n, false)
+	defer putInts(iwork)
+	v := lapack64.Sycon(bk.fact.mat, bk.ipiv, norm, work, iwork)
+	bk.cond = 1 / v
+}
+
+// Factorize calculates the Bunch-Kaufman factorization of the symmetric matrix
+// A and returns whether A is nonsingular. If Factorize returns false, the
+// factorization must not be used.
+func (bk *BunchKaufman) Factorize(a Symmetric) (ok bool) {
+	n := a.Symmetric()
+	if bk.isZero() {
+		bk.fact = NewSymDense(n, nil)
+	} else {
+		bk.fact = NewSymDense(n, use(bk.fact.mat.Data, n*n))
+	}
+	bk.fact.CopySym(a)
+	if cap(bk.ipiv) < n {
+		bk.ipiv = make([]int, n)
+	}
+	bk.ipiv = bk.ipiv[:n]
+
+	work := getFloats(n, false)
+	norm := lapack64.Lansy(CondNorm, bk.fact.mat, work)
+	putFloats(work)
+	ok = lapack64.Sytrf(bk.fact.mat, bk.ipiv)
+	if ok {
+		bk.updateCond(norm)
+	} else {
+		bk.Reset()
+	}
+	return ok
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (bk *BunchKaufman) Reset() {
+	if !bk.isZero() {
+		bk.fact.Reset()
+	}
+	bk.ipiv = bk.ipiv[:0]
+	bk.cond = math.Inf(1)
+}
+
+func (bk *BunchKaufman) isZero() bool {
+	return bk.fact == nil
+}
+
+func (bk *BunchKaufman) valid() bool {
+	return !bk.isZero() && !bk.fact.IsZero()
+}
+
+// Cond returns the condition number of the factorized matrix.
+func (bk *BunchKaufman) Cond() float64 {
+	return bk.cond
+}
+
+// Size returns the dimension of the factorized matrix.
+func (bk *BunchKaufman) Size() int {
+	if !bk.valid() {
+		panic(badBunchKaufman)
+	}
+	return bk.fact.mat.N
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+func (bk *BunchKaufman) Det() float64 {
+	det, sign := bk.LogDet()
+	return math.Exp(det) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space.
+func (bk *BunchKaufman) LogDet() (det float64, sign float64) {
+	if !bk.valid() {
+		panic(badBunchKaufman)
+	}
+	// The determinant of A is the determinant of D because the
+	// determinant of U is ±1.
+	n := bk.fact.mat.N
+	sign = 1.0
+	for k := n - 1; k >= 0; {
+		var v float64
+		if bk.ipiv[k] >= 0 {
+			// 1×1 diagonal block.
+			v = bk.fact.at(k, k)
+			k--
+		} else {
+			// 2×2 diagonal block.
+			d11 := bk.fact.at(k-1, k-1)
+			d12 := bk.fact.at(k-1, k)
+			d22 := bk.fact.at(k, k)
+			v = d11*d22 - d12*d12
+			k -= 2
+		}
+		if v < 0 {
+			sign *= -1
+		}
+		det += math.Log(math.Abs(v))
+	}
+	return det, sign
+}
+
+// Solve finds the matrix m that solves A * m = b where A is represented
+// by the Bunch-Kaufman factorization, placing the result in m.
+//
+// If A is near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (bk *BunchKaufman) Solve(m *Dense, b Matrix) error {
+	if !bk.valid() {
+		panic(badBunchKaufman)
+	}
+	n := bk.fact.mat.N
+	bm, bn := b.Dims()
+	if n != bm {
+		panic(ErrShape)
+	}
+
+	m.reuseAs(bm, bn)
+	if b != m {
+		m.Copy(b)
+	}
+	lapack64.Sytrs(bk.fact.mat, bk.ipiv, m.mat)
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
+
+// SolveVec finds the vector v that solves A * v = b where A is represented
+// by the Bunch-Kaufman factorization, placing the result in v.
+//
+// If A is near-singular a Condition error is returned. Please see
+// the documentation for Condition for more information.
+func (bk *BunchKaufman) SolveVec(v, b *VecDense) error {
+	if !bk.valid() {
+		panic(badBunchKaufman)
+	}
+	n := bk.fact.mat.N
+	if b.Len() != n {
+		panic(ErrShape)
+	}
+	if v != b {
+		v.checkOverlap(b.mat)
+	}
+	v.reuseAs(n)
+	if v != b {
+		v.CopyVec(b)
+	}
+	vMat := blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+	lapack64.Sytrs(bk.fact.mat, bk.ipiv, vMat)
+	if bk.cond > ConditionTolerance {
+		return Condition(bk.cond)
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func randSymDense(n int, rnd *rand.Rand) *SymDense {
+	a := NewSymDense(n, nil)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			a.SetSym(i, j, rnd.NormFloat64())
+		}
+	}
+	return a
+}
+
+func TestBunchKaufman(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 25} {
+		for cas := 0; cas < 5; cas++ {
+			a := randSymDense(n, rnd)
+			if cas == 0 {
+				// Force 2×2 pivot blocks.
+				for i := 0; i < n; i++ {
+					a.SetSym(i, i, 0)
+				}
+				if n == 1 {
+					a.SetSym(0, 0, 1)
+				}
+			}
+
+			for _, bk := range []*BunchKaufman{
+				{},
+				{fact: NewSymDense(n+1, nil)},
+			} {
+				ok := bk.Factorize(a)
+				if !ok {
+					t.Errorf("n=%d: unexpected singular factorization", n)
+					continue
+				}
+				if bk.Size() != n {
+					t.Errorf("n=%d: unexpected size: got %d", n, bk.Size())
+				}
+
+				var lu LU
+				lu.Factorize(a)
+				if got, want := bk.Det(), lu.Det(); math.Abs(got-want) > 1e-10*math.Max(1, math.Abs(want)) {
+					t.Errorf("n=%d: unexpected determinant: got %v want %v", n, got, want)
+				}
+				if got, want := bk.Cond(), Cond(a, 1); got > want*(1+1e-10) || got < want/10 {
+					t.Errorf("n=%d: unexpected condition number: got %v want %v", n, got, want)
+				}
+
+				want := NewDense(n, 3, nil)
+				for i := 0; i < n; i++ {
+					for j := 0; j < 3; j++ {
+						want.Set(i, j, rnd.NormFloat64())
+					}
+				}
+				var b Dense
+				b.Mul(a, want)
+				var x Dense
+				err := bk.Solve(&x, &b)
+				if err != nil {
+					t.Errorf("n=%d: unexpected error from Solve: %v", n, err)
+				}
+				tol := 1e-14 * bk.Cond() * float64(n)
+				if !EqualApprox(&x, want, tol) {
+					t.Errorf("n=%d: unexpected Solve result:\ngot:\n%v\nwant:\n%v", n, Formatted(&x), Formatted(want))
+				}
+
+				wantVec := want.ColView(0)
+				var bVec, xVec VecDense
+				bVec.MulVec(a, wantVec)
+				err = bk.SolveVec(&xVec, &bVec)
+				if err != nil {
+					t.Errorf("n=%d: unexpected error from SolveVec: %v", n, err)
+				}
+				if !EqualApprox(&xVec, wantVec, tol) {
+					t.Errorf("n=%d: unexpected SolveVec result", n)
+				}
+				// In-place solve.
+				err = bk.SolveVec(&bVec, &bVec)
+				if err != nil {
+					t.Errorf("n=%d: unexpected error from in-place SolveVec: %v", n, err)
+				}
+				if !EqualApprox(&bVec, wantVec, tol) {
+					t.Errorf("n=%d: unexpected in-place SolveVec result", n)
+				}
+			}
+		}
+	}
+}
+
+func TestBunchKaufmanSingular(t *testing.T) {
+	a := NewSymDense(3, []float64{
+		1, 2, 3,
+		0, 4, 6,
+		0, 0, 9,
+	})
+	var bk BunchKaufman
+	if bk.Factorize(a) {
+		t.Errorf("singular matrix not detected")
+	}
+	if panicked, _ := panics(func() { bk.Solve(&Dense{}, NewDense(3, 1, nil)) }); !panicked {
+		t.Errorf("expected panic for unsuccessful factorization")
+	}
+
+	// A near-singular matrix is factorized, but solving returns a
+	// Condition error.
+	a = NewSymDense(2, []float64{
+		1, 1,
+		0, 1 + 2.220446049250313e-16,
+	})
+	if !bk.Factorize(a) {
+		t.Fatalf("unexpected singular factorization")
+	}
+	var x VecDense
+	err := bk.SolveVec(&x, NewVecDense(2, []float64{1, 2}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("unexpected error for near-singular matrix: got %v want Condition", err)
+	}
+}
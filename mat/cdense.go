@@ -0,0 +1,396 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+var (
+	cDense *CDense
+
+	_ CMatrix      = cDense
+	_ RawCMatrixer = cDense
+	_ Reseter      = cDense
+)
+
+// CDense is a dense matrix representation with complex data.
+type CDense struct {
+	mat cblas128.General
+
+	capRows, capCols int
+}
+
+// NewCDense creates a new complex Dense matrix with r rows and c columns.
+// If data == nil, a new slice is allocated for the backing slice.
+// If len(data) == r*c, data is used as the backing slice, and changes to the
+// elements of the returned CDense will be reflected in data.
+// If neither of these is true, NewCDense will panic.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+func NewCDense(r, c int, data []complex128) *CDense {
+	if data != nil && r*c != len(data) {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, r*c)
+	}
+	return &CDense{
+		mat: cblas128.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   data,
+		},
+		capRows: r,
+		capCols: c,
+	}
+}
+
+// reuseAs resizes an empty matrix to a r×c matrix,
+// or checks that a non-empty matrix is r×c.
+func (m *CDense) reuseAs(r, c int) {
+	if m.mat.Rows > m.capRows || m.mat.Cols > m.capCols {
+		// Panic as a string, not a mat.Error.
+		panic("mat: caps not correctly set")
+	}
+	if m.IsZero() {
+		m.mat = cblas128.General{
+			Rows:   r,
+			Cols:   c,
+			Stride: c,
+			Data:   useC(m.mat.Data, r*c),
+		}
+		m.capRows = r
+		m.capCols = c
+		return
+	}
+	if r != m.mat.Rows || c != m.mat.Cols {
+		panic(ErrShape)
+	}
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (m *CDense) Reset() {
+	// Row, Cols and Stride must be zeroed in unison.
+	m.mat.Rows, m.mat.Cols, m.mat.Stride = 0, 0, 0
+	m.capRows, m.capCols = 0, 0
+	m.mat.Data = m.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. CDense matrices can be zeroed using Reset.
+func (m *CDense) IsZero() bool {
+	// It must be the case that m.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return m.mat.Stride == 0
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CDense) Dims() (r, c int) { return m.mat.Rows, m.mat.Cols }
+
+// Caps returns the number of rows and columns in the backing matrix.
+func (m *CDense) Caps() (r, c int) { return m.capRows, m.capCols }
+
+// H performs an implicit conjugate transpose by returning the receiver inside a
+// Conjugate.
+func (m *CDense) H() CMatrix {
+	return Conjugate{m}
+}
+
+// RawCMatrix returns the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.General.
+func (m *CDense) RawCMatrix() cblas128.General { return m.mat }
+
+// SetRawCMatrix sets the underlying cblas128.General used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in b.
+func (m *CDense) SetRawCMatrix(b cblas128.General) {
+	m.capRows, m.capCols = b.Rows, b.Cols
+	m.mat = b
+}
+
+// Slice returns a new CMatrix that shares backing data with the receiver.
+// The returned matrix starts at {i,j} of the receiver and extends k-i rows
+// and l-j columns. The final row in the resulting matrix is k-1 and the
+// final column is l-1.
+// Slice panics with ErrIndexOutOfRange if the slice is outside the capacity
+// of the receiver.
+func (m *CDense) Slice(i, k, j, l int) CMatrix {
+	mr, mc := m.Caps()
+	if i < 0 || mr <= i || j < 0 || mc <= j || k <= i || mr < k || l <= j || mc < l {
+		panic(ErrIndexOutOfRange)
+	}
+	t := *m
+	t.mat.Data = t.mat.Data[i*t.mat.Stride+j : (k-1)*t.mat.Stride+l]
+	t.mat.Rows = k - i
+	t.mat.Cols = l - j
+	t.capRows -= i
+	t.capCols -= j
+	return &t
+}
+
+// RawRowView returns a slice backed by the same array as backing the
+// receiver.
+func (m *CDense) RawRowView(i int) []complex128 {
+	if i >= m.mat.Rows || i < 0 {
+		panic(ErrRowAccess)
+	}
+	return m.rawRowView(i)
+}
+
+func (m *CDense) rawRowView(i int) []complex128 {
+	return m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+m.mat.Cols]
+}
+
+// Copy makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two matrices and
+// returns the number of rows and columns it copied. If a aliases the receiver
+// and is a conjugated CDense with a non-unitary stride, Copy will panic.
+func (m *CDense) Copy(a CMatrix) (r, c int) {
+	r, c = a.Dims()
+	if a == CMatrix(m) {
+		return r, c
+	}
+	r = min(r, m.mat.Rows)
+	c = min(c, m.mat.Cols)
+	if r == 0 || c == 0 {
+		return 0, 0
+	}
+
+	aU, conj := unconjugate(a)
+	switch aU := aU.(type) {
+	case RawCMatrixer:
+		amat := aU.RawCMatrix()
+		if conj {
+			if amat.Stride != 1 {
+				m.checkOverlap(amat)
+			}
+			for i := 0; i < r; i++ {
+				mi := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+				for j := range mi {
+					mi[j] = cmplx.Conj(amat.Data[j*amat.Stride+i])
+				}
+			}
+		} else {
+			switch o := offsetComplex(m.mat.Data, amat.Data); {
+			case o < 0:
+				for i := r - 1; i >= 0; i-- {
+					copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+				}
+			case o > 0:
+				for i := 0; i < r; i++ {
+					copy(m.mat.Data[i*m.mat.Stride:i*m.mat.Stride+c], amat.Data[i*amat.Stride:i*amat.Stride+c])
+				}
+			default:
+				// Nothing to do.
+			}
+		}
+	default:
+		for i := 0; i < r; i++ {
+			for j := 0; j < c; j++ {
+				m.set(i, j, a.At(i, j))
+			}
+		}
+	}
+
+	return r, c
+}
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Add(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	m.reuseAs(ar, ac)
+	m.apply2(a, b, func(x, y complex128) complex128 { return x + y })
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *CDense) Sub(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(ErrShape)
+	}
+	m.reuseAs(ar, ac)
+	m.apply2(a, b, func(x, y complex128) complex128 { return x - y })
+}
+
+// apply2 places fn(a[i,j], b[i,j]) into the (i,j) element of the receiver,
+// which must already have the shape of a and b.
+func (m *CDense) apply2(a, b CMatrix, fn func(x, y complex128) complex128) {
+	r, c := a.Dims()
+	if arm, ok := a.(RawCMatrixer); ok {
+		if brm, ok := b.(RawCMatrixer); ok {
+			amat, bmat := arm.RawCMatrix(), brm.RawCMatrix()
+			if m != a {
+				m.checkOverlap(amat)
+			}
+			if m != b {
+				m.checkOverlap(bmat)
+			}
+			for i := 0; i < r; i++ {
+				ai := amat.Data[i*amat.Stride : i*amat.Stride+c]
+				bi := bmat.Data[i*bmat.Stride : i*bmat.Stride+c]
+				mi := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+				for j, v := range ai {
+					mi[j] = fn(v, bi[j])
+				}
+			}
+			return
+		}
+	}
+
+	aU, _ := unconjugate(a)
+	bU, _ := unconjugate(b)
+	if m == aU || m == bU {
+		w := NewCDense(r, c, nil)
+		w.apply2(a, b, fn)
+		m.Copy(w)
+		return
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.set(i, j, fn(a.At(i, j), b.At(i, j)))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *CDense) Scale(f complex128, a CMatrix) {
+	ar, ac := a.Dims()
+	m.reuseAs(ar, ac)
+
+	aU, conj := unconjugate(a)
+	if rm, ok := aU.(RawCMatrixer); ok && !conj {
+		amat := rm.RawCMatrix()
+		if m != aU {
+			m.checkOverlap(amat)
+		}
+		for i := 0; i < ar; i++ {
+			ai := amat.Data[i*amat.Stride : i*amat.Stride+ac]
+			mi := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+ac]
+			for j, v := range ai {
+				mi[j] = f * v
+			}
+		}
+		return
+	}
+
+	if m == aU {
+		w := NewCDense(ar, ac, nil)
+		w.Scale(f, a)
+		m.Copy(w)
+		return
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, f*a.At(i, j))
+		}
+	}
+}
+
+// Conj places the element-wise conjugate of a in the receiver.
+func (m *CDense) Conj(a CMatrix) {
+	r, c := a.Dims()
+	m.reuseAs(r, c)
+	if m != a {
+		m.Copy(a)
+	}
+	for i := 0; i < r; i++ {
+		mi := m.rawRowView(i)
+		for j, v := range mi {
+			mi[j] = cmplx.Conj(v)
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+func (m *CDense) Mul(a, b CMatrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+
+	if ac != br {
+		panic(ErrShape)
+	}
+
+	aU, aConj := unconjugate(a)
+	bU, bConj := unconjugate(b)
+	if m == aU || m == bU {
+		w := NewCDense(ar, bc, nil)
+		w.Mul(a, b)
+		m.reuseAs(ar, bc)
+		m.Copy(w)
+		return
+	}
+	m.reuseAs(ar, bc)
+
+	aT := blas.NoTrans
+	if aConj {
+		aT = blas.ConjTrans
+	}
+	bT := blas.NoTrans
+	if bConj {
+		bT = blas.ConjTrans
+	}
+
+	if arm, ok := aU.(RawCMatrixer); ok {
+		if brm, ok := bU.(RawCMatrixer); ok {
+			amat := arm.RawCMatrix()
+			bmat := brm.RawCMatrix()
+			m.checkOverlap(amat)
+			m.checkOverlap(bmat)
+			cblas128.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
+			return
+		}
+	}
+
+	row := make([]complex128, ac)
+	for r := 0; r < ar; r++ {
+		for i := range row {
+			row[i] = a.At(r, i)
+		}
+		for c := 0; c < bc; c++ {
+			var v complex128
+			for i, e := range row {
+				v += e * b.At(i, c)
+			}
+			m.mat.Data[r*m.mat.Stride+c] = v
+		}
+	}
+}
+
+// unconjugate unconjugates a matrix if applicable. If a is an Unconjugator, then
+// unconjugate returns the underlying matrix and true. If it is not, then it returns
+// the input matrix and false.
+func unconjugate(a CMatrix) (CMatrix, bool) {
+	if ut, ok := a.(Unconjugator); ok {
+		return ut.Unconjugate(), true
+	}
+	return a, false
+}
+
+// useC returns a complex128 slice with l elements, using c if it
+// has the necessary capacity, otherwise creating a new slice.
+func useC(c []complex128, l int) []complex128 {
+	if l <= cap(c) {
+		return c[:l]
+	}
+	return make([]complex128, l)
+}
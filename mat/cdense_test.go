@@ -0,0 +1,288 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+// basicCMatrix is a CMatrix that hides the storage of a CDense.
+type basicCMatrix CDense
+
+func (m *basicCMatrix) At(i, j int) complex128 { return (*CDense)(m).At(i, j) }
+func (m *basicCMatrix) Dims() (r, c int)       { return (*CDense)(m).Dims() }
+func (m *basicCMatrix) H() CMatrix             { return Conjugate{m} }
+
+func randCDense(r, c int, rnd *rand.Rand) *CDense {
+	m := NewCDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			m.Set(i, j, complex(rnd.NormFloat64(), rnd.NormFloat64()))
+		}
+	}
+	return m
+}
+
+func TestNewCDense(t *testing.T) {
+	m := NewCDense(2, 3, []complex128{
+		1, 2i, 3 + 1i,
+		4, 5 - 2i, 6,
+	})
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("unexpected dimensions: got %d×%d want 2×3", r, c)
+	}
+	if v := m.At(1, 1); v != 5-2i {
+		t.Errorf("unexpected value: got %v want 5-2i", v)
+	}
+	m.Set(0, 2, -1i)
+	if v := m.RawCMatrix().Data[2]; v != -1i {
+		t.Errorf("Set not reflected in backing data: got %v want -1i", v)
+	}
+	h := m.H()
+	if r, c := h.Dims(); r != 3 || c != 2 {
+		t.Errorf("unexpected conjugate transpose dimensions: got %d×%d want 3×2", r, c)
+	}
+	if v := h.At(1, 1); v != 5+2i {
+		t.Errorf("unexpected conjugate transpose value: got %v want 5+2i", v)
+	}
+	if panicked, _ := panics(func() { NewCDense(2, 2, make([]complex128, 3)) }); !panicked {
+		t.Errorf("expected panic for mismatched data length")
+	}
+	if panicked, _ := panics(func() { m.At(2, 0) }); !panicked {
+		t.Errorf("expected panic for out of range access")
+	}
+}
+
+func TestCDenseSlice(t *testing.T) {
+	m := NewCDense(3, 4, nil)
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 4; j++ {
+			m.Set(i, j, complex(float64(i), float64(j)))
+		}
+	}
+	s := m.Slice(1, 3, 1, 4).(*CDense)
+	if r, c := s.Dims(); r != 2 || c != 3 {
+		t.Fatalf("unexpected slice dimensions: got %d×%d want 2×3", r, c)
+	}
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			if got, want := s.At(i, j), m.At(i+1, j+1); got != want {
+				t.Errorf("unexpected slice value at (%d,%d): got %v want %v", i, j, got, want)
+			}
+		}
+	}
+	s.Set(0, 0, 100)
+	if m.At(1, 1) != 100 {
+		t.Errorf("slice does not share data with the receiver")
+	}
+	if panicked, _ := panics(func() { m.Slice(0, 4, 0, 1) }); !panicked {
+		t.Errorf("expected panic for slice out of range")
+	}
+}
+
+func TestCDenseArithmetic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, k, c int }{
+		{1, 1, 1},
+		{2, 3, 4},
+		{5, 1, 3},
+		{4, 4, 4},
+		{7, 3, 2},
+	} {
+		a := randCDense(test.r, test.k, rnd)
+		b := randCDense(test.k, test.c, rnd)
+		a2 := randCDense(test.r, test.k, rnd)
+
+		var sum, diff CDense
+		sum.Add(a, a2)
+		diff.Sub(a, a2)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.k; j++ {
+				if sum.At(i, j) != a.At(i, j)+a2.At(i, j) {
+					t.Errorf("unexpected Add result at (%d,%d)", i, j)
+				}
+				if diff.At(i, j) != a.At(i, j)-a2.At(i, j) {
+					t.Errorf("unexpected Sub result at (%d,%d)", i, j)
+				}
+			}
+		}
+
+		var scaled CDense
+		f := 2 - 3i
+		scaled.Scale(f, a)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.k; j++ {
+				if scaled.At(i, j) != f*a.At(i, j) {
+					t.Errorf("unexpected Scale result at (%d,%d)", i, j)
+				}
+			}
+		}
+
+		want := NewCDense(test.r, test.c, nil)
+		for i := 0; i < test.r; i++ {
+			for j := 0; j < test.c; j++ {
+				var v complex128
+				for l := 0; l < test.k; l++ {
+					v += a.At(i, l) * b.At(l, j)
+				}
+				want.Set(i, j, v)
+			}
+		}
+		var got CDense
+		got.Mul(a, b)
+		if !CEqualApprox(&got, want, 1e-14) {
+			t.Errorf("unexpected Mul result for %d×%d by %d×%d", test.r, test.k, test.k, test.c)
+		}
+		got.Reset()
+		got.Mul((*basicCMatrix)(a), (*basicCMatrix)(b))
+		if !CEqualApprox(&got, want, 1e-14) {
+			t.Errorf("unexpected Mul result for non-raw %d×%d by %d×%d", test.r, test.k, test.k, test.c)
+		}
+
+		// (A^H * A)^H = A^H * A.
+		var ah CDense
+		ah.Mul(a.H(), a)
+		var ahh CDense
+		ahh.Mul(Conjugate{a}, a)
+		if !CEqualApprox(&ah, ahh.H(), 1e-14) {
+			t.Errorf("A^H * A is not Hermitian for %d×%d", test.r, test.k)
+		}
+		for i := 0; i < test.k; i++ {
+			for j := 0; j < test.k; j++ {
+				var v complex128
+				for l := 0; l < test.r; l++ {
+					v += cmplx.Conj(a.At(l, i)) * a.At(l, j)
+				}
+				if cmplx.Abs(ah.At(i, j)-v) > 1e-14 {
+					t.Errorf("unexpected A^H * A result at (%d,%d): got %v want %v", i, j, ah.At(i, j), v)
+				}
+			}
+		}
+
+		if test.r == test.k && test.k == test.c {
+			// In-place multiplication.
+			aCopy := NewCDense(test.r, test.k, nil)
+			aCopy.Copy(a)
+			aCopy.Mul(aCopy, b)
+			if !CEqualApprox(aCopy, want, 1e-14) {
+				t.Errorf("unexpected in-place Mul result for %d×%d", test.r, test.c)
+			}
+		}
+	}
+
+	var m CDense
+	if panicked, _ := panics(func() { m.Mul(NewCDense(2, 3, nil), NewCDense(2, 3, nil)) }); !panicked {
+		t.Errorf("expected panic for shape mismatch in Mul")
+	}
+	if panicked, _ := panics(func() { m.Add(NewCDense(2, 3, nil), NewCDense(3, 2, nil)) }); !panicked {
+		t.Errorf("expected panic for shape mismatch in Add")
+	}
+}
+
+func TestCDenseCopy(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randCDense(3, 4, rnd)
+
+	var m CDense
+	m.reuseAs(4, 3)
+	m.Copy(a.H())
+	if !CEqual(&m, a.H()) {
+		t.Errorf("unexpected copy of conjugate transpose")
+	}
+
+	b := NewCDense(2, 2, nil)
+	if r, c := b.Copy(a); r != 2 || c != 2 {
+		t.Errorf("unexpected copy size: got %d×%d want 2×2", r, c)
+	}
+	if !CEqual(b, a.Slice(0, 2, 0, 2)) {
+		t.Errorf("unexpected partial copy")
+	}
+}
+
+func TestCDenseMarshal(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ r, c int }{
+		{1, 1},
+		{3, 2},
+		{2, 5},
+	} {
+		a := randCDense(test.r, test.c, rnd)
+		buf, err := a.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error marshaling: %v", err)
+		}
+		if want := 16 + 16*test.r*test.c; len(buf) != want {
+			t.Errorf("unexpected buffer length: got %d want %d", len(buf), want)
+		}
+		var b CDense
+		err = b.UnmarshalBinary(buf)
+		if err != nil {
+			t.Fatalf("unexpected error unmarshaling: %v", err)
+		}
+		if !CEqual(a, &b) {
+			t.Errorf("round trip mismatch for %d×%d", test.r, test.c)
+		}
+
+		var c CDense
+		if err := c.UnmarshalBinary(buf[:len(buf)-1]); err != errBadBuffer {
+			t.Errorf("unexpected error for truncated buffer: got %v want %v", err, errBadBuffer)
+		}
+	}
+}
+
+func TestCVecDense(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randCDense(4, 3, rnd)
+	x := NewCVecDense(3, []complex128{1 + 1i, -2, 3i})
+
+	var y CVecDense
+	y.MulVec(a, x)
+	for i := 0; i < 4; i++ {
+		var want complex128
+		for j := 0; j < 3; j++ {
+			want += a.At(i, j) * x.AtVec(j)
+		}
+		if cmplx.Abs(y.AtVec(i)-want) > 1e-14 {
+			t.Errorf("unexpected MulVec result at %d: got %v want %v", i, y.AtVec(i), want)
+		}
+	}
+
+	var z CVecDense
+	z.MulVec(a.H(), &y)
+	for i := 0; i < 3; i++ {
+		var want complex128
+		for j := 0; j < 4; j++ {
+			want += cmplx.Conj(a.At(j, i)) * y.AtVec(j)
+		}
+		if cmplx.Abs(z.AtVec(i)-want) > 1e-13 {
+			t.Errorf("unexpected conjugate MulVec result at %d: got %v want %v", i, z.AtVec(i), want)
+		}
+	}
+
+	var s CVecDense
+	s.AddVec(x, x)
+	s.SubVec(&s, x)
+	s.ScaleVec(2i, &s)
+	for i := 0; i < 3; i++ {
+		if s.AtVec(i) != 2i*x.AtVec(i) {
+			t.Errorf("unexpected vector arithmetic result at %d", i)
+		}
+	}
+
+	buf, err := x.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %v", err)
+	}
+	var u CVecDense
+	err = u.UnmarshalBinary(buf)
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling: %v", err)
+	}
+	if !CEqual(x, &u) {
+		t.Errorf("vector round trip mismatch")
+	}
+}
@@ -378,30 +378,7 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 		if alpha != 1 {
 			blas64.Scal(n, math.Sqrt(alpha), blas64.Vector{1, work})
 		}
-		umat := c.chol.mat
-		stride := umat.Stride
-		for i := 0; i < n; i++ {
-			// Compute parameters of the Givens matrix that zeroes
-			// the i-th element of x.
-			c, s, r, _ := blas64.Rotg(umat.Data[i*stride+i], work[i])
-			if r < 0 {
-				// Multiply by -1 to have positive diagonal
-				// elemnts.
-				r *= -1
-				c *= -1
-				s *= -1
-			}
-			umat.Data[i*stride+i] = r
-			if i < n-1 {
-				// Multiply the extended factorization matrix by
-				// the Givens matrix from the left. Only
-				// the i-th row and x are modified.
-				blas64.Rot(n-i-1,
-					blas64.Vector{1, umat.Data[i*stride+i+1 : i*stride+n]},
-					blas64.Vector{1, work[i+1 : n]},
-					c, s)
-			}
-		}
+		cholUpdate(c.chol.mat, work)
 		c.updateCond(-1)
 		return true
 	}
@@ -470,6 +447,156 @@ func (c *Cholesky) SymRankOne(orig *Cholesky, alpha float64, x *VecDense) (ok bo
 	return ok
 }
 
+// cholUpdate overwrites the upper triangular Cholesky factor U with the
+// Cholesky factor of U^T*U + x*x^T, where x is held in work. work is
+// overwritten.
+func cholUpdate(umat blas64.Triangular, work []float64) {
+	n := umat.N
+	stride := umat.Stride
+	for i := 0; i < n; i++ {
+		// Compute parameters of the Givens matrix that zeroes
+		// the i-th element of x.
+		c, s, r, _ := blas64.Rotg(umat.Data[i*stride+i], work[i])
+		if r < 0 {
+			// Multiply by -1 to have positive diagonal
+			// elemnts.
+			r *= -1
+			c *= -1
+			s *= -1
+		}
+		umat.Data[i*stride+i] = r
+		if i < n-1 {
+			// Multiply the extended factorization matrix by
+			// the Givens matrix from the left. Only
+			// the i-th row and x are modified.
+			blas64.Rot(n-i-1,
+				blas64.Vector{1, umat.Data[i*stride+i+1 : i*stride+n]},
+				blas64.Vector{1, work[i+1 : n]},
+				c, s)
+		}
+	}
+}
+
+// ExtendVecSym computes the Cholesky decomposition of the original matrix A,
+// whose Cholesky decomposition is in a, extended by the vector v by one row
+// and one column, and stores the result into the receiver. The extended
+// matrix is
+//  [A   w]
+//  [w^T k],
+// where w = v[:n] and k = v[n] for an n×n matrix A. ExtendVecSym will panic
+// if a is not a valid factorization or if the length of v is not n+1.
+//
+// ExtendVecSym returns whether the extended matrix is positive definite. If
+// it is not, the receiver is not modified.
+//
+// ExtendVecSym takes O(n²) time, compared to O(n³) for computing the
+// factorization of the extended matrix from scratch.
+func (c *Cholesky) ExtendVecSym(a *Cholesky, v *VecDense) (ok bool) {
+	n := a.Size()
+	if v.Len() != n+1 {
+		panic(ErrShape)
+	}
+
+	// The extended factor is
+	//  [U u]
+	//  [0 d],
+	// where U^T * u = w and d^2 = k - u^T * u.
+	work := getFloats(n, false)
+	defer putFloats(work)
+	for i := range work {
+		work[i] = v.at(i)
+	}
+	ok = lapack64.Trtrs(blas.Trans, a.chol.RawTriangular(), blas64.General{
+		Rows:   n,
+		Cols:   1,
+		Stride: 1,
+		Data:   work,
+	})
+	if !ok {
+		// The original matrix is singular. Should not happen, because
+		// the factorization is valid.
+		panic(badCholesky)
+	}
+	d := v.at(n) - blas64.Dot(n, blas64.Vector{Inc: 1, Data: work}, blas64.Vector{Inc: 1, Data: work})
+	if d <= 0 {
+		return false
+	}
+
+	u := NewTriDense(n+1, Upper, nil)
+	u.Copy(a.chol)
+	for i, w := range work {
+		u.SetTri(i, n, w)
+	}
+	u.SetTri(n, n, math.Sqrt(d))
+	c.chol = u
+	c.updateCond(-1)
+	return true
+}
+
+// DeleteRowColSym computes the Cholesky decomposition of the original matrix
+// A, whose Cholesky decomposition is in orig, with row and column k removed,
+// and stores the result into the receiver. DeleteRowColSym will panic if
+// orig is not a valid factorization, if k is not in [0, n) for an n×n matrix
+// A, or if A is 1×1.
+//
+// DeleteRowColSym takes O(n²) time, compared to O(n³) for computing the
+// factorization of the reduced matrix from scratch.
+func (c *Cholesky) DeleteRowColSym(orig *Cholesky, k int) {
+	n := orig.Size()
+	if k < 0 || n <= k {
+		panic(ErrIndexOutOfRange)
+	}
+	if n == 1 {
+		panic(ErrShape)
+	}
+
+	// Partition U as
+	//  [U11 u12 U13]
+	//  [ 0  u22 u23]
+	//  [ 0   0  U33].
+	// The reduced factor is
+	//  [U11 U13]
+	//  [ 0   S ],
+	// where S^T * S = U33^T * U33 + u23^T * u23.
+	src := orig.chol.mat
+	u := NewTriDense(n-1, Upper, nil)
+	dst := u.mat
+	for i := 0; i < n; i++ {
+		if i == k {
+			continue
+		}
+		ri := i
+		if i > k {
+			ri--
+		}
+		for j := i; j < n; j++ {
+			if j == k {
+				continue
+			}
+			rj := j
+			if j > k {
+				rj--
+			}
+			dst.Data[ri*dst.Stride+rj] = src.Data[i*src.Stride+j]
+		}
+	}
+	if k < n-1 {
+		m := n - k - 1
+		work := getFloats(m, false)
+		copy(work, src.Data[k*src.Stride+k+1:k*src.Stride+n])
+		cholUpdate(blas64.Triangular{
+			N:      m,
+			Stride: dst.Stride,
+			Data:   dst.Data[k*dst.Stride+k:],
+			Uplo:   blas.Upper,
+			Diag:   blas.NonUnit,
+		}, work)
+		putFloats(work)
+	}
+	c.chol = u
+	c.updateCond(-1)
+}
+
 func (c *Cholesky) isZero() bool {
 	return c.chol == nil
 }
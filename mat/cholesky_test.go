@@ -421,6 +421,120 @@ func TestCholeskySymRankOne(t *testing.T) {
 	}
 }
 
+func TestCholeskyExtendVecSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 20} {
+		data := make([]float64, (n+1)*(n+1))
+		for i := range data {
+			data[i] = rnd.NormFloat64()
+		}
+		var full SymDense
+		full.SymOuterK(1, NewDense(n+1, n+1, data))
+
+		a := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				a.SetSym(i, j, full.At(i, j))
+			}
+		}
+		v := NewVecDense(n+1, nil)
+		for i := 0; i <= n; i++ {
+			v.SetVec(i, full.At(i, n))
+		}
+
+		for _, inPlace := range []bool{false, true} {
+			var chol Cholesky
+			ok := chol.Factorize(a)
+			if !ok {
+				t.Fatalf("bad test, Cholesky factorization failed")
+			}
+			dst := &chol
+			if !inPlace {
+				dst = &Cholesky{}
+			}
+			ok = dst.ExtendVecSym(&chol, v)
+			if !ok {
+				t.Errorf("n=%d: unexpected failure", n)
+				continue
+			}
+			if dst.Size() != n+1 {
+				t.Errorf("n=%d: unexpected size %d", n, dst.Size())
+			}
+			var got SymDense
+			dst.To(&got)
+			if !EqualApprox(&got, &full, 1e-12) {
+				t.Errorf("n=%d: mismatch between extended matrix and from Cholesky", n)
+			}
+		}
+
+		// Extending by a vector that makes the matrix indefinite
+		// must fail and leave the receiver unchanged.
+		var chol Cholesky
+		chol.Factorize(a)
+		bad := NewVecDense(n+1, nil)
+		bad.CopyVec(v)
+		bad.SetVec(n, -1)
+		if chol.ExtendVecSym(&chol, bad) {
+			t.Errorf("n=%d: unexpected success for indefinite matrix", n)
+		}
+		if chol.Size() != n {
+			t.Errorf("n=%d: receiver modified after failure", n)
+		}
+	}
+}
+
+func TestCholeskyDeleteRowColSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 5, 10, 20} {
+		data := make([]float64, n*n)
+		for i := range data {
+			data[i] = rnd.NormFloat64()
+		}
+		var a SymDense
+		a.SymOuterK(1, NewDense(n, n, data))
+
+		for _, k := range []int{0, n / 2, n - 1} {
+			want := NewSymDense(n-1, nil)
+			for i := 0; i < n-1; i++ {
+				for j := i; j < n-1; j++ {
+					ai, aj := i, j
+					if i >= k {
+						ai++
+					}
+					if j >= k {
+						aj++
+					}
+					want.SetSym(i, j, a.At(ai, aj))
+				}
+			}
+
+			for _, inPlace := range []bool{false, true} {
+				var chol Cholesky
+				ok := chol.Factorize(&a)
+				if !ok {
+					t.Fatalf("bad test, Cholesky factorization failed")
+				}
+				dst := &chol
+				if !inPlace {
+					dst = &Cholesky{}
+				}
+				dst.DeleteRowColSym(&chol, k)
+				var got SymDense
+				dst.To(&got)
+				if !EqualApprox(&got, want, 1e-12) {
+					t.Errorf("n=%d, k=%d: mismatch between reduced matrix and from Cholesky", n, k)
+				}
+				u := dst.UTo(nil)
+				for i := 0; i < n-1; i++ {
+					if u.At(i, i) <= 0 {
+						t.Errorf("n=%d, k=%d: non-positive diagonal element", n, k)
+					}
+				}
+			}
+		}
+	}
+}
+
 func BenchmarkCholeskySmall(b *testing.B) {
 	benchmarkCholesky(b, 2)
 }
@@ -4,6 +4,13 @@
 
 package mat
 
+import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
 // CMatrix is the basic matrix interface type for complex matrices.
 type CMatrix interface {
 	// Dims returns the dimensions of a Matrix.
@@ -20,6 +27,13 @@ type CMatrix interface {
 	H() CMatrix
 }
 
+// A RawCMatrixer can return a cblas128.General representation of the receiver.
+// Changes to the cblas128.General.Data slice will be reflected in the original
+// matrix, changes to the Rows, Cols and Stride fields will not.
+type RawCMatrixer interface {
+	RawCMatrix() cblas128.General
+}
+
 var (
 	_ CMatrix      = Conjugate{}
 	_ Unconjugator = Conjugate{}
@@ -69,3 +83,55 @@ type Unconjugator interface {
 	// conjugate transpose.
 	Unconjugate() CMatrix
 }
+
+// CEqual returns whether the matrices a and b have the same size
+// and are element-wise equal.
+func CEqual(a, b CMatrix) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if a.At(i, j) != b.At(i, j) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CEqualApprox returns whether the matrices a and b have the same size and contain all equal
+// elements with tolerance for element-wise equality specified by epsilon. Matrices
+// with non-equal shapes are not equal.
+func CEqualApprox(a, b CMatrix, epsilon float64) bool {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		return false
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			if !cEqualWithinAbsOrRel(a.At(i, j), b.At(i, j), epsilon, epsilon) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// cEqualWithinAbsOrRel returns true if a and b are equal to within
+// the absolute tolerance or relative tolerance.
+func cEqualWithinAbsOrRel(a, b complex128, absTol, relTol float64) bool {
+	if a == b {
+		return true
+	}
+	delta := cmplx.Abs(a - b)
+	if delta <= absTol {
+		return true
+	}
+	// We depend on the division in this relationship to identify
+	// infinities.
+	return delta/math.Max(cmplx.Abs(a), cmplx.Abs(b)) <= relTol
+}
@@ -31,6 +31,14 @@ const (
 	//  A = U * Σ * V^T
 	// where U is of size m×m, Σ is an m×n diagonal matrix, and V is an n×n matrix.
 	SVDFull
+	// SVDTruncated computes only the k largest singular values and the
+	// corresponding singular vectors,
+	//  A ≈ U_k * Σ_k * V_k^T
+	// where U_k is of size m×k, Σ_k is a diagonal matrix of size k×k and V_k
+	// is of size n×k. Truncated decompositions are computed by
+	// SVD.FactorizeTruncated and SVD.FactorizeRandomized; SVDTruncated is not
+	// a valid kind for SVD.Factorize.
+	SVDTruncated
 )
 
 // GSVDKind specifies the treatment of singular vectors during a GSVD
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+var (
+	errNoData      = errors.New("mat: no data")
+	errHeaderWidth = errors.New("mat: header length mismatch")
+)
+
+// CSVFormat describes the layout of matrix data held as comma-separated
+// values. Each record holds one row of the matrix.
+type CSVFormat struct {
+	// Comma is the field delimiter. If Comma is zero, ',' is used.
+	Comma rune
+
+	// Header specifies whether the first record holds the column names.
+	Header bool
+
+	// Missing is the token that marks a missing value. Fields equal to
+	// Missing after trimming surrounding white space are read with a
+	// value and weight of zero, as by floats.ParseWithNA. When writing,
+	// NaN elements of the matrix are written as Missing.
+	Missing string
+}
+
+// Read reads all the records in r and returns the matrix of values, the
+// matrix of weights and, if f.Header is true, the column names. An element
+// of weights is zero if the corresponding value was missing and one
+// otherwise. All records must have the same number of fields.
+func (f CSVFormat) Read(r io.Reader) (data, weights *Dense, names []string, err error) {
+	cr := csv.NewReader(r)
+	if f.Comma != 0 {
+		cr.Comma = f.Comma
+	}
+	records, err := cr.ReadAll()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if f.Header {
+		if len(records) == 0 {
+			return nil, nil, nil, errNoData
+		}
+		names = records[0]
+		for i, name := range names {
+			names[i] = strings.TrimSpace(name)
+		}
+		records = records[1:]
+	}
+	if len(records) == 0 || len(records[0]) == 0 {
+		return nil, nil, nil, errNoData
+	}
+
+	rows, cols := len(records), len(records[0])
+	data = NewDense(rows, cols, nil)
+	weights = NewDense(rows, cols, nil)
+	for i, rec := range records {
+		for j, field := range rec {
+			v, w, err := floats.ParseWithNA(strings.TrimSpace(field), f.Missing)
+			if err != nil {
+				return nil, nil, nil, fmt.Errorf("mat: record %d field %d: %v", i, j, err)
+			}
+			data.set(i, j, v)
+			weights.set(i, j, w)
+		}
+	}
+	return data, weights, names, nil
+}
+
+// Write writes the matrix a to w with one record per row. If f.Header is
+// true, names is written as the first record and must have one element for
+// each column of a. NaN elements of a are written as f.Missing if it is not
+// empty.
+func (f CSVFormat) Write(w io.Writer, a Matrix, names []string) error {
+	r, c := a.Dims()
+	cw := csv.NewWriter(w)
+	if f.Comma != 0 {
+		cw.Comma = f.Comma
+	}
+	if f.Header {
+		if len(names) != c {
+			return errHeaderWidth
+		}
+		if err := cw.Write(names); err != nil {
+			return err
+		}
+	}
+	rec := make([]string, c)
+	for i := 0; i < r; i++ {
+		for j := range rec {
+			v := a.At(i, j)
+			if math.IsNaN(v) && f.Missing != "" {
+				rec[j] = f.Missing
+				continue
+			}
+			rec[j] = strconv.FormatFloat(v, 'g', -1, 64)
+		}
+		if err := cw.Write(rec); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
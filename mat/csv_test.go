@@ -0,0 +1,108 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCSVRead(t *testing.T) {
+	for i, test := range []struct {
+		format CSVFormat
+		input  string
+
+		data, weights *Dense
+		names         []string
+	}{
+		{
+			input:   "1,2,3\n4,5,6\n",
+			data:    NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+			weights: NewDense(2, 3, []float64{1, 1, 1, 1, 1, 1}),
+		},
+		{
+			format:  CSVFormat{Header: true, Missing: "NA"},
+			input:   "a, b\n1.5, NA\nNA, -2e3\n",
+			data:    NewDense(2, 2, []float64{1.5, 0, 0, -2e3}),
+			weights: NewDense(2, 2, []float64{1, 0, 0, 1}),
+			names:   []string{"a", "b"},
+		},
+		{
+			format:  CSVFormat{Comma: '\t'},
+			input:   "1\t\t3\n",
+			data:    NewDense(1, 3, []float64{1, 0, 3}),
+			weights: NewDense(1, 3, []float64{1, 0, 1}),
+		},
+	} {
+		data, weights, names, err := test.format.Read(strings.NewReader(test.input))
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+			continue
+		}
+		if !Equal(data, test.data) {
+			t.Errorf("unexpected data for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(data), Formatted(test.data))
+		}
+		if !Equal(weights, test.weights) {
+			t.Errorf("unexpected weights for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(weights), Formatted(test.weights))
+		}
+		if !reflect.DeepEqual(names, test.names) {
+			t.Errorf("unexpected names for test %d: got %q, want %q", i, names, test.names)
+		}
+	}
+
+	for i, input := range []string{
+		"",
+		"1,2\n3\n",
+		"1,x\n",
+	} {
+		_, _, _, err := CSVFormat{}.Read(strings.NewReader(input))
+		if err == nil {
+			t.Errorf("expected error for invalid input %d", i)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	a := NewDense(3, 2, []float64{1, math.Pi, -0.1, math.NaN(), 1e300, 5})
+	f := CSVFormat{Header: true, Missing: "NA"}
+	var buf bytes.Buffer
+	err := f.Write(&buf, a, []string{"x", "y"})
+	if err != nil {
+		t.Fatalf("unexpected error writing CSV: %v", err)
+	}
+	want := "x,y\n1,3.141592653589793\n-0.1,NA\n1e+300,5\n"
+	if buf.String() != want {
+		t.Errorf("unexpected CSV output:\ngot:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	data, weights, names, err := f.Read(&buf)
+	if err != nil {
+		t.Fatalf("unexpected error reading CSV: %v", err)
+	}
+	if !reflect.DeepEqual(names, []string{"x", "y"}) {
+		t.Errorf("unexpected names: %q", names)
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 2; j++ {
+			v := a.At(i, j)
+			if math.IsNaN(v) {
+				if weights.At(i, j) != 0 {
+					t.Errorf("missing value at (%d,%d) has non-zero weight", i, j)
+				}
+				continue
+			}
+			if data.At(i, j) != v || weights.At(i, j) != 1 {
+				t.Errorf("mismatch at (%d,%d): got %v, want %v", i, j, data.At(i, j), v)
+			}
+		}
+	}
+
+	if err := f.Write(&buf, a, []string{"x"}); err == nil {
+		t.Errorf("expected error for header length mismatch")
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/cblas128"
+)
+
+var (
+	cVector *CVecDense
+
+	_ CMatrix = cVector
+	_ CVector = cVector
+	_ Reseter = cVector
+)
+
+// CVector is a complex column vector.
+type CVector interface {
+	CMatrix
+	AtVec(int) complex128
+	Len() int
+}
+
+// CVecDense represents a complex column vector.
+type CVecDense struct {
+	mat cblas128.Vector
+	n   int
+	// A BLAS vector can have a negative increment, but allowing this
+	// in the mat type complicates a lot of code, and doesn't gain anything.
+	// CVecDense must have positive increment in this package.
+}
+
+// NewCVecDense creates a new CVecDense of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned CVecDense
+// will be reflected in data. If neither of these is true, NewCVecDense will panic.
+func NewCVecDense(n int, data []complex128) *CVecDense {
+	if len(data) != n && data != nil {
+		panic(ErrShape)
+	}
+	if data == nil {
+		data = make([]complex128, n)
+	}
+	return &CVecDense{
+		mat: cblas128.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// SliceVec returns a new CVecDense that shares backing data with the receiver.
+// The returned vector starts at i of the receiver and extends k-i elements.
+// SliceVec panics with ErrIndexOutOfRange if the slice is outside the capacity
+// of the receiver.
+func (v *CVecDense) SliceVec(i, k int) *CVecDense {
+	if i < 0 || k <= i || v.Cap() < k {
+		panic(ErrIndexOutOfRange)
+	}
+	return &CVecDense{
+		n: k - i,
+		mat: cblas128.Vector{
+			Inc:  v.mat.Inc,
+			Data: v.mat.Data[i*v.mat.Inc : (k-1)*v.mat.Inc+1],
+		},
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *CVecDense) Dims() (r, c int) {
+	if v.IsZero() {
+		return 0, 0
+	}
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *CVecDense) Len() int {
+	return v.n
+}
+
+// Cap returns the capacity of the vector.
+func (v *CVecDense) Cap() int {
+	if v.IsZero() {
+		return 0
+	}
+	return (cap(v.mat.Data)-1)/v.mat.Inc + 1
+}
+
+// H performs an implicit conjugate transpose by returning the receiver inside a
+// Conjugate.
+func (v *CVecDense) H() CMatrix {
+	return Conjugate{v}
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (v *CVecDense) Reset() {
+	// No change of Inc or n to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.n = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized vectors can be the
+// receiver for size-restricted operations. CVecDenses can be zeroed using Reset.
+func (v *CVecDense) IsZero() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// RawCVector returns the underlying cblas128.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned cblas128.Vector.
+func (v *CVecDense) RawCVector() cblas128.Vector {
+	return v.mat
+}
+
+// reuseAs resizes an empty vector to a r×1 vector,
+// or checks that a non-empty matrix is r×1.
+func (v *CVecDense) reuseAs(r int) {
+	if v.IsZero() {
+		v.mat = cblas128.Vector{
+			Inc:  1,
+			Data: useC(v.mat.Data, r),
+		}
+		v.n = r
+		return
+	}
+	if r != v.n {
+		panic(ErrShape)
+	}
+}
+
+// asGeneral returns a cblas128.General representation of the receiver with the
+// same underlying data.
+func (v *CVecDense) asGeneral() cblas128.General {
+	return cblas128.General{
+		Rows:   v.n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+}
+
+// CopyVec makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two vectors and
+// returns the number of elements it copied.
+func (v *CVecDense) CopyVec(a CVector) int {
+	n := min(v.Len(), a.Len())
+	if v == a {
+		return n
+	}
+	if r, ok := a.(*CVecDense); ok {
+		cblas128.Copy(n, r.mat, v.mat)
+		return n
+	}
+	for i := 0; i < n; i++ {
+		v.setVec(i, a.AtVec(i))
+	}
+	return n
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *CVecDense) ScaleVec(alpha complex128, a CVector) {
+	n := a.Len()
+	v.reuseAs(n)
+	if v != a {
+		v.CopyVec(a)
+	}
+	cblas128.Scal(n, alpha, v.mat)
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *CVecDense) AddVec(a, b CVector) {
+	n := a.Len()
+	if n != b.Len() {
+		panic(ErrShape)
+	}
+	v.reuseAs(n)
+	for i := 0; i < n; i++ {
+		v.setVec(i, a.AtVec(i)+b.AtVec(i))
+	}
+}
+
+// SubVec subtracts the vector b from a, placing the result in the receiver.
+func (v *CVecDense) SubVec(a, b CVector) {
+	n := a.Len()
+	if n != b.Len() {
+		panic(ErrShape)
+	}
+	v.reuseAs(n)
+	for i := 0; i < n; i++ {
+		v.setVec(i, a.AtVec(i)-b.AtVec(i))
+	}
+}
+
+// MulVec computes a * b. The result is stored into the receiver.
+// MulVec panics if the number of columns in a does not equal the number of rows in b.
+func (v *CVecDense) MulVec(a CMatrix, b CVector) {
+	r, c := a.Dims()
+	if c != b.Len() {
+		panic(ErrShape)
+	}
+
+	aU, conj := unconjugate(a)
+	if v == aU || v == b {
+		w := NewCVecDense(r, nil)
+		w.MulVec(a, b)
+		v.reuseAs(r)
+		v.CopyVec(w)
+		return
+	}
+	v.reuseAs(r)
+
+	if rm, ok := aU.(RawCMatrixer); ok {
+		if bv, ok := b.(*CVecDense); ok {
+			amat := rm.RawCMatrix()
+			// We don't know that a is a *CDense, so make
+			// a temporary CDense to check overlap.
+			(&CDense{mat: amat}).checkOverlap(v.asGeneral())
+			(&CDense{mat: bv.asGeneral()}).checkOverlap(v.asGeneral())
+			t := blas.NoTrans
+			if conj {
+				t = blas.ConjTrans
+			}
+			cblas128.Gemv(t, 1, amat, bv.mat, 0, v.mat)
+			return
+		}
+	}
+
+	for i := 0; i < r; i++ {
+		var sum complex128
+		for j := 0; j < c; j++ {
+			sum += a.At(i, j) * b.AtVec(j)
+		}
+		v.setVec(i, sum)
+	}
+}
+
+// Conj places the element-wise conjugate of a in the receiver.
+func (v *CVecDense) Conj(a CVector) {
+	n := a.Len()
+	v.reuseAs(n)
+	for i := 0; i < n; i++ {
+		v.setVec(i, cmplx.Conj(a.AtVec(i)))
+	}
+}
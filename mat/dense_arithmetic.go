@@ -429,6 +429,30 @@ func (m *Dense) Mul(a, b Matrix) {
 		}
 	}
 
+	if aU, ok := aU.(MulVecToer); ok {
+		// Compute C = op(A) * B one column at a time.
+		x := NewVecDense(br, nil)
+		for j := 0; j < bc; j++ {
+			Col(x.mat.Data, j, b)
+			aU.MulVecTo(m.ColView(j), aTrans, x)
+		}
+		return
+	}
+	if bU, ok := bU.(MulVecToer); ok {
+		// Compute the rows of C = A * op(B) from C^T = op(B)^T * A^T.
+		x := NewVecDense(ac, nil)
+		for i := 0; i < ar; i++ {
+			Row(x.mat.Data, i, a)
+			bU.MulVecTo(m.RowView(i), !bTrans, x)
+		}
+		return
+	}
+
+	if isSparse(aU) || isSparse(bU) {
+		m.mulSparse(a, b)
+		return
+	}
+
 	row := getFloats(ac, false)
 	defer putFloats(row)
 	for r := 0; r < ar; r++ {
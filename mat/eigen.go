@@ -5,6 +5,11 @@
 package mat
 
 import (
+	"math"
+	"math/cmplx"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
 	"gonum.org/v1/gonum/lapack"
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
@@ -30,6 +35,8 @@ type EigenSym struct {
 // P is a matrix of the eigenvectors of A. If the vectors input argument is
 // false, the eigenvectors are not computed.
 //
+// The eigenvectors are computed using a divide and conquer algorithm.
+//
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, methods that require a successful factorization will panic.
 func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
@@ -43,11 +50,14 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	}
 	w := make([]float64, n)
 	work := []float64{0}
-	lapack64.Syev(jobz, sd.mat, w, work, -1)
+	iwork := []int{0}
+	lapack64.Syevd(jobz, sd.mat, w, work, -1, iwork, -1)
 
 	work = getFloats(int(work[0]), false)
-	ok = lapack64.Syev(jobz, sd.mat, w, work, len(work))
+	iwork = getInts(iwork[0], false)
+	ok = lapack64.Syevd(jobz, sd.mat, w, work, len(work), iwork, len(iwork))
 	putFloats(work)
+	putInts(iwork)
 	if !ok {
 		e.vectorsComputed = false
 		e.values = nil
@@ -60,9 +70,148 @@ func (e *EigenSym) Factorize(a Symmetric, vectors bool) (ok bool) {
 	return true
 }
 
+// FactorizeIndex computes the eigenvalues of the symmetric matrix a with
+// indices il through iu, inclusive, and optionally the corresponding
+// eigenvectors. The indices are zero-based and refer to the eigenvalues in
+// ascending order, so FactorizeIndex(a, 0, k-1, vectors) computes the k
+// smallest eigenvalues. If the vectors input argument is false, the
+// eigenvectors are not computed. FactorizeIndex will panic unless
+// 0 <= il <= iu < n.
+//
+// The eigenvalues are computed using the Multiple Relatively Robust
+// Representations algorithm, which is considerably faster than a full
+// decomposition when only a few eigenvalues are required.
+//
+// FactorizeIndex returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeIndex(a Symmetric, il, iu int, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	if il < 0 || iu < il || n <= iu {
+		panic(ErrIndexOutOfRange)
+	}
+	return e.factorizeRange(a, lapack.IndexEigenvalues, 0, 0, il, iu, vectors)
+}
+
+// FactorizeInterval computes the eigenvalues of the symmetric matrix a that lie
+// in the half-open interval (vl, vu], and optionally the corresponding
+// eigenvectors. If the vectors input argument is false, the eigenvectors are
+// not computed. FactorizeInterval will panic if vl >= vu.
+//
+// The eigenvalues are computed using the Multiple Relatively Robust
+// Representations algorithm, which is considerably faster than a full
+// decomposition when only a few eigenvalues are required.
+//
+// FactorizeInterval returns whether the decomposition succeeded. A successful
+// decomposition may contain no eigenvalues if none lie in the interval. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeInterval(a Symmetric, vl, vu float64, vectors bool) (ok bool) {
+	if vl >= vu {
+		panic("mat: invalid eigenvalue interval")
+	}
+	return e.factorizeRange(a, lapack.IntervalEigenvalues, vl, vu, 0, 0, vectors)
+}
+
+// factorizeRange computes the eigenvalues of a selected by rng and optionally
+// the corresponding eigenvectors using Syevr.
+func (e *EigenSym) factorizeRange(a Symmetric, rng lapack.EVRange, vl, vu float64, il, iu int, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	sd := NewSymDense(n, nil)
+	sd.CopySym(a)
+
+	jobz := lapack.EVJob(lapack.None)
+	if vectors {
+		jobz = lapack.ComputeEV
+	}
+	ncols := n
+	if rng == lapack.IndexEigenvalues {
+		ncols = iu - il + 1
+	}
+	var z blas64.General
+	if vectors {
+		z = blas64.General{
+			Rows:   n,
+			Cols:   ncols,
+			Stride: ncols,
+			Data:   make([]float64, n*ncols),
+		}
+	}
+	w := make([]float64, n)
+	isuppz := make([]int, 2*ncols)
+	work := []float64{0}
+	iwork := []int{0}
+	lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, isuppz, work, -1, iwork, -1)
+
+	work = getFloats(int(work[0]), false)
+	iwork = getInts(iwork[0], false)
+	m, ok := lapack64.Syevr(jobz, rng, sd.mat, vl, vu, il, iu, w, z, isuppz, work, len(work), iwork, len(iwork))
+	putFloats(work)
+	putInts(iwork)
+	if !ok {
+		e.vectorsComputed = false
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w[:m]
+	e.vectors = nil
+	if vectors {
+		e.vectors = NewDense(n, m, nil)
+		if m > 0 {
+			e.vectors.Copy(NewDense(n, ncols, z.Data).Slice(0, n, 0, m))
+		}
+	}
+	return true
+}
+
+// FactorizeTridiag computes the eigenvalue decomposition of the symmetric
+// tridiagonal matrix a. The sub-diagonal and the super-diagonal of a must be
+// equal, otherwise FactorizeTridiag will panic. If the vectors input argument
+// is false, the eigenvectors are not computed and the eigenvalues are found
+// in O(n^2) time.
+//
+// FactorizeTridiag returns whether the decomposition succeeded. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *EigenSym) FactorizeTridiag(a *Tridiag, vectors bool) (ok bool) {
+	n := a.n
+	for i, v := range a.dl {
+		if v != a.du[i] {
+			panic(badSymTridiag)
+		}
+	}
+	w := make([]float64, n)
+	copy(w, a.d)
+	offDiag := getFloats(n-1, false)
+	defer putFloats(offDiag)
+	copy(offDiag, a.dl)
+
+	var z *Dense
+	if vectors {
+		z = NewDense(n, n, nil)
+		work := getFloats(max(1, 2*n-2), false)
+		ok = lapack64.Steqr(lapack.TridiagEV, w, offDiag, z.mat, work)
+		putFloats(work)
+	} else {
+		ok = lapack64.Sterf(w, offDiag)
+	}
+	if !ok {
+		e.vectorsComputed = false
+		e.values = nil
+		e.vectors = nil
+		return false
+	}
+	e.vectorsComputed = vectors
+	e.values = w
+	e.vectors = z
+	return true
+}
+
 // succFact returns whether the receiver contains a successful factorization.
 func (e *EigenSym) succFact() bool {
-	return len(e.values) != 0
+	return e.values != nil
 }
 
 // Values extracts the eigenvalues of the factorized matrix. If dst is
@@ -99,10 +248,143 @@ func (m *Dense) EigenvectorsSym(e *EigenSym) {
 	if !e.vectorsComputed {
 		panic(badNoVect)
 	}
-	m.reuseAs(len(e.values), len(e.values))
+	r, c := e.vectors.Dims()
+	m.reuseAs(r, c)
 	m.Copy(e.vectors)
 }
 
+// GeneralizedEigenSym is a type for creating and using the eigenvalue
+// decomposition of a symmetric-definite generalized eigenproblem
+//  A * x = λ * B * x
+// where A is symmetric and B is symmetric positive definite.
+type GeneralizedEigenSym struct {
+	vectorsComputed bool
+
+	values  []float64
+	vectors *Dense
+}
+
+// Factorize computes the eigenvalues of the symmetric-definite generalized
+// eigenproblem
+//  A * x = λ * B * x
+// and optionally the eigenvectors, where a is symmetric and b is symmetric
+// positive definite. The eigenvalues are real. If the vectors input argument
+// is false, the eigenvectors are not computed.
+//
+// The problem is reduced to the standard symmetric eigenproblem
+//  C * y = λ * y,  C = U^-T * A * U^-1,  x = U^-1 * y
+// using the Cholesky factorization B = U^T * U.
+//
+// Factorize returns whether the decomposition succeeded. It returns false if
+// b is not positive definite or if the eigenvalue computation fails. If the
+// decomposition failed, methods that require a successful factorization will
+// panic.
+func (e *GeneralizedEigenSym) Factorize(a, b Symmetric, vectors bool) (ok bool) {
+	n := a.Symmetric()
+	if b.Symmetric() != n {
+		panic(ErrShape)
+	}
+	e.vectorsComputed = false
+	e.values = nil
+	e.vectors = nil
+
+	var chol Cholesky
+	if !chol.Factorize(b) {
+		return false
+	}
+	u := chol.chol.mat
+
+	// Form C = U^-T * A * U^-1. Only the upper triangle of C is
+	// referenced by Syev.
+	c := DenseCopyOf(a)
+	blas64.Trsm(blas.Left, blas.Trans, 1, u, c.mat)
+	blas64.Trsm(blas.Right, blas.NoTrans, 1, u, c.mat)
+	sym := blas64.Symmetric{
+		N:      n,
+		Stride: c.mat.Stride,
+		Data:   c.mat.Data,
+		Uplo:   blas.Upper,
+	}
+
+	jobz := lapack.EVJob(lapack.None)
+	if vectors {
+		jobz = lapack.ComputeEV
+	}
+	w := make([]float64, n)
+	work := []float64{0}
+	lapack64.Syev(jobz, sym, w, work, -1)
+
+	work = getFloats(int(work[0]), false)
+	ok = lapack64.Syev(jobz, sym, w, work, len(work))
+	putFloats(work)
+	if !ok {
+		return false
+	}
+	if vectors {
+		// Recover the eigenvectors of the generalized problem,
+		// x = U^-1 * y.
+		blas64.Trsm(blas.Left, blas.NoTrans, 1, u, c.mat)
+		e.vectors = c
+	}
+	e.vectorsComputed = vectors
+	e.values = w
+	return true
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *GeneralizedEigenSym) succFact() bool {
+	return e.values != nil
+}
+
+// Values extracts the eigenvalues of the factorized problem in ascending
+// order. If dst is non-nil, the values are stored in-place into dst. In this
+// case dst must have length n, otherwise Values will panic. If dst is nil,
+// then a new slice will be allocated of the proper length and filled with the
+// eigenvalues.
+//
+// Values panics if the decomposition was not successful.
+func (e *GeneralizedEigenSym) Values(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]float64, len(e.values))
+	}
+	if len(dst) != len(e.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, e.values)
+	return dst
+}
+
+// VectorsTo stores the eigenvectors of the decomposition into the columns of
+// dst. If dst is nil, a new matrix is allocated. The receiver dst must either
+// be empty or have dimensions n×n, otherwise VectorsTo will panic. VectorsTo
+// returns the matrix containing the eigenvectors.
+//
+// The eigenvectors are stored in the same order as their eigenvalues and are
+// normalized so that they are B-orthonormal, that is
+//  X^T * B * X = I.
+//
+// VectorsTo panics if the factorization was not successful or if the
+// decomposition did not compute the eigenvectors.
+func (e *GeneralizedEigenSym) VectorsTo(dst *Dense) *Dense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.vectorsComputed {
+		panic(badNoVect)
+	}
+	n := len(e.values)
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(e.vectors)
+	return dst
+}
+
 // Eigen is a type for creating and using the eigenvalue decomposition of a dense matrix.
 type Eigen struct {
 	n int // The size of the factorized matrix.
@@ -117,7 +399,7 @@ type Eigen struct {
 
 // succFact returns whether the receiver contains a successful factorization.
 func (e *Eigen) succFact() bool {
-	return len(e.values) != 0
+	return e.values != nil
 }
 
 // Factorize computes the eigenvalues of the square matrix a, and optionally
@@ -141,9 +423,6 @@ func (e *Eigen) succFact() bool {
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, methods that require a successful factorization will panic.
 func (e *Eigen) Factorize(a Matrix, left, right bool) (ok bool) {
-	// TODO(btracey): Change implementation to store VecDenses as a *CMat when
-	// #308 is resolved.
-
 	// Copy a because it is modified during the Lapack call.
 	r, c := a.Dims()
 	if r != c {
@@ -213,48 +492,296 @@ func (e *Eigen) Values(dst []complex128) []complex128 {
 	return dst
 }
 
-// Vectors returns the right eigenvectors of the decomposition. Vectors
-// will panic if the right eigenvectors were not computed during the factorization,
-// or if the factorization was not successful.
-//
-// The returned matrix will contain the right eigenvectors of the decomposition
-// in the columns of the n×n matrix in the same order as their eigenvalues.
-// If the j-th eigenvalue is real, then
-//  u_j = VL[:,j],
-//  v_j = VR[:,j],
-// and if it is not real, then j and j+1 form a complex conjugate pair and the
-// eigenvectors can be recovered as
-//  u_j     = VL[:,j] + i*VL[:,j+1],
-//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
-//  v_j     = VR[:,j] + i*VR[:,j+1],
-//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
-// where i is the imaginary unit. The computed eigenvectors are normalized to
-// have Euclidean norm equal to 1 and largest component real.
-//
-// BUG: This signature and behavior will change when issue #308 is resolved.
-func (e *Eigen) Vectors() *Dense {
+// VectorsTo stores the right eigenvectors of the decomposition into the columns
+// of dst. If dst is nil, a new matrix is allocated. The receiver dst must either
+// be empty or have dimensions n×n, otherwise VectorsTo will panic. VectorsTo
+// returns the matrix containing the eigenvectors.
+//
+// The eigenvectors are stored in the same order as their eigenvalues. The
+// eigenvectors of a complex conjugate pair of eigenvalues are themselves
+// complex conjugates. The computed eigenvectors are normalized to have
+// Euclidean norm equal to 1 and largest component real.
+//
+// VectorsTo will panic if the right eigenvectors were not computed during the
+// factorization, or if the factorization was not successful.
+func (e *Eigen) VectorsTo(dst *CDense) *CDense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.right {
+		panic(badNoVect)
+	}
+	return complexVectorsTo(dst, e.rVectors, e.values)
+}
+
+// LeftVectorsTo stores the left eigenvectors of the decomposition into the
+// columns of dst. If dst is nil, a new matrix is allocated. The receiver dst
+// must either be empty or have dimensions n×n, otherwise LeftVectorsTo will
+// panic. LeftVectorsTo returns the matrix containing the eigenvectors.
+//
+// The left eigenvector u_j of the j-th eigenvalue λ_j satisfies
+//  u_j^H * A = λ_j * u_j^H,
+// where u_j^H is the conjugate transpose of u_j. The eigenvectors are stored
+// and normalized as described in VectorsTo.
+//
+// LeftVectorsTo will panic if the left eigenvectors were not computed during
+// the factorization, or if the factorization was not successful.
+func (e *Eigen) LeftVectorsTo(dst *CDense) *CDense {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.left {
+		panic(badNoVect)
+	}
+	return complexVectorsTo(dst, e.lVectors, e.values)
+}
+
+// complexVectorsTo unpacks the eigenvectors held in the real LAPACK format
+// in d into the complex matrix dst. If the j-th eigenvalue is real, then the
+// eigenvector is held in d[:,j]. If it is not real, then j and j+1 form a
+// complex conjugate pair and the eigenvectors are
+//  v_j     = d[:,j] + i*d[:,j+1],
+//  v_{j+1} = d[:,j] - i*d[:,j+1].
+// Only the imaginary parts of values are used to identify the complex pairs.
+func complexVectorsTo(dst *CDense, d *Dense, values []complex128) *CDense {
+	n := len(values)
+	if dst == nil {
+		dst = NewCDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	for j := 0; j < n; j++ {
+		if imag(values[j]) == 0 {
+			for i := 0; i < n; i++ {
+				dst.set(i, j, complex(d.at(i, j), 0))
+			}
+			continue
+		}
+		for i := 0; i < n; i++ {
+			re := d.at(i, j)
+			im := d.at(i, j+1)
+			dst.set(i, j, complex(re, im))
+			dst.set(i, j+1, complex(re, -im))
+		}
+		j++
+	}
+	return dst
+}
+
+// ValueConditions returns the condition numbers of the eigenvalues of the
+// factorized matrix. The condition number of the simple eigenvalue λ_j is
+//  κ(λ_j) = ‖u_j‖ * ‖v_j‖ / |u_j^H * v_j|,
+// where u_j and v_j are the corresponding left and right eigenvectors. An
+// eigenvalue with a large condition number is sensitive to perturbations in
+// the factorized matrix; a perturbation of norm ε in A moves λ_j by at most
+// approximately κ(λ_j)*ε. The condition number of a multiple eigenvalue with
+// a defective eigenspace may be +Inf.
+//
+// If dst is non-nil, the condition numbers are stored in-place into dst. In
+// this case dst must have length n, otherwise ValueConditions will panic. If
+// dst is nil, then a new slice will be allocated of the proper length.
+//
+// ValueConditions panics if the factorization was not successful or if the
+// factorization did not compute both the left and right eigenvectors.
+func (e *Eigen) ValueConditions(dst []float64) []float64 {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if !e.left || !e.right {
+		panic(badNoVect)
+	}
+	if dst == nil {
+		dst = make([]float64, e.n)
+	}
+	if len(dst) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	u := e.LeftVectorsTo(nil)
+	v := e.VectorsTo(nil)
+	for j := 0; j < e.n; j++ {
+		var dot complex128
+		var nu, nv float64
+		for i := 0; i < e.n; i++ {
+			uij := u.at(i, j)
+			vij := v.at(i, j)
+			dot += cmplx.Conj(uij) * vij
+			nu += real(uij)*real(uij) + imag(uij)*imag(uij)
+			nv += real(vij)*real(vij) + imag(vij)*imag(vij)
+		}
+		dst[j] = math.Sqrt(nu) * math.Sqrt(nv) / cmplx.Abs(dot)
+	}
+	return dst
+}
+
+// GeneralizedEigen is a type for creating and using the eigenvalue
+// decomposition of a generalized eigenproblem for a pair of dense matrices
+//  A * x = λ * B * x
+// where neither A nor B need to be symmetric and B may be singular.
+type GeneralizedEigen struct {
+	n int // The size of the factorized matrices.
+
+	right bool // have the right eigenvectors been computed
+	left  bool // have the left eigenvectors been computed
+
+	alpha    []complex128
+	beta     []float64
+	rVectors *Dense
+	lVectors *Dense
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (e *GeneralizedEigen) succFact() bool {
+	return e.alpha != nil
+}
+
+// Factorize computes the generalized eigenvalues of the pair of square
+// matrices (a, b), and optionally the eigenvectors, using the QZ algorithm.
+//
+// A generalized eigenvalue of (A, B) is a scalar λ such that A - λ*B is
+// singular. It is represented as the pair (α, β) with λ = α / β. If B is
+// singular, β may be zero, in which case the eigenvalue is infinite.
+//
+// A right eigenvalue/eigenvector combination is defined by
+//  A * x_r = λ * B * x_r
+// and a left eigenvalue/eigenvector combination is defined by
+//  x_l^H * A = λ * x_l^H * B
+// where x_l^H is the conjugate transpose of x_l.
+//
+// In all cases, Factorize computes the eigenvalues of the pair. If right and
+// left are true, then the right and left eigenvectors will be computed,
+// respectively. Factorize panics if the input matrices are not square or do
+// not have the same size.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (e *GeneralizedEigen) Factorize(a, b Matrix, left, right bool) (ok bool) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrShape)
+	}
+	if br, bc := b.Dims(); br != r || bc != c {
+		panic(ErrShape)
+	}
+	// Copy a and b because they are modified during the Lapack call.
+	var sa, sb Dense
+	sa.Clone(a)
+	sb.Clone(b)
+
+	var vl, vr Dense
+	var jobvl lapack.LeftEVJob = lapack.None
+	var jobvr lapack.RightEVJob = lapack.None
+	if left {
+		vl = *NewDense(r, r, nil)
+		jobvl = lapack.ComputeLeftEV
+	}
+	if right {
+		vr = *NewDense(c, c, nil)
+		jobvr = lapack.ComputeRightEV
+	}
+
+	alphar := getFloats(c, false)
+	defer putFloats(alphar)
+	alphai := getFloats(c, false)
+	defer putFloats(alphai)
+	beta := make([]float64, c)
+
+	work := []float64{0}
+	lapack64.Ggev(jobvl, jobvr, sa.mat, sb.mat, alphar, alphai, beta, vl.mat, vr.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	first := lapack64.Ggev(jobvl, jobvr, sa.mat, sb.mat, alphar, alphai, beta, vl.mat, vr.mat, work, len(work))
+	putFloats(work)
+
+	if first != 0 {
+		e.alpha = nil
+		e.beta = nil
+		return false
+	}
+	e.n = r
+	e.right = right
+	e.left = left
+	e.lVectors = &vl
+	e.rVectors = &vr
+	alpha := make([]complex128, r)
+	for i, v := range alphar {
+		alpha[i] = complex(v, alphai[i])
+	}
+	e.alpha = alpha
+	e.beta = beta
+	return true
+}
+
+// Values extracts the generalized eigenvalues of the factorized pair as the
+// numerators alpha and the denominators beta of the ratios
+//  λ_j = alpha[j] / beta[j].
+// beta[j] is non-negative. If beta[j] is zero, λ_j is an infinite eigenvalue.
+// Complex conjugate pairs of eigenvalues appear consecutively with the
+// eigenvalue having the positive imaginary part first. The quotients may
+// easily over- or underflow, so it is left to the caller to form them.
+//
+// If alpha and beta are non-nil, the values are stored in-place into them.
+// In this case alpha and beta must have length n, otherwise Values will panic.
+// If they are nil, new slices will be allocated of the proper length and
+// filled with the eigenvalues.
+//
+// Values panics if the decomposition was not successful.
+func (e *GeneralizedEigen) Values(alpha []complex128, beta []float64) ([]complex128, []float64) {
+	if !e.succFact() {
+		panic(badFact)
+	}
+	if alpha == nil {
+		alpha = make([]complex128, e.n)
+	}
+	if beta == nil {
+		beta = make([]float64, e.n)
+	}
+	if len(alpha) != e.n || len(beta) != e.n {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(alpha, e.alpha)
+	copy(beta, e.beta)
+	return alpha, beta
+}
+
+// VectorsTo stores the right eigenvectors of the decomposition into the columns
+// of dst. If dst is nil, a new matrix is allocated. The receiver dst must either
+// be empty or have dimensions n×n, otherwise VectorsTo will panic. VectorsTo
+// returns the matrix containing the eigenvectors.
+//
+// The eigenvectors are stored in the same order as their eigenvalues. The
+// eigenvectors of a complex conjugate pair of eigenvalues are themselves
+// complex conjugates. Each eigenvector is scaled so that its largest
+// component has |real part| + |imaginary part| equal to 1.
+//
+// VectorsTo will panic if the right eigenvectors were not computed during the
+// factorization, or if the factorization was not successful.
+func (e *GeneralizedEigen) VectorsTo(dst *CDense) *CDense {
 	if !e.succFact() {
 		panic(badFact)
 	}
 	if !e.right {
 		panic(badNoVect)
 	}
-	return DenseCopyOf(e.rVectors)
+	return complexVectorsTo(dst, e.rVectors, e.alpha)
 }
 
-// LeftVectors returns the left eigenvectors of the decomposition. LeftVectors
-// will panic if the left eigenvectors were not computed during the factorization.
-// or if the factorization was not successful.
+// LeftVectorsTo stores the left eigenvectors of the decomposition into the
+// columns of dst. If dst is nil, a new matrix is allocated. The receiver dst
+// must either be empty or have dimensions n×n, otherwise LeftVectorsTo will
+// panic. LeftVectorsTo returns the matrix containing the eigenvectors.
 //
-// See the documentation in lapack64.Geev for the format of the vectors.
+// The left eigenvector u_j of the j-th eigenvalue λ_j satisfies
+//  u_j^H * A = λ_j * u_j^H * B,
+// where u_j^H is the conjugate transpose of u_j. The eigenvectors are stored
+// and normalized as described in VectorsTo.
 //
-// BUG: This signature and behavior will change when issue #308 is resolved.
-func (e *Eigen) LeftVectors() *Dense {
+// LeftVectorsTo will panic if the left eigenvectors were not computed during
+// the factorization, or if the factorization was not successful.
+func (e *GeneralizedEigen) LeftVectorsTo(dst *CDense) *CDense {
 	if !e.succFact() {
 		panic(badFact)
 	}
 	if !e.left {
 		panic(badNoVect)
 	}
-	return DenseCopyOf(e.lVectors)
+	return complexVectorsTo(dst, e.lVectors, e.alpha)
 }
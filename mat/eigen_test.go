@@ -5,6 +5,9 @@
 package mat
 
 import (
+	"fmt"
+	"math"
+	"math/cmplx"
 	"math/rand"
 	"testing"
 
@@ -16,8 +19,8 @@ func TestEigen(t *testing.T) {
 		a *Dense
 
 		values []complex128
-		left   *Dense
-		right  *Dense
+		left   *CDense
+		right  *CDense
 	}{
 		{
 			a: NewDense(3, 3, []float64{
@@ -26,12 +29,12 @@ func TestEigen(t *testing.T) {
 				0, 0, 1,
 			}),
 			values: []complex128{1, 1, 1},
-			left: NewDense(3, 3, []float64{
+			left: NewCDense(3, 3, []complex128{
 				1, 0, 0,
 				0, 1, 0,
 				0, 0, 1,
 			}),
-			right: NewDense(3, 3, []float64{
+			right: NewCDense(3, 3, []complex128{
 				1, 0, 0,
 				0, 1, 0,
 				0, 0, 1,
@@ -51,10 +54,10 @@ func TestEigen(t *testing.T) {
 		if !cmplxEqual(v1, test.values) {
 			t.Errorf("eigenvector mismatch. Case %v", i)
 		}
-		if !Equal(e1.LeftVectors(), test.left) {
+		if !CEqual(e1.LeftVectorsTo(nil), test.left) {
 			t.Errorf("left eigenvector mismatch. Case %v", i)
 		}
-		if !Equal(e1.Vectors(), test.right) {
+		if !CEqual(e1.VectorsTo(nil), test.right) {
 			t.Errorf("right eigenvector mismatch. Case %v", i)
 		}
 
@@ -68,15 +71,92 @@ func TestEigen(t *testing.T) {
 		if !cmplxEqual(v1, e4.Values(nil)) {
 			t.Errorf("eigenvector mismatch. Case %v", i)
 		}
-		if !Equal(e1.Vectors(), e2.Vectors()) {
+		if !CEqual(e1.VectorsTo(nil), e2.VectorsTo(nil)) {
 			t.Errorf("right eigenvector mismatch. Case %v", i)
 		}
-		if !Equal(e1.LeftVectors(), e3.LeftVectors()) {
+		if !CEqual(e1.LeftVectorsTo(nil), e3.LeftVectorsTo(nil)) {
 			t.Errorf("right eigenvector mismatch. Case %v", i)
 		}
+	}
+}
+
+func TestEigenComplexVectors(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		a := NewDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				a.Set(i, j, rnd.NormFloat64())
+			}
+		}
+		ac := NewCDense(n, n, nil)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				ac.Set(i, j, complex(a.At(i, j), 0))
+			}
+		}
+
+		var e Eigen
+		ok := e.Factorize(a, true, true)
+		if !ok {
+			t.Fatalf("n=%d: bad factorization", n)
+		}
+		values := e.Values(nil)
+		d := NewCDense(n, n, nil)
+		for i, v := range values {
+			d.Set(i, i, v)
+		}
+
+		// Check that A * V = V * D.
+		v := e.VectorsTo(nil)
+		var av, vd CDense
+		av.Mul(ac, v)
+		vd.Mul(v, d)
+		if !CEqualApprox(&av, &vd, 1e-10) {
+			t.Errorf("n=%d: A * V != V * D", n)
+		}
+
+		// Check that U^H * A = D * U^H.
+		u := NewCDense(n, n, nil)
+		e.LeftVectorsTo(u)
+		var ua, du CDense
+		ua.Mul(u.H(), ac)
+		du.Mul(d, u.H())
+		if !CEqualApprox(&ua, &du, 1e-10) {
+			t.Errorf("n=%d: U^H * A != D * U^H", n)
+		}
+
+		conds := e.ValueConditions(nil)
+		for j, c := range conds {
+			if c < 1-1e-12 {
+				t.Errorf("n=%d: condition number of eigenvalue %d less than one: %v", n, j, c)
+			}
+		}
+
+		if panicked, _ := panics(func() { e.VectorsTo(NewCDense(n+1, n+1, nil)) }); !panicked {
+			t.Errorf("n=%d: expected panic for mismatched destination", n)
+		}
+	}
+
+	// The condition numbers of the eigenvalues of
+	//  [1 t]
+	//  [0 2]
+	// are both sqrt(1+t^2).
+	for _, tv := range []float64{0, 0.5, 10, 1e4} {
+		var e Eigen
+		e.Factorize(NewDense(2, 2, []float64{1, tv, 0, 2}), true, true)
+		want := math.Sqrt(1 + tv*tv)
+		for j, c := range e.ValueConditions(nil) {
+			if math.Abs(c-want) > 1e-10*want {
+				t.Errorf("t=%v: unexpected condition number of eigenvalue %d: got %v want %v", tv, j, c, want)
+			}
+		}
+	}
 
-		// TODO(btracey): Also add in a test for correctness when #308 is
-		// resolved and we have a CMat.Mul().
+	var e Eigen
+	e.Factorize(NewDense(2, 2, []float64{1, 2, 3, 4}), false, true)
+	if panicked, _ := panics(func() { e.ValueConditions(nil) }); !panicked {
+		t.Errorf("expected panic for missing left eigenvectors")
 	}
 }
 
@@ -162,3 +242,442 @@ func TestSymEigen(t *testing.T) {
 		}
 	}
 }
+
+func TestSymEigenTridiag(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 70} {
+		a := randTridiag(n, rnd)
+		copy(a.du, a.dl)
+
+		var want EigenSym
+		if !want.Factorize(NewSymDense(n, DenseCopyOf(a).mat.Data), false) {
+			t.Fatalf("n=%d: bad test", n)
+		}
+
+		var es EigenSym
+		ok := es.FactorizeTridiag(a, true)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		if !floats.EqualApprox(es.values, want.values, 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch", n)
+		}
+		if !isOrthonormal(es.vectors, 1e-12) {
+			t.Errorf("n=%d: eigenvectors not orthonormal", n)
+		}
+		for i := 0; i < n; i++ {
+			v := NewVecDense(n, Col(nil, i, es.vectors))
+			var m VecDense
+			m.MulVec(a, v)
+
+			var scal VecDense
+			scal.ScaleVec(es.values[i], v)
+
+			if !EqualApprox(&m, &scal, 1e-12) {
+				t.Errorf("n=%d: eigenvalue %d does not match", n, i)
+			}
+		}
+
+		var es2 EigenSym
+		es2.FactorizeTridiag(a, false)
+		if !floats.EqualApprox(es2.values, es.values, 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch when no vectors computed", n)
+		}
+		if panicked, _ := panics(func() { new(Dense).EigenvectorsSym(&es2) }); !panicked {
+			t.Errorf("n=%d: expected panic extracting eigenvectors that were not computed", n)
+		}
+	}
+
+	a := NewTridiag(3, []float64{1, 2}, []float64{1, 2, 3}, []float64{1, 3})
+	var es EigenSym
+	if panicked, _ := panics(func() { es.FactorizeTridiag(a, false) }); !panicked {
+		t.Errorf("expected panic for non-symmetric tridiagonal matrix")
+	}
+}
+
+func TestSymEigenRange(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 70} {
+		a := make([]float64, n*n)
+		for i := range a {
+			a[i] = rnd.NormFloat64()
+		}
+		s := NewSymDense(n, a)
+
+		var want EigenSym
+		if !want.Factorize(s, false) {
+			t.Fatalf("n=%d: bad test", n)
+		}
+
+		for _, test := range []struct {
+			il, iu int
+		}{
+			{0, n - 1},
+			{0, 0},
+			{n - 1, n - 1},
+			{n / 4, n / 2},
+		} {
+			var es EigenSym
+			ok := es.FactorizeIndex(s, test.il, test.iu, true)
+			if !ok {
+				t.Errorf("n=%d,il=%d,iu=%d: unexpected factorization failure", n, test.il, test.iu)
+				continue
+			}
+			checkSymEigenRange(t, s, &es, want.values[test.il:test.iu+1], fmt.Sprintf("n=%d,il=%d,iu=%d", n, test.il, test.iu))
+
+			var es2 EigenSym
+			es2.FactorizeIndex(s, test.il, test.iu, false)
+			if !floats.EqualApprox(es2.values, es.values, 1e-12) {
+				t.Errorf("n=%d,il=%d,iu=%d: eigenvalue mismatch when no vectors computed", n, test.il, test.iu)
+			}
+		}
+
+		// Choose interval bounds between adjacent eigenvalues so that the
+		// expected set of eigenvalues is unambiguous.
+		bound := func(i int) float64 {
+			switch {
+			case i == 0:
+				return want.values[0] - 1
+			case i == n:
+				return want.values[n-1] + 1
+			}
+			return (want.values[i-1] + want.values[i]) / 2
+		}
+		for _, test := range []struct {
+			lo, hi int
+		}{
+			{0, n},
+			{0, 1},
+			{n / 4, n/2 + 1},
+			{n, n},
+		} {
+			vl := bound(test.lo)
+			vu := bound(test.hi)
+			if test.lo == test.hi {
+				vu = vl + 1
+			}
+			var es EigenSym
+			ok := es.FactorizeInterval(s, vl, vu, true)
+			if !ok {
+				t.Errorf("n=%d,vl=%v,vu=%v: unexpected factorization failure", n, vl, vu)
+				continue
+			}
+			checkSymEigenRange(t, s, &es, want.values[test.lo:test.hi], fmt.Sprintf("n=%d,vl=%v,vu=%v", n, vl, vu))
+		}
+	}
+
+	// Intervals containing no eigenvalues of matrices whose tridiagonal
+	// form splits into several blocks.
+	for _, test := range []struct {
+		s      *SymDense
+		vl, vu float64
+	}{
+		{
+			s: NewSymDense(3, []float64{
+				2, 0, 0,
+				0, 3, 0,
+				0, 0, 4,
+			}),
+			vl: 2.2, vu: 2.8,
+		},
+		{
+			s: NewSymDense(4, []float64{
+				1, 1, 0, 0,
+				1, 1, 0, 0,
+				0, 0, 5, 2,
+				0, 0, 2, 5,
+			}),
+			vl: 5.5, vu: 6.5,
+		},
+		{
+			s: NewSymDense(4, []float64{
+				1, 1, 0, 0,
+				1, 1, 0, 0,
+				0, 0, 5, 2,
+				0, 0, 2, 5,
+			}),
+			vl: 100, vu: 200,
+		},
+	} {
+		n := test.s.Symmetric()
+		name := fmt.Sprintf("split,n=%d,vl=%v,vu=%v", n, test.vl, test.vu)
+		var es EigenSym
+		if !es.FactorizeInterval(test.s, test.vl, test.vu, true) {
+			t.Errorf("%s: unexpected factorization failure", name)
+		} else {
+			checkSymEigenRange(t, test.s, &es, nil, name)
+		}
+		var es2 EigenSym
+		if !es2.FactorizeInterval(test.s, test.vl, test.vu, false) {
+			t.Errorf("%s: unexpected factorization failure when no vectors computed", name)
+		} else if len(es2.Values(nil)) != 0 {
+			t.Errorf("%s: unexpected eigenvalues when no vectors computed", name)
+		}
+	}
+
+	s := NewSymDense(3, []float64{8, 2, 4, 2, 6, 10, 4, 10, 5})
+	var es EigenSym
+	if panicked, _ := panics(func() { es.FactorizeIndex(s, 2, 1, true) }); !panicked {
+		t.Errorf("expected panic for il > iu")
+	}
+	if panicked, _ := panics(func() { es.FactorizeIndex(s, 0, 3, true) }); !panicked {
+		t.Errorf("expected panic for iu out of range")
+	}
+	if panicked, _ := panics(func() { es.FactorizeInterval(s, 1, 1, true) }); !panicked {
+		t.Errorf("expected panic for empty interval")
+	}
+}
+
+func checkSymEigenRange(t *testing.T, s *SymDense, es *EigenSym, want []float64, name string) {
+	n := s.Symmetric()
+	values := es.Values(nil)
+	if len(values) != len(want) {
+		t.Errorf("%s: unexpected number of eigenvalues: got %d, want %d", name, len(values), len(want))
+		return
+	}
+	if !floats.EqualApprox(values, want, 1e-12) {
+		t.Errorf("%s: eigenvalue mismatch", name)
+	}
+	var vecs Dense
+	vecs.EigenvectorsSym(es)
+	r, c := vecs.Dims()
+	if r != n || c != len(want) {
+		t.Errorf("%s: unexpected eigenvector dimensions: got %d×%d, want %d×%d", name, r, c, n, len(want))
+		return
+	}
+	for j := 0; j < c; j++ {
+		v := NewVecDense(n, Col(nil, j, &vecs))
+		if math.Abs(Norm(v, 2)-1) > 1e-12 {
+			t.Errorf("%s: eigenvector %d not normalized", name, j)
+		}
+		for k := 0; k < j; k++ {
+			u := NewVecDense(n, Col(nil, k, &vecs))
+			if math.Abs(Dot(u, v)) > 1e-12 {
+				t.Errorf("%s: eigenvectors %d and %d not orthogonal", name, k, j)
+			}
+		}
+
+		var m VecDense
+		m.MulVec(s, v)
+		var scal VecDense
+		scal.ScaleVec(values[j], v)
+		if !EqualApprox(&m, &scal, 1e-12) {
+			t.Errorf("%s: eigenvalue %d does not match", name, j)
+		}
+	}
+}
+
+func TestGeneralizedEigenSym(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		a := randSymDense(n, rnd)
+		// B = M^T*M + I is positive definite.
+		m := NewDense(n, n, nil)
+		for i := range m.mat.Data {
+			m.mat.Data[i] = rnd.NormFloat64()
+		}
+		b := NewSymDense(n, nil)
+		b.SymOuterK(1, m.T())
+		for i := 0; i < n; i++ {
+			b.SetSym(i, i, b.At(i, i)+1)
+		}
+
+		var ge GeneralizedEigenSym
+		ok := ge.Factorize(a, b, true)
+		if !ok {
+			t.Errorf("n=%d: unexpected factorization failure", n)
+			continue
+		}
+		values := ge.Values(nil)
+		for i := 1; i < n; i++ {
+			if values[i] < values[i-1] {
+				t.Errorf("n=%d: eigenvalues not in ascending order", n)
+				break
+			}
+		}
+		x := ge.VectorsTo(nil)
+
+		// Check that A*X = B*X*Λ.
+		var ax, bx Dense
+		ax.Mul(a, x)
+		bx.Mul(b, x)
+		for j := 0; j < n; j++ {
+			col := bx.ColView(j)
+			col.ScaleVec(values[j], col)
+		}
+		if !EqualApprox(&ax, &bx, 1e-10) {
+			t.Errorf("n=%d: A*X != B*X*Λ", n)
+		}
+
+		// Check that the eigenvectors are B-orthonormal.
+		var xtbx Dense
+		xtbx.Product(x.T(), b, x)
+		if !EqualApprox(&xtbx, eye(n), 1e-10) {
+			t.Errorf("n=%d: eigenvectors are not B-orthonormal", n)
+		}
+
+		var ge2 GeneralizedEigenSym
+		ge2.Factorize(a, b, false)
+		if !floats.EqualApprox(ge2.Values(nil), values, 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch when no vectors computed", n)
+		}
+		if panicked, _ := panics(func() { ge2.VectorsTo(nil) }); !panicked {
+			t.Errorf("n=%d: expected panic extracting eigenvectors that were not computed", n)
+		}
+
+		// With B = I the problem is the standard eigenproblem.
+		var es EigenSym
+		es.Factorize(a, false)
+		id := NewSymDense(n, nil)
+		for i := 0; i < n; i++ {
+			id.SetSym(i, i, 1)
+		}
+		ge2.Factorize(a, id, false)
+		if !floats.EqualApprox(ge2.Values(nil), es.Values(nil), 1e-12) {
+			t.Errorf("n=%d: eigenvalue mismatch with identity B", n)
+		}
+	}
+
+	// B is not positive definite.
+	a := NewSymDense(2, []float64{1, 0, 0, 1})
+	b := NewSymDense(2, []float64{1, 2, 2, 1})
+	var ge GeneralizedEigenSym
+	if ge.Factorize(a, b, true) {
+		t.Errorf("unexpected success with indefinite B")
+	}
+	if panicked, _ := panics(func() { ge.Values(nil) }); !panicked {
+		t.Errorf("expected panic using failed factorization")
+	}
+}
+
+func TestGeneralizedEigen(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 30} {
+		for _, singular := range []bool{false, true} {
+			a := NewDense(n, n, nil)
+			b := NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					a.Set(i, j, rnd.NormFloat64())
+					if !singular || i != n-1 {
+						b.Set(i, j, rnd.NormFloat64())
+					}
+				}
+			}
+			ac := NewCDense(n, n, nil)
+			bc := NewCDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					ac.Set(i, j, complex(a.At(i, j), 0))
+					bc.Set(i, j, complex(b.At(i, j), 0))
+				}
+			}
+
+			var ge GeneralizedEigen
+			ok := ge.Factorize(a, b, true, true)
+			if !ok {
+				t.Fatalf("n=%d, singular=%t: bad factorization", n, singular)
+			}
+			alpha, beta := ge.Values(nil, nil)
+			var ninf int
+			for j, bv := range beta {
+				if bv < 0 {
+					t.Errorf("n=%d, singular=%t: negative beta[%d]", n, singular, j)
+				}
+				if bv <= 1e-12*cmplx.Abs(alpha[j]) {
+					ninf++
+				}
+			}
+			if singular && ninf == 0 {
+				t.Errorf("n=%d: no infinite eigenvalue for singular B", n)
+			}
+			da := NewCDense(n, n, nil)
+			db := NewCDense(n, n, nil)
+			for i := range alpha {
+				da.Set(i, i, alpha[i])
+				db.Set(i, i, complex(beta[i], 0))
+			}
+
+			// Check that A * V * D_β = B * V * D_α.
+			v := ge.VectorsTo(nil)
+			var av, bv, avb, bva CDense
+			av.Mul(ac, v)
+			bv.Mul(bc, v)
+			avb.Mul(&av, db)
+			bva.Mul(&bv, da)
+			if !CEqualApprox(&avb, &bva, 1e-10) {
+				t.Errorf("n=%d, singular=%t: A * V * D_β != B * V * D_α", n, singular)
+			}
+
+			// Check that D_β * U^H * A = D_α * U^H * B.
+			u := NewCDense(n, n, nil)
+			ge.LeftVectorsTo(u)
+			var ua, ub, bua, aub CDense
+			ua.Mul(u.H(), ac)
+			ub.Mul(u.H(), bc)
+			bua.Mul(db, &ua)
+			aub.Mul(da, &ub)
+			if !CEqualApprox(&bua, &aub, 1e-10) {
+				t.Errorf("n=%d, singular=%t: D_β * U^H * A != D_α * U^H * B", n, singular)
+			}
+
+			var ge2 GeneralizedEigen
+			ge2.Factorize(a, b, false, false)
+			if panicked, _ := panics(func() { ge2.VectorsTo(nil) }); !panicked {
+				t.Errorf("n=%d: expected panic extracting eigenvectors that were not computed", n)
+			}
+			if panicked, _ := panics(func() { ge.Values(make([]complex128, n+1), nil) }); !panicked {
+				t.Errorf("n=%d: expected panic for mismatched destination", n)
+			}
+		}
+	}
+
+	// With B = I the eigenvalues are the same as of the standard
+	// eigenproblem.
+	a := NewDense(3, 3, []float64{
+		1, 2, 0,
+		-2, 1, 3,
+		0.5, 0, 4,
+	})
+	var e Eigen
+	e.Factorize(a, false, false)
+	var ge GeneralizedEigen
+	ge.Factorize(a, eye(3), false, false)
+	alpha, beta := ge.Values(nil, nil)
+	for i, v := range e.Values(nil) {
+		if cmplx.Abs(alpha[i]/complex(beta[i], 0)-v) > 1e-12 {
+			t.Errorf("eigenvalue mismatch with identity B: got %v want %v", alpha[i]/complex(beta[i], 0), v)
+		}
+	}
+}
+
+func TestEigenUnfactorized(t *testing.T) {
+	// A zero value decomposition does not contain a successful
+	// factorization, while a successful factorization may contain no
+	// eigenvalues.
+	for _, test := range []struct {
+		name string
+		fn   func()
+	}{
+		{name: "EigenSym", fn: func() { var e EigenSym; e.Values(nil) }},
+		{name: "GeneralizedEigenSym", fn: func() { var e GeneralizedEigenSym; e.Values(nil) }},
+		{name: "Eigen", fn: func() { var e Eigen; e.Values(nil) }},
+		{name: "GeneralizedEigen", fn: func() { var e GeneralizedEigen; e.Values(nil, nil) }},
+		{name: "Schur", fn: func() { var s Schur; s.Values(nil) }},
+	} {
+		panicked, message := panics(test.fn)
+		if !panicked || message != badFact {
+			t.Errorf("%s: expected panic %q for unfactorized receiver, got %q", test.name, badFact, message)
+		}
+	}
+
+	s := NewSymDense(2, []float64{1, 0, 0, 2})
+	var es EigenSym
+	if !es.FactorizeInterval(s, 5, 6, false) {
+		t.Fatalf("unexpected factorization failure")
+	}
+	if panicked, message := panics(func() { es.Values(nil) }); panicked {
+		t.Errorf("unexpected panic for empty factorization: %s", message)
+	}
+}
@@ -133,6 +133,8 @@ var (
 	ErrSliceLengthMismatch = Error{"matrix: input slice length mismatch"}
 	ErrNotPSD              = Error{"matrix: input not positive symmetric definite"}
 	ErrFailedEigen         = Error{"matrix: eigendecomposition not successful"}
+	ErrNegativeEigenvalue  = Error{"matrix: matrix has eigenvalue on the negative real axis"}
+	ErrSparseIndex         = Error{"matrix: malformed sparse index"}
 )
 
 // ErrorStack represents matrix handling errors that have been recovered by Maybe wrappers.
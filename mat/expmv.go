@@ -0,0 +1,393 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/floats"
+)
+
+// expmvTheta holds, for a truncated Taylor series of degree m, the largest
+// value θ_m of t*‖A‖_1 for which the backward error of the series is bounded
+// by the unit roundoff. The values are from table 3.1 of Al-Mohy and Higham
+// (2011).
+var expmvTheta = [...]struct {
+	m     int
+	theta float64
+}{
+	{1, 2.29e-16}, {2, 2.58e-8}, {3, 1.39e-5}, {4, 3.40e-4}, {5, 2.40e-3},
+	{6, 9.07e-3}, {7, 2.38e-2}, {8, 5.00e-2}, {9, 8.96e-2}, {10, 1.44e-1},
+	{11, 2.14e-1}, {12, 3.00e-1}, {13, 4.00e-1}, {14, 5.14e-1}, {15, 6.41e-1},
+	{16, 7.81e-1}, {17, 9.31e-1}, {18, 1.09}, {19, 1.26}, {20, 1.44},
+	{21, 1.62}, {22, 1.82}, {23, 2.01}, {24, 2.22}, {25, 2.43},
+	{26, 2.64}, {27, 2.86}, {28, 3.08}, {29, 3.31}, {30, 3.54},
+	{35, 4.7}, {40, 6.0}, {45, 7.2}, {50, 8.5}, {55, 9.9},
+}
+
+// ExpMulVec calculates the action of the matrix exponential of t*a on the
+// vector b, e^{t*a} * b, without forming e^{t*a}, and places the result in
+// the receiver. ExpMulVec will panic with ErrSquare if a is not square and
+// with ErrShape if the length of b does not match the size of a.
+//
+// ExpMulVec uses the truncated Taylor series method of A. H. Al-Mohy and
+// N. J. Higham, Computing the action of the matrix exponential, with an
+// application to exponential integrators, SIAM J. Sci. Comput. 33 (2011)
+// 488–511. The matrix a is accessed only through matrix-vector products, so
+// sparse matrices and implicit operators implementing Matrix are handled
+// efficiently, with the exception that the diagonal elements of a are read
+// once to compute its trace. The 1-norm of a is estimated from
+// matrix-vector products with a and its transpose unless a is sparse.
+func (v *VecDense) ExpMulVec(t float64, a Matrix, b *VecDense) {
+	n := checkExpMulVec(a, b)
+	mu, norm := expmvShift(a)
+
+	f := NewVecDense(n, nil)
+	expmv(f, t, a, mu, norm, b)
+	v.reuseAs(n)
+	v.CopyVec(f)
+}
+
+// ExpMulVecTimes calculates the action of the matrix exponential of t*a on
+// the vector b for each t in times, and places the results in the columns
+// of the receiver, so that column j holds e^{times[j]*a} * b. ExpMulVecTimes
+// will panic with ErrSquare if a is not square and with ErrShape if the
+// length of b does not match the size of a.
+//
+// Each result is propagated from the previous one by a step of length
+// times[j]-times[j-1], so the computation is most efficient when times is
+// sorted in increasing order. See ExpMulVec for details of the method.
+func (m *Dense) ExpMulVecTimes(times []float64, a Matrix, b *VecDense) {
+	n := checkExpMulVec(a, b)
+	mu, norm := expmvShift(a)
+
+	m.reuseAs(n, len(times))
+	x := NewVecDense(n, nil)
+	x.CopyVec(b)
+	f := NewVecDense(n, nil)
+	var prev float64
+	for j, t := range times {
+		expmv(f, t-prev, a, mu, norm, x)
+		x, f = f, x
+		m.ColView(j).CopyVec(x)
+		prev = t
+	}
+}
+
+// checkExpMulVec checks the shapes of the arguments to ExpMulVec and returns
+// the size of a.
+func checkExpMulVec(a Matrix, b *VecDense) int {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	if b.Len() != r {
+		panic(ErrShape)
+	}
+	return r
+}
+
+// expmvShift returns the shift mu = trace(a)/n and the 1-norm of a-mu*I.
+// Only the diagonal elements of a are read. If a is sparse, the 1-norm is
+// computed from its non-zero elements, and if shifting does not reduce the
+// 1-norm of a, mu is zero and the 1-norm of a is returned. Otherwise the
+// 1-norm of a-mu*I is estimated from matrix-vector products by expmvNormEst.
+func expmvShift(a Matrix) (mu, norm float64) {
+	n, _ := a.Dims()
+	diag := make([]float64, n)
+	var trace float64
+	for i := range diag {
+		diag[i] = a.At(i, i)
+		trace += diag[i]
+	}
+	mu = trace / float64(n)
+
+	aU, aTrans := untranspose(a)
+	if !isSparse(aU) {
+		return mu, expmvNormEst(a, mu)
+	}
+	colSum := make([]float64, n)
+	doNonZeroTrans(aU, aTrans, func(_, j int, v float64) {
+		colSum[j] += math.Abs(v)
+	})
+	var shifted float64
+	for j, v := range colSum {
+		d := diag[j]
+		norm = math.Max(norm, v)
+		shifted = math.Max(shifted, v-math.Abs(d)+math.Abs(d-mu))
+	}
+	if shifted < norm {
+		return mu, shifted
+	}
+	return 0, norm
+}
+
+// expmvNormEst returns an estimate of the 1-norm of a-mu*I computed only from
+// products with a and its transpose, using the block 1-norm estimator of
+// N. J. Higham and F. Tisseur, A block algorithm for matrix 1-norm estimation,
+// with an application to 1-norm pseudospectra, SIAM J. Matrix Anal. Appl. 21
+// (2000) 1185–1201, with two columns. The estimate is a lower bound on the
+// 1-norm and is exact in most cases.
+func expmvNormEst(a Matrix, mu float64) float64 {
+	const (
+		t     = 2
+		itmax = 5
+	)
+	n, _ := a.Dims()
+
+	// mul stores op(a-mu*I) * x into dst.
+	mul := func(dst *VecDense, trans bool, x *VecDense) {
+		if trans {
+			dst.MulVec(a.T(), x)
+		} else {
+			dst.MulVec(a, x)
+		}
+		if mu != 0 {
+			dst.AddScaledVec(dst, -mu, x)
+		}
+	}
+
+	if n <= 2*t {
+		// Small matrices are handled exactly using the columns of
+		// the identity.
+		var norm float64
+		e := NewVecDense(n, nil)
+		y := NewVecDense(n, nil)
+		for j := 0; j < n; j++ {
+			e.SetVec(j, 1)
+			mul(y, false, e)
+			e.SetVec(j, 0)
+			norm = math.Max(norm, Norm(y, 1))
+		}
+		return norm
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	x := make([]*VecDense, t)
+	y := make([]*VecDense, t)
+	s := make([]*VecDense, t)
+	sOld := make([]*VecDense, t)
+	for j := range x {
+		x[j] = NewVecDense(n, nil)
+		y[j] = NewVecDense(n, nil)
+		s[j] = NewVecDense(n, nil)
+		sOld[j] = NewVecDense(n, nil)
+	}
+
+	// The starting block has the vector of ones as its first column and
+	// random ±1 vectors not parallel to it as the others, all scaled to
+	// unit 1-norm.
+	for i := 0; i < n; i++ {
+		x[0].SetVec(i, 1)
+	}
+	for j := 1; j < t; j++ {
+		for {
+			randomSignVec(x[j], rnd)
+			if !parallelSignVec(x[j], x[:j]) {
+				break
+			}
+		}
+	}
+	for j := range x {
+		x[j].ScaleVec(1/float64(n), x[j])
+	}
+
+	visited := make([]bool, n)
+	ind := make([]int, t)
+	h := make([]float64, n)
+	z := NewVecDense(n, nil)
+	var est, estOld float64
+	indBest := -1
+	for k := 1; ; k++ {
+		est = 0
+		best := 0
+		for j := range x {
+			mul(y[j], false, x[j])
+			if v := Norm(y[j], 1); v > est {
+				est = v
+				best = j
+			}
+		}
+		if k >= 2 && est <= estOld {
+			return estOld
+		}
+		if k >= 2 {
+			indBest = ind[best]
+		}
+		estOld = est
+		if k > itmax {
+			return est
+		}
+
+		s, sOld = sOld, s
+		for j := range s {
+			for i := 0; i < n; i++ {
+				if y[j].at(i) < 0 {
+					s[j].setVec(i, -1)
+				} else {
+					s[j].setVec(i, 1)
+				}
+			}
+		}
+		if k >= 2 {
+			converged := true
+			for j := range s {
+				if !parallelSignVec(s[j], sOld) {
+					converged = false
+					break
+				}
+			}
+			if converged {
+				return est
+			}
+		}
+		// Replace columns of S that are parallel to earlier columns
+		// of S or to columns of the previous S by random ±1 vectors.
+		for j := 1; j < t; j++ {
+			for parallelSignVec(s[j], s[:j]) || (k >= 2 && parallelSignVec(s[j], sOld)) {
+				randomSignVec(s[j], rnd)
+			}
+		}
+
+		for i := range h {
+			h[i] = 0
+		}
+		for j := range s {
+			mul(z, true, s[j])
+			for i := range h {
+				h[i] = math.Max(h[i], math.Abs(z.at(i)))
+			}
+		}
+		if k >= 2 && floats.Max(h) == h[indBest] {
+			return est
+		}
+
+		// Stop if the indices with the largest values of h have all
+		// been used, otherwise use as the next block the unit vectors
+		// for the largest values of h that have not been used yet.
+		largestIndices(ind, h, nil)
+		used := true
+		for _, i := range ind {
+			used = used && visited[i]
+		}
+		if used {
+			return est
+		}
+		largestIndices(ind, h, visited)
+		if ind[t-1] < 0 {
+			// All unit vectors have been used.
+			return est
+		}
+		for j, i := range ind {
+			visited[i] = true
+			for l := 0; l < n; l++ {
+				x[j].setVec(l, 0)
+			}
+			x[j].setVec(i, 1)
+		}
+	}
+}
+
+// largestIndices stores into ind the indices of the len(ind) largest
+// elements of h in decreasing order, skipping the indices i for which skip[i]
+// is true if skip is not nil. Elements of ind for which no index remains are
+// set to -1.
+func largestIndices(ind []int, h []float64, skip []bool) {
+	for j := range ind {
+		ind[j] = -1
+	outer:
+		for i, v := range h {
+			if skip != nil && skip[i] {
+				continue
+			}
+			for _, prev := range ind[:j] {
+				if i == prev {
+					continue outer
+				}
+			}
+			if ind[j] < 0 || v > h[ind[j]] {
+				ind[j] = i
+			}
+		}
+	}
+}
+
+// randomSignVec fills v with random ±1 values.
+func randomSignVec(v *VecDense, rnd *rand.Rand) {
+	for i := 0; i < v.Len(); i++ {
+		if rnd.Intn(2) == 0 {
+			v.setVec(i, -1)
+		} else {
+			v.setVec(i, 1)
+		}
+	}
+}
+
+// parallelSignVec returns whether the ±1 vector v is parallel to any of the
+// ±1 vectors in w.
+func parallelSignVec(v *VecDense, w []*VecDense) bool {
+	for _, u := range w {
+		if math.Abs(Dot(v, u)) == float64(v.Len()) {
+			return true
+		}
+	}
+	return false
+}
+
+// expmvDegree returns the degree m of the truncated Taylor series and the
+// number of steps s that minimize the number m*s of matrix-vector products
+// needed to evaluate e^{t*A} * b when tnorm = |t|*‖A‖_1.
+func expmvDegree(tnorm float64) (m, s int) {
+	if tnorm == 0 {
+		return 0, 1
+	}
+	cost := math.Inf(1)
+	for _, th := range expmvTheta {
+		steps := math.Max(1, math.Ceil(tnorm/th.theta))
+		if c := float64(th.m) * steps; c < cost {
+			cost = c
+			m = th.m
+			s = int(steps)
+		}
+	}
+	return m, s
+}
+
+// expmv computes e^{t*a} * b into f, where mu is the shift applied to a and
+// norm is the 1-norm of a-mu*I. f and b must not be the same vector.
+func expmv(f *VecDense, t float64, a Matrix, mu, norm float64, b *VecDense) {
+	f.CopyVec(b)
+	if t == 0 {
+		return
+	}
+	m, s := expmvDegree(math.Abs(t) * norm)
+	eta := math.Exp(t * mu / float64(s))
+
+	n := b.Len()
+	w := NewVecDense(n, nil)
+	w.CopyVec(b)
+	aw := NewVecDense(n, nil)
+	for i := 0; i < s; i++ {
+		c1 := Norm(w, math.Inf(1))
+		for j := 1; j <= m; j++ {
+			aw.MulVec(a, w)
+			if mu != 0 {
+				aw.AddScaledVec(aw, -mu, w)
+			}
+			w.ScaleVec(t/float64(s*j), aw)
+			c2 := Norm(w, math.Inf(1))
+			f.AddVec(f, w)
+			// Terminate the series early if the last two
+			// terms are negligible.
+			if c1+c2 <= dlamchE*Norm(f, math.Inf(1)) {
+				break
+			}
+			c1 = c2
+		}
+		f.ScaleVec(eta, f)
+		w.CopyVec(f)
+	}
+}
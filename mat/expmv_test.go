@@ -0,0 +1,186 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestExpMulVec(t *testing.T) {
+	// The exponential of a rotation generator.
+	rot := NewDense(2, 2, []float64{0, -1, 1, 0})
+	e1 := NewVecDense(2, []float64{1, 0})
+	for _, tm := range []float64{0, 0.5, 1, -2, 10, 100} {
+		var got VecDense
+		got.ExpMulVec(tm, rot, e1)
+		want := NewVecDense(2, []float64{math.Cos(tm), math.Sin(tm)})
+		if !EqualApprox(&got, want, 1e-12*math.Max(1, math.Abs(tm))) {
+			t.Errorf("unexpected result for rotation by %v: got %v, want %v", tm, got.RawVector().Data, want.RawVector().Data)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10, 30} {
+		a := randNormDense(n, n, rnd)
+		b := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			b.SetVec(i, rnd.NormFloat64())
+		}
+		for _, tm := range []float64{0.1, 1, -1.5} {
+			var ta Dense
+			ta.Scale(tm, a)
+			e := expSquared(&ta)
+			var want VecDense
+			want.MulVec(e, b)
+
+			var got VecDense
+			got.ExpMulVec(tm, a, b)
+			tol := 1e-10 * math.Max(1, Norm(&want, math.Inf(1)))
+			if !EqualApprox(&got, &want, tol) {
+				t.Errorf("n=%d, t=%v: unexpected result for Dense", n, tm)
+			}
+
+			// The same product through a sparse matrix and a
+			// transpose.
+			got.ExpMulVec(tm, CSRCopyOf(a), b)
+			if !EqualApprox(&got, &want, tol) {
+				t.Errorf("n=%d, t=%v: unexpected result for CSR", n, tm)
+			}
+			want.MulVec(e.T(), b)
+			got.ExpMulVec(tm, a.T(), b)
+			if !EqualApprox(&got, &want, tol) {
+				t.Errorf("n=%d, t=%v: unexpected result for transpose", n, tm)
+			}
+		}
+	}
+}
+
+// expSquared returns e^a computed by Dense.Exp with additional scaling
+// and squaring, which is accurate for larger norms of a.
+func expSquared(a Matrix) *Dense {
+	const squarings = 8
+	var e Dense
+	e.Scale(math.Ldexp(1, -squarings), a)
+	e.Exp(&e)
+	for i := 0; i < squarings; i++ {
+		e.Mul(&e, &e)
+	}
+	return &e
+}
+
+func TestExpMulVecTimes(t *testing.T) {
+	// Transition probabilities of a continuous-time Markov chain with
+	// generator Q evolve as p(t)^T = p(0)^T * e^{t*Q}.
+	const n = 20
+	rnd := rand.New(rand.NewSource(1))
+	trip := NewTriplet(n, n)
+	for i := 0; i < n; i++ {
+		var sum float64
+		for _, j := range []int{(i + 1) % n, (i + 7) % n} {
+			rate := rnd.Float64()
+			trip.Append(i, j, rate)
+			sum += rate
+		}
+		trip.Append(i, i, -sum)
+	}
+	q := trip.ToCSR()
+	p0 := NewVecDense(n, nil)
+	p0.SetVec(0, 1)
+
+	times := []float64{0, 0.1, 0.5, 1, 5, 20, 100}
+	var p Dense
+	p.ExpMulVecTimes(times, q.T(), p0)
+	if r, c := p.Dims(); r != n || c != len(times) {
+		t.Fatalf("unexpected dimensions: got %d×%d, want %d×%d", r, c, n, len(times))
+	}
+	for j, tm := range times {
+		col := p.ColView(j)
+		var sum float64
+		for i := 0; i < n; i++ {
+			v := col.At(i, 0)
+			if v < -1e-12 {
+				t.Errorf("t=%v: negative probability %v", tm, v)
+			}
+			sum += v
+		}
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("t=%v: probabilities do not sum to 1: %v", tm, sum)
+		}
+
+		var want VecDense
+		want.ExpMulVec(tm, q.T(), p0)
+		if !EqualApprox(col, &want, 1e-12) {
+			t.Errorf("t=%v: mismatch with ExpMulVec", tm)
+		}
+	}
+}
+
+// atCounter is a Matrix that counts the calls to its At method. Products
+// with an atCounter use its embedded Dense and do not call At.
+type atCounter struct {
+	*Dense
+	calls int
+}
+
+func (a *atCounter) At(i, j int) float64 {
+	a.calls++
+	return a.Dense.At(i, j)
+}
+
+func TestExpMulVecElementAccess(t *testing.T) {
+	const n = 50
+	rnd := rand.New(rand.NewSource(1))
+	a := &atCounter{Dense: randNormDense(n, n, rnd)}
+	b := NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		b.SetVec(i, rnd.NormFloat64())
+	}
+	var got VecDense
+	got.ExpMulVec(0.5, a, b)
+	if a.calls != n {
+		t.Errorf("unexpected number of element accesses: got %d, want %d", a.calls, n)
+	}
+
+	var want VecDense
+	want.ExpMulVec(0.5, a.Dense, b)
+	if !EqualApprox(&got, &want, 1e-12) {
+		t.Errorf("unexpected result for operator")
+	}
+}
+
+func TestExpmvNormEst(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 5, 10, 50} {
+		for k := 0; k < 20; k++ {
+			a := randNormDense(n, n, rnd)
+			mu := rnd.NormFloat64()
+			shifted := DenseCopyOf(a)
+			for i := 0; i < n; i++ {
+				shifted.Set(i, i, a.At(i, i)-mu)
+			}
+			norm := Norm(shifted, 1)
+			got := expmvNormEst(a, mu)
+			if got > norm*(1+1e-14) || got < norm/3 {
+				t.Errorf("n=%d: estimate %v not a close lower bound on norm %v", n, got, norm)
+			}
+			if n <= 4 && math.Abs(got-norm) > 1e-14*norm {
+				t.Errorf("n=%d: unexpected norm of small matrix: got %v, want %v", n, got, norm)
+			}
+
+			// The estimate is exact when one column dominates.
+			j := rnd.Intn(n)
+			for i := 0; i < n; i++ {
+				shifted.Set(i, j, 10*shifted.At(i, j))
+			}
+			norm = Norm(shifted, 1)
+			got = expmvNormEst(shifted, 0)
+			if math.Abs(got-norm) > 1e-14*norm {
+				t.Errorf("n=%d: unexpected estimate with dominant column: got %v, want %v", n, got, norm)
+			}
+		}
+	}
+}
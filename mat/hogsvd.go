@@ -101,7 +101,9 @@ func (gsvd *HOGSVD) Factorize(m ...Matrix) (ok bool) {
 		gsvd.err = errors.New("hogsvd: eigen decomposition failed")
 		return false
 	}
-	v := eig.Vectors()
+	// The eigenvalues of S are real, so its eigenvectors
+	// are real and can be used directly.
+	v := DenseCopyOf(eig.rVectors)
 	for j := 0; j < c; j++ {
 		cv := v.ColView(j)
 		cv.ScaleVec(1/blas64.Nrm2(c, cv.mat), cv)
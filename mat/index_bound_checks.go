@@ -225,3 +225,116 @@ func (s *SymBandDense) set(i, j int, v float64) {
 	}
 	s.mat.Data[i*s.mat.Stride+pj] = v
 }
+
+// At returns the element at row i, column j.
+func (t *Tridiag) At(i, j int) float64 {
+	return t.at(i, j)
+}
+
+func (t *Tridiag) at(i, j int) float64 {
+	if uint(i) >= uint(t.n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.n) {
+		panic(ErrColAccess)
+	}
+	switch j - i {
+	case -1:
+		return t.dl[j]
+	case 0:
+		return t.d[i]
+	case 1:
+		return t.du[i]
+	}
+	return 0
+}
+
+// SetBand sets the element at row i, column j to the value v.
+// It panics if the location is outside the appropriate region of the matrix.
+func (t *Tridiag) SetBand(i, j int, v float64) {
+	t.set(i, j, v)
+}
+
+func (t *Tridiag) set(i, j int, v float64) {
+	if uint(i) >= uint(t.n) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.n) {
+		panic(ErrColAccess)
+	}
+	switch j - i {
+	case -1:
+		t.dl[j] = v
+	case 0:
+		t.d[i] = v
+	case 1:
+		t.du[i] = v
+	default:
+		panic(ErrBandSet)
+	}
+}
+
+// At returns the element at row i, column j.
+func (m *CDense) At(i, j int) complex128 {
+	return m.at(i, j)
+}
+
+func (m *CDense) at(i, j int) complex128 {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	return m.mat.Data[i*m.mat.Stride+j]
+}
+
+// Set sets the element at row i, column j to the value v.
+func (m *CDense) Set(i, j int, v complex128) {
+	m.set(i, j, v)
+}
+
+func (m *CDense) set(i, j int, v complex128) {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(ErrColAccess)
+	}
+	m.mat.Data[i*m.mat.Stride+j] = v
+}
+
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *CVecDense) At(i, j int) complex128 {
+	if j != 0 {
+		panic(ErrColAccess)
+	}
+	return v.at(i)
+}
+
+// AtVec returns the element at row i.
+// It panics if i is out of bounds.
+func (v *CVecDense) AtVec(i int) complex128 {
+	return v.at(i)
+}
+
+func (v *CVecDense) at(i int) complex128 {
+	if uint(i) >= uint(v.n) {
+		panic(ErrRowAccess)
+	}
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *CVecDense) SetVec(i int, val complex128) {
+	v.setVec(i, val)
+}
+
+func (v *CVecDense) setVec(i int, val complex128) {
+	if uint(i) >= uint(v.n) {
+		panic(ErrVectorAccess)
+	}
+	v.mat.Data[i*v.mat.Inc] = val
+}
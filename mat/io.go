@@ -339,6 +339,171 @@ func (v *VecDense) UnmarshalBinaryFrom(r io.Reader) (int, error) {
 	return n, nil
 }
 
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+//
+// CDense is little-endian encoded as follows:
+//   0 -  7  number of rows    (int64)
+//   8 - 15  number of columns (int64)
+//  16 - ..  matrix data elements, each as a real part
+//           followed by an imaginary part (float64)
+//           [0,0] [0,1] ... [0,ncols-1]
+//           [1,0] [1,1] ... [1,ncols-1]
+//           ...
+//           [nrows-1,0] ... [nrows-1,ncols-1]
+func (m CDense) MarshalBinary() ([]byte, error) {
+	bufLen := int64(m.mat.Rows)*int64(m.mat.Cols)*int64(2*sizeFloat64) + 2*int64(sizeInt64)
+	if bufLen <= 0 {
+		// bufLen is too big and has wrapped around.
+		return nil, errTooBig
+	}
+
+	p := 0
+	buf := make([]byte, bufLen)
+	binary.LittleEndian.PutUint64(buf[p:p+sizeInt64], uint64(m.mat.Rows))
+	p += sizeInt64
+	binary.LittleEndian.PutUint64(buf[p:p+sizeInt64], uint64(m.mat.Cols))
+	p += sizeInt64
+
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := m.at(i, j)
+			binary.LittleEndian.PutUint64(buf[p:p+sizeFloat64], math.Float64bits(real(v)))
+			p += sizeFloat64
+			binary.LittleEndian.PutUint64(buf[p:p+sizeFloat64], math.Float64bits(imag(v)))
+			p += sizeFloat64
+		}
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero CDense matrix.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - matrix.ErrShape is returned if the number of rows or columns is negative,
+//  - an error is returned if the resulting CDense matrix is too
+//  big for the current architecture (e.g. a 16GB matrix written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled matrix, and so
+// it should not be used on untrusted data.
+func (m *CDense) UnmarshalBinary(data []byte) error {
+	if !m.IsZero() {
+		panic("mat: unmarshal into non-zero matrix")
+	}
+
+	if len(data) < 2*sizeInt64 {
+		return errTooSmall
+	}
+
+	p := 0
+	rows := int64(binary.LittleEndian.Uint64(data[p : p+sizeInt64]))
+	p += sizeInt64
+	cols := int64(binary.LittleEndian.Uint64(data[p : p+sizeInt64]))
+	p += sizeInt64
+	if rows < 0 || cols < 0 {
+		return errBadSize
+	}
+
+	size := rows * cols
+	if int(size) < 0 || size > maxLen/2 {
+		return errTooBig
+	}
+
+	if len(data) != int(size)*2*sizeFloat64+2*sizeInt64 {
+		return errBadBuffer
+	}
+
+	m.reuseAs(int(rows), int(cols))
+	for i := range m.mat.Data {
+		re := math.Float64frombits(binary.LittleEndian.Uint64(data[p : p+sizeFloat64]))
+		p += sizeFloat64
+		im := math.Float64frombits(binary.LittleEndian.Uint64(data[p : p+sizeFloat64]))
+		p += sizeFloat64
+		m.mat.Data[i] = complex(re, im)
+	}
+
+	return nil
+}
+
+// MarshalBinary encodes the receiver into a binary form and returns the result.
+//
+// CVecDense is little-endian encoded as follows:
+//   0 -  7  number of elements     (int64)
+//   8 - ..  vector's data elements, each as a real part
+//           followed by an imaginary part (float64)
+func (v CVecDense) MarshalBinary() ([]byte, error) {
+	bufLen := int64(sizeInt64) + int64(v.n)*int64(2*sizeFloat64)
+	if bufLen <= 0 {
+		// bufLen is too big and has wrapped around.
+		return nil, errTooBig
+	}
+
+	p := 0
+	buf := make([]byte, bufLen)
+	binary.LittleEndian.PutUint64(buf[p:p+sizeInt64], uint64(v.n))
+	p += sizeInt64
+
+	for i := 0; i < v.n; i++ {
+		z := v.at(i)
+		binary.LittleEndian.PutUint64(buf[p:p+sizeFloat64], math.Float64bits(real(z)))
+		p += sizeFloat64
+		binary.LittleEndian.PutUint64(buf[p:p+sizeFloat64], math.Float64bits(imag(z)))
+		p += sizeFloat64
+	}
+
+	return buf, nil
+}
+
+// UnmarshalBinary decodes the binary form into the receiver.
+// It panics if the receiver is a non-zero CVecDense.
+//
+// See MarshalBinary for the on-disk layout.
+//
+// Limited checks on the validity of the binary input are performed:
+//  - matrix.ErrShape is returned if the number of rows is negative,
+//  - an error is returned if the resulting CVecDense is too
+//  big for the current architecture (e.g. a 16GB vector written by a
+//  64b application and read back from a 32b application.)
+// UnmarshalBinary does not limit the size of the unmarshaled vector, and so
+// it should not be used on untrusted data.
+func (v *CVecDense) UnmarshalBinary(data []byte) error {
+	if !v.IsZero() {
+		panic("mat: unmarshal into non-zero vector")
+	}
+
+	if len(data) < sizeInt64 {
+		return errTooSmall
+	}
+
+	p := 0
+	n := int64(binary.LittleEndian.Uint64(data[p : p+sizeInt64]))
+	p += sizeInt64
+	if n < 0 {
+		return errBadSize
+	}
+	if n > maxLen/2 {
+		return errTooBig
+	}
+	if len(data) != int(n)*2*sizeFloat64+sizeInt64 {
+		return errBadBuffer
+	}
+
+	v.reuseAs(int(n))
+	for i := range v.mat.Data {
+		re := math.Float64frombits(binary.LittleEndian.Uint64(data[p : p+sizeFloat64]))
+		p += sizeFloat64
+		im := math.Float64frombits(binary.LittleEndian.Uint64(data[p : p+sizeFloat64]))
+		p += sizeFloat64
+		v.mat.Data[i] = complex(re, im)
+	}
+
+	return nil
+}
+
 // readFull reads from r into buf until it has read len(buf).
 // It returns the number of bytes copied and an error if fewer bytes were read.
 // If an EOF happens after reading fewer than len(buf) bytes, io.ErrUnexpectedEOF is returned.
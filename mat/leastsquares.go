@@ -0,0 +1,190 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+)
+
+const (
+	badLeastSquares = "mat: invalid least squares factorization"
+
+	// dlamchE is the machine epsilon.
+	dlamchE = 1.0 / (1 << 53)
+)
+
+// LeastSquares is a type for solving linear least-squares problems
+//  minimize ||W^{1/2} * (A * x - b)||_2
+// where A is an m×n matrix and W is an m×m diagonal matrix of non-negative
+// row weights. The problem is solved using the singular value decomposition
+// of W^{1/2}*A, so A may be rank deficient and may have fewer rows than
+// columns. Of all vectors x that minimize the residual, the one with the
+// minimum norm is found.
+//
+// Singular values that are at most rcond times the largest singular value
+// are treated as zero when computing the solution. The number of singular
+// values greater than this threshold is the effective rank of A.
+type LeastSquares struct {
+	sqrtw []float64
+	svd   SVD
+	rank  int
+}
+
+// Factorize computes the factorization of the m×n matrix a needed to solve
+// least-squares problems and returns whether the factorization succeeded.
+//
+// If weights is not nil, it must have length m and contain the non-negative
+// weights of the rows of A, otherwise Factorize will panic. If weights is nil,
+// all rows have unit weight.
+//
+// Singular values of W^{1/2}*A that are less than or equal to rcond times the
+// largest singular value are treated as zero. If rcond is negative, the
+// machine precision times max(m,n) is used.
+func (ls *LeastSquares) Factorize(a Matrix, weights []float64, rcond float64) (ok bool) {
+	m, n := a.Dims()
+	aw := DenseCopyOf(a)
+	if weights != nil {
+		if len(weights) != m {
+			panic(ErrShape)
+		}
+		ls.sqrtw = use(ls.sqrtw, m)
+		for i, w := range weights {
+			if w < 0 || math.IsNaN(w) {
+				panic("mat: invalid weight")
+			}
+			ls.sqrtw[i] = math.Sqrt(w)
+			blas64.Scal(n, ls.sqrtw[i], blas64.Vector{Inc: 1, Data: aw.mat.Data[i*aw.mat.Stride : i*aw.mat.Stride+n]})
+		}
+	} else {
+		ls.sqrtw = ls.sqrtw[:0]
+	}
+
+	ok = ls.svd.Factorize(aw, SVDThin)
+	if !ok {
+		ls.rank = 0
+		return false
+	}
+
+	if rcond < 0 {
+		rcond = dlamchE * float64(max(m, n))
+	}
+	s := ls.svd.s
+	ls.rank = 0
+	if len(s) > 0 && s[0] > 0 {
+		thresh := rcond * s[0]
+		for _, v := range s {
+			if v <= thresh {
+				break
+			}
+			ls.rank++
+		}
+	}
+	return true
+}
+
+// Rank returns the effective rank of the factorized matrix.
+func (ls *LeastSquares) Rank() int {
+	if ls.svd.kind == 0 {
+		panic(badLeastSquares)
+	}
+	return ls.rank
+}
+
+// Solve finds the minimum-norm matrix X that minimizes the weighted residual
+// of each column of the m×k matrix B
+//  ||W^{1/2} * (A * X[:,j] - B[:,j])||_2
+// and places the n×k result into dst.
+//
+// The weighted residual norms of the columns of B are stored into resid and
+// returned. If resid is nil, a new slice is allocated, otherwise resid must
+// have length k and Solve will panic otherwise.
+func (ls *LeastSquares) Solve(dst *Dense, b Matrix, resid []float64) []float64 {
+	if ls.svd.kind == 0 {
+		panic(badLeastSquares)
+	}
+	m := ls.svd.u.Rows
+	n := ls.svd.vt.Cols
+	br, bc := b.Dims()
+	if br != m {
+		panic(ErrShape)
+	}
+	if resid == nil {
+		resid = make([]float64, bc)
+	}
+	if len(resid) != bc {
+		panic(ErrSliceLengthMismatch)
+	}
+
+	// Weight the rows of B. bw is independent of dst, so the
+	// overlap of dst and b does not need to be considered.
+	bw := getWorkspace(m, bc, false)
+	defer putWorkspace(bw)
+	bw.Copy(b)
+	if len(ls.sqrtw) != 0 {
+		for i, w := range ls.sqrtw {
+			blas64.Scal(bc, w, blas64.Vector{Inc: 1, Data: bw.mat.Data[i*bw.mat.Stride : i*bw.mat.Stride+bc]})
+		}
+	}
+
+	r := ls.rank
+	dst.reuseAsZeroed(n, bc)
+	if r == 0 {
+		for j := range resid {
+			resid[j] = blas64.Nrm2(m, blas64.Vector{Inc: bw.mat.Stride, Data: bw.mat.Data[j:]})
+		}
+		return resid
+	}
+
+	// The columns of U and V corresponding to the non-negligible
+	// singular values.
+	ur := blas64.General{
+		Rows:   m,
+		Cols:   r,
+		Stride: ls.svd.u.Stride,
+		Data:   ls.svd.u.Data,
+	}
+	vtr := blas64.General{
+		Rows:   r,
+		Cols:   n,
+		Stride: ls.svd.vt.Stride,
+		Data:   ls.svd.vt.Data,
+	}
+
+	// c = U_r^T * B_w.
+	c := getWorkspace(r, bc, false)
+	defer putWorkspace(c)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, ur, bw.mat, 0, c.mat)
+
+	// The residual is B_w - U_r * U_r^T * B_w.
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, ur, c.mat, 1, bw.mat)
+	for j := range resid {
+		resid[j] = blas64.Nrm2(m, blas64.Vector{Inc: bw.mat.Stride, Data: bw.mat.Data[j:]})
+	}
+
+	// X = V_r * Σ_r^-1 * c.
+	for i := 0; i < r; i++ {
+		blas64.Scal(bc, 1/ls.svd.s[i], blas64.Vector{Inc: 1, Data: c.mat.Data[i*c.mat.Stride : i*c.mat.Stride+bc]})
+	}
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, vtr, c.mat, 0, dst.mat)
+	return resid
+}
+
+// SolveVec finds the minimum-norm vector x that minimizes the weighted residual
+//  ||W^{1/2} * (A * x - b)||_2
+// places the result into dst and returns the weighted residual norm.
+// Please see LeastSquares.Solve for the full documentation.
+func (ls *LeastSquares) SolveVec(dst *VecDense, b Vector) float64 {
+	if ls.svd.kind == 0 {
+		panic(badLeastSquares)
+	}
+	n := ls.svd.vt.Cols
+	dst.reuseAs(n)
+	var resid [1]float64
+	ls.Solve(dst.asDense(), b, resid[:])
+	return resid[0]
+}
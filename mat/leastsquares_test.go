@@ -0,0 +1,167 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestLeastSquares(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+		weighted   bool
+	}{
+		{1, 1, 1, false},
+		{5, 3, 3, false},
+		{10, 4, 4, false},
+		{10, 4, 4, true},
+		{10, 4, 2, false},
+		{10, 4, 2, true},
+		{3, 6, 3, false},
+		{3, 6, 2, true},
+		{7, 7, 0, false},
+	} {
+		m, n := test.m, test.n
+		prefix := fmt.Sprintf("m=%d,n=%d,rank=%d,weighted=%t", m, n, test.rank, test.weighted)
+
+		a := NewDense(m, n, nil)
+		if test.rank > 0 {
+			x := NewDense(m, test.rank, nil)
+			for i := range x.mat.Data {
+				x.mat.Data[i] = rnd.NormFloat64()
+			}
+			y := NewDense(test.rank, n, nil)
+			for i := range y.mat.Data {
+				y.mat.Data[i] = rnd.NormFloat64()
+			}
+			a.Mul(x, y)
+		}
+		var weights []float64
+		if test.weighted {
+			weights = make([]float64, m)
+			for i := range weights {
+				weights[i] = rnd.Float64() + 0.1
+			}
+		}
+
+		var ls LeastSquares
+		if !ls.Factorize(a, weights, -1) {
+			t.Errorf("%s: unexpected factorization failure", prefix)
+			continue
+		}
+		if rank := ls.Rank(); rank != test.rank {
+			t.Errorf("%s: unexpected rank: got %d want %d", prefix, rank, test.rank)
+		}
+
+		// Compute the minimum-norm solution independently as
+		//  X = (W^{1/2}*A)^+ * W^{1/2}*B
+		// using the pseudo-inverse formed from the full SVD.
+		aw := DenseCopyOf(a)
+		sqrtw := NewDense(m, m, nil)
+		for i := 0; i < m; i++ {
+			w := 1.0
+			if weights != nil {
+				w = math.Sqrt(weights[i])
+			}
+			sqrtw.Set(i, i, w)
+		}
+		aw.Mul(sqrtw, a)
+		pinv := pseudoInverse(aw, test.rank)
+
+		const k = 3
+		b := NewDense(m, k, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		var bw, want Dense
+		bw.Mul(sqrtw, b)
+		want.Mul(pinv, &bw)
+
+		var x Dense
+		resid := ls.Solve(&x, b, nil)
+		if !EqualApprox(&x, &want, 1e-10) {
+			t.Errorf("%s: unexpected solution:\ngot:\n%v\nwant:\n%v", prefix, Formatted(&x), Formatted(&want))
+		}
+
+		var r Dense
+		r.Mul(aw, &x)
+		r.Sub(&r, &bw)
+		for j := 0; j < k; j++ {
+			wantResid := Norm(r.ColView(j), 2)
+			if math.Abs(resid[j]-wantResid) > 1e-10 {
+				t.Errorf("%s: unexpected residual norm for column %d: got %v want %v", prefix, j, resid[j], wantResid)
+			}
+		}
+
+		var xv VecDense
+		bv := b.ColView(1)
+		rv := ls.SolveVec(&xv, bv)
+		if !EqualApprox(&xv, x.ColView(1), 1e-14) {
+			t.Errorf("%s: SolveVec does not match Solve", prefix)
+		}
+		if math.Abs(rv-resid[1]) > 1e-14 {
+			t.Errorf("%s: SolveVec residual does not match Solve: got %v want %v", prefix, rv, resid[1])
+		}
+	}
+
+	var ls LeastSquares
+	if panicked, _ := panics(func() { ls.Rank() }); !panicked {
+		t.Errorf("expected panic using an unfactorized LeastSquares")
+	}
+	if panicked, _ := panics(func() { ls.Factorize(NewDense(2, 2, nil), []float64{1, -1}, -1) }); !panicked {
+		t.Errorf("expected panic for negative weight")
+	}
+}
+
+func TestLeastSquaresCollinear(t *testing.T) {
+	// The second column duplicates the first so the minimum-norm
+	// solution splits the coefficient equally between them.
+	a := NewDense(4, 3, []float64{
+		1, 1, 0,
+		2, 2, 1,
+		3, 3, 0,
+		4, 4, 1,
+	})
+	b := NewVecDense(4, []float64{2, 5, 6, 9})
+	var ls LeastSquares
+	ls.Factorize(a, nil, 1e-12)
+	if ls.Rank() != 2 {
+		t.Errorf("unexpected rank: got %d want 2", ls.Rank())
+	}
+	var x VecDense
+	resid := ls.SolveVec(&x, b)
+	want := NewVecDense(3, []float64{1, 1, 1})
+	if !EqualApprox(&x, want, 1e-12) {
+		t.Errorf("unexpected solution: got %v want %v", Formatted(x.T()), Formatted(want.T()))
+	}
+	if resid > 1e-12 {
+		t.Errorf("unexpected residual for consistent system: got %v", resid)
+	}
+}
+
+// pseudoInverse returns the Moore-Penrose pseudo-inverse of a computed from
+// the largest rank singular values.
+func pseudoInverse(a *Dense, rank int) *Dense {
+	m, n := a.Dims()
+	var svd SVD
+	if !svd.Factorize(a, SVDFull) {
+		panic("bad test: SVD failed")
+	}
+	u := svd.UTo(nil)
+	v := svd.VTo(nil)
+	s := svd.Values(nil)
+	sinv := NewDense(n, m, nil)
+	for i := 0; i < rank; i++ {
+		sinv.Set(i, i, 1/s[i])
+	}
+	var tmp, pinv Dense
+	tmp.Mul(v, sinv)
+	pinv.Mul(&tmp, u.T())
+	return &pinv
+}
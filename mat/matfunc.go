@@ -0,0 +1,325 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+var errSignNoConverge = errors.New("mat: matrix sign iteration did not converge")
+
+// Sqrt calculates the principal square root of the matrix a, that is the
+// unique square root whose eigenvalues have positive real parts, and places
+// the result in the receiver. Sqrt will panic with ErrSquare if a is not
+// square.
+//
+// Sqrt uses the real Schur method of Higham (1987): a is reduced to real
+// Schur form A = Z * T * Z^T, the square root R of the quasi-triangular T is
+// computed block column by block column, and the result is Z * R * Z^T.
+//
+// If a has a real negative eigenvalue, a has no real principal square root
+// and ErrNegativeEigenvalue is returned. If the Schur decomposition of a
+// fails, a non-nil error is returned. In both cases the receiver is not
+// modified. If a is singular such that the square root does not exist or is
+// very ill-conditioned, a perturbed problem is solved and a Condition error
+// with value +Inf is returned.
+func (m *Dense) Sqrt(a Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+
+	var s Schur
+	if !s.Factorize(a, true) {
+		return errSchurFailed
+	}
+	for _, v := range s.values {
+		if imag(v) == 0 && real(v) < 0 {
+			return ErrNegativeEigenvalue
+		}
+	}
+
+	r := getWorkspace(n, n, true)
+	defer putWorkspace(r)
+	ok := sqrtQuasiTri(r, s.t)
+
+	// Transform back, X = Z * R * Z^T.
+	tmp := getWorkspace(n, n, false)
+	defer putWorkspace(tmp)
+	tmp.Mul(s.z, r)
+	m.reuseAs(n, n)
+	m.Mul(tmp, s.z.T())
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// sqrtQuasiTri computes the principal square root of the upper
+// quasi-triangular matrix t in Schur canonical form and stores it into r,
+// which must be zeroed on entry. The diagonal blocks of t must not have real
+// negative eigenvalues. sqrtQuasiTri returns false if the equations for the
+// off-diagonal blocks were singular or nearly singular and perturbed values
+// were used.
+func sqrtQuasiTri(r, t *Dense) (ok bool) {
+	n := t.mat.Rows
+	td := t.mat.Data
+	ts := t.mat.Stride
+	rd := r.mat.Data
+	rs := r.mat.Stride
+
+	ok = true
+	for j := 0; j < n; {
+		bj := 1
+		if j+1 < n && td[(j+1)*ts+j] != 0 {
+			bj = 2
+		}
+
+		// Compute the square root of the diagonal block.
+		if bj == 1 {
+			rd[j*rs+j] = math.Sqrt(td[j*ts+j])
+		} else {
+			a := td[j*ts+j]
+			b := td[j*ts+j+1]
+			c := td[(j+1)*ts+j]
+			d := td[(j+1)*ts+j+1]
+			// The block has eigenvalues theta ± i*mu. Its square root
+			// with eigenvalues alpha ± i*beta, alpha > 0, is
+			//  alpha*I + (T_jj - theta*I) / (2*alpha),
+			// since (T_jj - theta*I)^2 = -mu^2 * I.
+			theta := (a + d) / 2
+			h := (a - d) / 2
+			mu := math.Sqrt(-b*c - h*h)
+			abs := math.Hypot(theta, mu)
+			var alpha float64
+			if theta >= 0 {
+				alpha = math.Sqrt((theta + abs) / 2)
+			} else {
+				// Avoid cancellation in theta + abs.
+				alpha = mu / (2 * math.Sqrt((abs-theta)/2))
+			}
+			f := 1 / (2 * alpha)
+			rd[j*rs+j] = alpha + (a-theta)*f
+			rd[j*rs+j+1] = b * f
+			rd[(j+1)*rs+j] = c * f
+			rd[(j+1)*rs+j+1] = alpha + (d-theta)*f
+		}
+
+		// The off-diagonal part of the block column satisfies
+		//  R[:j,:j] * R[:j,j] + R[:j,j] * R[j,j] = T[:j,j],
+		// which is a quasi-triangular Sylvester equation.
+		if j > 0 {
+			x := r.Slice(0, j, j, j+bj).(*Dense)
+			x.Copy(t.Slice(0, j, j, j+bj))
+			rjj := r.Slice(j, j+bj, j, j+bj).(*Dense)
+			r00 := r.Slice(0, j, 0, j).(*Dense)
+			scale, okj := lapack64.Trsyl(blas.NoTrans, blas.NoTrans, 1, r00.mat, rjj.mat, x.mat)
+			if scale != 1 {
+				x.Scale(1/scale, x)
+			}
+			ok = ok && okj
+		}
+
+		j += bj
+	}
+	return ok
+}
+
+// logPadeNodes and logPadeWeights are the nodes and weights of the 8-point
+// Gauss-Legendre quadrature rule on [-1, 1]. They define the partial fraction
+// form of the [8/8] Padé approximant to log(1+x).
+var (
+	logPadeNodes = [...]float64{
+		-0.9602898564975363, -0.7966664774136267, -0.5255324099163290, -0.1834346424956498,
+		0.1834346424956498, 0.5255324099163290, 0.7966664774136267, 0.9602898564975363,
+	}
+	logPadeWeights = [...]float64{
+		0.1012285362903763, 0.2223810344533745, 0.3137066458778873, 0.3626837833783620,
+		0.3626837833783620, 0.3137066458778873, 0.2223810344533745, 0.1012285362903763,
+	}
+)
+
+// Log calculates the principal logarithm of the matrix a, that is the unique
+// logarithm whose eigenvalues have imaginary parts in (-π, π), and places the
+// result in the receiver. Log will panic with ErrSquare if a is not square.
+//
+// Log uses the inverse scaling and squaring method: a is reduced to real
+// Schur form A = Z * T * Z^T, square roots of T are taken repeatedly until
+// T^{1/2^s} is close to the identity, the logarithm of T^{1/2^s} is
+// evaluated by an [8/8] Padé approximant and the result is
+//  log(A) = 2^s * Z * log(T^{1/2^s}) * Z^T.
+// See N. J. Higham, Evaluating Padé approximants of the matrix logarithm,
+// SIAM J. Matrix Anal. Appl. 22 (2001) 1126–1135.
+//
+// If a has a zero eigenvalue, ErrSingular is returned, and if a has a real
+// negative eigenvalue, a has no real principal logarithm and
+// ErrNegativeEigenvalue is returned. If the Schur decomposition of a fails, a
+// non-nil error is returned. In all these cases the receiver is not modified.
+func (m *Dense) Log(a Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+
+	var s Schur
+	if !s.Factorize(a, true) {
+		return errSchurFailed
+	}
+	for _, v := range s.values {
+		if imag(v) != 0 {
+			continue
+		}
+		switch {
+		case real(v) == 0:
+			return ErrSingular
+		case real(v) < 0:
+			return ErrNegativeEigenvalue
+		}
+	}
+
+	// theta is the bound on ‖T-I‖_1 below which the
+	// [8/8] Padé approximant is accurate to double precision.
+	const (
+		theta   = 0.25
+		maxRoot = 64
+	)
+
+	t := getWorkspace(n, n, false)
+	defer putWorkspace(t)
+	t.Copy(s.t)
+	r := getWorkspace(n, n, false)
+	defer putWorkspace(r)
+
+	// Take square roots until T is sufficiently close to the identity.
+	var k int
+	for ; k < maxRoot && normOneMinusI(t) > theta; k++ {
+		for i := 0; i < n; i++ {
+			zero(r.mat.Data[i*r.mat.Stride : i*r.mat.Stride+n])
+		}
+		if !sqrtQuasiTri(r, t) {
+			return Condition(math.Inf(1))
+		}
+		t, r = r, t
+	}
+
+	// Form X = T - I and evaluate the Padé approximant in partial fraction
+	// form,
+	//  log(I+X) ≈ \sum_j w_j * X * (I + x_j*X)^{-1},
+	// where x_j and w_j are the Gauss-Legendre nodes and weights on [0, 1].
+	for i := 0; i < n; i++ {
+		t.mat.Data[i*t.mat.Stride+i]--
+	}
+	sum := getWorkspace(n, n, true)
+	defer putWorkspace(sum)
+	for j, node := range logPadeNodes {
+		r.Scale((1+node)/2, t)
+		for i := 0; i < n; i++ {
+			r.mat.Data[i*r.mat.Stride+i]++
+		}
+		// I + x_j*X is well-conditioned because ‖x_j*X‖_1 <= theta,
+		// so any Condition error can be ignored.
+		var term Dense
+		term.Solve(r, t)
+		term.Scale(logPadeWeights[j]/2, &term)
+		sum.Add(sum, &term)
+	}
+	sum.Scale(math.Ldexp(1, k), sum)
+
+	// Transform back, log(A) = Z * log(T) * Z^T.
+	r.Mul(s.z, sum)
+	m.reuseAs(n, n)
+	m.Mul(r, s.z.T())
+	return nil
+}
+
+// normOneMinusI returns the 1-norm of a - I for the n×n matrix a.
+func normOneMinusI(a *Dense) float64 {
+	n := a.mat.Rows
+	var norm float64
+	for j := 0; j < n; j++ {
+		var sum float64
+		for i := 0; i < n; i++ {
+			v := a.mat.Data[i*a.mat.Stride+j]
+			if i == j {
+				v--
+			}
+			sum += math.Abs(v)
+		}
+		norm = math.Max(norm, sum)
+	}
+	return norm
+}
+
+// Sign calculates the matrix sign function of the matrix a and places the
+// result in the receiver. If a = V * J * V^{-1} is the Jordan canonical form
+// of a, then
+//  sign(A) = V * diag(sign(Re(λ_i))) * V^{-1},
+// so that sign(A) is an involution whose eigenvalues are ±1. Sign will panic
+// with ErrSquare if a is not square.
+//
+// Sign uses the Newton iteration
+//  X_{k+1} = (μ_k*X_k + (μ_k*X_k)^{-1}) / 2,  X_0 = A,
+// with norm scaling μ_k = sqrt(‖X_k^{-1}‖_1/‖X_k‖_1) in the early iterations.
+//
+// The sign function is not defined if a has an eigenvalue on the imaginary
+// axis. In this case an iterate is singular and a Condition error with value
+// +Inf is returned, or the iteration does not converge and a non-nil error is
+// returned. The receiver is not modified if an error is returned.
+func (m *Dense) Sign(a Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+
+	const maxIter = 100
+	tol := math.Sqrt(float64(n) * dlamchE)
+
+	x := getWorkspace(n, n, false)
+	defer putWorkspace(x)
+	x.Copy(a)
+	xinv := getWorkspace(n, n, false)
+	defer putWorkspace(xinv)
+	next := getWorkspace(n, n, false)
+	defer putWorkspace(next)
+
+	scaling := true
+	for k := 0; k < maxIter; k++ {
+		err := xinv.Inverse(x)
+		if cond, ok := err.(Condition); ok && math.IsInf(float64(cond), 1) {
+			return err
+		}
+		mu := 1.0
+		if scaling {
+			mu = math.Sqrt(Norm(xinv, 1) / Norm(x, 1))
+		}
+		next.Scale(mu/2, x)
+		xinv.Scale(1/(2*mu), xinv)
+		next.Add(next, xinv)
+
+		x.Sub(next, x)
+		diff := Norm(x, 1)
+		norm := Norm(next, 1)
+		x, next = next, x
+		if diff <= tol*norm {
+			// Convergence is quadratic, so one more step after the
+			// relative change falls below sqrt(ε) attains full
+			// accuracy.
+			xinv.Inverse(x)
+			x.Add(x, xinv)
+			x.Scale(0.5, x)
+			m.reuseAs(n, n)
+			m.Copy(x)
+			return nil
+		}
+		if diff <= 1e-2*norm {
+			scaling = false
+		}
+	}
+	return errSignNoConverge
+}
@@ -0,0 +1,194 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randShiftedDense returns a random n×n matrix whose eigenvalues are
+// likely to lie in the right half-plane.
+func randShiftedDense(n int, rnd *rand.Rand) *Dense {
+	a := randNormDense(n, n, rnd)
+	shift := 2*math.Sqrt(float64(n)) + 1
+	for i := 0; i < n; i++ {
+		a.Set(i, i, a.At(i, i)+shift)
+	}
+	return a
+}
+
+func TestSqrt(t *testing.T) {
+	for i, test := range []struct {
+		a, want *Dense
+	}{
+		{
+			a:    NewDense(2, 2, []float64{4, 0, 0, 9}),
+			want: NewDense(2, 2, []float64{2, 0, 0, 3}),
+		},
+		{
+			// Rotation by π/2.
+			a:    NewDense(2, 2, []float64{0, -1, 1, 0}),
+			want: NewDense(2, 2, []float64{math.Sqrt2 / 2, -math.Sqrt2 / 2, math.Sqrt2 / 2, math.Sqrt2 / 2}),
+		},
+		{
+			a:    NewDense(3, 3, []float64{1, 4, 0, 0, 1, 0, 0, 0, 0}),
+			want: NewDense(3, 3, []float64{1, 2, 0, 0, 1, 0, 0, 0, 0}),
+		},
+	} {
+		var got Dense
+		err := got.Sqrt(test.a)
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+			continue
+		}
+		if !EqualApprox(&got, test.want, 1e-14) {
+			t.Errorf("unexpected result for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(&got), Formatted(test.want))
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randShiftedDense(n, rnd)
+		var x Dense
+		err := x.Sqrt(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var xx Dense
+		xx.Mul(&x, &x)
+		if !EqualApprox(&xx, a, 1e-12*Norm(a, 1)) {
+			t.Errorf("n=%d: X*X != A", n)
+		}
+		var eig Eigen
+		eig.Factorize(&x, false, false)
+		for _, v := range eig.Values(nil) {
+			if real(v) <= 0 {
+				t.Errorf("n=%d: square root is not principal, eigenvalue %v", n, v)
+			}
+		}
+	}
+
+	var x Dense
+	err := x.Sqrt(NewDense(2, 2, []float64{-1, 0, 0, 1}))
+	if err != ErrNegativeEigenvalue {
+		t.Errorf("unexpected error for negative eigenvalue: got %v, want %v", err, ErrNegativeEigenvalue)
+	}
+}
+
+func TestLog(t *testing.T) {
+	for i, test := range []struct {
+		a, want *Dense
+	}{
+		{
+			a:    NewDense(2, 2, []float64{math.E, 0, 0, 1}),
+			want: NewDense(2, 2, []float64{1, 0, 0, 0}),
+		},
+		{
+			// Rotation by 3.
+			a:    NewDense(2, 2, []float64{math.Cos(3), -math.Sin(3), math.Sin(3), math.Cos(3)}),
+			want: NewDense(2, 2, []float64{0, -3, 3, 0}),
+		},
+		{
+			a:    NewDense(2, 2, []float64{1e3, 1e3, 0, 1e3}),
+			want: NewDense(2, 2, []float64{math.Log(1e3), 1, 0, math.Log(1e3)}),
+		},
+	} {
+		var got Dense
+		err := got.Log(test.a)
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+			continue
+		}
+		if !EqualApprox(&got, test.want, 1e-13) {
+			t.Errorf("unexpected result for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(&got), Formatted(test.want))
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randShiftedDense(n, rnd)
+		var l Dense
+		err := l.Log(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var e Dense
+		e.Exp(&l)
+		if !EqualApprox(&e, a, 1e-10*Norm(a, 1)) {
+			t.Errorf("n=%d: exp(log(A)) != A", n)
+		}
+
+		// The logarithm of a symmetric positive definite matrix
+		// is its spectral logarithm.
+		var spd SymDense
+		spd.SymOuterK(1, a)
+		var sym SymDense
+		sym.ApplySpectral(math.Log, &spd)
+		l.Log(&spd)
+		if !EqualApprox(&l, &sym, 1e-12) {
+			t.Errorf("n=%d: log(A) mismatch for symmetric A", n)
+		}
+	}
+
+	var l Dense
+	err := l.Log(NewDense(2, 2, []float64{-1, 0, 0, 1}))
+	if err != ErrNegativeEigenvalue {
+		t.Errorf("unexpected error for negative eigenvalue: got %v, want %v", err, ErrNegativeEigenvalue)
+	}
+	err = l.Log(NewDense(2, 2, []float64{0, 1, 0, 1}))
+	if err != ErrSingular {
+		t.Errorf("unexpected error for singular matrix: got %v, want %v", err, ErrSingular)
+	}
+}
+
+func TestSign(t *testing.T) {
+	var s Dense
+	err := s.Sign(NewDense(3, 3, []float64{3, 1, 0, 0, -2, 5, 0, 0, 1}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var ss Dense
+	ss.Mul(&s, &s)
+	if !EqualApprox(&ss, eye(3), 1e-13) {
+		t.Errorf("sign(A)^2 != I")
+	}
+	for i, want := range []float64{1, -1, 1} {
+		if math.Abs(s.At(i, i)-want) > 1e-13 {
+			t.Errorf("unexpected diagonal element %d: got %v, want %v", i, s.At(i, i), want)
+		}
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		a := randNormDense(n, n, rnd)
+		s.Reset()
+		ss.Reset()
+		err := s.Sign(a)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		ss.Mul(&s, &s)
+		if !EqualApprox(&ss, eye(n), 1e-10) {
+			t.Errorf("n=%d: sign(A)^2 != I", n)
+		}
+		var as, sa Dense
+		as.Mul(a, &s)
+		sa.Mul(&s, a)
+		if !EqualApprox(&as, &sa, 1e-10*Norm(a, 1)) {
+			t.Errorf("n=%d: sign(A) does not commute with A", n)
+		}
+	}
+
+	s.Reset()
+	err = s.Sign(NewDense(2, 2, []float64{0, 1, -1, 0}))
+	if err == nil {
+		t.Errorf("expected error for eigenvalues on the imaginary axis")
+	}
+}
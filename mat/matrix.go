@@ -200,6 +200,19 @@ type ColNonZeroDoer interface {
 	DoColNonZero(j int, fn func(i, j int, v float64))
 }
 
+// A MulVecToer can compute the product of itself, or its transpose, with a
+// vector without access to its elements. MulVecTo stores a * x into dst if
+// trans is false and a^T * x if trans is true. dst will have been sized to
+// hold the result and will not share data with x.
+//
+// VecDense.MulVec and Dense.Mul compute products with a MulVecToer operand
+// using MulVecTo, one vector at a time. Other operations may access the
+// elements of a MulVecToer through At.
+type MulVecToer interface {
+	Matrix
+	MulVecTo(dst *VecDense, trans bool, x *VecDense)
+}
+
 // TODO(btracey): Consider adding CopyCol/CopyRow if the behavior seems useful.
 // TODO(btracey): Add in fast paths to Row/Col for the other concrete types
 // (TriDense, etc.) as well as relevant interfaces (RowColer, RawRowViewer, etc.)
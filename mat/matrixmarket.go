@@ -0,0 +1,438 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	mmBanner = "%%MatrixMarket"
+	mmHint   = "% gonum:"
+
+	// mmMaxPrealloc is the largest number of coordinate entries for
+	// which storage is allocated before the entries are read.
+	mmMaxPrealloc = 1024
+
+	// mmMaxHeaderLen is the largest number of elements allocated for a
+	// matrix whose storage size is determined by the header rather than
+	// by the data that have been read.
+	mmMaxHeaderLen = 1 << 28
+)
+
+var (
+	errMMBanner      = errors.New("mat: invalid Matrix Market banner")
+	errMMUnsupported = errors.New("mat: unsupported Matrix Market format")
+	errMMShort       = errors.New("mat: too few Matrix Market entries")
+	errMMHint        = errors.New("mat: invalid gonum type hint in Matrix Market data")
+)
+
+// WriteMatrixMarket writes the matrix a to w in the Matrix Market exchange
+// format (https://math.nist.gov/MatrixMarket/formats.html).
+//
+// The layout is chosen according to the type of a:
+//  - symmetric band matrices are written in coordinate symmetric format,
+//  - other symmetric matrices are written in array symmetric format,
+//  - triangular, band and sparse matrices are written in coordinate general
+//    format, holding the non-zero elements,
+//  - all other matrices are written in array general format.
+// For vector, triangular, band, symmetric band and CSC matrices a comment
+// recording the type is written so that ReadMatrixMarket returns a matrix of
+// the same type.
+func WriteMatrixMarket(w io.Writer, a Matrix) error {
+	bw := bufio.NewWriter(w)
+	r, c := a.Dims()
+	switch t := a.(type) {
+	case *VecDense:
+		writeMMArray(bw, a, "general", "VecDense")
+	case SymBanded:
+		n := t.Symmetric()
+		_, k := t.Bandwidth()
+		writeMMCoordinate(bw, r, c, "symmetric", fmt.Sprintf("SymBandDense %d", k), func(fn func(i, j int, v float64)) {
+			for j := 0; j < n; j++ {
+				for i := j; i < min(n, j+k+1); i++ {
+					fn(i, j, t.At(i, j))
+				}
+			}
+		})
+	case Symmetric:
+		writeMMArray(bw, a, "symmetric", "")
+	case Triangular:
+		n, kind := t.Triangle()
+		hint := "TriDense lower"
+		if kind == Upper {
+			hint = "TriDense upper"
+		}
+		writeMMCoordinate(bw, r, c, "general", hint, func(fn func(i, j int, v float64)) {
+			for j := 0; j < n; j++ {
+				lo, hi := j, n
+				if kind == Upper {
+					lo, hi = 0, j+1
+				}
+				for i := lo; i < hi; i++ {
+					fn(i, j, t.At(i, j))
+				}
+			}
+		})
+	case *CSR, *CSC, *Triplet, *Tridiag:
+		hint := ""
+		if _, ok := t.(*CSC); ok {
+			hint = "CSC"
+		}
+		writeMMCoordinate(bw, r, c, "general", hint, func(fn func(i, j int, v float64)) {
+			t.(NonZeroDoer).DoNonZero(fn)
+		})
+	case Banded:
+		kl, ku := t.Bandwidth()
+		writeMMCoordinate(bw, r, c, "general", fmt.Sprintf("BandDense %d %d", kl, ku), func(fn func(i, j int, v float64)) {
+			for j := 0; j < c; j++ {
+				for i := max(0, j-ku); i < min(r, j+kl+1); i++ {
+					fn(i, j, t.At(i, j))
+				}
+			}
+		})
+	default:
+		writeMMArray(bw, a, "general", "")
+	}
+	return bw.Flush()
+}
+
+// writeMMArray writes a in array format. Only the lower triangle of a is
+// written if symmetry is "symmetric".
+func writeMMArray(w *bufio.Writer, a Matrix, symmetry, hint string) {
+	r, c := a.Dims()
+	fmt.Fprintf(w, "%s matrix array real %s\n", mmBanner, symmetry)
+	if hint != "" {
+		fmt.Fprintf(w, "%s %s\n", mmHint, hint)
+	}
+	fmt.Fprintf(w, "%d %d\n", r, c)
+	for j := 0; j < c; j++ {
+		i := 0
+		if symmetry == "symmetric" {
+			i = j
+		}
+		for ; i < r; i++ {
+			w.WriteString(strconv.FormatFloat(a.At(i, j), 'g', -1, 64))
+			w.WriteByte('\n')
+		}
+	}
+}
+
+// writeMMCoordinate writes the non-zero elements visited by do in coordinate
+// format.
+func writeMMCoordinate(w *bufio.Writer, r, c int, symmetry, hint string, do func(func(i, j int, v float64))) {
+	type entry struct {
+		i, j int
+		v    float64
+	}
+	var entries []entry
+	do(func(i, j int, v float64) {
+		if v != 0 {
+			entries = append(entries, entry{i, j, v})
+		}
+	})
+	fmt.Fprintf(w, "%s matrix coordinate real %s\n", mmBanner, symmetry)
+	if hint != "" {
+		fmt.Fprintf(w, "%s %s\n", mmHint, hint)
+	}
+	fmt.Fprintf(w, "%d %d %d\n", r, c, len(entries))
+	for _, e := range entries {
+		fmt.Fprintf(w, "%d %d %s\n", e.i+1, e.j+1, strconv.FormatFloat(e.v, 'g', -1, 64))
+	}
+}
+
+// ReadMatrixMarket reads a real matrix in the Matrix Market exchange format
+// from r. Real, integer and pattern fields are supported; the elements of a
+// pattern matrix are set to one. Complex and Hermitian matrices are not
+// supported.
+//
+// The type of the returned matrix depends on the layout of the data:
+//  - array general matrices are returned as *Dense,
+//  - array symmetric matrices are returned as *SymDense,
+//  - array skew-symmetric matrices are returned as *Dense,
+//  - coordinate matrices are returned as *CSR, with the elements of
+//    symmetric and skew-symmetric matrices mirrored across the diagonal.
+// Data written by WriteMatrixMarket from a vector, triangular, band,
+// symmetric band or CSC matrix is returned as *VecDense, *TriDense,
+// *BandDense, *SymBandDense or *CSC respectively.
+//
+// The sizes in the header are not trusted. Storage for the elements is grown
+// as they are read, and ReadMatrixMarket returns an error for data that are
+// shorter than the header describes or whose storage would be too large.
+func ReadMatrixMarket(r io.Reader) (Matrix, error) {
+	sc := bufio.NewScanner(r)
+	if !sc.Scan() {
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errMMBanner
+	}
+	banner := strings.Fields(strings.ToLower(sc.Text()))
+	if len(banner) != 5 || banner[0] != strings.ToLower(mmBanner) || banner[1] != "matrix" {
+		return nil, errMMBanner
+	}
+	format, field, symmetry := banner[2], banner[3], banner[4]
+	switch {
+	case format != "array" && format != "coordinate",
+		field != "real" && field != "integer" && field != "pattern",
+		symmetry != "general" && symmetry != "symmetric" && symmetry != "skew-symmetric",
+		format == "array" && field == "pattern":
+		return nil, errMMUnsupported
+	}
+
+	// Skip comments, recording a type hint if present.
+	var hint []string
+	var size []string
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if strings.HasPrefix(line, mmHint) {
+			hint = strings.Fields(strings.TrimPrefix(line, mmHint))
+			continue
+		}
+		if line == "" || line[0] == '%' {
+			continue
+		}
+		size = strings.Fields(line)
+		break
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	want := 2
+	if format == "coordinate" {
+		want = 3
+	}
+	if len(size) != want {
+		return nil, errBadSize
+	}
+	dims, err := parseInts(size)
+	if err != nil {
+		return nil, err
+	}
+	rows, cols := dims[0], dims[1]
+	if rows <= 0 || cols <= 0 || (symmetry != "general" && rows != cols) {
+		return nil, errBadSize
+	}
+
+	// next returns the fields of the next non-empty line.
+	next := func() ([]string, error) {
+		for sc.Scan() {
+			f := strings.Fields(sc.Text())
+			if len(f) != 0 {
+				return f, nil
+			}
+		}
+		if err := sc.Err(); err != nil {
+			return nil, err
+		}
+		return nil, errMMShort
+	}
+
+	if format == "array" {
+		// The values are collected as they are read so that the
+		// header does not determine the size of any allocation.
+		var n int
+		switch symmetry {
+		case "general":
+			if int64(rows) > maxLen/int64(cols) {
+				return nil, errTooBig
+			}
+			n = rows * cols
+		case "symmetric":
+			if int64(rows) > maxLen/int64(rows+1) {
+				return nil, errTooBig
+			}
+			n = rows * (rows + 1) / 2
+		case "skew-symmetric":
+			if int64(rows) > maxLen/int64(rows) {
+				return nil, errTooBig
+			}
+			n = rows * (rows - 1) / 2
+		}
+		var vals []float64
+		for len(vals) < n {
+			f, err := next()
+			if err != nil {
+				return nil, err
+			}
+			v, err := strconv.ParseFloat(f[0], 64)
+			if err != nil {
+				return nil, err
+			}
+			vals = append(vals, v)
+		}
+
+		var k int
+		switch {
+		case symmetry == "symmetric":
+			s := NewSymDense(rows, nil)
+			for j := 0; j < cols; j++ {
+				for i := j; i < rows; i++ {
+					s.SetSym(i, j, vals[k])
+					k++
+				}
+			}
+			return s, nil
+		case symmetry == "skew-symmetric":
+			data := NewDense(rows, cols, nil)
+			for j := 0; j < cols; j++ {
+				for i := j + 1; i < rows; i++ {
+					data.set(i, j, vals[k])
+					data.set(j, i, -vals[k])
+					k++
+				}
+			}
+			return data, nil
+		case len(hint) == 1 && hint[0] == "VecDense" && cols == 1:
+			return NewVecDense(rows, vals), nil
+		}
+		data := NewDense(rows, cols, nil)
+		for j := 0; j < cols; j++ {
+			for i := 0; i < rows; i++ {
+				data.set(i, j, vals[k])
+				k++
+			}
+		}
+		return data, nil
+	}
+
+	nnz := dims[2]
+	if nnz < 0 {
+		return nil, errBadSize
+	}
+	// The sparse result holds index arrays of length rows+1 or cols+1
+	// that are allocated from the header alone.
+	if rows > mmMaxHeaderLen || cols > mmMaxHeaderLen {
+		return nil, errTooBig
+	}
+	type entry struct {
+		i, j int
+		v    float64
+	}
+	entries := make([]entry, 0, min(nnz, mmMaxPrealloc))
+	for k := 0; k < nnz; k++ {
+		f, err := next()
+		if err != nil {
+			return nil, err
+		}
+		if len(f) < 2 || (field != "pattern" && len(f) < 3) {
+			return nil, errMMShort
+		}
+		ij, err := parseInts(f[:2])
+		if err != nil {
+			return nil, err
+		}
+		i, j := ij[0]-1, ij[1]-1
+		if i < 0 || rows <= i || j < 0 || cols <= j {
+			return nil, ErrIndexOutOfRange
+		}
+		v := 1.0
+		if field != "pattern" {
+			v, err = strconv.ParseFloat(f[2], 64)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, entry{i, j, v})
+	}
+
+	if len(hint) > 0 {
+		switch {
+		case hint[0] == "TriDense" && len(hint) == 2 && rows == cols:
+			if rows > mmMaxHeaderLen/rows {
+				return nil, errTooBig
+			}
+			kind := TriKind(hint[1] == "upper")
+			t := NewTriDense(rows, kind, nil)
+			for _, e := range entries {
+				if (kind == Upper && e.i > e.j) || (kind == Lower && e.i < e.j) {
+					return nil, ErrTriangleSet
+				}
+				t.SetTri(e.i, e.j, e.v)
+			}
+			return t, nil
+		case hint[0] == "BandDense" && len(hint) == 3:
+			bw, err := parseInts(hint[1:])
+			if err != nil {
+				return nil, err
+			}
+			kl, ku := bw[0], bw[1]
+			if kl < 0 || rows <= kl || ku < 0 || cols <= ku {
+				return nil, errMMHint
+			}
+			if kl+ku+1 > mmMaxHeaderLen/rows {
+				return nil, errTooBig
+			}
+			b := NewBandDense(rows, cols, kl, ku, nil)
+			for _, e := range entries {
+				if e.i-e.j > kl || e.j-e.i > ku {
+					return nil, ErrBandSet
+				}
+				b.SetBand(e.i, e.j, e.v)
+			}
+			return b, nil
+		case hint[0] == "SymBandDense" && len(hint) == 2 && symmetry == "symmetric":
+			bw, err := parseInts(hint[1:])
+			if err != nil {
+				return nil, err
+			}
+			k := bw[0]
+			if k < 0 || rows <= k {
+				return nil, errMMHint
+			}
+			if k+1 > mmMaxHeaderLen/rows {
+				return nil, errTooBig
+			}
+			s := NewSymBandDense(rows, k, nil)
+			for _, e := range entries {
+				i, j := e.j, e.i
+				if i > j {
+					i, j = j, i
+				}
+				if j-i > k {
+					return nil, ErrBandSet
+				}
+				s.SetSymBand(i, j, e.v)
+			}
+			return s, nil
+		}
+	}
+
+	t := NewTriplet(rows, cols)
+	for _, e := range entries {
+		t.Append(e.i, e.j, e.v)
+		if e.i == e.j {
+			continue
+		}
+		switch symmetry {
+		case "symmetric":
+			t.Append(e.j, e.i, e.v)
+		case "skew-symmetric":
+			t.Append(e.j, e.i, -e.v)
+		}
+	}
+	if len(hint) == 1 && hint[0] == "CSC" {
+		return t.ToCSC(), nil
+	}
+	return t.ToCSR(), nil
+}
+
+// parseInts parses the decimal integers in s.
+func parseInts(s []string) ([]int, error) {
+	v := make([]int, len(s))
+	for i, f := range s {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return nil, err
+		}
+		v[i] = n
+	}
+	return v, nil
+}
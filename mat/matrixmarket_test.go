@@ -0,0 +1,170 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadMatrixMarket(t *testing.T) {
+	for i, test := range []struct {
+		input string
+		want  Matrix
+	}{
+		{
+			input: `%%MatrixMarket matrix array real general
+% A comment.
+2 3
+1
+4
+2
+5
+3
+6
+`,
+			want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			input: `%%MatrixMarket matrix array real symmetric
+3 3
+1
+2
+3
+4
+5
+6
+`,
+			want: NewSymDense(3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}),
+		},
+		{
+			input: `%%MatrixMarket matrix array real skew-symmetric
+2 2
+7
+`,
+			want: NewDense(2, 2, []float64{0, -7, 7, 0}),
+		},
+		{
+			input: `%%MatrixMarket matrix coordinate integer general
+3 4 3
+1 1 1
+3 2 -2
+2 4 5
+`,
+			want: NewDense(3, 4, []float64{1, 0, 0, 0, 0, 0, 0, 5, 0, -2, 0, 0}),
+		},
+		{
+			input: `%%MatrixMarket matrix coordinate pattern symmetric
+3 3 2
+2 1
+3 3
+`,
+			want: NewDense(3, 3, []float64{0, 1, 0, 1, 0, 0, 0, 0, 1}),
+		},
+	} {
+		got, err := ReadMatrixMarket(strings.NewReader(test.input))
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(test.want) && reflect.TypeOf(got) != reflect.TypeOf(&CSR{}) {
+			t.Errorf("unexpected type for test %d: %T", i, got)
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("unexpected result for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(got), Formatted(test.want))
+		}
+	}
+
+	for i, input := range []string{
+		"",
+		"%%MatrixMarket matrix array complex general\n1 1\n1 0\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 2\n1 1 1\n",
+		"%%MatrixMarket matrix coordinate real general\n2 2 1\n3 1 1\n",
+		"%%MatrixMarket matrix array real symmetric\n2 3\n1\n",
+	} {
+		_, err := ReadMatrixMarket(strings.NewReader(input))
+		if err == nil {
+			t.Errorf("expected error for invalid input %d", i)
+		}
+	}
+
+	// Sizes in the header must not determine allocations before the
+	// data are read, and invalid hints must not cause a panic.
+	for i, test := range []struct {
+		input string
+		want  error
+	}{
+		{"%%MatrixMarket matrix array real general\n4294967296 4294967296\n1\n", errTooBig},
+		{"%%MatrixMarket matrix array real general\n1000000000 1000000000", errMMShort},
+		{"%%MatrixMarket matrix array real symmetric\n1000000000 1000000000\n1\n2\n", errMMShort},
+		{"%%MatrixMarket matrix coordinate real general\n2 2 100000000000000000", errMMShort},
+		{"%%MatrixMarket matrix coordinate real general\n2 2 9223372036854775807\n1 1 1\n", errMMShort},
+		{"%%MatrixMarket matrix coordinate real general\n2000000000 2 0\n", errTooBig},
+		{"%%MatrixMarket matrix coordinate real general\n% gonum: TriDense upper\n100000 100000 0\n", errTooBig},
+		{"%%MatrixMarket matrix coordinate real symmetric\n% gonum: SymBandDense -1\n3 3 1\n1 1 1\n", errMMHint},
+		{"%%MatrixMarket matrix coordinate real symmetric\n% gonum: SymBandDense 3\n3 3 1\n1 1 1\n", errMMHint},
+		{"%%MatrixMarket matrix coordinate real general\n% gonum: BandDense 5 5\n3 3 1\n1 1 1\n", errMMHint},
+		{"%%MatrixMarket matrix coordinate real general\n% gonum: BandDense 0 -2\n3 3 1\n1 1 1\n", errMMHint},
+	} {
+		_, err := ReadMatrixMarket(strings.NewReader(test.input))
+		if err != test.want {
+			t.Errorf("unexpected error for input %d: got %v, want %v", i, err, test.want)
+		}
+	}
+}
+
+func TestMatrixMarketRoundTrip(t *testing.T) {
+	band := NewBandDense(4, 5, 1, 2, nil)
+	symBand := NewSymBandDense(4, 1, nil)
+	tri := NewTriDense(3, Lower, nil)
+	for i := 0; i < 4; i++ {
+		for j := max(0, i-1); j < min(5, i+3); j++ {
+			band.SetBand(i, j, float64(10*i+j+1))
+		}
+		for j := i; j < min(4, i+2); j++ {
+			symBand.SetSymBand(i, j, float64(10*i+j+1))
+		}
+	}
+	for i := 0; i < 3; i++ {
+		for j := 0; j <= i; j++ {
+			tri.SetTri(i, j, float64(10*i+j+1))
+		}
+	}
+	trip := NewTriplet(3, 4)
+	trip.Append(0, 3, 1.5)
+	trip.Append(2, 1, -2)
+
+	for _, a := range []Matrix{
+		NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6.5}),
+		NewVecDense(3, []float64{1, -2, 3e-20}),
+		NewSymDense(3, []float64{1, 2, 3, 2, 4, 5, 3, 5, 6}),
+		NewTriDense(3, Upper, []float64{1, 2, 3, 0, 4, 5, 0, 0, 6}),
+		tri,
+		band,
+		symBand,
+		trip.ToCSR(),
+		trip.ToCSC(),
+	} {
+		var buf bytes.Buffer
+		err := WriteMatrixMarket(&buf, a)
+		if err != nil {
+			t.Errorf("unexpected error writing %T: %v", a, err)
+			continue
+		}
+		got, err := ReadMatrixMarket(&buf)
+		if err != nil {
+			t.Errorf("unexpected error reading %T: %v", a, err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(a) {
+			t.Errorf("type mismatch: got %T, want %T", got, a)
+		}
+		if !Equal(got, a) {
+			t.Errorf("%T round trip mismatch:\ngot:\n%v\nwant:\n%v", a, Formatted(got), Formatted(a))
+		}
+	}
+}
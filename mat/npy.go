@@ -0,0 +1,281 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"archive/zip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	npyMagic = "\x93NUMPY"
+
+	// npyMaxHeaderLen is the largest header length accepted by ReadNpy.
+	// It matches the limit used by NumPy when reading headers.
+	npyMaxHeaderLen = 10000
+
+	// npyChunkLen is the number of bytes of array data read at a time.
+	npyChunkLen = 1 << 16
+)
+
+var (
+	errNpyMagic   = errors.New("mat: invalid npy magic")
+	errNpyVersion = errors.New("mat: unsupported npy version")
+	errNpyHeader  = errors.New("mat: invalid npy header")
+	errNpyShape   = errors.New("mat: unsupported npy array shape")
+
+	npyDescr   = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortran = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShape   = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+)
+
+// WriteNpy writes the matrix a to w in the NumPy .npy format, version 1.0,
+// as little-endian float64 values in C order. A *VecDense is written as a
+// one-dimensional array and all other matrices as two-dimensional arrays.
+func WriteNpy(w io.Writer, a Matrix) error {
+	r, c := a.Dims()
+	shape := fmt.Sprintf("(%d, %d)", r, c)
+	if _, ok := a.(*VecDense); ok {
+		shape = fmt.Sprintf("(%d,)", r)
+	}
+	header := fmt.Sprintf("{'descr': '<f8', 'fortran_order': False, 'shape': %s, }", shape)
+	// The total length of the preamble, the header and the
+	// terminating newline must be a multiple of 64.
+	const preamble = len(npyMagic) + 4
+	pad := 63 - (preamble+len(header))%64
+	header += strings.Repeat(" ", pad) + "\n"
+
+	buf := make([]byte, preamble, preamble+len(header)+8*r*c)
+	copy(buf, npyMagic)
+	buf[6] = 1
+	buf[7] = 0
+	binary.LittleEndian.PutUint16(buf[8:], uint16(len(header)))
+	buf = append(buf, header...)
+	var b [8]byte
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			binary.LittleEndian.PutUint64(b[:], math.Float64bits(a.At(i, j)))
+			buf = append(buf, b[:]...)
+		}
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// ReadNpy reads an array in the NumPy .npy format from r. One-dimensional
+// arrays are returned as *VecDense and two-dimensional arrays as *Dense.
+// Arrays of floating point, signed and unsigned integer and boolean elements
+// of either byte order and in C or Fortran order are supported. The elements
+// are converted to float64. ReadNpy returns io.ErrUnexpectedEOF if r holds
+// fewer elements than the header describes.
+func ReadNpy(r io.Reader) (Matrix, error) {
+	var pre [8]byte
+	if _, err := io.ReadFull(r, pre[:]); err != nil {
+		return nil, err
+	}
+	if string(pre[:6]) != npyMagic {
+		return nil, errNpyMagic
+	}
+	var hlen int
+	switch pre[6] {
+	case 1:
+		var b [2]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		hlen = int(binary.LittleEndian.Uint16(b[:]))
+	case 2, 3:
+		var b [4]byte
+		if _, err := io.ReadFull(r, b[:]); err != nil {
+			return nil, err
+		}
+		hlen = int(binary.LittleEndian.Uint32(b[:]))
+	default:
+		return nil, errNpyVersion
+	}
+	if hlen > npyMaxHeaderLen {
+		return nil, errNpyHeader
+	}
+	hbuf := make([]byte, hlen)
+	if _, err := io.ReadFull(r, hbuf); err != nil {
+		return nil, err
+	}
+	header := string(hbuf)
+
+	descr := npyDescr.FindStringSubmatch(header)
+	fortran := npyFortran.FindStringSubmatch(header)
+	shapeStr := npyShape.FindStringSubmatch(header)
+	if descr == nil || fortran == nil || shapeStr == nil {
+		return nil, errNpyHeader
+	}
+	var shape []int
+	for _, f := range strings.Split(shapeStr[1], ",") {
+		f = strings.TrimSpace(f)
+		if f == "" {
+			continue
+		}
+		n, err := strconv.Atoi(strings.TrimSuffix(f, "L"))
+		if err != nil {
+			return nil, errNpyHeader
+		}
+		shape = append(shape, n)
+	}
+	var rows, cols int
+	switch len(shape) {
+	case 1:
+		rows, cols = shape[0], 1
+	case 2:
+		rows, cols = shape[0], shape[1]
+	default:
+		return nil, errNpyShape
+	}
+	if rows <= 0 || cols <= 0 {
+		return nil, errBadSize
+	}
+
+	conv, size, err := npyDecoder(descr[1])
+	if err != nil {
+		return nil, err
+	}
+	if int64(rows) > maxLen/int64(cols) {
+		return nil, errTooBig
+	}
+	if n := int64(rows * cols); n > maxLen/int64(size) || n > maxLen/int64(sizeFloat64) {
+		return nil, errTooBig
+	}
+	// The data are read in chunks so that the storage grows only with
+	// the data actually present rather than with the shape in the header.
+	n := rows * cols
+	var data []float64
+	raw := make([]byte, (npyChunkLen/size)*size)
+	for len(data) < n {
+		chunk := raw[:min(len(raw), (n-len(data))*size)]
+		if _, err := io.ReadFull(r, chunk); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
+		for k := 0; k < len(chunk); k += size {
+			data = append(data, conv(chunk[k:k+size]))
+		}
+	}
+
+	if len(shape) == 1 {
+		return NewVecDense(rows, data), nil
+	}
+	if fortran[1] == "True" {
+		m := NewDense(cols, rows, data)
+		var t Dense
+		t.Clone(m.T())
+		return &t, nil
+	}
+	return NewDense(rows, cols, data), nil
+}
+
+// npyDecoder returns a function converting a single element described by
+// the NumPy type descriptor descr to float64, and the size of the element
+// in bytes.
+func npyDecoder(descr string) (conv func([]byte) float64, size int, err error) {
+	if len(descr) < 3 {
+		return nil, 0, fmt.Errorf("mat: unsupported npy type %q", descr)
+	}
+	var order binary.ByteOrder
+	switch descr[0] {
+	case '<', '|', '=':
+		order = binary.LittleEndian
+	case '>':
+		order = binary.BigEndian
+	default:
+		return nil, 0, fmt.Errorf("mat: unsupported npy type %q", descr)
+	}
+	size, err = strconv.Atoi(descr[2:])
+	if err != nil {
+		return nil, 0, fmt.Errorf("mat: unsupported npy type %q", descr)
+	}
+	switch kind := descr[1]; {
+	case kind == 'f' && size == 4:
+		conv = func(b []byte) float64 { return float64(math.Float32frombits(order.Uint32(b))) }
+	case kind == 'f' && size == 8:
+		conv = func(b []byte) float64 { return math.Float64frombits(order.Uint64(b)) }
+	case kind == 'i' && size == 1:
+		conv = func(b []byte) float64 { return float64(int8(b[0])) }
+	case kind == 'i' && size == 2:
+		conv = func(b []byte) float64 { return float64(int16(order.Uint16(b))) }
+	case kind == 'i' && size == 4:
+		conv = func(b []byte) float64 { return float64(int32(order.Uint32(b))) }
+	case kind == 'i' && size == 8:
+		conv = func(b []byte) float64 { return float64(int64(order.Uint64(b))) }
+	case (kind == 'u' || kind == 'b') && size == 1:
+		conv = func(b []byte) float64 { return float64(b[0]) }
+	case kind == 'u' && size == 2:
+		conv = func(b []byte) float64 { return float64(order.Uint16(b)) }
+	case kind == 'u' && size == 4:
+		conv = func(b []byte) float64 { return float64(order.Uint32(b)) }
+	case kind == 'u' && size == 8:
+		conv = func(b []byte) float64 { return float64(order.Uint64(b)) }
+	default:
+		return nil, 0, fmt.Errorf("mat: unsupported npy type %q", descr)
+	}
+	return conv, size, nil
+}
+
+// WriteNpz writes the named matrices in arrays to w as a NumPy .npz archive.
+// Each matrix is stored in the archive as name.npy in the format written by
+// WriteNpy.
+func WriteNpz(w io.Writer, arrays map[string]Matrix) error {
+	names := make([]string, 0, len(arrays))
+	for name := range arrays {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	zw := zip.NewWriter(w)
+	for _, name := range names {
+		f, err := zw.Create(name + ".npy")
+		if err != nil {
+			return err
+		}
+		if err := WriteNpy(f, arrays[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// ReadNpz reads the arrays in the NumPy .npz archive held in r, which has the
+// given size in bytes, and returns them keyed by name without the .npy
+// extension. See ReadNpy for the supported arrays.
+func ReadNpz(r io.ReaderAt, size int64) (map[string]Matrix, error) {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, err
+	}
+	arrays := make(map[string]Matrix, len(zr.File))
+	for _, f := range zr.File {
+		if !strings.HasSuffix(f.Name, ".npy") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		m, err := ReadNpy(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("mat: %s: %v", f.Name, err)
+		}
+		arrays[strings.TrimSuffix(f.Name, ".npy")] = m
+	}
+	return arrays, nil
+}
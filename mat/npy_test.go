@@ -0,0 +1,162 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"reflect"
+	"testing"
+)
+
+// npyBytes returns a version 1.0 .npy file with the given header and
+// raw data.
+func npyBytes(header string, data interface{}, order binary.ByteOrder) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(npyMagic)
+	buf.Write([]byte{1, 0})
+	binary.Write(&buf, binary.LittleEndian, uint16(len(header)))
+	buf.WriteString(header)
+	binary.Write(&buf, order, data)
+	return buf.Bytes()
+}
+
+func TestReadNpy(t *testing.T) {
+	for i, test := range []struct {
+		input []byte
+		want  Matrix
+	}{
+		{
+			input: npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 3), }\n",
+				[]float64{1, 2, 3, 4, 5, 6}, binary.LittleEndian),
+			want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6}),
+		},
+		{
+			input: npyBytes("{'descr': '>i4', 'fortran_order': True, 'shape': (2, 3), }\n",
+				[]int32{1, 4, 2, 5, 3, -6}, binary.BigEndian),
+			want: NewDense(2, 3, []float64{1, 2, 3, 4, 5, -6}),
+		},
+		{
+			input: npyBytes("{'descr': '<f4', 'fortran_order': False, 'shape': (3,), }\n",
+				[]float32{0.5, -1, 2}, binary.LittleEndian),
+			want: NewVecDense(3, []float64{0.5, -1, 2}),
+		},
+		{
+			input: npyBytes("{'descr': '|u1', 'fortran_order': False, 'shape': (1, 2), }\n",
+				[]uint8{0, 255}, binary.LittleEndian),
+			want: NewDense(1, 2, []float64{0, 255}),
+		},
+	} {
+		got, err := ReadNpy(bytes.NewReader(test.input))
+		if err != nil {
+			t.Errorf("unexpected error for test %d: %v", i, err)
+			continue
+		}
+		if reflect.TypeOf(got) != reflect.TypeOf(test.want) {
+			t.Errorf("unexpected type for test %d: got %T, want %T", i, got, test.want)
+		}
+		if !Equal(got, test.want) {
+			t.Errorf("unexpected result for test %d:\ngot:\n%v\nwant:\n%v", i, Formatted(got), Formatted(test.want))
+		}
+	}
+
+	for i, input := range [][]byte{
+		[]byte("NUMPY"),
+		npyBytes("{'descr': '<c16', 'fortran_order': False, 'shape': (1,), }\n", []float64{1, 0}, binary.LittleEndian),
+		npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (1, 1, 1), }\n", []float64{1}, binary.LittleEndian),
+		npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2, 2), }\n", []float64{1}, binary.LittleEndian),
+	} {
+		_, err := ReadNpy(bytes.NewReader(input))
+		if err == nil {
+			t.Errorf("expected error for invalid input %d", i)
+		}
+	}
+
+	// A header that is too long or a shape larger than the data must not
+	// cause large allocations.
+	longHeader := append([]byte(npyMagic), 2, 0, 0xff, 0xff, 0xff, 0xff)
+	longHeader = append(longHeader, "{'descr'"...)
+	for i, test := range []struct {
+		input []byte
+		want  error
+	}{
+		{npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (4294967296, 4294967296), }\n", []float64{1}, binary.LittleEndian), errTooBig},
+		{npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (2305843009213693952, 4), }\n", []float64{1}, binary.LittleEndian), errTooBig},
+		{npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000, 1000000000), }\n", []float64{1}, binary.LittleEndian), io.ErrUnexpectedEOF},
+		{npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (1000000000, 1000000000), }\n", []float64{}, binary.LittleEndian), io.ErrUnexpectedEOF},
+		{longHeader, errNpyHeader},
+	} {
+		_, err := ReadNpy(bytes.NewReader(test.input))
+		if err != test.want {
+			t.Errorf("unexpected error for input %d: got %v, want %v", i, err, test.want)
+		}
+	}
+
+	// Data spanning several read chunks.
+	const r, c = 100, 200
+	data := make([]float64, r*c)
+	for i := range data {
+		data[i] = float64(i)
+	}
+	got, err := ReadNpy(bytes.NewReader(npyBytes("{'descr': '<f8', 'fortran_order': False, 'shape': (100, 200), }\n", data, binary.LittleEndian)))
+	if err != nil {
+		t.Fatalf("unexpected error for large input: %v", err)
+	}
+	if !Equal(got, NewDense(r, c, data)) {
+		t.Errorf("unexpected result for large input")
+	}
+}
+
+func TestNpyRoundTrip(t *testing.T) {
+	for _, a := range []Matrix{
+		NewDense(2, 3, []float64{1, 2, 3, 4, 5, 6.5}),
+		NewVecDense(3, []float64{1, -2, 3e-20}),
+		NewSymDense(2, []float64{1, 2, 2, 3}),
+		NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6}).T(),
+	} {
+		var buf bytes.Buffer
+		err := WriteNpy(&buf, a)
+		if err != nil {
+			t.Errorf("unexpected error writing %T: %v", a, err)
+			continue
+		}
+		if n := bytes.IndexByte(buf.Bytes(), '\n'); (n+1)%64 != 0 {
+			t.Errorf("header of %T not aligned to 64 bytes: %d", a, n+1)
+		}
+		got, err := ReadNpy(&buf)
+		if err != nil {
+			t.Errorf("unexpected error reading %T: %v", a, err)
+			continue
+		}
+		if !Equal(got, a) {
+			t.Errorf("%T round trip mismatch:\ngot:\n%v\nwant:\n%v", a, Formatted(got), Formatted(a))
+		}
+	}
+}
+
+func TestNpzRoundTrip(t *testing.T) {
+	arrays := map[string]Matrix{
+		"a": NewDense(2, 2, []float64{1, 2, 3, 4}),
+		"v": NewVecDense(3, []float64{5, 6, 7}),
+	}
+	var buf bytes.Buffer
+	err := WriteNpz(&buf, arrays)
+	if err != nil {
+		t.Fatalf("unexpected error writing npz: %v", err)
+	}
+	got, err := ReadNpz(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("unexpected error reading npz: %v", err)
+	}
+	if len(got) != len(arrays) {
+		t.Errorf("unexpected number of arrays: got %d, want %d", len(got), len(arrays))
+	}
+	for name, want := range arrays {
+		if !Equal(got[name], want) {
+			t.Errorf("mismatch for array %q", name)
+		}
+	}
+}
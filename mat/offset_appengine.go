@@ -8,7 +8,10 @@ package mat
 
 import "reflect"
 
-var sizeOfFloat64 = int(reflect.TypeOf(float64(0)).Size())
+var (
+	sizeOfFloat64    = int(reflect.TypeOf(float64(0)).Size())
+	sizeOfComplex128 = int(reflect.TypeOf(complex128(0)).Size())
+)
 
 // offset returns the number of float64 values b[0] is after a[0].
 func offset(a, b []float64) int {
@@ -22,3 +25,15 @@ func offset(a, b []float64) int {
 	// move. See https://golang.org/issue/12445.
 	return int(vb0.UnsafeAddr()-va0.UnsafeAddr()) / sizeOfFloat64
 }
+
+func offsetComplex(a, b []complex128) int {
+	va0 := reflect.ValueOf(a).Index(0)
+	vb0 := reflect.ValueOf(b).Index(0)
+	if va0.Addr() == vb0.Addr() {
+		return 0
+	}
+	// This expression must be atomic with respect to GC moves.
+	// At this stage this is true, because the GC does not
+	// move. See https://golang.org/issue/12445.
+	return int(vb0.UnsafeAddr()-va0.UnsafeAddr()) / sizeOfComplex128
+}
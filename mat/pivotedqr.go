@@ -0,0 +1,204 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+const badPivotedQR = "mat: invalid pivoted QR factorization"
+
+// PivotedQR is a type for creating and using the QR factorization with column
+// pivoting of a matrix. The factorization has the form
+//  A * P = Q * R
+// where P is a permutation matrix, Q is an orthonormal matrix and R is an upper
+// trapezoidal matrix whose diagonal elements are non-increasing in absolute
+// value. The pivoting makes the factorization rank-revealing, so it can be
+// used to determine the numerical rank of A.
+type PivotedQR struct {
+	qr    *Dense
+	tau   []float64
+	pivot []int
+	cond  float64
+}
+
+func (qr *PivotedQR) updateCond() {
+	// A * P = Q * R, where Q is orthonormal and P is a permutation. Neither
+	// multiplication changes the condition number, so it is the condition
+	// number of R.
+	r, c := qr.qr.Dims()
+	k := min(r, c)
+	work := getFloats(3*k, false)
+	iwork := getInts(k, false)
+	t := qr.qr.asTriDense(k, blas.NonUnit, blas.Upper)
+	v := lapack64.Trcon(CondNorm, t.mat, work, iwork)
+	putFloats(work)
+	putInts(iwork)
+	qr.cond = 1 / v
+}
+
+// Factorize computes the QR factorization with column pivoting of the m×n
+// matrix a. The factorization always exists even if A is singular.
+//
+// The factors can be extracted using the QTo, RTo and PTo methods.
+func (qr *PivotedQR) Factorize(a Matrix) {
+	m, n := a.Dims()
+	if qr.qr == nil {
+		qr.qr = &Dense{}
+	}
+	qr.qr.Clone(a)
+	qr.tau = make([]float64, min(m, n))
+	if cap(qr.pivot) < n {
+		qr.pivot = make([]int, n)
+	}
+	qr.pivot = qr.pivot[:n]
+	for i := range qr.pivot {
+		// All columns are free to be pivoted.
+		qr.pivot[i] = -1
+	}
+
+	work := []float64{0}
+	lapack64.Geqp3(qr.qr.mat, qr.pivot, qr.tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Geqp3(qr.qr.mat, qr.pivot, qr.tau, work, len(work))
+	putFloats(work)
+	qr.updateCond()
+}
+
+func (qr *PivotedQR) isZero() bool {
+	return len(qr.pivot) == 0
+}
+
+// Cond returns the condition number of the factorized matrix, estimated from
+// the leading min(m,n)×min(m,n) upper triangle of R.
+func (qr *PivotedQR) Cond() float64 {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	return qr.cond
+}
+
+// Rank returns the numerical rank of the factorized matrix, that is the number
+// of diagonal elements of R whose absolute value is greater than tol times the
+// absolute value of the first diagonal element of R. Rank will panic if tol is
+// negative.
+//
+// The numerical rank determined from a column-pivoted QR factorization is
+// reliable in most cases, but may overestimate the rank for some matrices.
+// The SVD should be used when a guaranteed rank estimate is required.
+func (qr *PivotedQR) Rank(tol float64) int {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	if tol < 0 {
+		panic("mat: negative tolerance")
+	}
+	r, c := qr.qr.Dims()
+	k := min(r, c)
+	if k == 0 {
+		return 0
+	}
+	stride := qr.qr.mat.Stride
+	thresh := tol * math.Abs(qr.qr.mat.Data[0])
+	var rank int
+	for i := 0; i < k; i++ {
+		if math.Abs(qr.qr.mat.Data[i*stride+i]) <= thresh {
+			break
+		}
+		rank++
+	}
+	return rank
+}
+
+// Pivot returns the column permutation of the factorization. Column j of A*P
+// is column pivot[j] of A. If dst is nil, a new slice is allocated, otherwise
+// dst must have length n and the permutation is stored into it.
+func (qr *PivotedQR) Pivot(dst []int) []int {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	if dst == nil {
+		dst = make([]int, len(qr.pivot))
+	}
+	if len(dst) != len(qr.pivot) {
+		panic(badSliceLength)
+	}
+	copy(dst, qr.pivot)
+	return dst
+}
+
+// PTo extracts the n×n permutation matrix P from a pivoted QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting P matrix is returned.
+func (qr *PivotedQR) PTo(dst *Dense) *Dense {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	n := len(qr.pivot)
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAsZeroed(n, n)
+	}
+	for j, p := range qr.pivot {
+		dst.mat.Data[p*dst.mat.Stride+j] = 1
+	}
+	return dst
+}
+
+// RTo extracts the m×n upper trapezoidal matrix from a pivoted QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting dst matrix is returned.
+func (qr *PivotedQR) RTo(dst *Dense) *Dense {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+	for i := 0; i < min(r, c); i++ {
+		copy(dst.mat.Data[i*dst.mat.Stride+i:i*dst.mat.Stride+c], qr.qr.mat.Data[i*qr.qr.mat.Stride+i:i*qr.qr.mat.Stride+c])
+	}
+	return dst
+}
+
+// QTo extracts the m×m orthonormal matrix Q from a pivoted QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+func (qr *PivotedQR) QTo(dst *Dense) *Dense {
+	if qr.isZero() {
+		panic(badPivotedQR)
+	}
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r*r; i += r + 1 {
+		dst.mat.Data[i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	v := blas64.General{
+		Rows:   r,
+		Cols:   len(qr.tau),
+		Stride: qr.qr.mat.Stride,
+		Data:   qr.qr.mat.Data,
+	}
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, blas.NoTrans, v, qr.tau, dst.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, blas.NoTrans, v, qr.tau, dst.mat, work, len(work))
+	putFloats(work)
+
+	return dst
+}
@@ -0,0 +1,106 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestPivotedQR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, rank int
+	}{
+		{1, 1, 1},
+		{5, 5, 5},
+		{10, 5, 5},
+		{5, 10, 5},
+		{10, 5, 3},
+		{5, 10, 2},
+		{20, 20, 7},
+		{8, 6, 0},
+	} {
+		m, n := test.m, test.n
+		prefix := fmt.Sprintf("m=%d,n=%d,rank=%d", m, n, test.rank)
+
+		// Construct a matrix with the given rank as a product of
+		// m×rank and rank×n random matrices.
+		a := NewDense(m, n, nil)
+		if test.rank > 0 {
+			x := NewDense(m, test.rank, nil)
+			for i := range x.mat.Data {
+				x.mat.Data[i] = rnd.NormFloat64()
+			}
+			y := NewDense(test.rank, n, nil)
+			for i := range y.mat.Data {
+				y.mat.Data[i] = rnd.NormFloat64()
+			}
+			a.Mul(x, y)
+		}
+		aCopy := DenseCopyOf(a)
+
+		var qr PivotedQR
+		qr.Factorize(a)
+		if !Equal(a, aCopy) {
+			t.Errorf("%s: a modified during factorization", prefix)
+		}
+
+		q := qr.QTo(nil)
+		if !isOrthonormal(q, 1e-12) {
+			t.Errorf("%s: Q is not orthonormal", prefix)
+		}
+		r := qr.RTo(nil)
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				if r.At(i, j) != 0 {
+					t.Errorf("%s: R not upper trapezoidal at (%d,%d)", prefix, i, j)
+				}
+			}
+		}
+		for i := 1; i < min(m, n); i++ {
+			if math.Abs(r.At(i, i)) > math.Abs(r.At(i-1, i-1))*(1+1e-12) {
+				t.Errorf("%s: diagonal of R is not non-increasing at %d", prefix, i)
+			}
+		}
+		p := qr.PTo(nil)
+		var ap, qrMat Dense
+		ap.Mul(a, p)
+		qrMat.Mul(q, r)
+		if !EqualApprox(&ap, &qrMat, 1e-12) {
+			t.Errorf("%s: A*P != Q*R", prefix)
+		}
+
+		pivot := qr.Pivot(nil)
+		for j, pj := range pivot {
+			for i := 0; i < m; i++ {
+				if ap.At(i, j) != a.At(i, pj) {
+					t.Errorf("%s: pivot does not match permutation matrix", prefix)
+					break
+				}
+			}
+		}
+
+		if rank := qr.Rank(1e-10); rank != test.rank {
+			t.Errorf("%s: unexpected rank: got %d want %d", prefix, rank, test.rank)
+		}
+		if rank := qr.Rank(0); test.rank == 0 && rank != 0 {
+			t.Errorf("%s: unexpected rank with zero tolerance: got %d want 0", prefix, rank)
+		}
+		if test.rank == min(m, n) && qr.Cond() > 1e8 {
+			t.Errorf("%s: unexpectedly large condition number %v for full rank matrix", prefix, qr.Cond())
+		}
+		if test.rank < min(m, n) && qr.Cond() < 1e12 {
+			t.Errorf("%s: unexpectedly small condition number %v for rank deficient matrix", prefix, qr.Cond())
+		}
+	}
+
+	var qr PivotedQR
+	if panicked, _ := panics(func() { qr.Rank(0) }); !panicked {
+		t.Errorf("expected panic using an unfactorized PivotedQR")
+	}
+}
@@ -17,6 +17,11 @@ type QR struct {
 	qr   *Dense
 	tau  []float64
 	cond float64
+
+	// q holds the explicit orthonormal factor once the
+	// factorization has been updated. In this case qr
+	// holds R and tau is not used.
+	q *Dense
 }
 
 func (qr *QR) updateCond() {
@@ -48,6 +53,7 @@ func (qr *QR) Factorize(a Matrix) {
 		qr.qr = &Dense{}
 	}
 	qr.qr.Clone(a)
+	qr.q = nil
 	work := []float64{0}
 	qr.tau = make([]float64, k)
 	lapack64.Geqrf(qr.qr.mat, qr.tau, work, -1)
@@ -101,6 +107,10 @@ func (qr *QR) QTo(dst *Dense) *Dense {
 	} else {
 		dst.reuseAsZeroed(r, r)
 	}
+	if qr.q != nil {
+		dst.Copy(qr.q)
+		return dst
+	}
 
 	// Set Q = I.
 	for i := 0; i < r*r; i += r + 1 {
@@ -158,17 +168,9 @@ func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
 		for i := c; i < r; i++ {
 			zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
 		}
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.NoTrans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
+		qr.mulQ(blas.NoTrans, x)
 	} else {
-		work := []float64{0}
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, -1)
-		work = getFloats(int(work[0]), false)
-		lapack64.Ormqr(blas.Left, blas.Trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
-		putFloats(work)
+		qr.mulQ(blas.Trans, x)
 
 		ok := lapack64.Trtrs(blas.NoTrans, t, x.mat)
 		if !ok {
@@ -184,6 +186,27 @@ func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
 	return nil
 }
 
+// mulQ computes x = Q * x or x = Q^T * x depending on trans.
+func (qr *QR) mulQ(trans blas.Transpose, x *Dense) {
+	if qr.q != nil {
+		r, c := x.Dims()
+		tmp := getWorkspace(r, c, false)
+		if trans == blas.Trans {
+			tmp.Mul(qr.q.T(), x)
+		} else {
+			tmp.Mul(qr.q, x)
+		}
+		x.Copy(tmp)
+		putWorkspace(tmp)
+		return
+	}
+	work := []float64{0}
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
+	putFloats(work)
+}
+
 // SolveVec finds a minimum-norm solution to a system of linear equations.
 // Please see QR.Solve for the full documentation.
 func (qr *QR) SolveVec(v *VecDense, trans bool, b *VecDense) error {
@@ -200,3 +223,293 @@ func (qr *QR) SolveVec(v *VecDense, trans bool, b *VecDense) error {
 	}
 	return qr.Solve(v.asDense(), trans, b.asDense())
 }
+
+// RankOne updates a QR factorization as if a rank-one update had been applied
+// to the original matrix A, storing the result into the receiver. That is, if
+// in the original QR decomposition Q * R = A, in the updated decomposition
+//  Q * R = A + alpha * x * y^T.
+// RankOne will panic if orig does not hold a factorization, or if the length
+// of x does not match the number of rows of A or the length of y does not
+// match the number of columns of A.
+//
+// RankOne uses Givens rotations as described in section 12.5.1 of Golub and
+// Van Loan, Matrix Computations, 3rd edition, and takes O(m²) time for an
+// m×n matrix A, compared to O(m²n) for computing the factorization from
+// scratch. After any update the receiver holds Q explicitly.
+func (qr *QR) RankOne(orig *QR, alpha float64, x, y *VecDense) {
+	if orig.qr == nil {
+		panic(badFact)
+	}
+	m, n := orig.qr.Dims()
+	if x.Len() != m || y.Len() != n {
+		panic(ErrShape)
+	}
+	qr.explicitFrom(orig)
+	r := qr.qr
+	q := qr.q
+
+	// w = Q^T * x. The scaling by alpha is applied when the update is added
+	// to the first row of H.
+	var w VecDense
+	w.MulVec(q.T(), x)
+	wd := w.mat.Data
+
+	// Rotate w into a multiple of e_0, transforming R into upper
+	// Hessenberg form.
+	for k := m - 1; k > 0; k-- {
+		c, s, rk, _ := blas64.Rotg(wd[k-1], wd[k])
+		wd[k-1] = rk
+		wd[k] = 0
+		rotRows(r, k-1, k, k-1, c, s)
+		rotCols(q, k-1, k, c, s)
+	}
+
+	// Apply the update to the first row of H.
+	for j := 0; j < n; j++ {
+		r.mat.Data[j] += alpha * wd[0] * y.at(j)
+	}
+
+	// Restore upper triangular form.
+	for k := 0; k < min(n, m-1); k++ {
+		c, s, rk, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rk)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+	qr.updateCond()
+}
+
+// InsertRow updates a QR factorization as if the row x had been inserted
+// into the original matrix A before row i, storing the result into the
+// receiver. After the update the factorized matrix has one more row than A.
+// InsertRow will panic if orig does not hold a factorization, if i is not in
+// [0, m] where m is the number of rows of A, or if the length of x does not
+// match the number of columns of A.
+//
+// InsertRow takes O(m²) time. After any update the receiver holds Q
+// explicitly.
+func (qr *QR) InsertRow(orig *QR, i int, x *VecDense) {
+	if orig.qr == nil {
+		panic(badFact)
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m < i {
+		panic(ErrRowAccess)
+	}
+	if x.Len() != n {
+		panic(ErrShape)
+	}
+	qr.explicitFrom(orig)
+
+	// Form
+	//  [ x^T ] = [ 1 0 ] * [ x^T ]
+	//  [ A   ]   [ 0 Q ]   [ R   ],
+	// where the right-most matrix is upper Hessenberg.
+	r := NewDense(m+1, n, nil)
+	for j := 0; j < n; j++ {
+		r.mat.Data[j] = x.at(j)
+	}
+	r.Slice(1, m+1, 0, n).(*Dense).Copy(qr.qr)
+	q := NewDense(m+1, m+1, nil)
+	q.mat.Data[0] = 1
+	q.Slice(1, m+1, 1, m+1).(*Dense).Copy(qr.q)
+
+	// Restore upper triangular form.
+	for k := 0; k < n; k++ {
+		c, s, rk, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rk)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+
+	// Move the first row of Q to row i.
+	row := make([]float64, m+1)
+	copy(row, q.rawRowView(0))
+	for k := 0; k < i; k++ {
+		copy(q.rawRowView(k), q.rawRowView(k+1))
+	}
+	copy(q.rawRowView(i), row)
+
+	qr.qr = r
+	qr.q = q
+	qr.updateCond()
+}
+
+// DeleteRow updates a QR factorization as if row i had been removed from the
+// original matrix A, storing the result into the receiver. After the update
+// the factorized matrix has one less row than A. DeleteRow will panic if
+// orig does not hold a factorization, if i is not in [0, m) where m is the
+// number of rows of A, or if A has no more rows than columns.
+//
+// DeleteRow takes O(m²) time. After any update the receiver holds Q
+// explicitly.
+func (qr *QR) DeleteRow(orig *QR, i int) {
+	if orig.qr == nil {
+		panic(badFact)
+	}
+	m, n := orig.qr.Dims()
+	if i < 0 || m <= i {
+		panic(ErrRowAccess)
+	}
+	if m-1 < n {
+		panic(ErrShape)
+	}
+	qr.explicitFrom(orig)
+	r := qr.qr
+	q := qr.q
+
+	// Rotate row i of Q into a multiple of e_0^T, transforming R into
+	// upper Hessenberg form. The first column of Q is then ±e_i.
+	for k := m - 1; k > 0; k-- {
+		c, s, _, _ := blas64.Rotg(q.at(i, k-1), q.at(i, k))
+		rotCols(q, k-1, k, c, s)
+		q.set(i, k, 0)
+		rotRows(r, k-1, k, k-1, c, s)
+	}
+
+	// Remove row i and the first column from Q and the first row from R.
+	qNew := NewDense(m-1, m-1, nil)
+	for k, l := 0, 0; k < m; k++ {
+		if k == i {
+			continue
+		}
+		copy(qNew.rawRowView(l), q.rawRowView(k)[1:])
+		l++
+	}
+	rNew := NewDense(m-1, n, nil)
+	rNew.Copy(r.Slice(1, m, 0, n))
+
+	qr.qr = rNew
+	qr.q = qNew
+	qr.updateCond()
+}
+
+// InsertCol updates a QR factorization as if the column x had been inserted
+// into the original matrix A before column j, storing the result into the
+// receiver. After the update the factorized matrix has one more column than
+// A. InsertCol will panic if orig does not hold a factorization, if j is not
+// in [0, n] where n is the number of columns of A, if the length of x does
+// not match the number of rows of A, or if A has no more rows than columns.
+//
+// InsertCol takes O(m²) time. After any update the receiver holds Q
+// explicitly.
+func (qr *QR) InsertCol(orig *QR, j int, x *VecDense) {
+	if orig.qr == nil {
+		panic(badFact)
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n < j {
+		panic(ErrColAccess)
+	}
+	if x.Len() != m || m < n+1 {
+		panic(ErrShape)
+	}
+	qr.explicitFrom(orig)
+	q := qr.q
+
+	// Form [R[:,:j] Q^T*x R[:,j:]].
+	r := NewDense(m, n+1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(qr.qr.Slice(0, m, 0, j))
+	}
+	r.ColView(j).MulVec(q.T(), x)
+	if j < n {
+		r.Slice(0, m, j+1, n+1).(*Dense).Copy(qr.qr.Slice(0, m, j, n))
+	}
+
+	// Zero the new column below the diagonal. The rotations do not
+	// destroy the triangular structure of the columns to the right.
+	for k := m - 1; k > j; k-- {
+		c, s, rk, _ := blas64.Rotg(r.at(k-1, j), r.at(k, j))
+		r.set(k-1, j, rk)
+		r.set(k, j, 0)
+		rotRows(r, k-1, k, max(j+1, k), c, s)
+		rotCols(q, k-1, k, c, s)
+	}
+
+	qr.qr = r
+	qr.updateCond()
+}
+
+// DeleteCol updates a QR factorization as if column j had been removed from
+// the original matrix A, storing the result into the receiver. After the
+// update the factorized matrix has one less column than A. DeleteCol will
+// panic if orig does not hold a factorization, if j is not in [0, n) where n
+// is the number of columns of A, or if A has only one column.
+//
+// DeleteCol takes O(m*n) time. After any update the receiver holds Q
+// explicitly.
+func (qr *QR) DeleteCol(orig *QR, j int) {
+	if orig.qr == nil {
+		panic(badFact)
+	}
+	m, n := orig.qr.Dims()
+	if j < 0 || n <= j {
+		panic(ErrColAccess)
+	}
+	if n == 1 {
+		panic(ErrShape)
+	}
+	qr.explicitFrom(orig)
+	q := qr.q
+
+	// Remove column j of R, leaving an upper Hessenberg
+	// matrix in the columns from j onwards.
+	r := NewDense(m, n-1, nil)
+	if j > 0 {
+		r.Slice(0, m, 0, j).(*Dense).Copy(qr.qr.Slice(0, m, 0, j))
+	}
+	if j < n-1 {
+		r.Slice(0, m, j, n-1).(*Dense).Copy(qr.qr.Slice(0, m, j+1, n))
+	}
+
+	// Restore upper triangular form.
+	for k := j; k < n-1; k++ {
+		c, s, rk, _ := blas64.Rotg(r.at(k, k), r.at(k+1, k))
+		r.set(k, k, rk)
+		r.set(k+1, k, 0)
+		rotRows(r, k, k+1, k+1, c, s)
+		rotCols(q, k, k+1, c, s)
+	}
+
+	qr.qr = r
+	qr.updateCond()
+}
+
+// explicitFrom sets the receiver to hold the factorization in orig with an
+// explicit orthonormal factor Q, ready to be updated.
+func (qr *QR) explicitFrom(orig *QR) {
+	if orig == qr && qr.q != nil {
+		return
+	}
+	q := orig.QTo(nil)
+	r := orig.RTo(nil)
+	qr.q = q
+	qr.qr = r
+	qr.tau = nil
+	qr.cond = orig.cond
+}
+
+// rotRows applies the plane rotation defined by c and s to rows i and k of
+// a, starting from column j.
+func rotRows(a *Dense, i, k, j int, c, s float64) {
+	n := a.mat.Cols
+	if j >= n {
+		return
+	}
+	blas64.Rot(n-j,
+		blas64.Vector{Inc: 1, Data: a.mat.Data[i*a.mat.Stride+j : i*a.mat.Stride+n]},
+		blas64.Vector{Inc: 1, Data: a.mat.Data[k*a.mat.Stride+j : k*a.mat.Stride+n]},
+		c, s)
+}
+
+// rotCols applies the plane rotation defined by c and s to columns i and k
+// of a.
+func rotCols(a *Dense, i, k int, c, s float64) {
+	blas64.Rot(a.mat.Rows,
+		blas64.Vector{Inc: a.mat.Stride, Data: a.mat.Data[i:]},
+		blas64.Vector{Inc: a.mat.Stride, Data: a.mat.Data[k:]},
+		c, s)
+}
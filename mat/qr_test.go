@@ -203,3 +203,186 @@ func TestSolveQRCond(t *testing.T) {
 		}
 	}
 }
+
+func TestQRUpdate(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	randVec := func(n int) *VecDense {
+		v := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			v.SetVec(i, rnd.NormFloat64())
+		}
+		return v
+	}
+	for _, test := range []struct{ m, n int }{
+		{2, 1},
+		{3, 3},
+		{5, 3},
+		{10, 4},
+		{8, 7},
+	} {
+		m, n := test.m, test.n
+		a := randNormDense(m, n, rnd)
+
+		for _, inPlace := range []bool{false, true} {
+			// RankOne.
+			x := randVec(m)
+			y := randVec(n)
+			var want Dense
+			want.Outer(0.5, x, y)
+			want.Add(&want, a)
+			var orig, got QR
+			orig.Factorize(a)
+			dst := &got
+			if inPlace {
+				dst = &orig
+			}
+			dst.RankOne(&orig, 0.5, x, y)
+			checkQRUpdate(t, "RankOne", dst, &want)
+
+			// InsertRow.
+			for _, i := range []int{0, m / 2, m} {
+				x := randVec(n)
+				want := NewDense(m+1, n, nil)
+				for k := 0; k < m+1; k++ {
+					switch {
+					case k < i:
+						want.SetRow(k, a.RawRowView(k))
+					case k == i:
+						want.SetRow(k, x.RawVector().Data)
+					default:
+						want.SetRow(k, a.RawRowView(k-1))
+					}
+				}
+				var orig, got QR
+				orig.Factorize(a)
+				dst := &got
+				if inPlace {
+					dst = &orig
+				}
+				dst.InsertRow(&orig, i, x)
+				checkQRUpdate(t, "InsertRow", dst, want)
+			}
+
+			// DeleteRow.
+			if m > n {
+				for _, i := range []int{0, m / 2, m - 1} {
+					want := NewDense(m-1, n, nil)
+					for k, l := 0, 0; k < m; k++ {
+						if k == i {
+							continue
+						}
+						want.SetRow(l, a.RawRowView(k))
+						l++
+					}
+					var orig, got QR
+					orig.Factorize(a)
+					dst := &got
+					if inPlace {
+						dst = &orig
+					}
+					dst.DeleteRow(&orig, i)
+					checkQRUpdate(t, "DeleteRow", dst, want)
+				}
+			}
+
+			// InsertCol.
+			if m > n {
+				for _, j := range []int{0, n / 2, n} {
+					x := randVec(m)
+					want := NewDense(m, n+1, nil)
+					for k := 0; k < n+1; k++ {
+						switch {
+						case k < j:
+							want.ColView(k).CopyVec(a.ColView(k))
+						case k == j:
+							want.ColView(k).CopyVec(x)
+						default:
+							want.ColView(k).CopyVec(a.ColView(k - 1))
+						}
+					}
+					var orig, got QR
+					orig.Factorize(a)
+					dst := &got
+					if inPlace {
+						dst = &orig
+					}
+					dst.InsertCol(&orig, j, x)
+					checkQRUpdate(t, "InsertCol", dst, want)
+				}
+			}
+
+			// DeleteCol.
+			if n > 1 {
+				for _, j := range []int{0, n / 2, n - 1} {
+					want := NewDense(m, n-1, nil)
+					for k, l := 0, 0; k < n; k++ {
+						if k == j {
+							continue
+						}
+						want.ColView(l).CopyVec(a.ColView(k))
+						l++
+					}
+					var orig, got QR
+					orig.Factorize(a)
+					dst := &got
+					if inPlace {
+						dst = &orig
+					}
+					dst.DeleteCol(&orig, j)
+					checkQRUpdate(t, "DeleteCol", dst, want)
+				}
+			}
+		}
+	}
+
+	// Recursive least squares: a sequence of row insertions followed by a
+	// solve must agree with a solve using a fresh factorization.
+	const n = 4
+	a := randNormDense(n, n, rnd)
+	var qr QR
+	qr.Factorize(a)
+	for k := 0; k < 10; k++ {
+		x := randVec(n)
+		qr.InsertRow(&qr, n+k, x)
+		var grown Dense
+		grown.Stack(a, x.T())
+		a = &grown
+	}
+	b := randNormDense(n+10, 1, rnd)
+	var got, want Dense
+	if err := qr.Solve(&got, false, b); err != nil {
+		t.Errorf("unexpected error solving with updated QR: %v", err)
+	}
+	var fresh QR
+	fresh.Factorize(a)
+	fresh.Solve(&want, false, b)
+	if !EqualApprox(&got, &want, 1e-12) {
+		t.Errorf("least-squares solution mismatch after row insertions")
+	}
+}
+
+func checkQRUpdate(t *testing.T, name string, qr *QR, want *Dense) {
+	m, n := want.Dims()
+	if r, c := qr.qr.Dims(); r != m || c != n {
+		t.Errorf("%s: unexpected dimensions %d×%d, want %d×%d", name, r, c, m, n)
+		return
+	}
+	q := qr.QTo(nil)
+	if !isOrthonormal(q, 1e-13) {
+		t.Errorf("%s: %d×%d: Q is not orthonormal", name, m, n)
+	}
+	r := qr.RTo(nil)
+	for i := 1; i < m; i++ {
+		for j := 0; j < min(i, n); j++ {
+			if r.At(i, j) != 0 {
+				t.Errorf("%s: %d×%d: R is not upper triangular", name, m, n)
+				return
+			}
+		}
+	}
+	var got Dense
+	got.Mul(q, r)
+	if !EqualApprox(&got, want, 1e-13) {
+		t.Errorf("%s: %d×%d: Q*R does not equal the updated matrix", name, m, n)
+	}
+}
@@ -0,0 +1,262 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// Schur is a type for creating and using the real Schur decomposition of a
+// square matrix.
+type Schur struct {
+	vectors bool
+
+	t      *Dense
+	z      *Dense
+	values []complex128
+}
+
+// succFact returns whether the receiver contains a successful factorization.
+func (s *Schur) succFact() bool {
+	return s.values != nil
+}
+
+// Factorize computes the real Schur decomposition of the square matrix a.
+// The real Schur decomposition is defined as
+//  A = Z * T * Z^T
+// where Z is an orthogonal matrix of Schur vectors and T is an upper
+// quasi-triangular matrix in Schur canonical form. T is block upper triangular
+// with 1×1 and 2×2 blocks on the diagonal. Each 1×1 block holds a real
+// eigenvalue of A and each 2×2 block holds a complex conjugate pair of
+// eigenvalues and is in standard form
+//  [ a b ]
+//  [ c a ]
+// with b*c < 0. If the vectors input argument is false, the Schur vectors are
+// not computed.
+//
+// Factorize returns whether the decomposition succeeded. If the decomposition
+// failed, methods that require a successful factorization will panic.
+func (s *Schur) Factorize(a Matrix, vectors bool) (ok bool) {
+	r, c := a.Dims()
+	if r != c {
+		panic(ErrSquare)
+	}
+	n := r
+
+	t := NewDense(n, n, nil)
+	t.Copy(a)
+
+	// Reduce A to upper Hessenberg form.
+	tau := make([]float64, n-1)
+	work := []float64{0}
+	lapack64.Gehrd(t.mat, 0, n-1, tau, work, -1)
+	work = getFloats(int(work[0]), false)
+	lapack64.Gehrd(t.mat, 0, n-1, tau, work, len(work))
+
+	var z *Dense
+	compz := lapack.EVComp(lapack.None)
+	if vectors {
+		// Form the orthogonal matrix Q used in the reduction.
+		z = NewDense(n, n, nil)
+		z.Copy(t)
+		work[0] = 0
+		lapack64.Orghr(0, n-1, z.mat, tau, work, -1)
+		if int(work[0]) > len(work) {
+			putFloats(work)
+			work = getFloats(int(work[0]), false)
+		}
+		lapack64.Orghr(0, n-1, z.mat, tau, work, len(work))
+		compz = lapack.OriginalEV
+	} else {
+		z = &Dense{}
+	}
+	putFloats(work)
+
+	// Zero the elements below the first subdiagonal that
+	// hold the elementary reflectors.
+	for i := 2; i < n; i++ {
+		zero(t.mat.Data[i*t.mat.Stride : i*t.mat.Stride+i-1])
+	}
+
+	wr := make([]float64, n)
+	wi := make([]float64, n)
+	work = []float64{0}
+	lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, t.mat, 0, n-1, wr, wi, z.mat, work, -1)
+	work = getFloats(max(n, int(work[0])), false)
+	unconverged := lapack64.Hseqr(lapack.EigenvaluesAndSchur, compz, t.mat, 0, n-1, wr, wi, z.mat, work, len(work))
+	putFloats(work)
+	if unconverged != 0 {
+		s.vectors = false
+		s.t = nil
+		s.z = nil
+		s.values = nil
+		return false
+	}
+
+	s.vectors = vectors
+	s.t = t
+	if vectors {
+		s.z = z
+	} else {
+		s.z = nil
+	}
+	values := make([]complex128, n)
+	for i, v := range wr {
+		values[i] = complex(v, wi[i])
+	}
+	s.values = values
+	return true
+}
+
+// Values extracts the eigenvalues of the factorized matrix in the order in
+// which they appear on the diagonal of T. Complex conjugate pairs of eigenvalues
+// appear consecutively with the eigenvalue having the positive imaginary part
+// first. If dst is non-nil, the values are stored in-place into dst. In this
+// case dst must have length n, otherwise Values will panic. If dst is nil, then
+// a new slice will be allocated of the proper length and filled with the
+// eigenvalues.
+//
+// Values panics if the Schur decomposition was not successful.
+func (s *Schur) Values(dst []complex128) []complex128 {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if dst == nil {
+		dst = make([]complex128, len(s.values))
+	}
+	if len(dst) != len(s.values) {
+		panic(ErrSliceLengthMismatch)
+	}
+	copy(dst, s.values)
+	return dst
+}
+
+// TTo extracts the n×n upper quasi-triangular Schur form T from the
+// decomposition. If dst is nil, a new matrix is allocated. The resulting
+// dst matrix is returned.
+//
+// TTo panics if the Schur decomposition was not successful.
+func (s *Schur) TTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	n := len(s.values)
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(s.t)
+	return dst
+}
+
+// ZTo extracts the n×n orthogonal matrix of Schur vectors Z from the
+// decomposition. If dst is nil, a new matrix is allocated. The resulting
+// dst matrix is returned.
+//
+// ZTo panics if the Schur decomposition was not successful or if the
+// decomposition did not compute the Schur vectors.
+func (s *Schur) ZTo(dst *Dense) *Dense {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	if !s.vectors {
+		panic(badNoVect)
+	}
+	n := len(s.values)
+	if dst == nil {
+		dst = NewDense(n, n, nil)
+	} else {
+		dst.reuseAs(n, n)
+	}
+	dst.Copy(s.z)
+	return dst
+}
+
+// Reorder reorders the Schur decomposition so that the eigenvalues selected
+// by selected are moved to the leading diagonal blocks of T. The i-th
+// eigenvalue, as returned by Values, is selected if selected[i] is true. To
+// keep the decomposition real, both eigenvalues of a complex conjugate pair are
+// moved if either of them is selected. The relative order of the selected
+// eigenvalues is preserved. selected must have length n, otherwise Reorder
+// will panic.
+//
+// Reorder returns the number m of selected eigenvalues, counting both members
+// of the selected complex conjugate pairs. If the Schur vectors were computed,
+// the leading m columns of Z form an orthonormal basis of the invariant
+// subspace of A corresponding to the selected eigenvalues.
+//
+// If ok is false, two adjacent blocks of T were too close to swap because the
+// problem is very ill-conditioned. In this case T and Z may have been
+// partially reordered but the decomposition is still valid.
+//
+// Reorder panics if the Schur decomposition was not successful.
+func (s *Schur) Reorder(selected []bool) (m int, ok bool) {
+	if !s.succFact() {
+		panic(badFact)
+	}
+	n := len(s.values)
+	if len(selected) != n {
+		panic(ErrSliceLengthMismatch)
+	}
+
+	compq := lapack.EVComp(lapack.None)
+	var q blas64.General
+	if s.vectors {
+		compq = lapack.UpdateSchur
+		q = s.z.mat
+	}
+	work := getFloats(n, false)
+	defer putFloats(work)
+
+	ok = true
+	var pair bool
+	for k := 0; k < n; k++ {
+		if pair {
+			pair = false
+			continue
+		}
+		swap := selected[k]
+		if k < n-1 && s.t.at(k+1, k) != 0 {
+			pair = true
+			swap = swap || selected[k+1]
+		}
+		if !swap {
+			continue
+		}
+		if k != m {
+			_, _, ok = lapack64.Trexc(compq, s.t.mat, q, k, m, work)
+			if !ok {
+				break
+			}
+		}
+		m++
+		if pair {
+			m++
+		}
+	}
+	s.updateValues()
+	return m, ok
+}
+
+// updateValues recomputes the eigenvalues from the diagonal blocks of T.
+func (s *Schur) updateValues() {
+	n := len(s.values)
+	for k := 0; k < n; k++ {
+		tkk := s.t.at(k, k)
+		if k == n-1 || s.t.at(k+1, k) == 0 {
+			s.values[k] = complex(tkk, 0)
+			continue
+		}
+		im := math.Sqrt(math.Abs(s.t.at(k, k+1))) * math.Sqrt(math.Abs(s.t.at(k+1, k)))
+		s.values[k] = complex(tkk, im)
+		s.values[k+1] = complex(tkk, -im)
+		k++
+	}
+}
@@ -0,0 +1,150 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+func TestSchur(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 31} {
+		for cas := 0; cas < 5; cas++ {
+			a := NewDense(n, n, nil)
+			for i := range a.mat.Data {
+				a.mat.Data[i] = rnd.NormFloat64()
+			}
+
+			var s Schur
+			ok := s.Factorize(a, true)
+			if !ok {
+				t.Fatalf("n=%d: bad factorization", n)
+			}
+			tm := s.TTo(nil)
+			z := s.ZTo(nil)
+			checkSchur(t, n, a, tm, z, s.Values(nil))
+
+			// The eigenvalues must agree with those from Eigen.
+			var e Eigen
+			e.Factorize(a, false, false)
+			got := s.Values(nil)
+			want := e.Values(nil)
+			sortComplex(got)
+			sortComplex(want)
+			for i := range got {
+				if cmplx.Abs(got[i]-want[i]) > 1e-10 {
+					t.Errorf("n=%d: eigenvalue mismatch: got %v want %v", n, got[i], want[i])
+				}
+			}
+
+			// Factorizing without vectors must give the same Schur form.
+			var s2 Schur
+			s2.Factorize(a, false)
+			if !EqualApprox(s2.TTo(nil), tm, 1e-14) {
+				t.Errorf("n=%d: Schur form mismatch when no vectors computed", n)
+			}
+			if panicked, _ := panics(func() { s2.ZTo(nil) }); !panicked {
+				t.Errorf("n=%d: expected panic for missing Schur vectors", n)
+			}
+
+			// Move the eigenvalues in the left half-plane to the top-left.
+			values := s.Values(nil)
+			selected := make([]bool, n)
+			wantM := 0
+			for i, v := range values {
+				selected[i] = real(v) < 0
+				if selected[i] {
+					wantM++
+				}
+			}
+			m, ok := s.Reorder(selected)
+			if !ok {
+				t.Errorf("n=%d: reordering failed", n)
+				continue
+			}
+			if m != wantM {
+				t.Errorf("n=%d: unexpected number of selected eigenvalues: got %d want %d", n, m, wantM)
+			}
+			values = s.Values(nil)
+			for i, v := range values {
+				if (i < m) != (real(v) < 0) {
+					t.Errorf("n=%d: eigenvalue %v at position %d not reordered with m=%d", n, v, i, m)
+				}
+			}
+			tm = s.TTo(tm)
+			z = s.ZTo(z)
+			checkSchur(t, n, a, tm, z, values)
+			if m > 0 && m < n {
+				// The leading m columns of Z span an invariant subspace.
+				z1 := z.Slice(0, n, 0, m)
+				var az1, z1t11 Dense
+				az1.Mul(a, z1)
+				z1t11.Mul(z1, tm.Slice(0, m, 0, m))
+				if !EqualApprox(&az1, &z1t11, 1e-10) {
+					t.Errorf("n=%d: leading Schur vectors do not span an invariant subspace", n)
+				}
+			}
+		}
+	}
+}
+
+// checkSchur checks that tm is in Schur canonical form with the given
+// eigenvalues on its diagonal, that z is orthonormal, and that a = z*tm*z^T.
+func checkSchur(t *testing.T, n int, a, tm, z *Dense, values []complex128) {
+	if !isOrthonormal(z, 1e-12) {
+		t.Errorf("n=%d: Z is not orthonormal", n)
+	}
+	var zt, ztz Dense
+	zt.Mul(z, tm)
+	ztz.Mul(&zt, z.T())
+	if !EqualApprox(&ztz, a, 1e-10) {
+		t.Errorf("n=%d: A != Z*T*Z^T", n)
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if tm.At(i, j) != 0 {
+				t.Errorf("n=%d: T not quasi-triangular at (%d,%d)", n, i, j)
+			}
+		}
+	}
+	for k := 0; k < n; k++ {
+		if k == n-1 || tm.At(k+1, k) == 0 {
+			if values[k] != complex(tm.At(k, k), 0) {
+				t.Errorf("n=%d: eigenvalue %d mismatch with diagonal of T", n, k)
+			}
+			continue
+		}
+		if k < n-2 && tm.At(k+2, k+1) != 0 {
+			t.Errorf("n=%d: consecutive nonzero subdiagonal elements at %d", n, k)
+		}
+		if tm.At(k, k) != tm.At(k+1, k+1) || tm.At(k, k+1)*tm.At(k+1, k) >= 0 {
+			t.Errorf("n=%d: 2×2 block at %d not in standard form", n, k)
+		}
+		im := math.Sqrt(-tm.At(k, k+1) * tm.At(k+1, k))
+		if math.Abs(imag(values[k])-im) > 1e-12*im || values[k+1] != cmplx.Conj(values[k]) {
+			t.Errorf("n=%d: complex eigenvalue pair %d mismatch with T", n, k)
+		}
+		k++
+	}
+}
+
+func sortComplex(v []complex128) {
+	sort.Sort(byRealImag(v))
+}
+
+type byRealImag []complex128
+
+func (v byRealImag) Len() int { return len(v) }
+func (v byRealImag) Less(i, j int) bool {
+	if real(v[i]) != real(v[j]) {
+		return real(v[i]) < real(v[j])
+	}
+	return imag(v[i]) < imag(v[j])
+}
+func (v byRealImag) Swap(i, j int) { v[i], v[j] = v[j], v[i] }
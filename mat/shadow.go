@@ -7,6 +7,7 @@ package mat
 import (
 	"gonum.org/v1/gonum/blas"
 	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/blas/cblas128"
 )
 
 const (
@@ -190,6 +191,46 @@ func (v *VecDense) checkOverlap(a blas64.Vector) bool {
 	return false
 }
 
+func (m *CDense) checkOverlap(a cblas128.General) bool {
+	mat := m.RawCMatrix()
+	if cap(mat.Data) == 0 || cap(a.Data) == 0 {
+		return false
+	}
+
+	off := offsetComplex(mat.Data[:1], a.Data[:1])
+
+	if off == 0 {
+		// At least one element overlaps.
+		if mat.Cols == a.Cols && mat.Rows == a.Rows && mat.Stride == a.Stride {
+			panic(regionIdentity)
+		}
+		panic(regionOverlap)
+	}
+
+	if off > 0 && len(mat.Data) <= off {
+		// We know m is completely before a.
+		return false
+	}
+	if off < 0 && len(a.Data) <= -off {
+		// We know m is completely after a.
+		return false
+	}
+
+	if mat.Stride != a.Stride {
+		// Too hard, so assume the worst.
+		panic(mismatchedStrides)
+	}
+
+	if off < 0 {
+		off = -off
+		mat.Cols, a.Cols = a.Cols, mat.Cols
+	}
+	if rectanglesOverlap(off, mat.Cols, a.Cols, mat.Stride) {
+		panic(regionOverlap)
+	}
+	return false
+}
+
 // rectanglesOverlap returns whether the strided rectangles a and b overlap
 // when b is offset by off elements after a but has at least one element before
 // the end of a. off must be positive. a and b have aCols and bCols respectively.
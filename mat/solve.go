@@ -30,6 +30,8 @@ func (m *Dense) Solve(a, b Matrix) error {
 	aU, aTrans := untranspose(a)
 	bU, bTrans := untranspose(b)
 	switch rma := aU.(type) {
+	case *Tridiag:
+		return m.solveTridiag(rma, aTrans, b)
 	case RawTriangular:
 		side := blas.Left
 		tA := blas.NoTrans
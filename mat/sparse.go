@@ -0,0 +1,670 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/internal/asm/f64"
+)
+
+var (
+	triplet *Triplet
+	_       Matrix      = triplet
+	_       NonZeroDoer = triplet
+
+	csr *CSR
+	_   Matrix         = csr
+	_   Reseter        = csr
+	_   NonZeroDoer    = csr
+	_   RowNonZeroDoer = csr
+	_   ColNonZeroDoer = csr
+
+	csc *CSC
+	_   Matrix         = csc
+	_   Reseter        = csc
+	_   NonZeroDoer    = csc
+	_   RowNonZeroDoer = csc
+	_   ColNonZeroDoer = csc
+)
+
+// Triplet is a sparse matrix in coordinate (COO) format. It is intended
+// for incremental construction of sparse matrices which are then converted
+// to CSR or CSC format for computation.
+//
+// A Triplet may hold more than one entry for the same element. The value
+// of such an element is the sum of its entries.
+type Triplet struct {
+	r, c int
+	i, j []int
+	v    []float64
+}
+
+// NewTriplet returns a new empty r×c Triplet. NewTriplet will panic
+// if r or c is negative.
+func NewTriplet(r, c int) *Triplet {
+	if r < 0 || c < 0 {
+		panic("mat: negative dimension")
+	}
+	return &Triplet{r: r, c: c}
+}
+
+// Append adds the value v to the element at row i, column j.
+func (t *Triplet) Append(i, j int, v float64) {
+	if uint(i) >= uint(t.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.c) {
+		panic(ErrColAccess)
+	}
+	t.i = append(t.i, i)
+	t.j = append(t.j, j)
+	t.v = append(t.v, v)
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (t *Triplet) Dims() (r, c int) { return t.r, t.c }
+
+// At returns the element at row i, column j. At is linear in the number
+// of stored entries.
+func (t *Triplet) At(i, j int) float64 {
+	if uint(i) >= uint(t.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(t.c) {
+		panic(ErrColAccess)
+	}
+	var v float64
+	for k, ik := range t.i {
+		if ik == i && t.j[k] == j {
+			v += t.v[k]
+		}
+	}
+	return v
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (t *Triplet) T() Matrix {
+	return Transpose{t}
+}
+
+// NNZ returns the number of stored entries in the matrix.
+func (t *Triplet) NNZ() int {
+	return len(t.v)
+}
+
+// DoNonZero calls the function fn for each of the non-zero stored entries
+// of t in the order they were appended. Entries for the same element are
+// passed to fn separately. The function fn takes a row/column index and the
+// entry value.
+func (t *Triplet) DoNonZero(fn func(i, j int, v float64)) {
+	for k, v := range t.v {
+		if v != 0 {
+			fn(t.i[k], t.j[k], v)
+		}
+	}
+}
+
+// ToCSR returns a CSR representation of the receiver. Multiple entries
+// for the same element are summed.
+func (t *Triplet) ToCSR() *CSR {
+	// Bucket the entries by column to form the transpose,
+	// then transpose back so that the column indices of
+	// each row are sorted.
+	var at CSR
+	at.r, at.c = t.c, t.r
+	at.indptr = make([]int, t.c+1)
+	for _, j := range t.j {
+		at.indptr[j+1]++
+	}
+	for j := 0; j < t.c; j++ {
+		at.indptr[j+1] += at.indptr[j]
+	}
+	at.ind = make([]int, len(t.i))
+	at.data = make([]float64, len(t.v))
+	next := make([]int, t.c)
+	copy(next, at.indptr)
+	for k, j := range t.j {
+		at.ind[next[j]] = t.i[k]
+		at.data[next[j]] = t.v[k]
+		next[j]++
+	}
+	m := transposeCSR(&at)
+	m.sumDuplicates()
+	return &m
+}
+
+// ToCSC returns a CSC representation of the receiver. Multiple entries
+// for the same element are summed.
+func (t *Triplet) ToCSC() *CSC {
+	return t.ToCSR().ToCSC()
+}
+
+// CSR is a sparse matrix in compressed sparse row format.
+type CSR struct {
+	r, c int
+	// indptr holds the offsets into ind and data of each row; the
+	// column indices and values of row i are stored in
+	// ind[indptr[i]:indptr[i+1]] and data[indptr[i]:indptr[i+1]].
+	indptr []int
+	ind    []int
+	data   []float64
+}
+
+// NewCSR returns a new r×c CSR matrix using the provided row offsets, column
+// indices and values. The column indices and values of row i are held in
+// ind[indptr[i]:indptr[i+1]] and data[indptr[i]:indptr[i+1]], and the column
+// indices within each row must be strictly increasing. The slices are used as
+// the backing data of the returned matrix.
+//
+// NewCSR will panic with ErrShape if the slice lengths do not agree with r and
+// with each other, and with ErrSparseIndex if the index structure is malformed.
+func NewCSR(r, c int, indptr, ind []int, data []float64) *CSR {
+	if r < 0 || c < 0 {
+		panic("mat: negative dimension")
+	}
+	if len(indptr) != r+1 || len(ind) != len(data) {
+		panic(ErrShape)
+	}
+	if indptr[0] != 0 || indptr[r] != len(ind) {
+		panic(ErrSparseIndex)
+	}
+	for i := 0; i < r; i++ {
+		if indptr[i] > indptr[i+1] {
+			panic(ErrSparseIndex)
+		}
+		last := -1
+		for _, j := range ind[indptr[i]:indptr[i+1]] {
+			if j <= last || c <= j {
+				panic(ErrSparseIndex)
+			}
+			last = j
+		}
+	}
+	return &CSR{r: r, c: c, indptr: indptr, ind: ind, data: data}
+}
+
+// CSRCopyOf returns a newly allocated CSR copy of the non-zero elements of a.
+func CSRCopyOf(a Matrix) *CSR {
+	r, c := a.Dims()
+	m := &CSR{r: r, c: c, indptr: make([]int, r+1)}
+
+	aU, trans := untranspose(a)
+	switch aU := aU.(type) {
+	case *CSR:
+		if trans {
+			t := transposeCSR(aU)
+			return &t
+		}
+		m.ind = append(m.ind, aU.ind...)
+		m.data = append(m.data, aU.data...)
+		copy(m.indptr, aU.indptr)
+		return m
+	case *CSC:
+		if trans {
+			return CSRCopyOf(&aU.t)
+		}
+		return aU.ToCSR()
+	case *Triplet:
+		if trans {
+			t := aU.ToCSR()
+			tt := transposeCSR(t)
+			return &tt
+		}
+		return aU.ToCSR()
+	}
+
+	if rnz, ok := a.(RowNonZeroDoer); ok {
+		for i := 0; i < r; i++ {
+			start := len(m.ind)
+			rnz.DoRowNonZero(i, func(_, j int, v float64) {
+				m.ind = append(m.ind, j)
+				m.data = append(m.data, v)
+			})
+			if !sort.IntsAreSorted(m.ind[start:]) {
+				sort.Sort(sparseRow{ind: m.ind[start:], data: m.data[start:]})
+			}
+			m.indptr[i+1] = len(m.ind)
+		}
+		return m
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			v := a.At(i, j)
+			if v != 0 {
+				m.ind = append(m.ind, j)
+				m.data = append(m.data, v)
+			}
+		}
+		m.indptr[i+1] = len(m.ind)
+	}
+	return m
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSR) Dims() (r, c int) { return m.r, m.c }
+
+// At returns the element at row i, column j.
+func (m *CSR) At(i, j int) float64 {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	return m.at(i, j)
+}
+
+func (m *CSR) at(i, j int) float64 {
+	start, end := m.indptr[i], m.indptr[i+1]
+	k := start + sort.SearchInts(m.ind[start:end], j)
+	if k < end && m.ind[k] == j {
+		return m.data[k]
+	}
+	return 0
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSR) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix.
+func (m *CSR) NNZ() int {
+	return len(m.data)
+}
+
+// RawCSR returns the row offsets, column indices and values used by the
+// receiver. Changes to the values will be reflected in the receiver. The
+// index slices must not be modified.
+func (m *CSR) RawCSR() (indptr, ind []int, data []float64) {
+	return m.indptr, m.ind, m.data
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (m *CSR) Reset() {
+	m.r, m.c = 0, 0
+	m.indptr = m.indptr[:0]
+	m.ind = m.ind[:0]
+	m.data = m.data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. CSR matrices can be zeroed using Reset.
+func (m *CSR) IsZero() bool {
+	return len(m.indptr) == 0
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSR) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < m.r; i++ {
+		for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+			if m.data[k] != 0 {
+				fn(i, m.ind[k], m.data[k])
+			}
+		}
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSR) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(m.r) {
+		panic(ErrRowAccess)
+	}
+	for k := m.indptr[i]; k < m.indptr[i+1]; k++ {
+		if m.data[k] != 0 {
+			fn(i, m.ind[k], m.data[k])
+		}
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of m. The function fn
+// takes a row/column index and the element value of m at (i, j). DoColNonZero performs a
+// binary search of each row, so CSC should be preferred for column-wise access.
+func (m *CSR) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(m.c) {
+		panic(ErrColAccess)
+	}
+	for i := 0; i < m.r; i++ {
+		v := m.at(i, j)
+		if v != 0 {
+			fn(i, j, v)
+		}
+	}
+}
+
+// ToCSC returns a CSC representation of the receiver.
+func (m *CSR) ToCSC() *CSC {
+	return &CSC{t: transposeCSR(m)}
+}
+
+// ToDense returns a Dense representation of the receiver.
+func (m *CSR) ToDense() *Dense {
+	d := NewDense(m.r, m.c, nil)
+	m.DoNonZero(func(i, j int, v float64) {
+		d.set(i, j, v)
+	})
+	return d
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul
+// will panic. Mul is intended for sparse a and b; inputs that are not CSR
+// matrices are first converted to CSR using CSRCopyOf.
+//
+// The storage of the receiver is replaced by the result.
+func (m *CSR) Mul(a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		panic(ErrShape)
+	}
+	if !m.IsZero() && (m.r != ar || m.c != bc) {
+		panic(ErrShape)
+	}
+
+	ac2, ok := a.(*CSR)
+	if !ok {
+		ac2 = CSRCopyOf(a)
+	}
+	bc2, ok := b.(*CSR)
+	if !ok {
+		bc2 = CSRCopyOf(b)
+	}
+
+	// Gustavson's algorithm using a dense accumulator.
+	indptr := make([]int, ar+1)
+	var (
+		ind  []int
+		data []float64
+	)
+	work := make([]float64, bc)
+	mark := make([]int, bc)
+	for j := range mark {
+		mark[j] = -1
+	}
+	for i := 0; i < ar; i++ {
+		start := len(ind)
+		for ka := ac2.indptr[i]; ka < ac2.indptr[i+1]; ka++ {
+			l := ac2.ind[ka]
+			av := ac2.data[ka]
+			for kb := bc2.indptr[l]; kb < bc2.indptr[l+1]; kb++ {
+				j := bc2.ind[kb]
+				if mark[j] != i {
+					mark[j] = i
+					work[j] = 0
+					ind = append(ind, j)
+				}
+				work[j] += av * bc2.data[kb]
+			}
+		}
+		sort.Ints(ind[start:])
+		for _, j := range ind[start:] {
+			data = append(data, work[j])
+		}
+		indptr[i+1] = len(ind)
+	}
+
+	*m = CSR{r: ar, c: bc, indptr: indptr, ind: ind, data: data}
+}
+
+// sumDuplicates combines adjacent entries with the same column index in
+// each row. The column indices of each row must be sorted.
+func (m *CSR) sumDuplicates() {
+	var n int
+	start := 0
+	for i := 0; i < m.r; i++ {
+		end := m.indptr[i+1]
+		rowStart := n
+		for k := start; k < end; k++ {
+			if n > rowStart && m.ind[n-1] == m.ind[k] {
+				m.data[n-1] += m.data[k]
+				continue
+			}
+			m.ind[n] = m.ind[k]
+			m.data[n] = m.data[k]
+			n++
+		}
+		start = end
+		m.indptr[i+1] = n
+	}
+	m.ind = m.ind[:n]
+	m.data = m.data[:n]
+}
+
+// transposeCSR returns the transpose of a in CSR format. The column indices
+// of each row of the result are sorted, whether or not those of a are.
+func transposeCSR(a *CSR) CSR {
+	t := CSR{
+		r:      a.c,
+		c:      a.r,
+		indptr: make([]int, a.c+1),
+		ind:    make([]int, len(a.ind)),
+		data:   make([]float64, len(a.data)),
+	}
+	for _, j := range a.ind {
+		t.indptr[j+1]++
+	}
+	for j := 0; j < a.c; j++ {
+		t.indptr[j+1] += t.indptr[j]
+	}
+	next := make([]int, a.c)
+	copy(next, t.indptr)
+	for i := 0; i < a.r; i++ {
+		for k := a.indptr[i]; k < a.indptr[i+1]; k++ {
+			j := a.ind[k]
+			t.ind[next[j]] = i
+			t.data[next[j]] = a.data[k]
+			next[j]++
+		}
+	}
+	return t
+}
+
+// CSC is a sparse matrix in compressed sparse column format.
+type CSC struct {
+	// t holds the transpose of the matrix in CSR format.
+	t CSR
+}
+
+// NewCSC returns a new r×c CSC matrix using the provided column offsets, row
+// indices and values. The row indices and values of column j are held in
+// ind[indptr[j]:indptr[j+1]] and data[indptr[j]:indptr[j+1]], and the row
+// indices within each column must be strictly increasing. The slices are used
+// as the backing data of the returned matrix.
+//
+// NewCSC will panic with ErrShape if the slice lengths do not agree with c and
+// with each other, and with ErrSparseIndex if the index structure is malformed.
+func NewCSC(r, c int, indptr, ind []int, data []float64) *CSC {
+	return &CSC{t: *NewCSR(c, r, indptr, ind, data)}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (m *CSC) Dims() (r, c int) { return m.t.c, m.t.r }
+
+// At returns the element at row i, column j.
+func (m *CSC) At(i, j int) float64 {
+	if uint(i) >= uint(m.t.c) {
+		panic(ErrRowAccess)
+	}
+	if uint(j) >= uint(m.t.r) {
+		panic(ErrColAccess)
+	}
+	return m.t.at(j, i)
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (m *CSC) T() Matrix {
+	return Transpose{m}
+}
+
+// NNZ returns the number of stored elements in the matrix.
+func (m *CSC) NNZ() int {
+	return len(m.t.data)
+}
+
+// RawCSC returns the column offsets, row indices and values used by the
+// receiver. Changes to the values will be reflected in the receiver. The
+// index slices must not be modified.
+func (m *CSC) RawCSC() (indptr, ind []int, data []float64) {
+	return m.t.indptr, m.t.ind, m.t.data
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+//
+// See the Reseter interface for more information.
+func (m *CSC) Reset() {
+	m.t.Reset()
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. CSC matrices can be zeroed using Reset.
+func (m *CSC) IsZero() bool {
+	return m.t.IsZero()
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSC) DoNonZero(fn func(i, j int, v float64)) {
+	m.t.DoNonZero(func(j, i int, v float64) {
+		fn(i, j, v)
+	})
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of m. The function fn
+// takes a row/column index and the element value of m at (i, j). DoRowNonZero performs a
+// binary search of each column, so CSR should be preferred for row-wise access.
+func (m *CSC) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if uint(i) >= uint(m.t.c) {
+		panic(ErrRowAccess)
+	}
+	m.t.DoColNonZero(i, func(j, i int, v float64) {
+		fn(i, j, v)
+	})
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of m. The function fn
+// takes a row/column index and the element value of m at (i, j).
+func (m *CSC) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if uint(j) >= uint(m.t.r) {
+		panic(ErrColAccess)
+	}
+	m.t.DoRowNonZero(j, func(j, i int, v float64) {
+		fn(i, j, v)
+	})
+}
+
+// ToCSR returns a CSR representation of the receiver.
+func (m *CSC) ToCSR() *CSR {
+	t := transposeCSR(&m.t)
+	return &t
+}
+
+// ToDense returns a Dense representation of the receiver.
+func (m *CSC) ToDense() *Dense {
+	r, c := m.Dims()
+	d := NewDense(r, c, nil)
+	m.DoNonZero(func(i, j int, v float64) {
+		d.set(i, j, v)
+	})
+	return d
+}
+
+// isSparse returns whether a is one of the sparse matrix types.
+func isSparse(a Matrix) bool {
+	switch a.(type) {
+	case *CSR, *CSC, *Triplet, *Tridiag:
+		return true
+	}
+	return false
+}
+
+// doNonZeroTrans calls fn for each of the non-zero elements of a, which
+// must be a NonZeroDoer, or a transpose of a NonZeroDoer if trans is true.
+func doNonZeroTrans(a Matrix, trans bool, fn func(i, j int, v float64)) {
+	nz := a.(NonZeroDoer)
+	if trans {
+		nz.DoNonZero(func(i, j int, v float64) {
+			fn(j, i, v)
+		})
+		return
+	}
+	nz.DoNonZero(fn)
+}
+
+// mulSparse takes the matrix product of a and b, at least one of which is
+// sparse, placing the result in the receiver. The receiver must have the
+// shape of the product and must not alias a or b.
+func (m *Dense) mulSparse(a, b Matrix) {
+	r, c := m.Dims()
+	for i := 0; i < r; i++ {
+		zero(m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c])
+	}
+
+	aU, aTrans := untranspose(a)
+	bU, bTrans := untranspose(b)
+	if isSparse(aU) {
+		switch b.(type) {
+		case *CSR, *Tridiag:
+		default:
+			if isSparse(bU) {
+				// Row access is only efficient for CSR and Tridiag.
+				b = CSRCopyOf(b)
+				bU, bTrans = b, false
+			}
+		}
+		// Row i of the result accumulates a[i,l] * row l of b.
+		var bmat blas64.General
+		bRaw := false
+		if rm, ok := bU.(RawMatrixer); ok && !bTrans {
+			bmat = rm.RawMatrix()
+			bRaw = true
+		}
+		brow, bRowDoer := b.(RowNonZeroDoer)
+		doNonZeroTrans(aU, aTrans, func(i, l int, v float64) {
+			mi := m.mat.Data[i*m.mat.Stride : i*m.mat.Stride+c]
+			switch {
+			case bRaw:
+				f64.AxpyUnitary(v, bmat.Data[l*bmat.Stride:l*bmat.Stride+c], mi)
+			case bRowDoer:
+				brow.DoRowNonZero(l, func(_, j int, bv float64) {
+					mi[j] += v * bv
+				})
+			default:
+				for j := range mi {
+					mi[j] += v * b.At(l, j)
+				}
+			}
+		})
+		return
+	}
+
+	// Column j of the result accumulates b[l,j] * column l of a.
+	doNonZeroTrans(bU, bTrans, func(l, j int, v float64) {
+		for i := 0; i < r; i++ {
+			m.mat.Data[i*m.mat.Stride+j] += v * a.At(i, l)
+		}
+	})
+}
+
+// sparseRow sorts the column indices and values of a sparse row.
+type sparseRow struct {
+	ind  []int
+	data []float64
+}
+
+func (r sparseRow) Len() int           { return len(r.ind) }
+func (r sparseRow) Less(i, j int) bool { return r.ind[i] < r.ind[j] }
+func (r sparseRow) Swap(i, j int) {
+	r.ind[i], r.ind[j] = r.ind[j], r.ind[i]
+	r.data[i], r.data[j] = r.data[j], r.data[i]
+}
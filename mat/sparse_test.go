@@ -0,0 +1,231 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// randTriplet returns a random r×c Triplet with approximately density*r*c
+// entries, some of which are duplicated, and its Dense equivalent.
+func randTriplet(r, c int, density float64, rnd *rand.Rand) (*Triplet, *Dense) {
+	t := NewTriplet(r, c)
+	d := NewDense(r, c, nil)
+	n := int(density * float64(r*c))
+	for k := 0; k < n; k++ {
+		i := rnd.Intn(r)
+		j := rnd.Intn(c)
+		v := rnd.NormFloat64()
+		t.Append(i, j, v)
+		d.Set(i, j, d.At(i, j)+v)
+		if rnd.Float64() < 0.2 {
+			// Add a duplicate entry.
+			t.Append(i, j, 1)
+			d.Set(i, j, d.At(i, j)+1)
+		}
+	}
+	return t, d
+}
+
+func TestSparseFormats(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, c    int
+		density float64
+	}{
+		{1, 1, 1},
+		{3, 5, 0.3},
+		{5, 3, 0.5},
+		{10, 10, 0.1},
+		{20, 7, 0.2},
+		{6, 6, 0},
+	} {
+		trip, want := randTriplet(test.r, test.c, test.density, rnd)
+		csr := trip.ToCSR()
+		csc := trip.ToCSC()
+		for _, m := range []struct {
+			name string
+			m    Matrix
+		}{
+			{"Triplet", trip},
+			{"CSR", csr},
+			{"CSC", csc},
+			{"CSR from CSC", csc.ToCSR()},
+			{"CSC from CSR", csr.ToCSC()},
+			{"CSRCopyOf Dense", CSRCopyOf(want)},
+			{"CSRCopyOf CSR^T^T", CSRCopyOf(CSRCopyOf(csr.T()).T())},
+			{"CSR ToDense", csr.ToDense()},
+			{"CSC ToDense", csc.ToDense()},
+		} {
+			prefix := fmt.Sprintf("%d×%d %s", test.r, test.c, m.name)
+			if !EqualApprox(m.m, want, 1e-14) {
+				t.Errorf("%s: unexpected matrix:\ngot:\n%v\nwant:\n%v", prefix, Formatted(m.m), Formatted(want))
+			}
+			if nz, ok := m.m.(NonZeroDoer); ok {
+				got := NewDense(test.r, test.c, nil)
+				nz.DoNonZero(func(i, j int, v float64) {
+					got.Set(i, j, got.At(i, j)+v)
+				})
+				if !EqualApprox(got, want, 1e-14) {
+					t.Errorf("%s: unexpected DoNonZero result", prefix)
+				}
+			}
+			if nz, ok := m.m.(RowNonZeroDoer); ok {
+				got := NewDense(test.r, test.c, nil)
+				for i := 0; i < test.r; i++ {
+					nz.DoRowNonZero(i, func(ii, j int, v float64) {
+						if ii != i {
+							t.Errorf("%s: unexpected row index: got %d want %d", prefix, ii, i)
+						}
+						got.Set(i, j, v)
+					})
+				}
+				if !EqualApprox(got, want, 1e-14) {
+					t.Errorf("%s: unexpected DoRowNonZero result", prefix)
+				}
+			}
+			if nz, ok := m.m.(ColNonZeroDoer); ok {
+				got := NewDense(test.r, test.c, nil)
+				for j := 0; j < test.c; j++ {
+					nz.DoColNonZero(j, func(i, jj int, v float64) {
+						if jj != j {
+							t.Errorf("%s: unexpected column index: got %d want %d", prefix, jj, j)
+						}
+						got.Set(i, j, v)
+					})
+				}
+				if !EqualApprox(got, want, 1e-14) {
+					t.Errorf("%s: unexpected DoColNonZero result", prefix)
+				}
+			}
+		}
+	}
+}
+
+func TestNewCSR(t *testing.T) {
+	m := NewCSR(3, 4, []int{0, 2, 2, 3}, []int{0, 3, 1}, []float64{1, 2, 3})
+	want := NewDense(3, 4, []float64{
+		1, 0, 0, 2,
+		0, 0, 0, 0,
+		0, 3, 0, 0,
+	})
+	if !Equal(m, want) {
+		t.Errorf("unexpected CSR matrix:\ngot:\n%v\nwant:\n%v", Formatted(m), Formatted(want))
+	}
+	c := NewCSC(4, 3, []int{0, 2, 2, 3}, []int{0, 3, 1}, []float64{1, 2, 3})
+	if !Equal(c, want.T()) {
+		t.Errorf("unexpected CSC matrix:\ngot:\n%v\nwant:\n%v", Formatted(c), Formatted(want.T()))
+	}
+
+	for _, test := range []struct {
+		name   string
+		r, c   int
+		indptr []int
+		ind    []int
+		data   []float64
+		want   error
+	}{
+		{"short indptr", 3, 4, []int{0, 2, 3}, []int{0, 3, 1}, []float64{1, 2, 3}, ErrShape},
+		{"data mismatch", 3, 4, []int{0, 2, 2, 3}, []int{0, 3, 1}, []float64{1, 2}, ErrShape},
+		{"bad final offset", 3, 4, []int{0, 2, 2, 2}, []int{0, 3, 1}, []float64{1, 2, 3}, ErrSparseIndex},
+		{"decreasing offset", 3, 4, []int{0, 2, 1, 3}, []int{0, 3, 1}, []float64{1, 2, 3}, ErrSparseIndex},
+		{"unsorted", 3, 4, []int{0, 2, 2, 3}, []int{3, 0, 1}, []float64{1, 2, 3}, ErrSparseIndex},
+		{"column out of range", 3, 4, []int{0, 2, 2, 3}, []int{0, 4, 1}, []float64{1, 2, 3}, ErrSparseIndex},
+	} {
+		panicked, message := panics(func() { NewCSR(test.r, test.c, test.indptr, test.ind, test.data) })
+		if !panicked || message != test.want.Error() {
+			t.Errorf("%s: unexpected panic: got %q want %q", test.name, message, test.want)
+		}
+	}
+}
+
+func TestSparseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		r, k, c int
+	}{
+		{1, 1, 1},
+		{3, 4, 5},
+		{5, 4, 3},
+		{10, 10, 10},
+		{12, 3, 7},
+	} {
+		aTrip, aDense := randTriplet(test.r, test.k, 0.3, rnd)
+		bTrip, bDense := randTriplet(test.k, test.c, 0.3, rnd)
+		var want Dense
+		want.Mul(aDense, bDense)
+
+		for _, a := range []struct {
+			name string
+			m    Matrix
+		}{
+			{"Dense", aDense},
+			{"CSR", aTrip.ToCSR()},
+			{"CSC", aTrip.ToCSC()},
+			{"Triplet", aTrip},
+			{"CSR^T^T", CSRCopyOf(aDense.T()).T()},
+		} {
+			for _, b := range []struct {
+				name string
+				m    Matrix
+			}{
+				{"Dense", bDense},
+				{"Dense^T^T", DenseCopyOf(bDense.T()).T()},
+				{"CSR", bTrip.ToCSR()},
+				{"CSC", bTrip.ToCSC()},
+				{"CSC^T^T", CSRCopyOf(bDense.T()).ToCSC().T()},
+			} {
+				prefix := fmt.Sprintf("%d×%d×%d %s*%s", test.r, test.k, test.c, a.name, b.name)
+
+				var got Dense
+				got.Mul(a.m, b.m)
+				if !EqualApprox(&got, &want, 1e-12) {
+					t.Errorf("%s: unexpected Dense.Mul result:\ngot:\n%v\nwant:\n%v", prefix, Formatted(&got), Formatted(&want))
+				}
+
+				var gotSparse CSR
+				gotSparse.Mul(a.m, b.m)
+				if !EqualApprox(&gotSparse, &want, 1e-12) {
+					t.Errorf("%s: unexpected CSR.Mul result:\ngot:\n%v\nwant:\n%v", prefix, Formatted(&gotSparse), Formatted(&want))
+				}
+			}
+		}
+
+		x := NewVecDense(test.k, nil)
+		for i := 0; i < test.k; i++ {
+			x.SetVec(i, rnd.NormFloat64())
+		}
+		var wantVec VecDense
+		wantVec.MulVec(aDense, x)
+		y := NewVecDense(test.r, nil)
+		for i := 0; i < test.r; i++ {
+			y.SetVec(i, rnd.NormFloat64())
+		}
+		var wantVecT VecDense
+		wantVecT.MulVec(aDense.T(), y)
+		for _, a := range []struct {
+			name string
+			m    Matrix
+		}{
+			{"CSR", aTrip.ToCSR()},
+			{"CSC", aTrip.ToCSC()},
+			{"Triplet", aTrip},
+		} {
+			prefix := fmt.Sprintf("%d×%d %s", test.r, test.k, a.name)
+			var got VecDense
+			got.MulVec(a.m, x)
+			if !EqualApprox(&got, &wantVec, 1e-12) {
+				t.Errorf("%s: unexpected MulVec result", prefix)
+			}
+			got.Reset()
+			got.MulVec(a.m.T(), y)
+			if !EqualApprox(&got, &wantVecT, 1e-12) {
+				t.Errorf("%s: unexpected transposed MulVec result", prefix)
+			}
+		}
+	}
+}
@@ -10,6 +10,11 @@ import (
 	"gonum.org/v1/gonum/lapack/lapack64"
 )
 
+// svdDivideMin is the largest value of min(m,n) for which SVD.Factorize uses
+// QR iteration rather than divide and conquer. Below this size the divide and
+// conquer bidiagonal solver falls back to QR iteration and offers no advantage.
+const svdDivideMin = 25
+
 // SVD is a type for creating and using the Singular Value Decomposition (SVD)
 // of a matrix.
 type SVD struct {
@@ -36,17 +41,19 @@ type SVD struct {
 // amount of memory if m >> n. See the documentation for the lapack.SVDKind values
 // for more information.
 //
+// When min(m,n) is larger than a small crossover size the decomposition is
+// computed using a divide and conquer algorithm, otherwise QR iteration is used.
+//
 // Factorize returns whether the decomposition succeeded. If the decomposition
 // failed, routines that require a successful factorization will panic.
 func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	m, n := a.Dims()
-	var jobU, jobVT lapack.SVDJob
+	var jobz lapack.SVDJob
 	switch kind {
 	default:
 		panic("svd: bad input kind")
 	case SVDNone:
-		jobU = lapack.SVDNone
-		jobVT = lapack.SVDNone
+		jobz = lapack.SVDNone
 	case SVDFull:
 		// TODO(btracey): This code should be modified to have the smaller
 		// matrix written in-place into aCopy when the lapack/native/dgesvd
@@ -63,8 +70,7 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 			Stride: n,
 			Data:   use(svd.vt.Data, n*n),
 		}
-		jobU = lapack.SVDAll
-		jobVT = lapack.SVDAll
+		jobz = lapack.SVDAll
 	case SVDThin:
 		// TODO(btracey): This code should be modified to have the larger
 		// matrix written in-place into aCopy when the lapack/native/dgesvd
@@ -81,8 +87,7 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 			Stride: n,
 			Data:   use(svd.vt.Data, min(m, n)*n),
 		}
-		jobU = lapack.SVDInPlace
-		jobVT = lapack.SVDInPlace
+		jobz = lapack.SVDInPlace
 	}
 
 	// A is destroyed on call, so copy the matrix.
@@ -91,10 +96,19 @@ func (svd *SVD) Factorize(a Matrix, kind SVDKind) (ok bool) {
 	svd.s = use(svd.s, min(m, n))
 
 	work := []float64{0}
-	lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
-	work = getFloats(int(work[0]), false)
-	ok = lapack64.Gesvd(jobU, jobVT, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
-	putFloats(work)
+	if min(m, n) <= svdDivideMin {
+		lapack64.Gesvd(jobz, jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, -1)
+		work = getFloats(int(work[0]), false)
+		ok = lapack64.Gesvd(jobz, jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work))
+		putFloats(work)
+	} else {
+		iwork := getInts(8*min(m, n), false)
+		lapack64.Gesdd(jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, -1, iwork)
+		work = getFloats(int(work[0]), false)
+		ok = lapack64.Gesdd(jobz, aCopy.mat, svd.u, svd.vt, svd.s, work, len(work), iwork)
+		putFloats(work)
+		putInts(iwork)
+	}
 	if !ok {
 		svd.kind = 0
 	}
@@ -109,16 +123,23 @@ func (svd *SVD) Kind() SVDKind {
 
 // Cond returns the 2-norm condition number for the factorized matrix. Cond will
 // panic if the receiver does not contain a successful factorization.
+//
+// The condition number is not available for a truncated decomposition and Cond
+// will panic if svd.Kind() == SVDTruncated.
 func (svd *SVD) Cond() float64 {
 	if svd.kind == 0 {
 		panic("svd: no decomposition computed")
 	}
+	if svd.kind == SVDTruncated {
+		panic("svd: condition number not available for truncated decomposition")
+	}
 	return svd.s[0] / svd.s[len(svd.s)-1]
 }
 
 // Values returns the singular values of the factorized matrix in decreasing order.
 // If the input slice is non-nil, the values will be stored in-place into the slice.
-// In this case, the slice must have length min(m,n), and Values will panic with
+// In this case, the slice must have length min(m,n), or k if svd.Kind() ==
+// SVDTruncated, and Values will panic with
 // matrix.ErrSliceLengthMismatch otherwise. If the input slice is nil,
 // a new slice of the appropriate length will be allocated and returned.
 //
@@ -139,10 +160,11 @@ func (svd *SVD) Values(s []float64) []float64 {
 
 // UTo extracts the matrix U from the singular value decomposition, storing
 // the result in-place into dst. U is size m×m if svd.Kind() == SVDFull,
-// of size m×min(m,n) if svd.Kind() == SVDThin, and UTo panics otherwise.
+// of size m×min(m,n) if svd.Kind() == SVDThin, of size m×k if svd.Kind() ==
+// SVDTruncated, and UTo panics otherwise.
 func (svd *SVD) UTo(dst *Dense) *Dense {
 	kind := svd.kind
-	if kind != SVDFull && kind != SVDThin {
+	if kind != SVDFull && kind != SVDThin && kind != SVDTruncated {
 		panic("mat: improper SVD kind")
 	}
 	r := svd.u.Rows
@@ -165,10 +187,11 @@ func (svd *SVD) UTo(dst *Dense) *Dense {
 
 // VTo extracts the matrix V from the singular value decomposition, storing
 // the result in-place into dst. V is size n×n if svd.Kind() == SVDFull,
-// of size n×min(m,n) if svd.Kind() == SVDThin, and VTo panics otherwise.
+// of size n×min(m,n) if svd.Kind() == SVDThin, of size n×k if svd.Kind() ==
+// SVDTruncated, and VTo panics otherwise.
 func (svd *SVD) VTo(dst *Dense) *Dense {
 	kind := svd.kind
-	if kind != SVDFull && kind != SVDThin {
+	if kind != SVDFull && kind != SVDThin && kind != SVDTruncated {
 		panic("mat: improper SVD kind")
 	}
 	r := svd.vt.Rows
@@ -170,3 +170,217 @@ func TestSVD(t *testing.T) {
 func extractSVD(svd *SVD) (s []float64, u, v *Dense) {
 	return svd.Values(nil), svd.UTo(nil), svd.VTo(nil)
 }
+
+// randDenseWithSingularValues returns a random m×n matrix with the singular
+// values s.
+func randDenseWithSingularValues(m, n int, s []float64, rnd *rand.Rand) *Dense {
+	k := len(s)
+	var qu, qv QR
+	x := NewDense(m, k, nil)
+	for i := range x.mat.Data {
+		x.mat.Data[i] = rnd.NormFloat64()
+	}
+	y := NewDense(n, k, nil)
+	for i := range y.mat.Data {
+		y.mat.Data[i] = rnd.NormFloat64()
+	}
+	qu.Factorize(x)
+	qv.Factorize(y)
+	u := qu.QTo(nil).Slice(0, m, 0, k)
+	v := qv.QTo(nil).Slice(0, n, 0, k)
+	var a Dense
+	a.Product(u, NewDiagonal(k, s), v.T())
+	return &a
+}
+
+func TestSVDTruncated(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+		a       Matrix
+	}{
+		{m: 10, n: 8, k: 3},
+		{m: 30, n: 30, k: 3},
+		{m: 100, n: 80, k: 5},
+		{m: 80, n: 100, k: 5},
+		{m: 300, n: 200, k: 10},
+		{m: 200, n: 50, k: 1},
+		// Rank-deficient matrix with fewer non-zero singular values
+		// than requested.
+		{m: 60, n: 50, k: 5, a: randDenseWithSingularValues(60, 50, []float64{3, 2, 1}, rnd)},
+		// Matrix with clustered singular values.
+		{m: 70, n: 60, k: 4, a: randDenseWithSingularValues(70, 60, []float64{10, 9.9999, 9.9998, 9.9997, 1, 0.5, 0.1, 0.05}, rnd)},
+		// Sparse matrix.
+		{m: 150, n: 150, k: 6, a: func() Matrix {
+			tr := NewTriplet(150, 150)
+			for i := 0; i < 150; i++ {
+				tr.Append(i, i, 2+float64(i)/150)
+				if i > 0 {
+					tr.Append(i, i-1, -1)
+				}
+				if i < 149 {
+					tr.Append(i, (i*7+3)%150, 0.5)
+				}
+			}
+			return tr.ToCSR()
+		}()},
+	} {
+		m, n, k := test.m, test.n, test.k
+		a := test.a
+		if a == nil {
+			d := NewDense(m, n, nil)
+			for i := range d.mat.Data {
+				d.mat.Data[i] = rnd.NormFloat64()
+			}
+			a = d
+		}
+
+		var full SVD
+		full.Factorize(a, SVDNone)
+		want := full.Values(nil)[:k]
+
+		var svd SVD
+		ok := svd.FactorizeTruncated(a, k)
+		if !ok {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected factorization failure", m, n, k)
+			continue
+		}
+		if svd.Kind() != SVDTruncated {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected kind %v", m, n, k, svd.Kind())
+		}
+		s, u, v := extractSVD(&svd)
+		if !floats.EqualApprox(s, want, 1e-10*want[0]) {
+			t.Errorf("m=%d, n=%d, k=%d: singular value mismatch: got %v, want %v", m, n, k, s, want)
+		}
+		checkTruncatedSVD(t, "FactorizeTruncated", a, s, u, v, 1e-10*want[0])
+
+		if panicked, _ := panics(func() { svd.Cond() }); !panicked {
+			t.Errorf("m=%d, n=%d, k=%d: expected panic calling Cond on truncated SVD", m, n, k)
+		}
+	}
+
+	var svd SVD
+	for _, k := range []int{0, 6} {
+		if panicked, _ := panics(func() { svd.FactorizeTruncated(NewDense(5, 8, nil), k) }); !panicked {
+			t.Errorf("expected panic for k=%d", k)
+		}
+	}
+}
+
+func TestSVDRandomized(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k    int
+		oversample int
+		power      int
+		s          []float64
+		tol        float64
+	}{
+		// Exactly low-rank matrices are recovered to working precision.
+		{m: 100, n: 80, k: 5, oversample: 0, power: 0, s: []float64{5, 4, 3, 2, 1}, tol: 1e-10},
+		{m: 80, n: 100, k: 3, oversample: 2, power: 0, s: []float64{5, 4, 3, 2, 1}, tol: 1e-10},
+		{m: 50, n: 50, k: 5, oversample: 45, power: 1, s: []float64{1, 1, 1, 1, 1, 1e-3, 1e-3}, tol: 1e-10},
+		// Rapidly decaying singular values.
+		{m: 200, n: 150, k: 5, oversample: 10, power: 2, s: geometric(40, 0.5), tol: 1e-6},
+	} {
+		m, n, k := test.m, test.n, test.k
+		a := randDenseWithSingularValues(m, n, test.s, rnd)
+
+		var svd SVD
+		ok := svd.FactorizeRandomized(a, k, test.oversample, test.power, rnd)
+		if !ok {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected factorization failure", m, n, k)
+			continue
+		}
+		if svd.Kind() != SVDTruncated {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected kind %v", m, n, k, svd.Kind())
+		}
+		s, u, v := extractSVD(&svd)
+		if !floats.EqualApprox(s, test.s[:k], test.tol) {
+			t.Errorf("m=%d, n=%d, k=%d: singular value mismatch: got %v, want %v", m, n, k, s, test.s[:k])
+		}
+		checkTruncatedSVD(t, "FactorizeRandomized", a, s, u, v, test.tol)
+	}
+
+	var svd SVD
+	if panicked, _ := panics(func() { svd.FactorizeRandomized(NewDense(5, 8, nil), 2, -1, 0, nil) }); !panicked {
+		t.Errorf("expected panic for negative oversampling")
+	}
+}
+
+func TestSVDTruncatedOperator(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, k int
+	}{
+		{m: 60, n: 40, k: 3},
+		{m: 40, n: 60, k: 3},
+		// The Krylov subspace would be as large as min(m,n), so the
+		// operator is formed explicitly.
+		{m: 30, n: 20, k: 3},
+	} {
+		m, n, k := test.m, test.n, test.k
+		d := randNormDense(m, n, rnd)
+		op := productOnly{d}
+
+		var full SVD
+		full.Factorize(d, SVDNone)
+		want := full.Values(nil)[:k]
+
+		var svd SVD
+		if !svd.FactorizeTruncated(op, k) {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected FactorizeTruncated failure", m, n, k)
+		} else if s := svd.Values(nil); !floats.EqualApprox(s, want, 1e-10*want[0]) {
+			t.Errorf("m=%d, n=%d, k=%d: FactorizeTruncated singular value mismatch: got %v, want %v", m, n, k, s, want)
+		}
+
+		// A sample as large as the matrix recovers it exactly.
+		if !svd.FactorizeRandomized(op, k, min(m, n)-k, 0, rnd) {
+			t.Errorf("m=%d, n=%d, k=%d: unexpected FactorizeRandomized failure", m, n, k)
+		} else if s := svd.Values(nil); !floats.EqualApprox(s, want, 1e-10*want[0]) {
+			t.Errorf("m=%d, n=%d, k=%d: FactorizeRandomized singular value mismatch: got %v, want %v", m, n, k, s, want)
+		}
+	}
+}
+
+// geometric returns the first n terms of the geometric sequence with ratio r
+// starting at 1.
+func geometric(n int, r float64) []float64 {
+	s := make([]float64, n)
+	v := 1.0
+	for i := range s {
+		s[i] = v
+		v *= r
+	}
+	return s
+}
+
+// checkTruncatedSVD checks that u and v have orthonormal columns and that
+//  A * V = U * Σ,
+//  A^T * U = V * Σ
+// within tol.
+func checkTruncatedSVD(t *testing.T, name string, a Matrix, s []float64, u, v *Dense, tol float64) {
+	m, n := a.Dims()
+	k := len(s)
+	var utu, vtv Dense
+	utu.Mul(u.T(), u)
+	vtv.Mul(v.T(), v)
+	if !EqualApprox(&utu, eye(k), 1e-12) {
+		t.Errorf("%s: m=%d, n=%d, k=%d: U is not orthonormal", name, m, n, k)
+	}
+	if !EqualApprox(&vtv, eye(k), 1e-12) {
+		t.Errorf("%s: m=%d, n=%d, k=%d: V is not orthonormal", name, m, n, k)
+	}
+	sigma := NewDiagonal(k, s)
+	var av, us, atu, vs Dense
+	av.Mul(a, v)
+	us.Mul(u, sigma)
+	atu.Mul(a.T(), u)
+	vs.Mul(v, sigma)
+	if !EqualApprox(&av, &us, tol) {
+		t.Errorf("%s: m=%d, n=%d, k=%d: A*V != U*Σ", name, m, n, k)
+	}
+	if !EqualApprox(&atu, &vs, tol) {
+		t.Errorf("%s: m=%d, n=%d, k=%d: A^T*U != V*Σ", name, m, n, k)
+	}
+}
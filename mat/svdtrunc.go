@@ -0,0 +1,363 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+	"math/rand"
+
+	"gonum.org/v1/gonum/blas/blas64"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+// truncatedSVDTol is the tolerance on the residual norm of the singular
+// triplets computed by FactorizeTruncated, relative to the largest computed
+// singular value.
+const truncatedSVDTol = 1e-12
+
+// FactorizeTruncated computes the k largest singular values and the
+// corresponding left and right singular vectors of the m×n matrix a,
+//  A ≈ U_k * Σ_k * V_k^T,
+// where U_k is of size m×k, Σ_k is a k×k diagonal matrix and V_k is of size
+// n×k. The singular triplets are computed using the Golub-Kahan-Lanczos
+// bidiagonalization with full reorthogonalization and thick restarting until
+// the norm of the residual of each is at most 1e-12 times the largest singular
+// value, so the singular values have an absolute error of that order and
+// small singular values may have a large relative error. The matrix a is only accessed through matrix-vector
+// products with a and its transpose, so the method is suited to large or
+// sparse matrices and to operators implementing MulVecToer when k is much
+// smaller than min(m,n). If the Krylov subspace needed would be as large as
+// min(m,n), the thin decomposition is computed instead and truncated. In that
+// case the elements of a are read, or a is formed explicitly from n products
+// if it implements MulVecToer.
+//
+// k must be in [1, min(m,n)], otherwise FactorizeTruncated will panic. After a
+// successful factorization, svd.Kind() == SVDTruncated.
+//
+// FactorizeTruncated returns whether the decomposition succeeded. If the
+// decomposition failed, routines that require a successful factorization will
+// panic.
+func (svd *SVD) FactorizeTruncated(a Matrix, k int) (ok bool) {
+	m, n := a.Dims()
+	if k < 1 || min(m, n) < k {
+		panic("svd: bad rank")
+	}
+	svd.kind = 0
+
+	// p is the dimension of the Krylov subspaces.
+	p := min(min(m, n), max(2*k+1, 20))
+	if p == min(m, n) {
+		if aU, _ := untranspose(a); isMulVecToer(aU) {
+			id := NewDense(n, n, nil)
+			for i := 0; i < n; i++ {
+				id.set(i, i, 1)
+			}
+			var ad Dense
+			ad.Mul(a, id)
+			a = &ad
+		}
+		var full SVD
+		if !full.Factorize(a, SVDThin) {
+			return false
+		}
+		u := full.UTo(nil)
+		v := full.VTo(nil)
+		svd.setTruncated(full.s[:k], u.Slice(0, m, 0, k), v.Slice(0, n, 0, k))
+		return true
+	}
+
+	rnd := rand.New(rand.NewSource(1))
+	maxRestarts := max(100, min(m, n))
+
+	// The columns of v and u hold the orthonormal bases of the right and
+	// left Krylov subspaces, respectively, and b holds the projection
+	// U^T * A * V. Column p of v holds the direction of the residual of the
+	// singular triplets.
+	v := NewDense(n, p+1, nil)
+	u := NewDense(m, p, nil)
+	b := NewDense(p, p, nil)
+	wm := NewVecDense(m, nil)
+	wn := NewVecDense(n, nil)
+	tmpm := NewVecDense(m, nil)
+	tmpn := NewVecDense(n, nil)
+	h := NewVecDense(p+1, nil)
+	dh := NewVecDense(p+1, nil)
+
+	v0 := v.ColView(0)
+	randomizeVec(v0, rnd)
+	v0.ScaleVec(1/Norm(v0, 2), v0)
+
+	var (
+		bsvd   SVD
+		ub, vb Dense
+		sigma  []float64
+
+		// kept is the number of singular vectors at the beginning
+		// of the bases retained from the previous restart.
+		kept int
+	)
+	for restarts := 0; ; restarts++ {
+		// Extend the bases to p vectors. On exit,
+		//  A * V = U * B,
+		//  A^T * U = V * B^T + beta * v_p * e_p^T.
+		var beta float64
+		for j := kept; j < p; j++ {
+			// Compute the next left basis vector.
+			wm.MulVec(a, v.ColView(j))
+			norm := Norm(wm, 2)
+			if j > 0 {
+				hj := h.SliceVec(0, j)
+				gramSchmidt(wm, u.Slice(0, m, 0, j), hj, dh.SliceVec(0, j), tmpm)
+				for i := 0; i < j; i++ {
+					b.set(i, j, hj.at(i))
+				}
+			}
+			alpha := Norm(wm, 2)
+			if alpha <= float64(m)*dlamchE*norm {
+				// A*v_j lies in the span of the left basis.
+				// Continue with a random vector orthogonal to it.
+				alpha = 0
+				randomOrthogonalVec(wm, u, j, h, dh, tmpm, rnd)
+				u.ColView(j).CopyVec(wm)
+			} else {
+				u.ColView(j).ScaleVec(1/alpha, wm)
+			}
+			b.set(j, j, alpha)
+
+			// Compute the next right basis vector.
+			wn.MulVec(a.T(), u.ColView(j))
+			norm = Norm(wn, 2)
+			hj := h.SliceVec(0, j+1)
+			gramSchmidt(wn, v.Slice(0, n, 0, j+1), hj, dh.SliceVec(0, j+1), tmpn)
+			for i := 0; i < j; i++ {
+				b.set(j, i, hj.at(i))
+			}
+			beta = Norm(wn, 2)
+			if beta <= float64(n)*dlamchE*norm {
+				// The bases span a pair of invariant subspaces.
+				beta = 0
+				if j == p-1 {
+					break
+				}
+				randomOrthogonalVec(wn, v, j+1, h, dh, tmpn, rnd)
+				v.ColView(j + 1).CopyVec(wn)
+				continue
+			}
+			v.ColView(j+1).ScaleVec(1/beta, wn)
+		}
+
+		// Compute the singular triplets of the projection. The residual
+		// norm of the j-th triplet is |beta * ub[p-1,j]|.
+		if !bsvd.Factorize(b, SVDFull) {
+			return false
+		}
+		sigma = bsvd.Values(sigma)
+		bsvd.UTo(&ub)
+		bsvd.VTo(&vb)
+		// The residual norm of the j-th triplet is |beta*ub[p-1,j]|.
+		// It is compared with the largest singular value, so the test
+		// bounds the absolute and not the relative error.
+		converged := true
+		for j := 0; j < k; j++ {
+			if math.Abs(beta*ub.at(p-1, j)) > truncatedSVDTol*sigma[0] {
+				converged = false
+				break
+			}
+		}
+		if converged {
+			var uk, vk Dense
+			uk.Mul(u, ub.Slice(0, p, 0, k))
+			vk.Mul(v.Slice(0, n, 0, p), vb.Slice(0, p, 0, k))
+			svd.setTruncated(sigma[:k], &uk, &vk)
+			return true
+		}
+		if restarts == maxRestarts {
+			return false
+		}
+
+		// Restart with the largest singular vectors followed by the
+		// residual direction.
+		kept = min(k+(p-k)/2, p-1)
+		var uk, vk Dense
+		uk.Mul(u, ub.Slice(0, p, 0, kept))
+		vk.Mul(v.Slice(0, n, 0, p), vb.Slice(0, p, 0, kept))
+		u.Slice(0, m, 0, kept).(*Dense).Copy(&uk)
+		v.Slice(0, n, 0, kept).(*Dense).Copy(&vk)
+		v.ColView(kept).CopyVec(v.ColView(p))
+		for i := 0; i < p; i++ {
+			zero(b.mat.Data[i*b.mat.Stride : i*b.mat.Stride+p])
+		}
+		for j := 0; j < kept; j++ {
+			b.set(j, j, sigma[j])
+		}
+	}
+}
+
+// FactorizeRandomized computes an approximation to the k largest singular
+// values and the corresponding left and right singular vectors of the m×n
+// matrix a,
+//  A ≈ U_k * Σ_k * V_k^T,
+// using a randomized range finder. The range of A is sampled by the product
+// of A with a random Gaussian matrix of k+oversample columns and the
+// decomposition is computed from the projection of A onto the sampled
+// subspace. Each of the powerIters power iterations multiplies the sample by
+// A*A^T, which improves the accuracy when the singular values of A decay
+// slowly. Typical values are 5 or 10 for oversample and 0, 1 or 2 for
+// powerIters.
+//
+// The method needs only 2*(powerIters+1) passes over a, and it is suited to
+// large matrices when the accuracy of FactorizeTruncated is not needed. The
+// passes are matrix products, so the elements of an operator implementing
+// MulVecToer are not accessed.
+// See
+//  Halko, N., Martinsson, P.-G., Tropp, J. A. (2011). Finding structure with
+//  randomness: Probabilistic algorithms for constructing approximate matrix
+//  decompositions. SIAM Review, 53(2), 217-288.
+//
+// src is used as the source of random numbers. If src is nil, the global
+// source of math/rand is used.
+//
+// k must be in [1, min(m,n)], and oversample and powerIters must not be
+// negative, otherwise FactorizeRandomized will panic. After a successful
+// factorization, svd.Kind() == SVDTruncated.
+//
+// FactorizeRandomized returns whether the decomposition succeeded. If the
+// decomposition failed, routines that require a successful factorization will
+// panic.
+func (svd *SVD) FactorizeRandomized(a Matrix, k, oversample, powerIters int, src *rand.Rand) (ok bool) {
+	m, n := a.Dims()
+	if k < 1 || min(m, n) < k {
+		panic("svd: bad rank")
+	}
+	if oversample < 0 {
+		panic("svd: negative oversampling")
+	}
+	if powerIters < 0 {
+		panic("svd: negative number of power iterations")
+	}
+	svd.kind = 0
+
+	normal := rand.NormFloat64
+	if src != nil {
+		normal = src.NormFloat64
+	}
+
+	l := min(k+oversample, min(m, n))
+	omega := NewDense(n, l, nil)
+	for i := range omega.mat.Data {
+		omega.mat.Data[i] = normal()
+	}
+
+	// Find an orthonormal basis Q of the range of A*(A^T*A)^powerIters*Ω.
+	var q, z Dense
+	q.Mul(a, omega)
+	orthonormalizeCols(&q)
+	for i := 0; i < powerIters; i++ {
+		z.Mul(a.T(), &q)
+		orthonormalizeCols(&z)
+		q.Mul(a, &z)
+		orthonormalizeCols(&q)
+	}
+
+	// Compute the SVD of the l×n matrix Q^T * A = U_B * Σ * V^T, so that
+	// A ≈ (Q * U_B) * Σ * V^T.
+	var b Dense
+	b.Mul(q.T(), a)
+	var small SVD
+	if !small.Factorize(&b, SVDThin) {
+		return false
+	}
+	ub := small.UTo(nil)
+	vb := small.VTo(nil)
+	var uk Dense
+	uk.Mul(&q, ub.Slice(0, l, 0, k))
+	svd.setTruncated(small.s[:k], &uk, vb.Slice(0, n, 0, k))
+	return true
+}
+
+// isMulVecToer returns whether a implements MulVecToer.
+func isMulVecToer(a Matrix) bool {
+	_, ok := a.(MulVecToer)
+	return ok
+}
+
+// setTruncated stores the singular values s and the singular vectors in the
+// columns of u and v into the receiver as a truncated decomposition.
+func (svd *SVD) setTruncated(s []float64, u, v Matrix) {
+	m, k := u.Dims()
+	n, _ := v.Dims()
+	svd.s = use(svd.s, k)
+	copy(svd.s, s)
+	svd.u = blas64.General{
+		Rows:   m,
+		Cols:   k,
+		Stride: k,
+		Data:   use(svd.u.Data, m*k),
+	}
+	svd.vt = blas64.General{
+		Rows:   k,
+		Cols:   n,
+		Stride: n,
+		Data:   use(svd.vt.Data, k*n),
+	}
+	(&Dense{mat: svd.u, capRows: m, capCols: k}).Copy(u)
+	(&Dense{mat: svd.vt, capRows: k, capCols: n}).Copy(v.T())
+	svd.kind = SVDTruncated
+}
+
+// orthonormalizeCols replaces the columns of the m×n matrix a, n <= m, with an
+// orthonormal basis of their span computed by the QR factorization of a.
+func orthonormalizeCols(a *Dense) {
+	_, n := a.Dims()
+	tau := getFloats(n, false)
+	work := []float64{0}
+	lapack64.Geqrf(a.mat, tau, work, -1)
+	lwork := int(work[0])
+	lapack64.Orgqr(n, a.mat, tau, work, -1)
+	lwork = max(lwork, int(work[0]))
+	work = getFloats(lwork, false)
+	lapack64.Geqrf(a.mat, tau, work, lwork)
+	lapack64.Orgqr(n, a.mat, tau, work, lwork)
+	putFloats(work)
+	putFloats(tau)
+}
+
+// gramSchmidt orthogonalizes w against the orthonormal columns of v using
+// classical Gram-Schmidt with one step of reorthogonalization, and stores the
+// projection coefficients v^T*w into h. dh and tmp are used as workspace.
+func gramSchmidt(w *VecDense, v Matrix, h, dh, tmp *VecDense) {
+	h.MulVec(v.T(), w)
+	tmp.MulVec(v, h)
+	w.SubVec(w, tmp)
+	dh.MulVec(v.T(), w)
+	tmp.MulVec(v, dh)
+	w.SubVec(w, tmp)
+	h.AddVec(h, dh)
+}
+
+// randomOrthogonalVec stores into w a random unit vector orthogonal to the
+// first c columns of v, which must be orthonormal. c must be less than the
+// length of w. h and dh must have length at least c and are used together with
+// tmp as workspace.
+func randomOrthogonalVec(w *VecDense, v *Dense, c int, h, dh, tmp *VecDense, rnd *rand.Rand) {
+	r, _ := v.Dims()
+	for {
+		randomizeVec(w, rnd)
+		if c > 0 {
+			gramSchmidt(w, v.Slice(0, r, 0, c), h.SliceVec(0, c), dh.SliceVec(0, c), tmp)
+		}
+		if norm := Norm(w, 2); norm > 0 {
+			w.ScaleVec(1/norm, w)
+			return
+		}
+	}
+}
+
+// randomizeVec fills v with normally distributed random numbers.
+func randomizeVec(v *VecDense, rnd *rand.Rand) {
+	for i := 0; i < v.Len(); i++ {
+		v.setVec(i, rnd.NormFloat64())
+	}
+}
@@ -0,0 +1,355 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"errors"
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+var errSchurFailed = errors.New("mat: Schur decomposition failed")
+
+// SolveSylvester solves the Sylvester matrix equation
+//  A * X + X * B = C
+// for X, where A is an m×m matrix, B is an n×n matrix and C is an m×n matrix,
+// and stores the result into the receiver. The equation has a unique solution
+// if and only if A and -B have no eigenvalues in common.
+//
+// SolveSylvester uses the Bartels-Stewart algorithm: A and B are reduced to
+// real Schur form and the resulting quasi-triangular equation is solved by
+// block substitution.
+//
+// If A and -B have common or very close eigenvalues, a perturbed equation is
+// solved and a Condition error with value +Inf is returned. If the Schur
+// decomposition of A or B fails, a non-nil error is returned and the receiver
+// is not modified.
+func (m *Dense) SolveSylvester(a, b, c Matrix) error {
+	ar, ac := a.Dims()
+	if ar != ac {
+		panic(ErrSquare)
+	}
+	br, bc := b.Dims()
+	if br != bc {
+		panic(ErrSquare)
+	}
+	cr, cc := c.Dims()
+	if cr != ar || cc != br {
+		panic(ErrShape)
+	}
+
+	var sa, sb Schur
+	if !sa.Factorize(a, true) || !sb.Factorize(b, true) {
+		return errSchurFailed
+	}
+
+	// Transform the right-hand side, F = U^T * C * V.
+	tmp := getWorkspace(ar, br, false)
+	defer putWorkspace(tmp)
+	f := getWorkspace(ar, br, false)
+	defer putWorkspace(f)
+	tmp.Mul(sa.z.T(), c)
+	f.Mul(tmp, sb.z)
+
+	// Solve S * Y + Y * T = scale * F.
+	scale, ok := lapack64.Trsyl(blas.NoTrans, blas.NoTrans, 1, sa.t.mat, sb.t.mat, f.mat)
+
+	// Transform back, X = U * Y * V^T.
+	tmp.Mul(sa.z, f)
+	m.reuseAs(ar, br)
+	m.Mul(tmp, sb.z.T())
+	if scale != 1 {
+		m.Scale(1/scale, m)
+	}
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// SolveLyapunov solves the continuous Lyapunov matrix equation
+//  A * X + X * A^T = Q
+// for X, where A and Q are n×n matrices, and stores the result into the
+// receiver. The equation has a unique solution if and only if no two
+// eigenvalues of A sum to zero. If Q is symmetric, then so is X. In
+// particular, if A is stable, that is all its eigenvalues have negative real
+// parts, and Q is symmetric negative definite, then X is symmetric positive
+// definite.
+//
+// If the equation is singular or nearly singular, a perturbed equation is
+// solved and a Condition error with value +Inf is returned. If the Schur
+// decomposition of A fails, a non-nil error is returned and the receiver is
+// not modified.
+func (m *Dense) SolveLyapunov(a, q Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	qr, qc := q.Dims()
+	if qr != n || qc != n {
+		panic(ErrShape)
+	}
+
+	var s Schur
+	if !s.Factorize(a, true) {
+		return errSchurFailed
+	}
+
+	// Transform the right-hand side, F = U^T * Q * U.
+	tmp := getWorkspace(n, n, false)
+	defer putWorkspace(tmp)
+	f := getWorkspace(n, n, false)
+	defer putWorkspace(f)
+	tmp.Mul(s.z.T(), q)
+	f.Mul(tmp, s.z)
+
+	// Solve S * Y + Y * S^T = scale * F.
+	scale, ok := lapack64.Trsyl(blas.NoTrans, blas.Trans, 1, s.t.mat, s.t.mat, f.mat)
+
+	// Transform back, X = U * Y * U^T.
+	tmp.Mul(s.z, f)
+	m.reuseAs(n, n)
+	m.Mul(tmp, s.z.T())
+	if scale != 1 {
+		m.Scale(1/scale, m)
+	}
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// SolveDiscreteLyapunov solves the discrete Lyapunov (Stein) matrix equation
+//  A * X * A^T - X = Q
+// for X, where A and Q are n×n matrices, and stores the result into the
+// receiver. The equation has a unique solution if and only if no product of
+// two eigenvalues of A equals one. If Q is symmetric, then so is X. In
+// particular, if A is stable, that is all its eigenvalues lie inside the unit
+// circle, and Q is symmetric negative definite, then X is symmetric positive
+// definite. The steady-state covariance P of the linear system
+// x_{k+1} = A*x_k + w_k with noise covariance W is the solution of
+//  A * P * A^T - P = -W.
+//
+// If the equation is singular or nearly singular, a perturbed equation is
+// solved and a Condition error with value +Inf is returned. If the Schur
+// decomposition of A fails, a non-nil error is returned and the receiver is
+// not modified.
+func (m *Dense) SolveDiscreteLyapunov(a, q Matrix) error {
+	n, c := a.Dims()
+	if n != c {
+		panic(ErrSquare)
+	}
+	qr, qc := q.Dims()
+	if qr != n || qc != n {
+		panic(ErrShape)
+	}
+
+	var s Schur
+	if !s.Factorize(a, true) {
+		return errSchurFailed
+	}
+
+	// Transform the right-hand side, F = U^T * Q * U.
+	tmp := getWorkspace(n, n, false)
+	defer putWorkspace(tmp)
+	f := getWorkspace(n, n, false)
+	defer putWorkspace(f)
+	tmp.Mul(s.z.T(), q)
+	f.Mul(tmp, s.z)
+
+	// Solve S * Y * S^T - Y = F.
+	ok := solveSteinSchur(s.t, f)
+
+	// Transform back, X = U * Y * U^T.
+	tmp.Mul(s.z, f)
+	m.reuseAs(n, n)
+	m.Mul(tmp, s.z.T())
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
+
+// solveSteinSchur solves the Stein equation
+//  S * Y * S^T - Y = F,
+// where S is an n×n upper quasi-triangular matrix in Schur canonical form,
+// by block substitution. On return, f is overwritten by Y. If solveSteinSchur
+// returns false, S has two eigenvalues whose product is close to one and a
+// perturbed equation was solved.
+func solveSteinSchur(s, f *Dense) (ok bool) {
+	n, _ := s.Dims()
+
+	// Find the starting indices of the diagonal blocks of S.
+	blk := make([]int, 0, n+1)
+	var smax float64
+	for k := 0; k < n; {
+		blk = append(blk, k)
+		if k < n-1 && s.at(k+1, k) != 0 {
+			k += 2
+		} else {
+			k++
+		}
+	}
+	blk = append(blk, n)
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			smax = math.Max(smax, math.Abs(s.at(i, j)))
+		}
+		if i > 0 {
+			smax = math.Max(smax, math.Abs(s.at(i, i-1)))
+		}
+	}
+	const (
+		eps     = 1.0 / (1 << 52)
+		safeMin = 2.2250738585072014e-308 // Smallest normal number.
+	)
+	smin := math.Max(eps*math.Max(1, smax*smax), safeMin)
+
+	ok = true
+	// g holds the contribution of the computed block columns of Y,
+	//  G = sum_{l>j} Y[:,l] * S[j,l]^T.
+	g := getWorkspace(n, 2, false)
+	defer putWorkspace(g)
+	var h, r, y [4]float64
+	for jb := len(blk) - 2; jb >= 0; jb-- {
+		j1, j2 := blk[jb], blk[jb+1]
+		nj := j2 - j1
+		for k := 0; k < n; k++ {
+			for c := 0; c < nj; c++ {
+				var sum float64
+				for l := j2; l < n; l++ {
+					sum += f.at(k, l) * s.at(j1+c, l)
+				}
+				g.set(k, c, sum)
+			}
+		}
+		for ib := len(blk) - 2; ib >= 0; ib-- {
+			i1, i2 := blk[ib], blk[ib+1]
+			ni := i2 - i1
+
+			// Compute the right-hand side
+			//  R = F[i,j] - sum_{k>=i} S[i,k] * G[k] - (sum_{k>i} S[i,k] * Y[k,j]) * S[j,j]^T.
+			for p := 0; p < ni; p++ {
+				for c := 0; c < nj; c++ {
+					var sg, sy float64
+					for k := i1; k < n; k++ {
+						sg += s.at(i1+p, k) * g.at(k, c)
+					}
+					for k := i2; k < n; k++ {
+						sy += s.at(i1+p, k) * f.at(k, j1+c)
+					}
+					r[p*nj+c] = f.at(i1+p, j1+c) - sg
+					h[p*nj+c] = sy
+				}
+			}
+			for p := 0; p < ni; p++ {
+				for c := 0; c < nj; c++ {
+					var sum float64
+					for d := 0; d < nj; d++ {
+						sum += h[p*nj+d] * s.at(j1+c, j1+d)
+					}
+					r[p*nj+c] -= sum
+				}
+			}
+
+			// Solve S[i,i] * Y[i,j] * S[j,j]^T - Y[i,j] = R.
+			if !solveSmallStein(ni, nj, s, i1, j1, r[:], y[:], smin) {
+				ok = false
+			}
+			for p := 0; p < ni; p++ {
+				for c := 0; c < nj; c++ {
+					f.set(i1+p, j1+c, y[p*nj+c])
+				}
+			}
+		}
+	}
+	return ok
+}
+
+// solveSmallStein solves the ni×nj Stein equation
+//  S[i1:i1+ni,i1:i1+ni] * Y * S[j1:j1+nj,j1:j1+nj]^T - Y = R
+// where ni and nj are 1 or 2, by Gaussian elimination with complete pivoting
+// on the equivalent Kronecker product system. r and y are stored in row-major
+// order with stride nj. Pivots smaller than smin in magnitude are replaced
+// by smin, in which case solveSmallStein returns false.
+func solveSmallStein(ni, nj int, s *Dense, i1, j1 int, r, y []float64, smin float64) (ok bool) {
+	ok = true
+	dim := ni * nj
+	var kmat [16]float64
+	var rhs [4]float64
+	for p := 0; p < ni; p++ {
+		for c := 0; c < nj; c++ {
+			row := p*nj + c
+			rhs[row] = r[row]
+			for pp := 0; pp < ni; pp++ {
+				for cc := 0; cc < nj; cc++ {
+					col := pp*nj + cc
+					v := s.at(i1+p, i1+pp) * s.at(j1+c, j1+cc)
+					if row == col {
+						v--
+					}
+					kmat[row*dim+col] = v
+				}
+			}
+		}
+	}
+
+	var perm [4]int
+	for i := range perm {
+		perm[i] = i
+	}
+	for k := 0; k < dim; k++ {
+		// Find the pivot in the trailing submatrix.
+		pr, pc := k, k
+		var pmax float64
+		for i := k; i < dim; i++ {
+			for j := k; j < dim; j++ {
+				if v := math.Abs(kmat[i*dim+j]); v > pmax {
+					pmax = v
+					pr, pc = i, j
+				}
+			}
+		}
+		// Swap rows and columns.
+		if pr != k {
+			for j := 0; j < dim; j++ {
+				kmat[k*dim+j], kmat[pr*dim+j] = kmat[pr*dim+j], kmat[k*dim+j]
+			}
+			rhs[k], rhs[pr] = rhs[pr], rhs[k]
+		}
+		if pc != k {
+			for i := 0; i < dim; i++ {
+				kmat[i*dim+k], kmat[i*dim+pc] = kmat[i*dim+pc], kmat[i*dim+k]
+			}
+			perm[k], perm[pc] = perm[pc], perm[k]
+		}
+		if pmax < smin {
+			kmat[k*dim+k] = smin
+			ok = false
+		}
+		for i := k + 1; i < dim; i++ {
+			l := kmat[i*dim+k] / kmat[k*dim+k]
+			for j := k + 1; j < dim; j++ {
+				kmat[i*dim+j] -= l * kmat[k*dim+j]
+			}
+			rhs[i] -= l * rhs[k]
+		}
+	}
+	// Back substitution.
+	var x [4]float64
+	for k := dim - 1; k >= 0; k-- {
+		sum := rhs[k]
+		for j := k + 1; j < dim; j++ {
+			sum -= kmat[k*dim+j] * x[j]
+		}
+		x[k] = sum / kmat[k*dim+k]
+	}
+	for k := 0; k < dim; k++ {
+		y[perm[k]] = x[k]
+	}
+	return ok
+}
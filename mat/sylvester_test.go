@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func randNormDense(r, c int, rnd *rand.Rand) *Dense {
+	m := NewDense(r, c, nil)
+	for i := range m.mat.Data {
+		m.mat.Data[i] = rnd.NormFloat64()
+	}
+	return m
+}
+
+func TestSolveSylvester(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ m, n int }{
+		{1, 1},
+		{1, 4},
+		{4, 1},
+		{3, 5},
+		{6, 6},
+		{20, 13},
+	} {
+		a := randNormDense(test.m, test.m, rnd)
+		b := randNormDense(test.n, test.n, rnd)
+		// Shift B so that A and -B are unlikely to share eigenvalues.
+		for i := 0; i < test.n; i++ {
+			b.Set(i, i, b.At(i, i)+10)
+		}
+		c := randNormDense(test.m, test.n, rnd)
+
+		var x Dense
+		err := x.SolveSylvester(a, b, c)
+		if err != nil {
+			t.Errorf("%d×%d: unexpected error: %v", test.m, test.n, err)
+			continue
+		}
+		var ax, xb Dense
+		ax.Mul(a, &x)
+		xb.Mul(&x, b)
+		ax.Add(&ax, &xb)
+		if !EqualApprox(&ax, c, 1e-10) {
+			t.Errorf("%d×%d: A*X + X*B != C", test.m, test.n)
+		}
+	}
+
+	// A and -B share the eigenvalue 1.
+	var x Dense
+	err := x.SolveSylvester(NewDense(2, 2, []float64{1, 0, 0, 2}), NewDense(1, 1, []float64{-1}), NewDense(2, 1, []float64{1, 1}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("expected Condition error for singular equation, got %v", err)
+	}
+}
+
+func TestSolveLyapunov(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		// Construct a stable A.
+		a := randNormDense(n, n, rnd)
+		for i := 0; i < n; i++ {
+			a.Set(i, i, a.At(i, i)-float64(n))
+		}
+		// Construct a symmetric negative definite Q.
+		g := randNormDense(n, n, rnd)
+		var q Dense
+		q.Mul(g, g.T())
+		for i := 0; i < n; i++ {
+			q.Set(i, i, q.At(i, i)+1)
+		}
+		q.Scale(-1, &q)
+
+		var x Dense
+		err := x.SolveLyapunov(a, &q)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var lhs, xat Dense
+		lhs.Mul(a, &x)
+		xat.Mul(&x, a.T())
+		lhs.Add(&lhs, &xat)
+		if !EqualApprox(&lhs, &q, 1e-10) {
+			t.Errorf("n=%d: A*X + X*A^T != Q", n)
+		}
+		if !EqualApprox(&x, x.T(), 1e-10) {
+			t.Errorf("n=%d: solution not symmetric", n)
+		}
+		var chol Cholesky
+		if !chol.Factorize(NewSymDense(n, DenseCopyOf(&x).mat.Data)) {
+			t.Errorf("n=%d: solution not positive definite", n)
+		}
+	}
+}
+
+func TestSolveDiscreteLyapunov(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 25} {
+		// Construct A with spectral radius less than one.
+		a := randNormDense(n, n, rnd)
+		a.Scale(0.9/Norm(a, 2), a)
+		g := randNormDense(n, n, rnd)
+		var q Dense
+		q.Mul(g, g.T())
+		q.Scale(-1, &q)
+
+		var x Dense
+		err := x.SolveDiscreteLyapunov(a, &q)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		var lhs, ax Dense
+		ax.Mul(a, &x)
+		lhs.Mul(&ax, a.T())
+		lhs.Sub(&lhs, &x)
+		if !EqualApprox(&lhs, &q, 1e-10) {
+			t.Errorf("n=%d: A*X*A^T - X != Q", n)
+		}
+		if !EqualApprox(&x, x.T(), 1e-10) {
+			t.Errorf("n=%d: solution not symmetric", n)
+		}
+	}
+
+	// Nonsymmetric right-hand side with complex eigenvalues of A.
+	a := NewDense(3, 3, []float64{
+		0.5, -0.6, 0.1,
+		0.6, 0.5, 0.2,
+		0, 0, -0.3,
+	})
+	q := randNormDense(3, 3, rnd)
+	var x Dense
+	err := x.SolveDiscreteLyapunov(a, q)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var lhs, ax Dense
+	ax.Mul(a, &x)
+	lhs.Mul(&ax, a.T())
+	lhs.Sub(&lhs, &x)
+	if !EqualApprox(&lhs, q, 1e-12) {
+		t.Errorf("A*X*A^T - X != Q for nonsymmetric Q")
+	}
+
+	// The eigenvalues 2 and 0.5 of A have product one.
+	x.Reset()
+	err = x.SolveDiscreteLyapunov(NewDense(2, 2, []float64{2, 1, 0, 0.5}), NewDense(2, 2, []float64{1, 0, 0, 1}))
+	if _, ok := err.(Condition); !ok {
+		t.Errorf("expected Condition error for singular equation, got %v", err)
+	}
+}
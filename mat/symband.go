@@ -14,6 +14,7 @@ var (
 	_            Matrix           = symBandDense
 	_            Symmetric        = symBandDense
 	_            Banded           = symBandDense
+	_            SymBanded        = symBandDense
 	_            RawSymBander     = symBandDense
 	_            MutableSymBanded = symBandDense
 
@@ -27,6 +28,14 @@ type SymBandDense struct {
 	mat blas64.SymmetricBand
 }
 
+// SymBanded is a symmetric band matrix interface type.
+type SymBanded interface {
+	Symmetric
+	// Bandwidth returns the lower and upper bandwidth values for
+	// the matrix. For a symmetric band matrix kl and ku are equal.
+	Bandwidth() (kl, ku int)
+}
+
 // MutableSymBanded is a symmetric band matrix interface type that allows elements
 // to be altered.
 type MutableSymBanded interface {
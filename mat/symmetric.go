@@ -500,3 +500,35 @@ func (s *SymDense) PowPSD(a Symmetric, pow float64) error {
 	}
 	return nil
 }
+
+// ApplySpectral computes the spectral function f(a) of the symmetric matrix
+// a and stores the result into the receiver. If a = U * D * U^T is the
+// eigendecomposition of a, then
+//  f(A) = U * diag(f(d_i)) * U^T.
+// For example, fn = math.Log computes the matrix logarithm of a positive
+// definite matrix, and fn = math.Sqrt computes the square root of a positive
+// semidefinite matrix.
+//
+// ApplySpectral returns an error if the eigendecomposition is not successful.
+func (s *SymDense) ApplySpectral(fn func(float64) float64, a Symmetric) error {
+	dim := a.Symmetric()
+	s.reuseAs(dim)
+
+	var eigen EigenSym
+	ok := eigen.Factorize(a, true)
+	if !ok {
+		return ErrFailedEigen
+	}
+	values := eigen.Values(nil)
+	for i, v := range values {
+		values[i] = fn(v)
+	}
+	var u Dense
+	u.EigenvectorsSym(&eigen)
+
+	s.SymOuterK(values[0], u.ColView(0))
+	for i := 1; i < dim; i++ {
+		s.SymRankOne(s, values[i], u.ColView(i))
+	}
+	return nil
+}
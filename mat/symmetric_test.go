@@ -6,6 +6,7 @@ package mat
 
 import (
 	"fmt"
+	"math"
 	"math/rand"
 	"os"
 	"reflect"
@@ -692,3 +693,45 @@ func TestPowPSD(t *testing.T) {
 		}
 	}
 }
+
+func TestApplySpectral(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		a := NewDense(n, n, nil)
+		for i := range a.mat.Data {
+			a.mat.Data[i] = rnd.NormFloat64()
+		}
+		var spd SymDense
+		spd.SymOuterK(1, a)
+		for i := 0; i < n; i++ {
+			spd.SetSym(i, i, spd.At(i, i)+1)
+		}
+
+		var got, want SymDense
+		err := got.ApplySpectral(math.Sqrt, &spd)
+		if err != nil {
+			t.Errorf("n=%d: unexpected error: %v", n, err)
+			continue
+		}
+		want.PowPSD(&spd, 0.5)
+		if !EqualApprox(&got, &want, 1e-12) {
+			t.Errorf("n=%d: sqrt mismatch with PowPSD", n)
+		}
+
+		var exp Dense
+		exp.Exp(&spd)
+		got.ApplySpectral(math.Exp, &spd)
+		if !EqualApprox(&got, &exp, 1e-10*Norm(&exp, 1)) {
+			t.Errorf("n=%d: exp mismatch with Dense.Exp", n)
+		}
+
+		// Applying log in place and then exp recovers the matrix.
+		c := NewSymDense(n, nil)
+		c.CopySym(&spd)
+		c.ApplySpectral(math.Log, c)
+		c.ApplySpectral(math.Exp, c)
+		if !EqualApprox(c, &spd, 1e-12*Norm(&spd, 1)) {
+			t.Errorf("n=%d: exp(log(A)) != A", n)
+		}
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack64"
+)
+
+var (
+	tridiag *Tridiag
+	_       Matrix        = tridiag
+	_       Banded        = tridiag
+	_       MutableBanded = tridiag
+
+	_ NonZeroDoer    = tridiag
+	_ RowNonZeroDoer = tridiag
+	_ ColNonZeroDoer = tridiag
+)
+
+const badSymTridiag = "mat: tridiagonal matrix is not symmetric"
+
+// Tridiag represents a square tridiagonal matrix. The sub-diagonal, the
+// diagonal and the super-diagonal of the matrix are stored in separate slices.
+//
+// Linear systems with a Tridiag matrix are solved by Dense.Solve and
+// VecDense.SolveVec in O(n) time using Gaussian elimination with partial
+// pivoting, and products with a Tridiag matrix computed by Dense.Mul and
+// VecDense.MulVec take time proportional to the number of non-zero elements.
+type Tridiag struct {
+	n int
+
+	dl []float64
+	d  []float64
+	du []float64
+}
+
+// NewTridiag creates a new n×n tridiagonal matrix with the sub-diagonal dl, the
+// diagonal d and the super-diagonal du. If dl, d and du are all nil, new slices
+// are allocated for the diagonals. Otherwise, d must have length n and dl and du
+// must have length n-1, and the slices are used as the backing data so that
+// changes to the elements of the returned Tridiag will be reflected in them.
+// NewTridiag will panic if n is not positive or if the lengths of the slices
+// are not correct.
+//
+// For example, the matrix
+//    1  2  0  0
+//    3  4  5  0
+//    0  6  7  8
+//    0  0  9 10
+// is created by
+//  NewTridiag(4, []float64{3, 6, 9}, []float64{1, 4, 7, 10}, []float64{2, 5, 8})
+func NewTridiag(n int, dl, d, du []float64) *Tridiag {
+	if n <= 0 {
+		if n == 0 {
+			panic(ErrZeroLength)
+		}
+		panic("mat: negative dimension")
+	}
+	if dl == nil && d == nil && du == nil {
+		dl = make([]float64, n-1)
+		d = make([]float64, n)
+		du = make([]float64, n-1)
+	}
+	if len(dl) != n-1 || len(d) != n || len(du) != n-1 {
+		panic(ErrShape)
+	}
+	return &Tridiag{
+		n:  n,
+		dl: dl,
+		d:  d,
+		du: du,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (t *Tridiag) Dims() (r, c int) {
+	return t.n, t.n
+}
+
+// Bandwidth returns the upper and lower bandwidths of the matrix. Both
+// bandwidths are one unless the matrix is 1×1.
+func (t *Tridiag) Bandwidth() (kl, ku int) {
+	if t.n == 1 {
+		return 0, 0
+	}
+	return 1, 1
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (t *Tridiag) T() Matrix {
+	return Transpose{t}
+}
+
+// TBand performs an implicit transpose by returning the receiver inside a TransposeBand.
+func (t *Tridiag) TBand() Banded {
+	return TransposeBand{t}
+}
+
+// Diagonals returns the sub-diagonal, the diagonal and the super-diagonal of
+// the receiver. Changes to the elements of the returned slices will be
+// reflected in the receiver.
+func (t *Tridiag) Diagonals() (dl, d, du []float64) {
+	return t.dl, t.d, t.du
+}
+
+// DoNonZero calls the function fn for each of the non-zero elements of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoNonZero(fn func(i, j int, v float64)) {
+	for i := 0; i < t.n; i++ {
+		t.DoRowNonZero(i, fn)
+	}
+}
+
+// DoRowNonZero calls the function fn for each of the non-zero elements of row i of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoRowNonZero(i int, fn func(i, j int, v float64)) {
+	if i < 0 || t.n <= i {
+		panic(ErrRowAccess)
+	}
+	if i > 0 && t.dl[i-1] != 0 {
+		fn(i, i-1, t.dl[i-1])
+	}
+	if t.d[i] != 0 {
+		fn(i, i, t.d[i])
+	}
+	if i < t.n-1 && t.du[i] != 0 {
+		fn(i, i+1, t.du[i])
+	}
+}
+
+// DoColNonZero calls the function fn for each of the non-zero elements of column j of t. The function fn
+// takes a row/column index and the element value of t at (i, j).
+func (t *Tridiag) DoColNonZero(j int, fn func(i, j int, v float64)) {
+	if j < 0 || t.n <= j {
+		panic(ErrColAccess)
+	}
+	if j > 0 && t.du[j-1] != 0 {
+		fn(j-1, j, t.du[j-1])
+	}
+	if t.d[j] != 0 {
+		fn(j, j, t.d[j])
+	}
+	if j < t.n-1 && t.dl[j] != 0 {
+		fn(j+1, j, t.dl[j])
+	}
+}
+
+// solveTridiag solves the system of linear equations
+//  A * X = B    if trans is false
+//  A^T * X = B  if trans is true
+// where A is the tridiagonal matrix a, placing the solution X into the
+// receiver. The receiver must already have the shape of B.
+//
+// If A is exactly singular a Condition error is returned and the contents of
+// the receiver are undefined.
+func (m *Dense) solveTridiag(a *Tridiag, trans bool, b Matrix) error {
+	n := a.n
+	_, bc := b.Dims()
+	if m != b {
+		// m may share data with b, so copy via a workspace.
+		tmp := getWorkspace(n, bc, false)
+		tmp.Copy(b)
+		m.Copy(tmp)
+		putWorkspace(tmp)
+	}
+
+	// Dgtsv overwrites the diagonals with the factorization of A.
+	work := getFloats(3*n-2, false)
+	defer putFloats(work)
+	dl := work[:n-1]
+	d := work[n-1 : 2*n-1]
+	du := work[2*n-1:]
+	copy(dl, a.dl)
+	copy(d, a.d)
+	copy(du, a.du)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	ok := lapack64.Gtsv(t, dl, d, du, m.mat)
+	if !ok {
+		return Condition(math.Inf(1))
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// randTridiag returns a random n×n tridiagonal matrix.
+func randTridiag(n int, rnd *rand.Rand) *Tridiag {
+	a := NewTridiag(n, nil, nil, nil)
+	for i := range a.d {
+		a.d[i] = rnd.NormFloat64()
+	}
+	for i := range a.dl {
+		a.dl[i] = rnd.NormFloat64()
+		a.du[i] = rnd.NormFloat64()
+	}
+	return a
+}
+
+func TestNewTridiag(t *testing.T) {
+	a := NewTridiag(4, []float64{3, 6, 9}, []float64{1, 4, 7, 10}, []float64{2, 5, 8})
+	want := NewDense(4, 4, []float64{
+		1, 2, 0, 0,
+		3, 4, 5, 0,
+		0, 6, 7, 8,
+		0, 0, 9, 10,
+	})
+	if !Equal(a, want) {
+		t.Errorf("unexpected matrix:\ngot:\n%v\nwant:\n%v", Formatted(a), Formatted(want))
+	}
+	if kl, ku := a.Bandwidth(); kl != 1 || ku != 1 {
+		t.Errorf("unexpected bandwidth: got %d,%d want 1,1", kl, ku)
+	}
+	if kl, ku := NewTridiag(1, nil, nil, nil).Bandwidth(); kl != 0 || ku != 0 {
+		t.Errorf("unexpected bandwidth for 1×1 matrix: got %d,%d want 0,0", kl, ku)
+	}
+	if !Equal(a.TBand(), want.T()) {
+		t.Errorf("unexpected transpose")
+	}
+
+	a.SetBand(2, 1, -1)
+	want.Set(2, 1, -1)
+	if !Equal(a, want) {
+		t.Errorf("SetBand not reflected in matrix")
+	}
+	if dl, _, _ := a.Diagonals(); dl[1] != -1 {
+		t.Errorf("SetBand not reflected in diagonals")
+	}
+	if panicked, message := panics(func() { a.SetBand(0, 2, 1) }); !panicked || message != ErrBandSet.Error() {
+		t.Errorf("expected panic setting outside the band")
+	}
+
+	got := NewDense(4, 4, nil)
+	a.DoNonZero(func(i, j int, v float64) {
+		got.Set(i, j, v)
+	})
+	if !Equal(got, want) {
+		t.Errorf("unexpected DoNonZero result")
+	}
+	got = NewDense(4, 4, nil)
+	for j := 0; j < 4; j++ {
+		a.DoColNonZero(j, func(i, j int, v float64) {
+			got.Set(i, j, v)
+		})
+	}
+	if !Equal(got, want) {
+		t.Errorf("unexpected DoColNonZero result")
+	}
+
+	for _, test := range []struct {
+		n         int
+		dl, d, du []float64
+	}{
+		{0, nil, nil, nil},
+		{-1, nil, nil, nil},
+		{3, []float64{1}, []float64{1, 2, 3}, []float64{1, 2}},
+		{3, []float64{1, 2}, []float64{1, 2}, []float64{1, 2}},
+		{3, []float64{1, 2}, []float64{1, 2, 3}, nil},
+	} {
+		if panicked, _ := panics(func() { NewTridiag(test.n, test.dl, test.d, test.du) }); !panicked {
+			t.Errorf("expected panic for n=%d, len(dl)=%d, len(d)=%d, len(du)=%d",
+				test.n, len(test.dl), len(test.d), len(test.du))
+		}
+	}
+}
+
+func TestTridiagSolve(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 10, 50} {
+		for _, trans := range []bool{false, true} {
+			a := randTridiag(n, rnd)
+			var aMat Matrix = a
+			if trans {
+				aMat = a.T()
+			}
+			aDense := DenseCopyOf(aMat)
+			for _, bc := range []int{1, 3} {
+				prefix := fmt.Sprintf("n=%d,trans=%t,bc=%d", n, trans, bc)
+				b := NewDense(n, bc, nil)
+				for i := range b.mat.Data {
+					b.mat.Data[i] = rnd.NormFloat64()
+				}
+				bCopy := DenseCopyOf(b)
+
+				var x Dense
+				err := x.Solve(aMat, b)
+				if err != nil {
+					t.Errorf("%s: unexpected error: %v", prefix, err)
+					continue
+				}
+				if !Equal(b, bCopy) {
+					t.Errorf("%s: b modified", prefix)
+				}
+				var want Dense
+				want.Solve(aDense, b)
+				if !EqualApprox(&x, &want, 1e-10) {
+					t.Errorf("%s: unexpected solution:\ngot:\n%v\nwant:\n%v", prefix, Formatted(&x), Formatted(&want))
+				}
+
+				// Solve in place.
+				err = b.Solve(aMat, b)
+				if err != nil {
+					t.Errorf("%s: unexpected error solving in place: %v", prefix, err)
+				}
+				if !Equal(b, &x) {
+					t.Errorf("%s: unexpected in-place solution", prefix)
+				}
+			}
+
+			prefix := fmt.Sprintf("n=%d,trans=%t", n, trans)
+			bv := NewVecDense(n, nil)
+			for i := 0; i < n; i++ {
+				bv.SetVec(i, rnd.NormFloat64())
+			}
+			var xv, want VecDense
+			err := xv.SolveVec(aMat, bv)
+			if err != nil {
+				t.Errorf("%s: unexpected error from SolveVec: %v", prefix, err)
+			}
+			want.SolveVec(aDense, bv)
+			if !EqualApprox(&xv, &want, 1e-10) {
+				t.Errorf("%s: unexpected vector solution", prefix)
+			}
+		}
+	}
+
+	// Singular matrix.
+	a := NewTridiag(3, []float64{1, 0}, []float64{1, 1, 0}, []float64{1, 0})
+	var x Dense
+	err := x.Solve(a, NewDense(3, 1, []float64{1, 2, 3}))
+	if c, ok := err.(Condition); !ok || !math.IsInf(float64(c), 1) {
+		t.Errorf("unexpected error for singular matrix: got %v want %v", err, Condition(math.Inf(1)))
+	}
+}
+
+func TestTridiagMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 10} {
+		a := randTridiag(n, rnd)
+		aDense := DenseCopyOf(a)
+		b := NewDense(n, 3, nil)
+		for i := range b.mat.Data {
+			b.mat.Data[i] = rnd.NormFloat64()
+		}
+		c := randTridiag(n, rnd)
+
+		for _, test := range []struct {
+			name string
+			a, b Matrix
+		}{
+			{"Tridiag*Dense", a, b},
+			{"Tridiag^T*Dense", a.T(), b},
+			{"Dense^T*Tridiag", b.T(), a},
+			{"Tridiag*Tridiag", a, c},
+			{"Tridiag*Tridiag^T", a, c.T()},
+		} {
+			var got, want Dense
+			got.Mul(test.a, test.b)
+			want.Mul(DenseCopyOf(test.a), DenseCopyOf(test.b))
+			if !EqualApprox(&got, &want, 1e-14) {
+				t.Errorf("n=%d %s: unexpected result:\ngot:\n%v\nwant:\n%v", n, test.name, Formatted(&got), Formatted(&want))
+			}
+		}
+
+		x := NewVecDense(n, nil)
+		for i := 0; i < n; i++ {
+			x.SetVec(i, rnd.NormFloat64())
+		}
+		for _, trans := range []bool{false, true} {
+			var aMat, aDenseMat Matrix = a, aDense
+			if trans {
+				aMat, aDenseMat = a.T(), aDense.T()
+			}
+			var got, want VecDense
+			got.MulVec(aMat, x)
+			want.MulVec(aDenseMat, x)
+			if !EqualApprox(&got, &want, 1e-14) {
+				t.Errorf("n=%d,trans=%t: unexpected MulVec result", n, trans)
+			}
+		}
+	}
+}
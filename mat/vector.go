@@ -327,6 +327,7 @@ func (v *VecDense) DivElemVec(a, b *VecDense) {
 
 // MulVec computes a * b. The result is stored into the receiver.
 // MulVec panics if the number of columns in a does not equal the number of rows in b.
+// If a implements MulVecToer, the product is computed by its MulVecTo method.
 func (v *VecDense) MulVec(a Matrix, b *VecDense) {
 	r, c := a.Dims()
 	br := b.Len()
@@ -390,6 +391,13 @@ func (v *VecDense) MulVec(a Matrix, b *VecDense) {
 			ta = blas.Trans
 		}
 		blas64.Trmv(ta, amat, v.mat)
+	case *CSR, *CSC, *Triplet, *Tridiag:
+		for i := 0; i < r; i++ {
+			v.mat.Data[i*v.mat.Inc] = 0
+		}
+		doNonZeroTrans(a, trans, func(i, j int, f float64) {
+			v.mat.Data[i*v.mat.Inc] += f * b.mat.Data[j*b.mat.Inc]
+		})
 	case RawMatrixer:
 		amat := a.RawMatrix()
 		// We don't know that a is a *Dense, so make
@@ -400,6 +408,8 @@ func (v *VecDense) MulVec(a Matrix, b *VecDense) {
 			t = blas.Trans
 		}
 		blas64.Gemv(t, 1, amat, b.mat, 0, v.mat)
+	case MulVecToer:
+		a.MulVecTo(v, trans, b)
 	default:
 		if trans {
 			col := make([]float64, ar)
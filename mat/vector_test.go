@@ -203,6 +203,62 @@ func TestVecDenseMul(t *testing.T) {
 	testTwoInput(t, "MulVec", &VecDense{}, method, denseComparison, legalTypesNotVecVec, legalSizeMulVec, 1e-14)
 }
 
+// productOnly is a MulVecToer that does not allow access to its elements.
+type productOnly struct {
+	a *Dense
+}
+
+func (m productOnly) Dims() (r, c int)    { return m.a.Dims() }
+func (m productOnly) At(i, j int) float64 { panic("unexpected element access") }
+func (m productOnly) T() Matrix           { return Transpose{m} }
+func (m productOnly) MulVecTo(dst *VecDense, trans bool, x *VecDense) {
+	if trans {
+		dst.MulVec(m.a.T(), x)
+	} else {
+		dst.MulVec(m.a, x)
+	}
+}
+
+func TestVecDenseMulVecToer(t *testing.T) {
+	a := NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	m := productOnly{a}
+
+	var got VecDense
+	got.MulVec(m, NewVecDense(2, []float64{1, -1}))
+	if want := NewVecDense(3, []float64{-1, -1, -1}); !Equal(&got, want) {
+		t.Errorf("unexpected product: got %v, want %v", got.RawVector().Data, want.RawVector().Data)
+	}
+	got.Reset()
+	got.MulVec(m.T(), NewVecDense(3, []float64{1, 0, 1}))
+	if want := NewVecDense(2, []float64{6, 8}); !Equal(&got, want) {
+		t.Errorf("unexpected transpose product: got %v, want %v", got.RawVector().Data, want.RawVector().Data)
+	}
+}
+
+func TestDenseMulMulVecToer(t *testing.T) {
+	a := NewDense(3, 2, []float64{1, 2, 3, 4, 5, 6})
+	b := NewDense(2, 2, []float64{1, -1, 2, 0})
+	c := NewDense(2, 3, []float64{1, 0, 1, 0, 1, 0})
+	m := productOnly{a}
+	for _, test := range []struct {
+		name         string
+		a, b         Matrix
+		wantA, wantB Matrix
+	}{
+		{"A*B", m, b, a, b},
+		{"A^T*C^T", m.T(), c.T(), a.T(), c.T()},
+		{"C*A", c, m, c, a},
+		{"B*A^T", b, m.T(), b, a.T()},
+	} {
+		var want, got Dense
+		want.Mul(test.wantA, test.wantB)
+		got.Mul(test.a, test.b)
+		if !EqualApprox(&got, &want, 1e-14) {
+			t.Errorf("%s: unexpected product:\ngot:\n%v\nwant:\n%v", test.name, Formatted(&got), Formatted(&want))
+		}
+	}
+}
+
 func TestVecDenseScale(t *testing.T) {
 	for i, test := range []struct {
 		a     *VecDense
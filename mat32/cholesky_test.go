@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randSPD returns a random well-conditioned symmetric positive definite
+// n×n matrix.
+func randSPD(n int, rnd *rand.Rand) *SymDense {
+	a := NewSymDense(n, nil)
+	a.SymOuterK(1, randDense(n, n, rnd))
+	for i := 0; i < n; i++ {
+		a.SetSym(i, i, a.At(i, i)+float32(n))
+	}
+	return a
+}
+
+func TestCholesky(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20, 100} {
+		a := randSPD(n, rnd)
+		var chol Cholesky
+		if !chol.Factorize(a) {
+			t.Fatalf("n=%d: unexpected factorization failure", n)
+		}
+		if chol.Size() != n {
+			t.Errorf("n=%d: unexpected size %d", n, chol.Size())
+		}
+
+		tol := float32(1e-4) * float32(n)
+		got := chol.To(nil)
+		if !EqualApprox(got, a, tol) {
+			t.Errorf("n=%d: reconstructed matrix does not match original", n)
+		}
+
+		var chol64 mat.Cholesky
+		chol64.Factorize(mat.NewSymDense(n, toMat64(a).RawMatrix().Data))
+		if d, want := float64(chol.LogDet()), chol64.LogDet(); math.Abs(d-want) > 1e-4*math.Abs(want) {
+			t.Errorf("n=%d: unexpected log determinant: got %v, want %v", n, d, want)
+		}
+
+		b := randDense(n, 3, rnd)
+		var x Dense
+		if err := chol.Solve(&x, b); err != nil {
+			t.Errorf("n=%d: unexpected error from Solve: %v", n, err)
+		}
+		var ax Dense
+		ax.Mul(a, &x)
+		if !EqualApprox(&ax, b, tol) {
+			t.Errorf("n=%d: Solve residual too large", n)
+		}
+
+		bv := b.ColView(0)
+		var xv VecDense
+		if err := chol.SolveVec(&xv, bv); err != nil {
+			t.Errorf("n=%d: unexpected error from SolveVec: %v", n, err)
+		}
+		if !EqualApprox(&xv, x.ColView(0), tol) {
+			t.Errorf("n=%d: SolveVec does not match Solve", n)
+		}
+	}
+
+	// An indefinite matrix.
+	a := NewSymDense(2, []float32{1, 2, 2, 1})
+	var chol Cholesky
+	if chol.Factorize(a) {
+		t.Errorf("unexpected factorization success for indefinite matrix")
+	}
+	if !panics(func() { chol.Size() }) {
+		t.Errorf("expected panic for failed factorization")
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/mat"
+)
+
+// Add adds a and b element-wise, placing the result in the receiver. Add
+// will panic if the two matrices do not have the same shape.
+func (m *Dense) Add(a, b Matrix) {
+	m.apply2(a, b, func(x, y float32) float32 { return x + y })
+}
+
+// Sub subtracts the matrix b from a, placing the result in the receiver. Sub
+// will panic if the two matrices do not have the same shape.
+func (m *Dense) Sub(a, b Matrix) {
+	m.apply2(a, b, func(x, y float32) float32 { return x - y })
+}
+
+// MulElem performs element-wise multiplication of a and b, placing the result
+// in the receiver. MulElem will panic if the two matrices do not have the same
+// shape.
+func (m *Dense) MulElem(a, b Matrix) {
+	m.apply2(a, b, func(x, y float32) float32 { return x * y })
+}
+
+// apply2 places fn(a[i,j], b[i,j]) into each element of the receiver.
+func (m *Dense) apply2(a, b Matrix, fn func(x, y float32) float32) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ar != br || ac != bc {
+		panic(mat.ErrShape)
+	}
+	if m.aliases(a) || m.aliases(b) {
+		var w Dense
+		w.apply2(a, b, fn)
+		m.reuseAs(ar, ac)
+		m.Copy(&w)
+		return
+	}
+	m.reuseAs(ar, ac)
+
+	if arm, ok := a.(RawMatrixer); ok {
+		if brm, ok := b.(RawMatrixer); ok {
+			amat, bmat := arm.RawMatrix(), brm.RawMatrix()
+			for i := 0; i < ar; i++ {
+				arow := amat.Data[i*amat.Stride : i*amat.Stride+ac]
+				brow := bmat.Data[i*bmat.Stride : i*bmat.Stride+ac]
+				mrow := m.rawRowView(i)
+				for j, v := range arow {
+					mrow[j] = fn(v, brow[j])
+				}
+			}
+			return
+		}
+	}
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, fn(a.At(i, j), b.At(i, j)))
+		}
+	}
+}
+
+// Scale multiplies the elements of a by f, placing the result in the receiver.
+func (m *Dense) Scale(f float32, a Matrix) {
+	m.Apply(func(_, _ int, v float32) float32 { return f * v }, a)
+}
+
+// Apply applies the function fn to each of the elements of a, placing the
+// resulting matrix in the receiver. The function fn takes a row/column
+// index and element value and returns some function of that tuple.
+func (m *Dense) Apply(fn func(i, j int, v float32) float32, a Matrix) {
+	ar, ac := a.Dims()
+	if m.aliases(a) {
+		var w Dense
+		w.Apply(fn, a)
+		m.reuseAs(ar, ac)
+		m.Copy(&w)
+		return
+	}
+	m.reuseAs(ar, ac)
+	for i := 0; i < ar; i++ {
+		for j := 0; j < ac; j++ {
+			m.set(i, j, fn(i, j, a.At(i, j)))
+		}
+	}
+}
+
+// Mul takes the matrix product of a and b, placing the result in the receiver.
+// If the number of columns in a does not equal the number of rows in b, Mul will panic.
+//
+// Operands that are not held as general row-major data, that is matrices other
+// than *Dense, *VecDense and their transposes, are copied before multiplying.
+func (m *Dense) Mul(a, b Matrix) {
+	ar, ac := a.Dims()
+	br, bc := b.Dims()
+	if ac != br {
+		panic(mat.ErrShape)
+	}
+	if m.aliases(a) || m.aliases(b) {
+		var w Dense
+		w.Mul(a, b)
+		m.reuseAs(ar, bc)
+		m.Copy(&w)
+		return
+	}
+	m.reuseAs(ar, bc)
+	if ar == 0 || bc == 0 {
+		return
+	}
+	amat, aT := rawGeneral(a)
+	bmat, bT := rawGeneral(b)
+	blas32.Gemm(aT, bT, 1, amat, bmat, 0, m.mat)
+}
+
+// rawGeneral returns the general matrix data of a and whether it is
+// transposed. Matrices without general data are copied.
+func rawGeneral(a Matrix) (blas32.General, blas.Transpose) {
+	aU, trans := untranspose(a)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	switch aU := aU.(type) {
+	case RawMatrixer:
+		return aU.RawMatrix(), t
+	case *VecDense:
+		return aU.asGeneral(), t
+	}
+	return DenseCopyOf(a).mat, blas.NoTrans
+}
+
+// aliases returns whether the receiver shares backing data with a.
+func (m *Dense) aliases(a Matrix) bool {
+	aU, _ := untranspose(a)
+	switch aU := aU.(type) {
+	case RawMatrixer:
+		return sameArray(m.mat.Data, aU.RawMatrix().Data)
+	case *VecDense:
+		return sameArray(m.mat.Data, aU.mat.Data)
+	case *SymDense:
+		return sameArray(m.mat.Data, aU.mat.Data)
+	case *TriDense:
+		return sameArray(m.mat.Data, aU.mat.Data)
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+// randDense returns an r×c matrix of normally distributed values.
+func randDense(r, c int, rnd *rand.Rand) *Dense {
+	d := NewDense(r, c, nil)
+	for i := range d.mat.Data {
+		d.mat.Data[i] = float32(rnd.NormFloat64())
+	}
+	return d
+}
+
+// toMat64 returns a float64 copy of a.
+func toMat64(a Matrix) *mat.Dense {
+	r, c := a.Dims()
+	d := mat.NewDense(r, c, nil)
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			d.Set(i, j, float64(a.At(i, j)))
+		}
+	}
+	return d
+}
+
+// equalApprox64 returns whether a is within tol of the float64 matrix b.
+func equalApprox64(a Matrix, b mat.Matrix, tol float64) bool {
+	return mat.EqualApprox(toMat64(a), b, tol)
+}
+
+func TestNewDense(t *testing.T) {
+	m := NewDense(2, 3, []float32{1, 2, 3, 4, 5, 6})
+	if r, c := m.Dims(); r != 2 || c != 3 {
+		t.Errorf("unexpected dimensions: got %d×%d", r, c)
+	}
+	if v := m.At(1, 2); v != 6 {
+		t.Errorf("unexpected element: got %v, want 6", v)
+	}
+	m.Set(0, 1, -1)
+	if v := m.RawMatrix().Data[1]; v != -1 {
+		t.Errorf("Set not reflected in backing data: got %v", v)
+	}
+	if !panics(func() { NewDense(2, 2, []float32{1}) }) {
+		t.Errorf("expected panic for data length mismatch")
+	}
+	if !panics(func() { m.At(2, 0) }) {
+		t.Errorf("expected panic for out of bounds access")
+	}
+	if !Equal(m.T(), NewDense(3, 2, []float32{1, 4, -1, 5, 3, 6})) {
+		t.Errorf("unexpected transpose")
+	}
+	if !Equal(m.ColView(1), NewVecDense(2, []float32{-1, 5})) {
+		t.Errorf("unexpected column view")
+	}
+}
+
+func TestDenseArithmetic(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	a := randDense(4, 3, rnd)
+	b := randDense(4, 3, rnd)
+	a64, b64 := toMat64(a), toMat64(b)
+
+	var got Dense
+	var want mat.Dense
+	got.Add(a, b)
+	want.Add(a64, b64)
+	if !equalApprox64(&got, &want, 1e-6) {
+		t.Errorf("unexpected Add result")
+	}
+	got.Sub(a, b)
+	want.Sub(a64, b64)
+	if !equalApprox64(&got, &want, 1e-6) {
+		t.Errorf("unexpected Sub result")
+	}
+	got.MulElem(a, b)
+	want.MulElem(a64, b64)
+	if !equalApprox64(&got, &want, 1e-6) {
+		t.Errorf("unexpected MulElem result")
+	}
+	got.Scale(2, a)
+	want.Scale(2, a64)
+	if !equalApprox64(&got, &want, 1e-6) {
+		t.Errorf("unexpected Scale result")
+	}
+
+	// Operations in place and with a transposed alias of the receiver.
+	sq := randDense(3, 3, rnd)
+	sq64 := toMat64(sq)
+	var wantSq mat.Dense
+	wantSq.Add(sq64, sq64.T())
+	sq.Add(sq, sq.T())
+	if !equalApprox64(sq, &wantSq, 1e-6) {
+		t.Errorf("unexpected Add result with aliased transpose")
+	}
+}
+
+func TestDenseMul(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		ar, ac, bc int
+	}{
+		{1, 1, 1},
+		{3, 4, 5},
+		{10, 1, 7},
+		{20, 30, 10},
+	} {
+		a := randDense(test.ar, test.ac, rnd)
+		b := randDense(test.ac, test.bc, rnd)
+		var want mat.Dense
+		want.Mul(toMat64(a), toMat64(b))
+		tol := 1e-5 * float64(test.ac)
+
+		var got Dense
+		got.Mul(a, b)
+		if !equalApprox64(&got, &want, tol) {
+			t.Errorf("%d×%d×%d: unexpected Mul result", test.ar, test.ac, test.bc)
+		}
+
+		// The same product through transposes.
+		at := DenseCopyOf(a.T())
+		bt := DenseCopyOf(b.T())
+		got.Reset()
+		got.Mul(at.T(), bt.T())
+		if !equalApprox64(&got, &want, tol) {
+			t.Errorf("%d×%d×%d: unexpected Mul result for transposes", test.ar, test.ac, test.bc)
+		}
+
+		// A symmetric and a triangular operand.
+		s := NewSymDense(test.ac, nil)
+		s.SymOuterK(1, randDense(test.ac, 2, rnd))
+		got.Reset()
+		got.Mul(a, s)
+		want.Reset()
+		want.Mul(toMat64(a), toMat64(s))
+		if !equalApprox64(&got, &want, tol) {
+			t.Errorf("%d×%d×%d: unexpected Mul result for symmetric operand", test.ar, test.ac, test.bc)
+		}
+	}
+
+	// The receiver may be an operand.
+	a := randDense(5, 5, rnd)
+	var want mat.Dense
+	want.Mul(toMat64(a), toMat64(a.T()))
+	a.Mul(a, a.T())
+	if !equalApprox64(a, &want, 1e-4) {
+		t.Errorf("unexpected Mul result for aliased receiver")
+	}
+
+	if !panics(func() {
+		var m Dense
+		m.Mul(NewDense(2, 3, nil), NewDense(2, 3, nil))
+	}) {
+		t.Errorf("expected panic for shape mismatch")
+	}
+}
+
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	fn()
+	return
+}
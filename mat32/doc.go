@@ -0,0 +1,29 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package mat32 provides implementations of float32 matrix structures and
+// linear algebra operations on them.
+//
+// mat32 mirrors a subset of the mat package for use where memory or memory
+// bandwidth is the limiting factor, for example in embedding tables and
+// neural network inference, where single precision halves the size of the
+// matrix data. The types and methods follow the conventions of mat: receivers
+// must be the correct size for an operation unless they are zero-valued,
+// in which case they are resized, and dimension mismatches cause a panic.
+//
+// mat32 provides:
+//  - Interfaces for Matrix classes (Matrix, Symmetric, Triangular)
+//  - Concrete implementations (Dense, VecDense, SymDense, TriDense)
+//  - Matrix arithmetic (Add, Sub, Scale, Mul, MulVec)
+//  - Types for constructing and using matrix factorizations (Cholesky, LU, QR)
+//
+// Arithmetic is performed by the blas32 package and factorizations by the
+// lapack32 package, so an alternative float32 BLAS or LAPACK implementation
+// registered with those packages will be used by mat32.
+//
+// The panics raised by mat32 are the mat.Error values of the mat package, so
+// mat.Maybe can be used to recover them. Solve methods return a mat.Condition
+// error only when the factorized matrix is exactly singular; unlike in mat
+// the condition number of the matrix is not estimated.
+package mat32 // import "gonum.org/v1/gonum/mat32"
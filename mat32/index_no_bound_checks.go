@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// This file must be kept in sync with index_bound_checks.go.
+
+//+build !bounds
+
+package mat32
+
+import "gonum.org/v1/gonum/mat"
+
+// At returns the element at row i, column j.
+func (m *Dense) At(i, j int) float32 {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(mat.ErrColAccess)
+	}
+	return m.at(i, j)
+}
+
+func (m *Dense) at(i, j int) float32 {
+	return m.mat.Data[i*m.mat.Stride+j]
+}
+
+// Set sets the element at row i, column j to the value v.
+func (m *Dense) Set(i, j int, v float32) {
+	if uint(i) >= uint(m.mat.Rows) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(m.mat.Cols) {
+		panic(mat.ErrColAccess)
+	}
+	m.set(i, j, v)
+}
+
+func (m *Dense) set(i, j int, v float32) {
+	m.mat.Data[i*m.mat.Stride+j] = v
+}
+
+// At returns the element at row i.
+// It panics if i is out of bounds or if j is not zero.
+func (v *VecDense) At(i, j int) float32 {
+	if uint(i) >= uint(v.n) {
+		panic(mat.ErrRowAccess)
+	}
+	if j != 0 {
+		panic(mat.ErrColAccess)
+	}
+	return v.at(i)
+}
+
+func (v *VecDense) at(i int) float32 {
+	return v.mat.Data[i*v.mat.Inc]
+}
+
+// SetVec sets the element at row i to the value val.
+// It panics if i is out of bounds.
+func (v *VecDense) SetVec(i int, val float32) {
+	if uint(i) >= uint(v.n) {
+		panic(mat.ErrVectorAccess)
+	}
+	v.setVec(i, val)
+}
+
+func (v *VecDense) setVec(i int, val float32) {
+	v.mat.Data[i*v.mat.Inc] = val
+}
+
+// At returns the element at row i and column j.
+func (s *SymDense) At(i, j int) float32 {
+	if uint(i) >= uint(s.mat.N) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(mat.ErrColAccess)
+	}
+	return s.at(i, j)
+}
+
+func (s *SymDense) at(i, j int) float32 {
+	if i > j {
+		i, j = j, i
+	}
+	return s.mat.Data[i*s.mat.Stride+j]
+}
+
+// SetSym sets the elements at (i,j) and (j,i) to the value v.
+func (s *SymDense) SetSym(i, j int, v float32) {
+	if uint(i) >= uint(s.mat.N) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(s.mat.N) {
+		panic(mat.ErrColAccess)
+	}
+	s.set(i, j, v)
+}
+
+func (s *SymDense) set(i, j int, v float32) {
+	if i > j {
+		i, j = j, i
+	}
+	s.mat.Data[i*s.mat.Stride+j] = v
+}
+
+// At returns the element at row i, column j.
+func (t *TriDense) At(i, j int) float32 {
+	if uint(i) >= uint(t.mat.N) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(t.mat.N) {
+		panic(mat.ErrColAccess)
+	}
+	return t.at(i, j)
+}
+
+func (t *TriDense) at(i, j int) float32 {
+	isUpper := t.triKind()
+	if (isUpper && i > j) || (!isUpper && i < j) {
+		return 0
+	}
+	return t.mat.Data[i*t.mat.Stride+j]
+}
+
+// SetTri sets the element at row i, column j to the value v.
+// It panics if the location is outside the appropriate half of the matrix.
+func (t *TriDense) SetTri(i, j int, v float32) {
+	if uint(i) >= uint(t.mat.N) {
+		panic(mat.ErrRowAccess)
+	}
+	if uint(j) >= uint(t.mat.N) {
+		panic(mat.ErrColAccess)
+	}
+	isUpper := t.isUpper()
+	if (isUpper && i > j) || (!isUpper && i < j) {
+		panic(mat.ErrTriangleSet)
+	}
+	t.set(i, j, v)
+}
+
+func (t *TriDense) set(i, j int, v float32) {
+	t.mat.Data[i*t.mat.Stride+j] = v
+}
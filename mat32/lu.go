@@ -0,0 +1,208 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack32"
+	"gonum.org/v1/gonum/mat"
+)
+
+const badSliceLength = "mat32: improper slice length"
+
+// LU is a type for creating and using the LU factorization of a matrix.
+type LU struct {
+	lu    *Dense
+	pivot []int
+}
+
+// Factorize computes the LU factorization of the square matrix a and stores the
+// result. The LU decomposition will complete regardless of the singularity of a.
+//
+// The LU factorization is computed with pivoting, and so really the decomposition
+// is a PLU decomposition where P is a permutation matrix. The individual matrix
+// factors can be extracted from the factorization using the Pivot method and
+// the LU LTo and UTo methods.
+func (lu *LU) Factorize(a Matrix) {
+	r, c := a.Dims()
+	if r != c {
+		panic(mat.ErrSquare)
+	}
+	if lu.lu == nil {
+		lu.lu = NewDense(r, r, nil)
+	} else {
+		lu.lu.Reset()
+		lu.lu.reuseAs(r, r)
+	}
+	lu.lu.Copy(a)
+	if cap(lu.pivot) < r {
+		lu.pivot = make([]int, r)
+	}
+	lu.pivot = lu.pivot[:r]
+	lapack32.Getrf(lu.lu.mat, lu.pivot)
+}
+
+// Reset resets the factorization so that it can be reused as the receiver of a
+// dimensionally restricted operation.
+func (lu *LU) Reset() {
+	if lu.lu != nil {
+		lu.lu.Reset()
+	}
+	lu.pivot = lu.pivot[:0]
+}
+
+// Det returns the determinant of the matrix that has been factorized. In many
+// expressions, using LogDet will be more numerically stable.
+func (lu *LU) Det() float32 {
+	det, sign := lu.LogDet()
+	return float32(math.Exp(float64(det))) * sign
+}
+
+// LogDet returns the log of the determinant and the sign of the determinant
+// for the matrix that has been factorized. Numerical stability in product and
+// division expressions is generally improved by working in log space. The
+// logarithm is accumulated in float64 so that it does not overflow for large
+// matrices.
+func (lu *LU) LogDet() (det float32, sign float32) {
+	_, n := lu.lu.Dims()
+	var logDet float64
+	sign = 1.0
+	for i := 0; i < n; i++ {
+		v := lu.lu.at(i, i)
+		if v < 0 {
+			sign *= -1
+		}
+		if lu.pivot[i] != i {
+			sign *= -1
+		}
+		logDet += math.Log(math.Abs(float64(v)))
+	}
+	return float32(logDet), sign
+}
+
+// Pivot returns pivot indices that enable the construction of the permutation
+// matrix P (see mat.Dense.Permutation). If swaps == nil, then new memory will be
+// allocated, otherwise the length of the input must be equal to the size of the
+// factorized matrix.
+func (lu *LU) Pivot(swaps []int) []int {
+	_, n := lu.lu.Dims()
+	if swaps == nil {
+		swaps = make([]int, n)
+	}
+	if len(swaps) != n {
+		panic(badSliceLength)
+	}
+	// Perform the inverse of the row swaps in order to find the final
+	// row swap position.
+	for i := range swaps {
+		swaps[i] = i
+	}
+	for i := n - 1; i >= 0; i-- {
+		v := lu.pivot[i]
+		swaps[i], swaps[v] = swaps[v], swaps[i]
+	}
+	return swaps
+}
+
+// LTo extracts the lower triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting L matrix is returned.
+func (lu *LU) LTo(dst *TriDense) *TriDense {
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewTriDense(n, mat.Lower, nil)
+	} else {
+		dst.reuseAs(n, mat.Lower)
+	}
+	// Extract the lower triangular elements.
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	// Set ones on the diagonal.
+	for i := 0; i < n; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+	return dst
+}
+
+// UTo extracts the upper triangular matrix from an LU factorization.
+// If dst is nil, a new matrix is allocated. The resulting U matrix is returned.
+func (lu *LU) UTo(dst *TriDense) *TriDense {
+	_, n := lu.lu.Dims()
+	if dst == nil {
+		dst = NewTriDense(n, mat.Upper, nil)
+	} else {
+		dst.reuseAs(n, mat.Upper)
+	}
+	// Extract the upper triangular elements.
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			dst.mat.Data[i*dst.mat.Stride+j] = lu.lu.mat.Data[i*lu.lu.mat.Stride+j]
+		}
+	}
+	return dst
+}
+
+// Solve solves a system of linear equations using the LU decomposition of a matrix.
+// It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into m.
+//
+// If A is exactly singular a mat.Condition error is returned.
+func (lu *LU) Solve(m *Dense, trans bool, b Matrix) error {
+	_, n := lu.lu.Dims()
+	br, bc := b.Dims()
+	if br != n {
+		panic(mat.ErrShape)
+	}
+	if lu.isSingular() {
+		return mat.Condition(math.Inf(1))
+	}
+
+	if m.aliases(b) && b != m {
+		b = DenseCopyOf(b)
+	}
+	m.reuseAs(n, bc)
+	m.Copy(b)
+	t := blas.NoTrans
+	if trans {
+		t = blas.Trans
+	}
+	lapack32.Getrs(t, lu.lu.mat, m.mat, lu.pivot)
+	return nil
+}
+
+// SolveVec solves a system of linear equations using the LU decomposition of a matrix.
+// It computes
+//  A * x = b if trans == false
+//  A^T * x = b if trans == true
+// In both cases, A is represented in LU factorized form, and the matrix x is
+// stored into v.
+//
+// If A is exactly singular a mat.Condition error is returned.
+func (lu *LU) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	_, n := lu.lu.Dims()
+	if b.Len() != n {
+		panic(mat.ErrShape)
+	}
+	v.reuseAs(n)
+	return lu.Solve(v.asDense(), trans, b.asDense())
+}
+
+// isSingular returns whether a diagonal element of U is zero.
+func (lu *LU) isSingular() bool {
+	_, n := lu.lu.Dims()
+	for i := 0; i < n; i++ {
+		if lu.lu.at(i, i) == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestLU(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 5, 20, 100} {
+		a := randDense(n, n, rnd)
+		var lu LU
+		lu.Factorize(a)
+
+		// Check that P * L * U = A, where row i of P is e_pivot[i].
+		l := lu.LTo(nil)
+		u := lu.UTo(nil)
+		var lu2 Dense
+		lu2.Mul(l, u)
+		pivot := lu.Pivot(nil)
+		tol := float32(1e-5) * float32(n)
+		for i, p := range pivot {
+			for j := 0; j < n; j++ {
+				if d := lu2.At(p, j) - a.At(i, j); d > tol || d < -tol {
+					t.Errorf("n=%d: reconstructed matrix does not match original at (%d, %d)", n, i, j)
+				}
+			}
+		}
+
+		var lu64 mat.LU
+		lu64.Factorize(toMat64(a))
+		ld, sign := lu.LogDet()
+		ld64, sign64 := lu64.LogDet()
+		if float64(sign) != sign64 || math.Abs(float64(ld)-ld64) > 1e-4*math.Max(1, math.Abs(ld64)) {
+			t.Errorf("n=%d: unexpected log determinant: got %v, %v, want %v, %v", n, ld, sign, ld64, sign64)
+		}
+
+		b := randDense(n, 2, rnd)
+		for _, trans := range []bool{false, true} {
+			var x Dense
+			if err := lu.Solve(&x, trans, b); err != nil {
+				t.Errorf("n=%d, trans=%t: unexpected error from Solve: %v", n, trans, err)
+			}
+			var x64 mat.Dense
+			lu64.Solve(&x64, trans, toMat64(b))
+			scale := mat.Norm(&x64, math.Inf(1))
+			if !equalApprox64(&x, &x64, 1e-3*scale) {
+				t.Errorf("n=%d, trans=%t: Solve does not match float64 result", n, trans)
+			}
+
+			var xv VecDense
+			if err := lu.SolveVec(&xv, trans, b.ColView(1)); err != nil {
+				t.Errorf("n=%d, trans=%t: unexpected error from SolveVec: %v", n, trans, err)
+			}
+			if !EqualApprox(&xv, x.ColView(1), 1e-6*float32(scale)) {
+				t.Errorf("n=%d, trans=%t: SolveVec does not match Solve", n, trans)
+			}
+		}
+	}
+
+	// A singular matrix.
+	a := NewDense(3, 3, []float32{1, 2, 3, 2, 4, 6, 1, 0, 1})
+	var lu LU
+	lu.Factorize(a)
+	var x Dense
+	err := lu.Solve(&x, false, NewDense(3, 1, []float32{1, 2, 3}))
+	if _, ok := err.(mat.Condition); !ok {
+		t.Errorf("expected Condition error for singular matrix, got %v", err)
+	}
+}
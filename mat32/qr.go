@@ -0,0 +1,155 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/lapack/lapack32"
+	"gonum.org/v1/gonum/mat"
+)
+
+// QR is a type for creating and using the QR factorization of a matrix.
+type QR struct {
+	qr  *Dense
+	tau []float32
+}
+
+// Factorize computes the QR factorization of an m×n matrix a where m >= n. The QR
+// factorization always exists even if A is singular.
+//
+// The QR decomposition is a factorization of the matrix A such that A = Q * R.
+// The matrix Q is an orthonormal m×m matrix, and R is an m×n upper triangular matrix.
+// Q and R can be extracted using the QTo and RTo methods.
+func (qr *QR) Factorize(a Matrix) {
+	m, n := a.Dims()
+	if m < n {
+		panic(mat.ErrShape)
+	}
+	k := min(m, n)
+	if qr.qr == nil {
+		qr.qr = &Dense{}
+	}
+	qr.qr.Clone(a)
+	work := []float32{0}
+	qr.tau = make([]float32, k)
+	lapack32.Geqrf(qr.qr.mat, qr.tau, work, -1)
+
+	work = make([]float32, int(work[0]))
+	lapack32.Geqrf(qr.qr.mat, qr.tau, work, len(work))
+}
+
+// RTo extracts the m×n upper trapezoidal matrix from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting dst matrix is returned.
+func (qr *QR) RTo(dst *Dense) *Dense {
+	r, c := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, c, nil)
+	} else {
+		dst.reuseAsZeroed(r, c)
+	}
+	for i := 0; i < c; i++ {
+		copy(dst.mat.Data[i*dst.mat.Stride+i:i*dst.mat.Stride+c], qr.qr.mat.Data[i*qr.qr.mat.Stride+i:i*qr.qr.mat.Stride+c])
+	}
+	return dst
+}
+
+// QTo extracts the m×m orthonormal matrix Q from a QR decomposition.
+// If dst is nil, a new matrix is allocated. The resulting Q matrix is returned.
+func (qr *QR) QTo(dst *Dense) *Dense {
+	r, _ := qr.qr.Dims()
+	if dst == nil {
+		dst = NewDense(r, r, nil)
+	} else {
+		dst.reuseAsZeroed(r, r)
+	}
+
+	// Set Q = I.
+	for i := 0; i < r; i++ {
+		dst.mat.Data[i*dst.mat.Stride+i] = 1
+	}
+
+	// Construct Q from the elementary reflectors.
+	qr.mulQ(blas.NoTrans, dst)
+	return dst
+}
+
+// Solve finds a minimum-norm solution to a system of linear equations defined
+// by the matrices A and b, where A is an m×n matrix represented in its QR factorized
+// form. If A is exactly singular a mat.Condition error is returned.
+//
+// The minimization problem solved depends on the input parameters.
+//  If trans == false, find X such that ||A*X - b||_2 is minimized.
+//  If trans == true, find the minimum norm solution of A^T * X = b.
+// The solution matrix, X, is stored in place into m.
+func (qr *QR) Solve(m *Dense, trans bool, b Matrix) error {
+	r, c := qr.qr.Dims()
+	br, bc := b.Dims()
+
+	// The QR solve algorithm stores the result in-place into the right hand side.
+	// The storage for the answer must be large enough to hold both b and x.
+	// However, this method's receiver must be the size of x. Copy b, and then
+	// copy the result into m at the end.
+	if trans {
+		if c != br {
+			panic(mat.ErrShape)
+		}
+		m.reuseAs(r, bc)
+	} else {
+		if r != br {
+			panic(mat.ErrShape)
+		}
+		m.reuseAs(c, bc)
+	}
+	// Do not need to worry about overlap between m and b because x has its own
+	// independent storage.
+	x := NewDense(max(r, c), bc, nil)
+	x.Copy(b)
+	t := qr.qr.asTriDense(qr.qr.mat.Cols, blas.NonUnit, blas.Upper).mat
+	if trans {
+		ok := lapack32.Trtrs(blas.Trans, t, x.mat)
+		if !ok {
+			return mat.Condition(math.Inf(1))
+		}
+		for i := c; i < r; i++ {
+			zero(x.mat.Data[i*x.mat.Stride : i*x.mat.Stride+bc])
+		}
+		qr.mulQ(blas.NoTrans, x)
+	} else {
+		qr.mulQ(blas.Trans, x)
+
+		ok := lapack32.Trtrs(blas.NoTrans, t, x.mat)
+		if !ok {
+			return mat.Condition(math.Inf(1))
+		}
+	}
+	// M was set above to be the correct size for the result.
+	m.Copy(x)
+	return nil
+}
+
+// mulQ computes x = Q * x or x = Q^T * x depending on trans.
+func (qr *QR) mulQ(trans blas.Transpose, x *Dense) {
+	work := []float32{0}
+	lapack32.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, -1)
+	work = make([]float32, int(work[0]))
+	lapack32.Ormqr(blas.Left, trans, qr.qr.mat, qr.tau, x.mat, work, len(work))
+}
+
+// SolveVec finds a minimum-norm solution to a system of linear equations.
+// Please see QR.Solve for the full documentation.
+func (qr *QR) SolveVec(v *VecDense, trans bool, b *VecDense) error {
+	r, c := qr.qr.Dims()
+	// The Solve implementation is non-trivial, so rather than duplicate the code,
+	// instead recast the VecDenses as Dense and call the matrix code.
+	if trans {
+		v.reuseAs(r)
+	} else {
+		v.reuseAs(c)
+	}
+	return qr.Solve(v.asDense(), trans, b.asDense())
+}
+
@@ -0,0 +1,85 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestQR(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n int
+	}{
+		{1, 1},
+		{5, 5},
+		{10, 4},
+		{50, 20},
+		{100, 100},
+	} {
+		m, n := test.m, test.n
+		a := randDense(m, n, rnd)
+		var qr QR
+		qr.Factorize(a)
+		q := qr.QTo(nil)
+		r := qr.RTo(nil)
+
+		tol := float32(1e-5) * float32(m)
+		var qtq Dense
+		qtq.Mul(q.T(), q)
+		eye := NewDense(m, m, nil)
+		for i := 0; i < m; i++ {
+			eye.Set(i, i, 1)
+		}
+		if !EqualApprox(&qtq, eye, tol) {
+			t.Errorf("%d×%d: Q is not orthonormal", m, n)
+		}
+		var qrProd Dense
+		qrProd.Mul(q, r)
+		if !EqualApprox(&qrProd, a, tol) {
+			t.Errorf("%d×%d: reconstructed matrix does not match original", m, n)
+		}
+
+		// Compare the least squares solution with the float64 result.
+		b := randDense(m, 2, rnd)
+		var x Dense
+		if err := qr.Solve(&x, false, b); err != nil {
+			t.Errorf("%d×%d: unexpected error from Solve: %v", m, n, err)
+		}
+		var qr64 mat.QR
+		qr64.Factorize(toMat64(a))
+		var x64 mat.Dense
+		qr64.Solve(&x64, false, toMat64(b))
+		scale := mat.Norm(&x64, math.Inf(1))
+		if !equalApprox64(&x, &x64, 1e-3*scale) {
+			t.Errorf("%d×%d: Solve does not match float64 result", m, n)
+		}
+
+		var xv VecDense
+		if err := qr.SolveVec(&xv, false, b.ColView(0)); err != nil {
+			t.Errorf("%d×%d: unexpected error from SolveVec: %v", m, n, err)
+		}
+		if !EqualApprox(&xv, x.ColView(0), 1e-6*float32(scale)) {
+			t.Errorf("%d×%d: SolveVec does not match Solve", m, n)
+		}
+
+		if m == n {
+			// The transposed system has a unique solution.
+			var xt Dense
+			if err := qr.Solve(&xt, true, b); err != nil {
+				t.Errorf("%d×%d: unexpected error from transposed Solve: %v", m, n, err)
+			}
+			var atx Dense
+			atx.Mul(a.T(), &xt)
+			if !EqualApprox(&atx, b, 1e-4*float32(n)*float32(scale)) {
+				t.Errorf("%d×%d: transposed Solve residual too large", m, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	triDense *TriDense
+	_        Matrix        = triDense
+	_        Triangular    = triDense
+	_        RawTriangular = triDense
+)
+
+const badTriangle = "mat32: invalid triangle"
+
+// TriDense represents an upper or lower triangular matrix in dense storage
+// format.
+type TriDense struct {
+	mat blas32.Triangular
+	cap int
+}
+
+// Triangular represents a triangular matrix. Triangular matrices are always square.
+type Triangular interface {
+	Matrix
+	// Triangle returns the number of rows/columns in the matrix and its
+	// orientation.
+	Triangle() (n int, kind mat.TriKind)
+}
+
+// A RawTriangular can return a view of itself as a BLAS Triangular matrix.
+type RawTriangular interface {
+	RawTriangular() blas32.Triangular
+}
+
+// NewTriDense creates a new Triangular matrix with n rows and columns. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n*n, data is
+// used as the backing slice, and changes to the elements of the returned TriDense
+// will be reflected in data. If neither of these is true, NewTriDense will panic.
+//
+// The data must be arranged in row-major order, i.e. the (i*c + j)-th
+// element in the data slice is the {i, j}-th element in the matrix.
+// Only the values in the triangular portion corresponding to kind are used.
+func NewTriDense(n int, kind mat.TriKind, data []float32) *TriDense {
+	if n < 0 {
+		panic("mat32: negative dimension")
+	}
+	if data != nil && len(data) != n*n {
+		panic(mat.ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n*n)
+	}
+	uplo := blas.Lower
+	if kind == mat.Upper {
+		uplo = blas.Upper
+	}
+	return &TriDense{
+		mat: blas32.Triangular{
+			N:      n,
+			Stride: n,
+			Data:   data,
+			Uplo:   uplo,
+			Diag:   blas.NonUnit,
+		},
+		cap: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix.
+func (t *TriDense) Dims() (r, c int) {
+	return t.mat.N, t.mat.N
+}
+
+// Triangle returns the dimension of t and its orientation. The returned
+// orientation is only valid when n is not zero.
+func (t *TriDense) Triangle() (n int, kind mat.TriKind) {
+	return t.mat.N, mat.TriKind(!t.IsZero()) && t.triKind()
+}
+
+func (t *TriDense) isUpper() bool {
+	return isUpperUplo(t.mat.Uplo)
+}
+
+func (t *TriDense) triKind() mat.TriKind {
+	return mat.TriKind(isUpperUplo(t.mat.Uplo))
+}
+
+func isUpperUplo(u blas.Uplo) bool {
+	switch u {
+	case blas.Upper:
+		return true
+	case blas.Lower:
+		return false
+	default:
+		panic(badTriangle)
+	}
+}
+
+// asSymBlas returns the receiver restructured as a blas32.Symmetric with the
+// same backing memory.
+func (t *TriDense) asSymBlas() blas32.Symmetric {
+	return blas32.Symmetric{
+		N:      t.mat.N,
+		Stride: t.mat.Stride,
+		Data:   t.mat.Data,
+		Uplo:   t.mat.Uplo,
+	}
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (t *TriDense) T() Matrix {
+	return Transpose{t}
+}
+
+// RawTriangular returns the underlying blas32.Triangular used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.Triangular.
+func (t *TriDense) RawTriangular() blas32.Triangular {
+	return t.mat
+}
+
+// Reset zeros the dimensions of the matrix so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+func (t *TriDense) Reset() {
+	// N and Stride must be zeroed in unison.
+	t.mat.N, t.mat.Stride = 0, 0
+	// Defensively zero Uplo to ensure
+	// it is set correctly later.
+	t.mat.Uplo = 0
+	t.mat.Data = t.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized matrices can be the
+// receiver for size-restricted operations. TriDense matrices can be zeroed using Reset.
+func (t *TriDense) IsZero() bool {
+	// It must be the case that t.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return t.mat.Stride == 0
+}
+
+// reuseAs resizes a zero receiver to an n×n triangular matrix with the given
+// orientation. If the receiver is non-zero, reuseAs checks that the receiver
+// is the correct size and orientation.
+func (t *TriDense) reuseAs(n int, kind mat.TriKind) {
+	ul := blas.Lower
+	if kind == mat.Upper {
+		ul = blas.Upper
+	}
+	if t.IsZero() {
+		t.mat = blas32.Triangular{
+			N:      n,
+			Stride: n,
+			Diag:   blas.NonUnit,
+			Data:   use(t.mat.Data, n*n),
+			Uplo:   ul,
+		}
+		t.cap = n
+		return
+	}
+	if t.mat.N != n {
+		panic(mat.ErrShape)
+	}
+	if t.mat.Uplo != ul {
+		panic(mat.ErrTriangle)
+	}
+}
+
+// copySymIntoTriangle copies the upper triangle of a symmetric matrix into
+// an upper triangular TriDense.
+func copySymIntoTriangle(t *TriDense, s Symmetric) {
+	n := t.mat.N
+	if s.Symmetric() != n {
+		panic("mat32: triangle size mismatch")
+	}
+	ts := t.mat.Stride
+	if rs, ok := s.(RawSymmetricer); ok {
+		sd := rs.RawSymmetric()
+		for i := 0; i < n; i++ {
+			copy(t.mat.Data[i*ts+i:i*ts+n], sd.Data[i*sd.Stride+i:i*sd.Stride+n])
+		}
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			t.mat.Data[i*ts+j] = s.At(i, j)
+		}
+	}
+}
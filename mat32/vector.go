@@ -0,0 +1,250 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"gonum.org/v1/gonum/blas"
+	"gonum.org/v1/gonum/blas/blas32"
+	"gonum.org/v1/gonum/mat"
+)
+
+var (
+	vector *VecDense
+
+	_ Matrix      = vector
+	_ Vector      = vector
+	_ RawVectorer = vector
+)
+
+// Vector is a column vector.
+type Vector interface {
+	Matrix
+	Len() int
+}
+
+// VecDense represents a column vector.
+type VecDense struct {
+	mat blas32.Vector
+	n   int
+	// VecDense must have positive increment in this package,
+	// as in the mat package.
+}
+
+// NewVecDense creates a new VecDense of length n. If data == nil,
+// a new slice is allocated for the backing slice. If len(data) == n, data is
+// used as the backing slice, and changes to the elements of the returned VecDense
+// will be reflected in data. If neither of these is true, NewVecDense will panic.
+func NewVecDense(n int, data []float32) *VecDense {
+	if len(data) != n && data != nil {
+		panic(mat.ErrShape)
+	}
+	if data == nil {
+		data = make([]float32, n)
+	}
+	return &VecDense{
+		mat: blas32.Vector{
+			Inc:  1,
+			Data: data,
+		},
+		n: n,
+	}
+}
+
+// Dims returns the number of rows and columns in the matrix. Columns is always 1
+// for a non-Reset vector.
+func (v *VecDense) Dims() (r, c int) {
+	if v.IsZero() {
+		return 0, 0
+	}
+	return v.n, 1
+}
+
+// Len returns the length of the vector.
+func (v *VecDense) Len() int {
+	return v.n
+}
+
+// T performs an implicit transpose by returning the receiver inside a Transpose.
+func (v *VecDense) T() Matrix {
+	return Transpose{v}
+}
+
+// Reset zeros the length of the vector so that it can be reused as the
+// receiver of a dimensionally restricted operation.
+func (v *VecDense) Reset() {
+	// No change of Inc or n to 0 may be
+	// made unless both are set to 0.
+	v.mat.Inc = 0
+	v.n = 0
+	v.mat.Data = v.mat.Data[:0]
+}
+
+// IsZero returns whether the receiver is zero-sized. Zero-sized vectors can be the
+// receiver for size-restricted operations. VecDenses can be zeroed using Reset.
+func (v *VecDense) IsZero() bool {
+	// It must be the case that v.Dims() returns
+	// zeros in this case. See comment in Reset().
+	return v.mat.Inc == 0
+}
+
+// reuseAs resizes an empty vector to a r×1 vector,
+// or checks that a non-empty matrix is r×1.
+func (v *VecDense) reuseAs(r int) {
+	if v.IsZero() {
+		v.mat = blas32.Vector{
+			Inc:  1,
+			Data: use(v.mat.Data, r),
+		}
+		v.n = r
+		return
+	}
+	if r != v.n {
+		panic(mat.ErrShape)
+	}
+}
+
+// RawVector returns the underlying blas32.Vector used by the receiver.
+// Changes to elements in the receiver following the call will be reflected
+// in returned blas32.Vector.
+func (v *VecDense) RawVector() blas32.Vector {
+	return v.mat
+}
+
+// CloneVec makes a copy of a into the receiver, overwriting the previous value
+// of the receiver.
+func (v *VecDense) CloneVec(a *VecDense) {
+	if v == a {
+		return
+	}
+	w := VecDense{
+		mat: blas32.Vector{
+			Inc:  1,
+			Data: make([]float32, a.n),
+		},
+		n: a.n,
+	}
+	blas32.Copy(a.n, a.mat, w.mat)
+	*v = w
+}
+
+// CopyVec makes a copy of elements of a into the receiver. It is similar to the
+// built-in copy; it copies as much as the overlap between the two vectors and
+// returns the number of elements it copied.
+func (v *VecDense) CopyVec(a *VecDense) int {
+	n := min(v.Len(), a.Len())
+	if v != a {
+		blas32.Copy(n, a.mat, v.mat)
+	}
+	return n
+}
+
+// ScaleVec scales the vector a by alpha, placing the result in the receiver.
+func (v *VecDense) ScaleVec(alpha float32, a *VecDense) {
+	v.reuseAs(a.Len())
+	v.CopyVec(a)
+	blas32.Scal(v.n, alpha, v.mat)
+}
+
+// AddScaledVec adds the vectors a and alpha*b, placing the result in the receiver.
+func (v *VecDense) AddScaledVec(a *VecDense, alpha float32, b *VecDense) {
+	n := a.Len()
+	if b.Len() != n {
+		panic(mat.ErrShape)
+	}
+	v.reuseAs(n)
+	switch {
+	case v == b && v == a:
+		blas32.Scal(n, alpha+1, v.mat)
+	case v == b:
+		// v <- a + alpha * v.
+		blas32.Scal(n, alpha, v.mat)
+		blas32.Axpy(n, 1, a.mat, v.mat)
+	default:
+		v.CopyVec(a)
+		blas32.Axpy(n, alpha, b.mat, v.mat)
+	}
+}
+
+// AddVec adds the vectors a and b, placing the result in the receiver.
+func (v *VecDense) AddVec(a, b *VecDense) {
+	v.AddScaledVec(a, 1, b)
+}
+
+// SubVec subtracts the vector b from a, placing the result in the receiver.
+func (v *VecDense) SubVec(a, b *VecDense) {
+	v.AddScaledVec(a, -1, b)
+}
+
+// MulVec computes a * b. The result is stored into the receiver.
+// MulVec panics if the number of columns in a does not equal the number of rows in b.
+func (v *VecDense) MulVec(a Matrix, b *VecDense) {
+	r, c := a.Dims()
+	if c != b.Len() {
+		panic(mat.ErrShape)
+	}
+	if v.aliases(a) || sameArray(v.mat.Data, b.mat.Data) {
+		var w VecDense
+		w.MulVec(a, b)
+		v.reuseAs(r)
+		v.CopyVec(&w)
+		return
+	}
+	v.reuseAs(r)
+	if r == 0 {
+		return
+	}
+
+	aU, trans := untranspose(a)
+	switch aU := aU.(type) {
+	case *SymDense:
+		blas32.Symv(1, aU.mat, b.mat, 0, v.mat)
+	case *TriDense:
+		v.CopyVec(b)
+		t := blas.NoTrans
+		if trans {
+			t = blas.Trans
+		}
+		blas32.Trmv(t, aU.mat, v.mat)
+	default:
+		amat, t := rawGeneral(a)
+		blas32.Gemv(t, 1, amat, b.mat, 0, v.mat)
+	}
+}
+
+// aliases returns whether the receiver shares backing data with a.
+func (v *VecDense) aliases(a Matrix) bool {
+	return v.asDense().aliases(a)
+}
+
+// Dot returns the sum of the element-wise product of a and b.
+// Dot panics if the matrix sizes are unequal.
+func Dot(a, b *VecDense) float32 {
+	n := a.Len()
+	if n != b.Len() {
+		panic(mat.ErrShape)
+	}
+	return blas32.Dot(n, a.mat, b.mat)
+}
+
+// asDense returns a Dense representation of the receiver with the same
+// underlying data.
+func (v *VecDense) asDense() *Dense {
+	return &Dense{
+		mat:     v.asGeneral(),
+		capRows: v.n,
+		capCols: 1,
+	}
+}
+
+// asGeneral returns a blas32.General representation of the receiver with the
+// same underlying data.
+func (v *VecDense) asGeneral() blas32.General {
+	return blas32.General{
+		Rows:   v.n,
+		Cols:   1,
+		Stride: v.mat.Inc,
+		Data:   v.mat.Data,
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package mat32
+
+import (
+	"math/rand"
+	"testing"
+
+	"gonum.org/v1/gonum/mat"
+)
+
+func TestVecDense(t *testing.T) {
+	a := NewVecDense(3, []float32{1, 2, 3})
+	b := NewVecDense(3, []float32{4, -5, 6})
+	if d := Dot(a, b); d != 12 {
+		t.Errorf("unexpected dot product: got %v, want 12", d)
+	}
+	var v VecDense
+	v.AddScaledVec(a, 2, b)
+	if !Equal(&v, NewVecDense(3, []float32{9, -8, 15})) {
+		t.Errorf("unexpected AddScaledVec result: %v", v.RawVector().Data)
+	}
+	v.SubVec(&v, a)
+	if !Equal(&v, NewVecDense(3, []float32{8, -10, 12})) {
+		t.Errorf("unexpected SubVec result: %v", v.RawVector().Data)
+	}
+	v.AddScaledVec(a, -1, &v)
+	if !Equal(&v, NewVecDense(3, []float32{-7, 12, -9})) {
+		t.Errorf("unexpected AddScaledVec result with aliased b: %v", v.RawVector().Data)
+	}
+	v.ScaleVec(0.5, &v)
+	if !Equal(&v, NewVecDense(3, []float32{-3.5, 6, -4.5})) {
+		t.Errorf("unexpected ScaleVec result: %v", v.RawVector().Data)
+	}
+}
+
+func TestMulVec(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 3, 10, 50} {
+		a := randDense(n, n+2, rnd)
+		x := randDense(n+2, 1, rnd).ColView(0)
+		y := randDense(n, 1, rnd).ColView(0)
+		var want mat.VecDense
+
+		var got VecDense
+		got.MulVec(a, x)
+		want.MulVec(toMat64(a), mat.NewVecDense(n+2, toMat64(x).RawMatrix().Data))
+		if !equalApprox64(&got, &want, 1e-5*float64(n)) {
+			t.Errorf("n=%d: unexpected MulVec result", n)
+		}
+		got.Reset()
+		got.MulVec(a.T(), y)
+		want.Reset()
+		want.MulVec(toMat64(a).T(), mat.NewVecDense(n, toMat64(y).RawMatrix().Data))
+		if !equalApprox64(&got, &want, 1e-5*float64(n)) {
+			t.Errorf("n=%d: unexpected MulVec result for transpose", n)
+		}
+
+		s := NewSymDense(n, nil)
+		s.SymOuterK(1, a)
+		got.Reset()
+		got.MulVec(s, y)
+		want.Reset()
+		want.MulVec(toMat64(s), mat.NewVecDense(n, toMat64(y).RawMatrix().Data))
+		if !equalApprox64(&got, &want, 1e-4*float64(n)) {
+			t.Errorf("n=%d: unexpected MulVec result for symmetric matrix", n)
+		}
+
+		// The receiver may be the vector operand.
+		y.MulVec(s, y)
+		if !equalApprox64(y, &want, 1e-4*float64(n)) {
+			t.Errorf("n=%d: unexpected MulVec result for aliased receiver", n)
+		}
+	}
+}
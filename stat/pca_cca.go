@@ -17,6 +17,7 @@ import (
 // if the call to PrincipalComponents was successful.
 type PC struct {
 	n, d    int
+	k       int // The number of computed components.
 	weights []float64
 	svd     *mat.SVD
 	ok      bool
@@ -41,14 +42,48 @@ func (c *PC) PrincipalComponents(a mat.Matrix, weights []float64) (ok bool) {
 
 	c.svd, c.ok = svdFactorizeCentered(c.svd, a, weights)
 	if c.ok {
+		c.k = min(c.n, c.d)
+		c.weights = append(c.weights[:0], weights...)
+	}
+	return c.ok
+}
+
+// PrincipalComponentsRank performs a weighted principal components analysis
+// on the n×d matrix a in the same way as PrincipalComponents, but computes
+// only the k leading principal components. The components are computed by a
+// truncated singular value decomposition of the centered data, which is
+// considerably faster than the full analysis when k is much smaller than
+// min(n, d). The centered data are not formed explicitly, so a is only
+// accessed through matrix-vector products when k is small enough.
+//
+// k must be in [1, min(n, d)], otherwise PrincipalComponentsRank will panic.
+//
+// PrincipalComponentsRank returns whether the analysis was successful.
+func (c *PC) PrincipalComponentsRank(a mat.Matrix, weights []float64, k int) (ok bool) {
+	c.n, c.d = a.Dims()
+	if weights != nil && len(weights) != c.n {
+		panic("stat: len(weights) != observations")
+	}
+	if k < 1 || min(c.n, c.d) < k {
+		panic("stat: rank out of range")
+	}
+
+	if c.svd == nil {
+		c.svd = &mat.SVD{}
+	}
+	c.ok = c.svd.FactorizeTruncated(newCenteredMatrix(a, weights), k)
+	if c.ok {
+		c.k = k
 		c.weights = append(c.weights[:0], weights...)
 	}
 	return c.ok
 }
 
 // VectorsTo returns the component direction vectors of a principal components
-// analysis. The vectors are returned in the columns of a d×min(n, d) matrix.
-// If dst is not nil it must either be zero-sized or be a d×min(n, d) matrix.
+// analysis. The vectors are returned in the columns of a d×k matrix, where k is
+// min(n, d) after PrincipalComponents and the requested rank after
+// PrincipalComponentsRank.
+// If dst is not nil it must either be zero-sized or be a d×k matrix.
 // dst will  be used as the destination for the direction vector data. If dst
 // is nil, a new mat.Dense is allocated for the destination.
 func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
@@ -57,7 +92,7 @@ func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
 	}
 
 	if dst != nil {
-		if d, n := dst.Dims(); !dst.IsZero() && (d != c.d || n != min(c.n, c.d)) {
+		if d, n := dst.Dims(); !dst.IsZero() && (d != c.d || n != c.k) {
 			panic(mat.ErrShape)
 		}
 	}
@@ -69,12 +104,13 @@ func (c *PC) VectorsTo(dst *mat.Dense) *mat.Dense {
 // in descending order.
 // If dst is not nil it is used to store the variances and returned.
 // Vars will panic if the receiver has not successfully performed a principal
-// components analysis or dst is not nil and the length of dst is not min(n, d).
+// components analysis or dst is not nil and the length of dst is not the number
+// of components k.
 func (c *PC) VarsTo(dst []float64) []float64 {
 	if !c.ok {
 		panic("stat: use of unsuccessful principal components analysis")
 	}
-	if dst != nil && len(dst) != min(c.n, c.d) {
+	if dst != nil && len(dst) != c.k {
 		panic("stat: length of slice does not match analysis")
 	}
 
@@ -278,6 +314,16 @@ func (c *CC) RightTo(dst *mat.Dense, spheredSpace bool) *mat.Dense {
 }
 
 func svdFactorizeCentered(work *mat.SVD, m mat.Matrix, weights []float64) (svd *mat.SVD, ok bool) {
+	if work == nil {
+		work = &mat.SVD{}
+	}
+	ok = work.Factorize(centerData(m, weights), mat.SVDThin)
+	return work, ok
+}
+
+// centerData returns a copy of m with centered columns and the rows scaled by
+// the square root of the corresponding weights.
+func centerData(m mat.Matrix, weights []float64) *mat.Dense {
 	n, d := m.Dims()
 	centered := mat.NewDense(n, d, nil)
 	col := make([]float64, n)
@@ -289,11 +335,91 @@ func svdFactorizeCentered(work *mat.SVD, m mat.Matrix, weights []float64) (svd *
 	for i, w := range weights {
 		floats.Scale(math.Sqrt(w), centered.RawRowView(i))
 	}
-	if work == nil {
-		work = &mat.SVD{}
+	return centered
+}
+
+// centeredMatrix is the matrix returned by centerData represented
+// implicitly as W^{1/2} * (A - 1*mean^T), where W is the diagonal matrix of
+// weights. Products with a centeredMatrix are computed from products with A,
+// so A is not copied.
+type centeredMatrix struct {
+	a     mat.Matrix
+	mean  *mat.VecDense
+	sqrtW []float64 // sqrtW is nil for unit weights.
+}
+
+// newCenteredMatrix returns the implicit form of centerData(m, weights). The
+// column means are computed from a product with m^T.
+func newCenteredMatrix(m mat.Matrix, weights []float64) *centeredMatrix {
+	n, d := m.Dims()
+	w := mat.NewVecDense(n, nil)
+	var sum float64
+	if weights == nil {
+		for i := 0; i < n; i++ {
+			w.SetVec(i, 1)
+		}
+		sum = float64(n)
+	} else {
+		for i, v := range weights {
+			w.SetVec(i, v)
+		}
+		sum = floats.Sum(weights)
 	}
-	ok = work.Factorize(centered, mat.SVDThin)
-	return work, ok
+	mean := mat.NewVecDense(d, nil)
+	mean.MulVec(m.T(), w)
+	mean.ScaleVec(1/sum, mean)
+
+	var sqrtW []float64
+	if weights != nil {
+		sqrtW = make([]float64, n)
+		for i, v := range weights {
+			sqrtW[i] = math.Sqrt(v)
+		}
+	}
+	return &centeredMatrix{a: m, mean: mean, sqrtW: sqrtW}
+}
+
+func (c *centeredMatrix) Dims() (int, int) { return c.a.Dims() }
+
+func (c *centeredMatrix) At(i, j int) float64 {
+	v := c.a.At(i, j) - c.mean.At(j, 0)
+	if c.sqrtW != nil {
+		v *= c.sqrtW[i]
+	}
+	return v
+}
+
+func (c *centeredMatrix) T() mat.Matrix { return mat.Transpose{Matrix: c} }
+
+// MulVecTo implements the mat.MulVecToer interface.
+func (c *centeredMatrix) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	if !trans {
+		// dst = W^{1/2} * (A*x - 1*(mean^T*x)).
+		dst.MulVec(c.a, x)
+		s := mat.Dot(c.mean, x)
+		for i := 0; i < dst.Len(); i++ {
+			v := dst.At(i, 0) - s
+			if c.sqrtW != nil {
+				v *= c.sqrtW[i]
+			}
+			dst.SetVec(i, v)
+		}
+		return
+	}
+	// dst = A^T*y - mean*(1^T*y), where y = W^{1/2} * x.
+	y := x
+	if c.sqrtW != nil {
+		y = mat.NewVecDense(x.Len(), nil)
+		for i, w := range c.sqrtW {
+			y.SetVec(i, w*x.At(i, 0))
+		}
+	}
+	dst.MulVec(c.a.T(), y)
+	var s float64
+	for i := 0; i < y.Len(); i++ {
+		s += y.At(i, 0)
+	}
+	dst.AddScaledVec(dst, -s, c.mean)
 }
 
 // scaleColsReciSqrt scales the columns of cols
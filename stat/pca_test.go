@@ -5,6 +5,8 @@
 package stat
 
 import (
+	"math"
+	"math/rand"
 	"testing"
 
 	"gonum.org/v1/gonum/floats"
@@ -175,6 +177,132 @@ tests:
 	}
 }
 
+func TestPrincipalComponentsRank(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, d, k  int
+		weighted bool
+	}{
+		{n: 200, d: 60, k: 5},
+		{n: 60, d: 200, k: 3},
+		{n: 100, d: 40, k: 4, weighted: true},
+		{n: 10, d: 4, k: 2},
+	} {
+		// Generate data with a few dominant directions of variance.
+		data := mat.NewDense(test.n, test.d, nil)
+		for i := 0; i < test.n; i++ {
+			for j := 0; j < test.d; j++ {
+				data.Set(i, j, rnd.NormFloat64()*math.Pow(0.7, float64(j))+float64(j))
+			}
+		}
+		var weights []float64
+		if test.weighted {
+			weights = make([]float64, test.n)
+			for i := range weights {
+				weights[i] = 1 + rnd.Float64()
+			}
+		}
+
+		var full, trunc PC
+		if !full.PrincipalComponents(data, weights) {
+			t.Fatalf("n=%d, d=%d: unexpected SVD failure", test.n, test.d)
+		}
+		if !trunc.PrincipalComponentsRank(data, weights, test.k) {
+			t.Errorf("n=%d, d=%d, k=%d: unexpected truncated SVD failure", test.n, test.d, test.k)
+			continue
+		}
+
+		wantVars := full.VarsTo(nil)[:test.k]
+		vars := trunc.VarsTo(nil)
+		if !approxEqual(vars, wantVars, 1e-10) {
+			t.Errorf("n=%d, d=%d, k=%d: unexpected variances got:%v, want:%v", test.n, test.d, test.k, vars, wantVars)
+		}
+
+		// The component directions are unique up to sign.
+		wantVecs := full.VectorsTo(nil)
+		vecs := trunc.VectorsTo(nil)
+		if r, c := vecs.Dims(); r != test.d || c != test.k {
+			t.Errorf("n=%d, d=%d, k=%d: unexpected dimensions of vectors %d×%d", test.n, test.d, test.k, r, c)
+			continue
+		}
+		for j := 0; j < test.k; j++ {
+			dot := mat.Dot(vecs.ColView(j), wantVecs.ColView(j))
+			if math.Abs(math.Abs(dot)-1) > 1e-8 {
+				t.Errorf("n=%d, d=%d, k=%d: component %d mismatch", test.n, test.d, test.k, j)
+			}
+		}
+	}
+}
+
+// noAtMatrix is a matrix whose elements must not be accessed. Products with
+// it are only possible through a mat.MulVecToer that wraps it.
+type noAtMatrix struct {
+	r, c int
+}
+
+func (m noAtMatrix) Dims() (r, c int)  { return m.r, m.c }
+func (noAtMatrix) At(i, j int) float64 { panic("unexpected element access") }
+func (m noAtMatrix) T() mat.Matrix     { return mat.Transpose{Matrix: m} }
+
+// productOnly is a mat.MulVecToer that computes its products with a but
+// panics if its elements are accessed.
+type productOnly struct {
+	noAtMatrix
+	a *mat.Dense
+}
+
+func newProductOnly(a *mat.Dense) productOnly {
+	r, c := a.Dims()
+	return productOnly{noAtMatrix: noAtMatrix{r, c}, a: a}
+}
+
+func (m productOnly) T() mat.Matrix { return mat.Transpose{Matrix: m} }
+
+func (m productOnly) MulVecTo(dst *mat.VecDense, trans bool, x *mat.VecDense) {
+	if trans {
+		dst.MulVec(m.a.T(), x)
+	} else {
+		dst.MulVec(m.a, x)
+	}
+}
+
+func TestCenteredMatrix(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	const n, d = 7, 4
+	data := mat.NewDense(n, d, nil)
+	for i := 0; i < n; i++ {
+		for j := 0; j < d; j++ {
+			data.Set(i, j, rnd.NormFloat64()+float64(j))
+		}
+	}
+	x := mat.NewVecDense(d, nil)
+	for j := 0; j < d; j++ {
+		x.SetVec(j, rnd.NormFloat64())
+	}
+	y := mat.NewVecDense(n, nil)
+	for i := 0; i < n; i++ {
+		y.SetVec(i, rnd.NormFloat64())
+	}
+	for _, weights := range [][]float64{nil, {1, 2, 0.5, 1, 3, 1.5, 1}} {
+		want := centerData(data, weights)
+		c := newCenteredMatrix(newProductOnly(data), weights)
+
+		var got, wantVec mat.VecDense
+		got.MulVec(c, x)
+		wantVec.MulVec(want, x)
+		if !mat.EqualApprox(&got, &wantVec, 1e-12) {
+			t.Errorf("weighted=%t: unexpected product", weights != nil)
+		}
+		got.Reset()
+		wantVec.Reset()
+		got.MulVec(c.T(), y)
+		wantVec.MulVec(want.T(), y)
+		if !mat.EqualApprox(&got, &wantVec, 1e-12) {
+			t.Errorf("weighted=%t: unexpected transpose product", weights != nil)
+		}
+	}
+}
+
 func approxEqual(a, b []float64, epsilon float64) bool {
 	if len(a) != len(b) {
 		return false